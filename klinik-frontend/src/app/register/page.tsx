@@ -1,7 +1,7 @@
 // src/app/register/page.tsx
 'use client'
 
-import { useState } from 'react'
+import { useEffect, useState } from 'react'
 import { useRouter } from 'next/navigation'
 import api from '@/lib/axios'
 import { motion } from 'framer-motion'
@@ -10,18 +10,24 @@ export default function RegisterPage() {
   const [fullName, setFullName] = useState('')
   const [email, setEmail] = useState('')
   const [password, setPassword] = useState('')
-  const [role, setRole] = useState('staff')
+  const [inviteToken, setInviteToken] = useState('')
   const [error, setError] = useState('')
   const router = useRouter()
 
+  // Link undangan dari admin berbentuk /register?token=...
+  useEffect(() => {
+    const token = new URLSearchParams(window.location.search).get('token')
+    if (token) setInviteToken(token)
+  }, [])
+
   const handleRegister = async (e: React.FormEvent) => {
     e.preventDefault()
     try {
       await api.post('/api/users/register', {
-        full_name: fullName,
+        fullName,
         email,
         password,
-        role,
+        inviteToken,
       })
       router.push('/login')
     } catch (err: any) {
@@ -63,15 +69,14 @@ export default function RegisterPage() {
             className="w-full border border-slate-300 rounded-lg p-3 focus:outline-none focus:ring-2 focus:ring-slate-500"
             required
           />
-          <select
-            value={role}
-            onChange={e => setRole(e.target.value)}
-            className="w-full border border-slate-300 rounded-lg p-3 bg-white focus:outline-none focus:ring-2 focus:ring-slate-500"
-          >
-            <option value="staff">Staff</option>
-            <option value="doctor">Doctor</option>
-            <option value="admin">Admin</option>
-          </select>
+          <input
+            type="text"
+            placeholder="Invitation Code"
+            value={inviteToken}
+            onChange={e => setInviteToken(e.target.value)}
+            className="w-full border border-slate-300 rounded-lg p-3 focus:outline-none focus:ring-2 focus:ring-slate-500"
+            required
+          />
 
           {error && <p className="text-red-500 text-sm">{error}</p>}
 
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"mental-klinik-backend/config"
+	"mental-klinik-backend/databases"
+	"mental-klinik-backend/middlewares"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
+	"mental-klinik-backend/utils"
+)
+
+const createAdminUsage = `usage:
+  go run main.go create-admin [flags]   buat admin pertama dari BOOTSTRAP_ADMIN_EMAIL,
+                                        BOOTSTRAP_ADMIN_PASSWORD dan BOOTSTRAP_ADMIN_NAME
+                                        (atau auth.bootstrapAdmin di file config)`
+
+// runCreateAdmin menjalankan subcommand create-admin. Gagal jika sudah ada admin.
+func runCreateAdmin(args []string) error {
+	cfg, err := config.Load(args)
+	if err != nil {
+		return err
+	}
+	if !cfg.Auth.BootstrapAdmin.Enabled() {
+		return errors.New(createAdminUsage)
+	}
+
+	db := database.ConnectDB(cfg.Database)
+	if err := database.EnsureSchemaUpToDate(db); err != nil {
+		return err
+	}
+
+	userRepo := repositories.NewUserRepository(db)
+	ids, err := utils.NewIDGenerator(cfg.IDs.Format, repositories.NewSequenceRepository(db))
+	if err != nil {
+		return err
+	}
+	sessions := services.NewSessionService(repositories.NewSessionRepository(db), userRepo,
+		middlewares.NewJWTManager(cfg.JWT), cfg.JWT.RefreshTTL.Duration)
+	users := services.NewUserService(userRepo, repositories.NewInvitationRepository(db), sessions, ids, cfg.Auth.AllowRegistration)
+
+	admin := cfg.Auth.BootstrapAdmin
+	user, err := users.BootstrapAdmin(admin.FullName, admin.Email, admin.Password)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("created admin %s (%s)\n", user.Email, user.ID)
+	return nil
+}
+
+// bootstrapAdmin dipanggil saat server start: jika auth.bootstrapAdmin diisi
+// dan belum ada admin, admin pertama dibuat.
+func bootstrapAdmin(cfg *config.Config, users services.UserService) error {
+	if !cfg.Auth.BootstrapAdmin.Enabled() {
+		return nil
+	}
+
+	admin := cfg.Auth.BootstrapAdmin
+	user, err := users.BootstrapAdmin(admin.FullName, admin.Email, admin.Password)
+	if errors.Is(err, services.ErrAdminAlreadyExists) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("bootstrap admin: %w", err)
+	}
+	log.Printf("Bootstrap admin created: %s (%s)", user.Email, user.ID)
+	return nil
+}
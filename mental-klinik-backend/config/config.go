@@ -0,0 +1,346 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"mental-klinik-backend/encryption"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/utils"
+)
+
+// Config adalah konfigurasi aplikasi yang sudah divalidasi. Nilai dibaca
+// berurutan dari: default -> file (YAML/TOML, opsional) -> environment -> flag,
+// sumber yang belakangan menimpa sumber sebelumnya.
+type Config struct {
+	Server     ServerConfig     `yaml:"server" toml:"server"`
+	Database   DatabaseConfig   `yaml:"database" toml:"database"`
+	JWT        JWTConfig        `yaml:"jwt" toml:"jwt"`
+	CORS       CORSConfig       `yaml:"cors" toml:"cors"`
+	Prediction PredictionConfig `yaml:"prediction" toml:"prediction"`
+	IDs        IDConfig         `yaml:"ids" toml:"ids"`
+	Auth       AuthConfig       `yaml:"auth" toml:"auth"`
+	Encryption EncryptionConfig `yaml:"encryption" toml:"encryption"`
+	Retention  RetentionConfig  `yaml:"retention" toml:"retention"`
+	NIK        NIKConfig        `yaml:"nik" toml:"nik"`
+	Duplicates DuplicatesConfig `yaml:"duplicates" toml:"duplicates"`
+}
+
+type ServerConfig struct {
+	Port string `yaml:"port" toml:"port"`
+}
+
+type DatabaseConfig struct {
+	Host     string `yaml:"host" toml:"host"`
+	Port     string `yaml:"port" toml:"port"`
+	User     string `yaml:"user" toml:"user"`
+	Password string `yaml:"password" toml:"password"`
+	Name     string `yaml:"name" toml:"name"`
+	SSLMode  string `yaml:"sslMode" toml:"sslMode"`
+}
+
+// DSN menyusun connection string Postgres
+func (d DatabaseConfig) DSN() string {
+	return fmt.Sprintf(
+		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+		d.Host, d.User, d.Password, d.Name, d.Port, d.SSLMode,
+	)
+}
+
+// JWTConfig mengatur access token (JWT, berumur pendek) dan refresh token
+// (opaque, disimpan sebagai hash di database, dirotasi setiap dipakai).
+type JWTConfig struct {
+	Secret     string   `yaml:"secret" toml:"secret"`
+	TTL        Duration `yaml:"ttl" toml:"ttl"`
+	RefreshTTL Duration `yaml:"refreshTtl" toml:"refreshTtl"`
+}
+
+type CORSConfig struct {
+	AllowOrigins []string `yaml:"allowOrigins" toml:"allowOrigins"`
+}
+
+type PredictionConfig struct {
+	URL     string   `yaml:"url" toml:"url"`
+	Timeout Duration `yaml:"timeout" toml:"timeout"`
+}
+
+// IDConfig mengatur format ID entity: "readable" (patient-001-xxxx, nomor dari
+// sequence database) atau "ulid".
+type IDConfig struct {
+	Format string `yaml:"format" toml:"format"`
+}
+
+// AuthConfig mengatur pendaftaran user. Secara default pendaftaran tertutup:
+// user baru hanya bisa mendaftar lewat undangan admin. AllowRegistration
+// membuka pendaftaran mandiri (role selalu staff), hanya untuk development.
+// BreakGlassTTL adalah lama akses darurat (break-the-glass) ke satu pasien.
+type AuthConfig struct {
+	AllowRegistration bool                 `yaml:"allowRegistration" toml:"allowRegistration"`
+	InviteTTL         Duration             `yaml:"inviteTtl" toml:"inviteTtl"`
+	BreakGlassTTL     Duration             `yaml:"breakGlassTtl" toml:"breakGlassTtl"`
+	BootstrapAdmin    BootstrapAdminConfig `yaml:"bootstrapAdmin" toml:"bootstrapAdmin"`
+}
+
+// BootstrapAdminConfig adalah admin pertama yang dibuat saat startup (atau
+// lewat subcommand create-admin) jika belum ada admin sama sekali.
+type BootstrapAdminConfig struct {
+	FullName string `yaml:"fullName" toml:"fullName"`
+	Email    string `yaml:"email" toml:"email"`
+	Password string `yaml:"password" toml:"password"`
+}
+
+// Enabled bernilai true jika email bootstrap admin diisi
+func (b BootstrapAdminConfig) Enabled() bool {
+	return b.Email != ""
+}
+
+// EncryptionConfig mengatur enkripsi field data pasien. MasterKeys berisi
+// "<id>:<base64 32 byte>", key pertama adalah key aktif dan key berikutnya
+// hanya dipakai membuka data key lama selama rotasi. IndexKey adalah key HMAC
+// untuk blind index NIK.
+type EncryptionConfig struct {
+	MasterKeys []string `yaml:"masterKeys" toml:"masterKeys"`
+	IndexKey   string   `yaml:"indexKey" toml:"indexKey"`
+}
+
+// RetentionConfig mengatur penghapusan / anonimisasi otomatis data lama.
+// Scheduler berjalan setiap Interval jika Enabled; DryRun membuat scheduler
+// hanya menulis laporan tanpa mengubah data. Kebijakan hanya bisa diatur
+// lewat file konfigurasi.
+type RetentionConfig struct {
+	Enabled   bool              `yaml:"enabled" toml:"enabled"`
+	Interval  Duration          `yaml:"interval" toml:"interval"`
+	DryRun    bool              `yaml:"dryRun" toml:"dryRun"`
+	BatchSize int               `yaml:"batchSize" toml:"batchSize"`
+	Policies  []RetentionPolicy `yaml:"policies" toml:"policies"`
+}
+
+// RetentionPolicy adalah satu aturan retensi, misalnya appointment yang
+// di-soft delete (basis deleted) dihapus permanen (action purge) setelah 2y
+type RetentionPolicy struct {
+	Entity    string       `yaml:"entity" toml:"entity"`
+	Action    string       `yaml:"action" toml:"action"`
+	Basis     string       `yaml:"basis" toml:"basis"`
+	RetainFor utils.Period `yaml:"retainFor" toml:"retainFor"`
+}
+
+// NIKConfig mengatur validasi NIK pasien. RegionsFile adalah CSV "code,name"
+// tabel wilayah lengkap (provinsi sampai kecamatan) dan setiap kode dicek
+// terhadap tabel itu. Kosong berarti memakai tabel bawaan yang hanya memuat
+// provinsi dan kabupaten / kota di Jawa dan Bali: kode kecamatan, dan kode
+// kabupaten / kota di luar Jawa dan Bali, diterima tanpa dicek.
+type NIKConfig struct {
+	RegionsFile string `yaml:"regionsFile" toml:"regionsFile"`
+}
+
+// DuplicatesConfig mengatur job deteksi pasien duplikat. Scheduler berjalan
+// setiap Interval jika Enabled; pasangan dengan skor minimal Threshold (0
+// sampai 1) masuk antrean review admin.
+type DuplicatesConfig struct {
+	Enabled   bool     `yaml:"enabled" toml:"enabled"`
+	Interval  Duration `yaml:"interval" toml:"interval"`
+	Threshold float64  `yaml:"threshold" toml:"threshold"`
+}
+
+// Duration membungkus time.Duration supaya bisa ditulis sebagai "5s" / "24h"
+// di file YAML maupun TOML.
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// Default mengembalikan konfigurasi bawaan untuk development lokal
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{Port: "8080"},
+		Database: DatabaseConfig{
+			Host:    "localhost",
+			Port:    "5432",
+			SSLMode: "disable",
+		},
+		JWT: JWTConfig{
+			TTL:        Duration{15 * time.Minute},
+			RefreshTTL: Duration{7 * 24 * time.Hour},
+		},
+		CORS: CORSConfig{
+			AllowOrigins: []string{"http://localhost:3000", "http://172.26.0.1:3000"},
+		},
+		Prediction: PredictionConfig{
+			URL:     "http://localhost:8000/predict",
+			Timeout: Duration{5 * time.Second},
+		},
+		IDs: IDConfig{Format: "readable"},
+		Auth: AuthConfig{
+			InviteTTL:      Duration{72 * time.Hour},
+			BreakGlassTTL:  Duration{time.Hour},
+			BootstrapAdmin: BootstrapAdminConfig{FullName: "Administrator"},
+		},
+		Retention: RetentionConfig{
+			Interval:  Duration{24 * time.Hour},
+			BatchSize: 500,
+		},
+		Duplicates: DuplicatesConfig{
+			Interval:  Duration{24 * time.Hour},
+			Threshold: 0.75,
+		},
+	}
+}
+
+// Load membaca konfigurasi dari .env (jika ada), file konfigurasi, environment
+// dan flag command line, lalu memvalidasi hasilnya.
+func Load(args []string) (*Config, error) {
+	// .env bersifat opsional (misalnya di container semua nilai dari environment)
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("load .env: %w", err)
+	}
+
+	flags, err := parseFlags(args)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+
+	path := flags.configFile
+	if path == "" {
+		path = lookupEnv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := loadFile(path, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyEnv(cfg); err != nil {
+		return nil, err
+	}
+	flags.apply(cfg)
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate memastikan nilai wajib terisi sebelum server dijalankan
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Server.Port == "" {
+		errs = append(errs, errors.New("server port is required"))
+	}
+	if c.Database.Host == "" {
+		errs = append(errs, errors.New("DB_HOST is required"))
+	}
+	if c.Database.Port == "" {
+		errs = append(errs, errors.New("DB_PORT is required"))
+	}
+	if c.Database.User == "" {
+		errs = append(errs, errors.New("DB_USER is required"))
+	}
+	if c.Database.Name == "" {
+		errs = append(errs, errors.New("DB_NAME is required"))
+	}
+	if strings.TrimSpace(c.JWT.Secret) == "" {
+		errs = append(errs, errors.New("JWT_SECRET is required"))
+	}
+	if c.JWT.TTL.Duration <= 0 {
+		errs = append(errs, errors.New("JWT TTL must be positive"))
+	}
+	if c.JWT.RefreshTTL.Duration <= c.JWT.TTL.Duration {
+		errs = append(errs, errors.New("JWT refresh TTL must be longer than the access token TTL"))
+	}
+	if len(c.CORS.AllowOrigins) == 0 {
+		errs = append(errs, errors.New("at least one CORS origin is required"))
+	}
+	if c.Prediction.URL == "" {
+		errs = append(errs, errors.New("PREDICTION_URL is required"))
+	}
+	if c.Prediction.Timeout.Duration <= 0 {
+		errs = append(errs, errors.New("prediction timeout must be positive"))
+	}
+
+	if c.IDs.Format != "readable" && c.IDs.Format != "ulid" {
+		errs = append(errs, fmt.Errorf("ID_FORMAT must be \"readable\" or \"ulid\", got %q", c.IDs.Format))
+	}
+
+	if c.Auth.InviteTTL.Duration <= 0 {
+		errs = append(errs, errors.New("invite TTL must be positive"))
+	}
+	if c.Auth.BreakGlassTTL.Duration <= 0 {
+		errs = append(errs, errors.New("break-glass TTL must be positive"))
+	}
+	if c.Auth.BootstrapAdmin.Enabled() && len(c.Auth.BootstrapAdmin.Password) < 8 {
+		errs = append(errs, errors.New("BOOTSTRAP_ADMIN_PASSWORD must be at least 8 characters"))
+	}
+
+	if len(c.Encryption.MasterKeys) == 0 {
+		errs = append(errs, errors.New("ENCRYPTION_MASTER_KEYS is required"))
+	} else if _, err := encryption.ParseMasterKeys(c.Encryption.MasterKeys); err != nil {
+		errs = append(errs, fmt.Errorf("invalid ENCRYPTION_MASTER_KEYS: %w", err))
+	}
+	if strings.TrimSpace(c.Encryption.IndexKey) == "" {
+		errs = append(errs, errors.New("ENCRYPTION_INDEX_KEY is required"))
+	} else if _, err := encryption.ParseKey(c.Encryption.IndexKey); err != nil {
+		errs = append(errs, fmt.Errorf("invalid ENCRYPTION_INDEX_KEY: %w", err))
+	}
+
+	if c.Retention.Interval.Duration <= 0 {
+		errs = append(errs, errors.New("retention interval must be positive"))
+	}
+	if c.Retention.BatchSize <= 0 {
+		errs = append(errs, errors.New("retention batch size must be positive"))
+	}
+	for i, policy := range c.Retention.Policies {
+		if err := policy.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("retention policy %d: %w", i+1, err))
+		}
+	}
+
+	if c.Duplicates.Interval.Duration <= 0 {
+		errs = append(errs, errors.New("duplicates interval must be positive"))
+	}
+	if c.Duplicates.Threshold <= 0 || c.Duplicates.Threshold > 1 {
+		errs = append(errs, errors.New("duplicates threshold must be greater than 0 and at most 1"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func (p RetentionPolicy) validate() error {
+	support, ok := models.RetentionEntities[p.Entity]
+	if !ok {
+		return fmt.Errorf("unknown entity %q", p.Entity)
+	}
+	if !slices.Contains(support.Actions, p.Action) {
+		return fmt.Errorf("%s supports action %s, got %q", p.Entity, strings.Join(support.Actions, "/"), p.Action)
+	}
+	if !slices.Contains(support.Bases, p.Basis) {
+		return fmt.Errorf("%s supports basis %s, got %q", p.Entity, strings.Join(support.Bases, "/"), p.Basis)
+	}
+	if p.RetainFor.IsZero() {
+		return errors.New("retainFor must be set, e.g. 2y")
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// loadFile membaca file konfigurasi YAML atau TOML berdasarkan ekstensinya
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, cfg)
+	case ".toml":
+		err = toml.Unmarshal(data, cfg)
+	default:
+		return fmt.Errorf("unsupported config file format: %s", path)
+	}
+	if err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// lookupEnv mengembalikan nilai env yang sudah di-trim
+func lookupEnv(key string) string {
+	return strings.TrimSpace(os.Getenv(key))
+}
+
+// applyEnv menimpa konfigurasi dengan environment variable yang terisi
+func applyEnv(cfg *Config) error {
+	setString(&cfg.Server.Port, "PORT")
+
+	setString(&cfg.Database.Host, "DB_HOST")
+	setString(&cfg.Database.Port, "DB_PORT")
+	setString(&cfg.Database.User, "DB_USER")
+	setString(&cfg.Database.Password, "DB_PASSWORD")
+	setString(&cfg.Database.Name, "DB_NAME")
+	setString(&cfg.Database.SSLMode, "DB_SSLMODE")
+
+	setString(&cfg.JWT.Secret, "JWT_SECRET")
+	if err := setDuration(&cfg.JWT.TTL, "JWT_TTL"); err != nil {
+		return err
+	}
+	if err := setDuration(&cfg.JWT.RefreshTTL, "JWT_REFRESH_TTL"); err != nil {
+		return err
+	}
+
+	if origins := lookupEnv("CORS_ALLOW_ORIGINS"); origins != "" {
+		cfg.CORS.AllowOrigins = splitList(origins)
+	}
+
+	setString(&cfg.Prediction.URL, "PREDICTION_URL")
+	if err := setDuration(&cfg.Prediction.Timeout, "PREDICTION_TIMEOUT"); err != nil {
+		return err
+	}
+
+	setString(&cfg.IDs.Format, "ID_FORMAT")
+
+	if err := setBool(&cfg.Auth.AllowRegistration, "ALLOW_REGISTRATION"); err != nil {
+		return err
+	}
+	if err := setDuration(&cfg.Auth.InviteTTL, "INVITE_TTL"); err != nil {
+		return err
+	}
+	if err := setDuration(&cfg.Auth.BreakGlassTTL, "BREAK_GLASS_TTL"); err != nil {
+		return err
+	}
+	setString(&cfg.Auth.BootstrapAdmin.FullName, "BOOTSTRAP_ADMIN_NAME")
+	setString(&cfg.Auth.BootstrapAdmin.Email, "BOOTSTRAP_ADMIN_EMAIL")
+	setString(&cfg.Auth.BootstrapAdmin.Password, "BOOTSTRAP_ADMIN_PASSWORD")
+
+	if keys := lookupEnv("ENCRYPTION_MASTER_KEYS"); keys != "" {
+		cfg.Encryption.MasterKeys = splitList(keys)
+	}
+	setString(&cfg.Encryption.IndexKey, "ENCRYPTION_INDEX_KEY")
+
+	if err := setBool(&cfg.Retention.Enabled, "RETENTION_ENABLED"); err != nil {
+		return err
+	}
+	if err := setBool(&cfg.Retention.DryRun, "RETENTION_DRY_RUN"); err != nil {
+		return err
+	}
+	if err := setDuration(&cfg.Retention.Interval, "RETENTION_INTERVAL"); err != nil {
+		return err
+	}
+	if v := lookupEnv("RETENTION_BATCH_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid RETENTION_BATCH_SIZE: %w", err)
+		}
+		cfg.Retention.BatchSize = n
+	}
+
+	setString(&cfg.NIK.RegionsFile, "NIK_REGIONS_FILE")
+
+	if err := setBool(&cfg.Duplicates.Enabled, "DUPLICATES_ENABLED"); err != nil {
+		return err
+	}
+	if err := setDuration(&cfg.Duplicates.Interval, "DUPLICATES_INTERVAL"); err != nil {
+		return err
+	}
+	if v := lookupEnv("DUPLICATES_THRESHOLD"); v != "" {
+		threshold, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid DUPLICATES_THRESHOLD: %w", err)
+		}
+		cfg.Duplicates.Threshold = threshold
+	}
+	return nil
+}
+
+func setString(target *string, key string) {
+	if v := lookupEnv(key); v != "" {
+		*target = v
+	}
+}
+
+func setBool(target *bool, key string) error {
+	v := lookupEnv(key)
+	if v == "" {
+		return nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	*target = b
+	return nil
+}
+
+func setDuration(target *Duration, key string) error {
+	v := lookupEnv(key)
+	if v == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	target.Duration = d
+	return nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// cliFlags menampung flag command line yang di-set user
+type cliFlags struct {
+	configFile    string
+	port          string
+	dbHost        string
+	dbPort        string
+	dbName        string
+	corsOrigins   string
+	predictionURL string
+}
+
+func parseFlags(args []string) (*cliFlags, error) {
+	f := &cliFlags{}
+	set := flag.NewFlagSet("mental-klinik-backend", flag.ContinueOnError)
+	set.StringVar(&f.configFile, "config", "", "path ke file konfigurasi (.yaml/.yml/.toml)")
+	set.StringVar(&f.port, "port", "", "port HTTP server")
+	set.StringVar(&f.dbHost, "db-host", "", "host database")
+	set.StringVar(&f.dbPort, "db-port", "", "port database")
+	set.StringVar(&f.dbName, "db-name", "", "nama database")
+	set.StringVar(&f.corsOrigins, "cors-origins", "", "daftar origin CORS, dipisah koma")
+	set.StringVar(&f.predictionURL, "prediction-url", "", "URL endpoint service prediksi")
+
+	if err := set.Parse(args); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *cliFlags) apply(cfg *Config) {
+	if f.port != "" {
+		cfg.Server.Port = f.port
+	}
+	if f.dbHost != "" {
+		cfg.Database.Host = f.dbHost
+	}
+	if f.dbPort != "" {
+		cfg.Database.Port = f.dbPort
+	}
+	if f.dbName != "" {
+		cfg.Database.Name = f.dbName
+	}
+	if f.corsOrigins != "" {
+		cfg.CORS.AllowOrigins = splitList(f.corsOrigins)
+	}
+	if f.predictionURL != "" {
+		cfg.Prediction.URL = f.predictionURL
+	}
+}
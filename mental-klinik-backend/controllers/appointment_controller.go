@@ -1,23 +1,78 @@
 package controllers
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
-	"strconv"
 	"time"
 
-	"mental-klinik-backend/databases"
 	"mental-klinik-backend/dto"
 	"mental-klinik-backend/models"
-	"mental-klinik-backend/utils"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
 
 	"github.com/gin-gonic/gin"
-	// "gorm.io/gorm"
 )
 
+type AppointmentController struct {
+	service services.AppointmentService
+	policy  *policy.Policy
+}
+
+func NewAppointmentController(service services.AppointmentService, policy *policy.Policy) *AppointmentController {
+	return &AppointmentController{service: service, policy: policy}
+}
+
+// appointmentResource: pemilik appointment adalah dokter/staff yang ditugaskan
+func appointmentResource(appointment *models.Appointment) policy.Resource {
+	return policy.Resource{
+		Type:      policy.Appointment,
+		ID:        appointment.ID,
+		PatientID: appointment.PatientID,
+		OwnerID:   appointment.UserID,
+	}
+}
+
+func toAppointmentResponse(appointment *models.Appointment) dto.AppointmentResponse {
+	return dto.AppointmentResponse{
+		ID:         appointment.ID,
+		PatientID:  appointment.PatientID,
+		UserID:     appointment.UserID,
+		ScheduleAt: appointment.ScheduleAt,
+		Status:     appointment.Status,
+		Notes:      appointment.Notes,
+		CreatedAt:  appointment.CreatedAt,
+		UpdatedAt:  appointment.UpdatedAt,
+		Patient: dto.PatientMiniResponse{
+			ID:       appointment.Patient.ID,
+			FullName: appointment.Patient.FullName,
+		},
+		User: dto.UserMiniResponse{
+			ID:       appointment.User.ID,
+			FullName: appointment.User.FullName,
+			Role:     appointment.User.Role,
+		},
+	}
+}
+
+// loadAppointment mengambil appointment untuk dicek policy-nya. Response error
+// langsung dikirim jika gagal.
+func (ac *AppointmentController) loadAppointment(c *gin.Context) (*models.Appointment, bool) {
+	appointment, err := ac.service.GetByID(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrAppointmentNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Appointment not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to fetch appointment"})
+		return nil, false
+	}
+	return appointment, true
+}
+
 // CreateAppointment godoc
 // @Summary Create a new appointment
-// @Description Create a new appointment between patient and doctor/staff.
+// @Description Create a new appointment between patient and doctor/staff. Doctors can only create appointments assigned to themselves.
 // @Tags Appointments
 // @Security BearerAuth
 // @Accept json
@@ -25,44 +80,31 @@ import (
 // @Param request body dto.CreateAppointmentRequest true "Appointment input data"
 // @Success 201 {object} dto.CreateAppointmentResponse
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/appointments [post]
-func CreateAppointment(c *gin.Context) {
+func (ac *AppointmentController) CreateAppointment(c *gin.Context) {
 	var input dto.CreateAppointmentRequest
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	// Validasi keberadaan Patient dan User
-	var patient models.Patient
-	if err := database.DB.First(&patient, "id = ?", input.PatientID).Error; err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Patient not found"})
+	resource := policy.Resource{Type: policy.Appointment, PatientID: input.PatientID, OwnerID: input.UserID}
+	if !authorize(c, ac.policy, policy.Create, resource) {
 		return
 	}
 
-	var user models.User
-	if err := database.DB.First(&user, "id = ?", input.UserID).Error; err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "User not found"})
-		return
-	}
-
-	// Generate ID
-	var count int64
-	database.DB.Model(&models.Appointment{}).Count(&count)
-	newID := utils.GenerateCustomAppointmentID(int(count + 1))
-
-	appointment := models.Appointment{
-		ID:         newID,
-		PatientID:  input.PatientID,
-		UserID:     input.UserID,
-		ScheduleAt: input.ScheduleAt,
-		Status:     "pending",
-		Notes:      input.Notes,
-	}
-
-	if err := database.DB.Create(&appointment).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to create appointment"})
+	appointment, err := ac.service.Create(input)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPatientNotFound):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Patient not found"})
+		case errors.Is(err, services.ErrUserNotFound):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "User not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to create appointment"})
+		}
 		return
 	}
 
@@ -83,81 +125,59 @@ func CreateAppointment(c *gin.Context) {
 
 // GetAllAppointments godoc
 // @Summary Get all appointments
-// @Description Get paginated list of appointments with optional search (by patient name), status filter, and sorting.
+// @Description Get paginated list of appointments with optional search (by patient name), status filter, and sorting. Doctors only see appointments of patients on their care team.
 // @Tags Appointments
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
+// @Param cursor query string false "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response"
+// @Param withTotal query bool false "Also count total rows in cursor mode" default(false)
 // @Param search query string false "Search by patient full name"
-// @Param status query string false "Filter by status (pending, done, cancelled)"
-// @Param sort query string false "Sort by field (e.g. schedule_at, created_at)" default(schedule_at)
-// @Param order query string false "Sort order (asc or desc)" default(asc)
+// @Param status query string false "Filter by status (pending, done, cancelled). Also status[in]=pending,done"
+// @Param patientId query string false "Filter by patient ID"
+// @Param userId query string false "Filter by doctor/staff user ID"
+// @Param scheduleAt[gte] query string false "Scheduled at or after (YYYY-MM-DD or RFC3339)"
+// @Param scheduleAt[lte] query string false "Scheduled at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, status, scheduleAt, createdAt, updatedAt)" default(scheduleAt)
+// @Param order query string false "Default sort order for fields without prefix (asc or desc)"
 // @Success 200 {object} dto.PaginatedAppointmentsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
 // @Router /api/appointments [get]
-func GetAllAppointments(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	limitStr := c.DefaultQuery("limit", "10")
-	search := c.Query("search")
-	status := c.Query("status")
-	sort := c.DefaultQuery("sort", "schedule_at")
-	order := c.DefaultQuery("order", "asc")
-
-	page, _ := strconv.Atoi(pageStr)
-	limit, _ := strconv.Atoi(limitStr)
-	offset := (page - 1) * limit
-
-	var appointments []models.Appointment
-	query := database.DB.Preload("Patient").Preload("User")
-
-	if search != "" {
-		query = query.Joins("JOIN patients ON patients.id = appointments.patient_id").
-			Where("patients.full_name ILIKE ?", "%"+search+"%")
+func (ac *AppointmentController) GetAllAppointments(c *gin.Context) {
+	if !authorize(c, ac.policy, policy.List, policy.Resource{Type: policy.Appointment}) {
+		return
 	}
 
-	if status != "" {
-		query = query.Where("appointments.status = ?", status)
+	query, ok := parseListQuery(c, repositories.AppointmentListSpec)
+	if !ok {
+		return
 	}
 
-	query.Order(fmt.Sprintf("%s %s", sort, order)).
-		Offset(offset).
-		Limit(limit).
-		Find(&appointments)
-
-	var total int64
-	database.DB.Model(&models.Appointment{}).Count(&total)
+	scope := ac.policy.ListScope(currentSubject(c), policy.Appointment)
+	appointments, pageInfo, err := ac.service.GetAll(repositories.AppointmentFilter{
+		Search:             c.Query("search"),
+		CareTeamOf:         scope.CareTeamOf,
+		EmergencyPatientID: scope.EmergencyPatientID,
+		Query:              *query,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to fetch appointments"})
+		return
+	}
 
 	// Convert ke DTO
 	var responses []dto.AppointmentResponse
-	for _, a := range appointments {
-		responses = append(responses, dto.AppointmentResponse{
-			ID:         a.ID,
-			PatientID:  a.PatientID,
-			UserID:     a.UserID,
-			ScheduleAt: a.ScheduleAt,
-			Status:     a.Status,
-			Notes:      a.Notes,
-			Patient: dto.PatientMiniResponse{
-				ID:       a.Patient.ID,
-				FullName: a.Patient.FullName,
-			},
-			User: dto.UserMiniResponse{
-				ID:       a.User.ID,
-				FullName: a.User.FullName,
-				Role:     a.User.Role,
-			},
-		})
+	for i := range appointments {
+		responses = append(responses, toAppointmentResponse(&appointments[i]))
 	}
 
 	c.JSON(http.StatusOK, dto.PaginatedAppointmentsResponse{
 		Data:       responses,
-		Total:      int(total),
-		Page:       page,
-		Limit:      limit,
-		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+		Pagination: newPagination(query, pageInfo),
 	})
 }
 
@@ -172,14 +192,14 @@ func GetAllAppointments(c *gin.Context) {
 // @Success 200 {object} dto.AppointmentResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Router /api/appointments/{id} [get]
-func GetAppointmentByID(c *gin.Context) {
-	id := c.Param("id")
-	var appointment models.Appointment
-
-	if err := database.DB.Preload("Patient").Preload("User").
-		First(&appointment, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Appointment not found"})
+func (ac *AppointmentController) GetAppointmentByID(c *gin.Context) {
+	appointment, ok := ac.loadAppointment(c)
+	if !ok {
+		return
+	}
+	if !authorize(c, ac.policy, policy.Read, appointmentResource(appointment)) {
 		return
 	}
 
@@ -208,7 +228,7 @@ func GetAppointmentByID(c *gin.Context) {
 
 // UpdateAppointment godoc
 // @Summary Update an appointment
-// @Description Update appointment schedule or notes by ID.
+// @Description Update appointment schedule or notes by ID. Doctors can only update their own appointments.
 // @Tags Appointments
 // @Security BearerAuth
 // @Accept json
@@ -217,48 +237,35 @@ func GetAppointmentByID(c *gin.Context) {
 // @Param request body dto.UpdateAppointmentRequest true "Updated appointment data"
 // @Success 200 {object} dto.UpdateAppointmentResponse
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/appointments/{id} [put]
-func UpdateAppointment(c *gin.Context) {
-	id := c.Param("id")
-	var appointment models.Appointment
-
-	if err := database.DB.First(&appointment, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Appointment not found"})
-		return
-	}
-
+func (ac *AppointmentController) UpdateAppointment(c *gin.Context) {
 	var input dto.UpdateAppointmentRequest
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	var updatedFields []dto.UpdatedField
-
-	if input.ScheduleAt != nil {
-		appointment.ScheduleAt = *input.ScheduleAt
-		updatedFields = append(updatedFields, dto.UpdatedField{
-			Field: "scheduleAt",
-			Value: input.ScheduleAt,
-		})
-	}
-	if input.Notes != nil {
-		appointment.Notes = *input.Notes
-		updatedFields = append(updatedFields, dto.UpdatedField{
-			Field: "notes",
-			Value: input.Notes,
-		})
+	appointment, ok := ac.loadAppointment(c)
+	if !ok {
+		return
 	}
-
-	if len(updatedFields) == 0 {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "No fields to update"})
+	if !authorize(c, ac.policy, policy.Update, appointmentResource(appointment)) {
 		return
 	}
 
-	if err := database.DB.Save(&appointment).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to update appointment"})
+	updatedFields, err := ac.service.Update(appointment.ID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrAppointmentNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Appointment not found"})
+		case errors.Is(err, services.ErrNoFieldsToUpdate):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "No fields to update"})
+		default:
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to update appointment"})
+		}
 		return
 	}
 
@@ -276,11 +283,20 @@ func UpdateAppointment(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Appointment ID"
 // @Success 200 {object} dto.MessageDeleteAppointmentResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/appointments/{id} [delete]
-func DeleteAppointment(c *gin.Context) {
-	id := c.Param("id")
-	if err := database.DB.Delete(&models.Appointment{}, "id = ?", id).Error; err != nil {
+func (ac *AppointmentController) DeleteAppointment(c *gin.Context) {
+	appointment, ok := ac.loadAppointment(c)
+	if !ok {
+		return
+	}
+	if !authorize(c, ac.policy, policy.Delete, appointmentResource(appointment)) {
+		return
+	}
+
+	if err := ac.service.Delete(appointment.ID); err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to delete appointment"})
 		return
 	}
@@ -290,20 +306,40 @@ func DeleteAppointment(c *gin.Context) {
 
 // GetAppointmentsByPatientID godoc
 // @Summary Get appointments by patient ID
-// @Description Mengambil semua janji temu berdasarkan ID pasien, beserta informasi pasien yang terkait
+// @Description Mengambil semua janji temu berdasarkan ID pasien, beserta informasi pasien yang terkait. Dokter hanya bisa melihat pasien di tim perawatannya.
 // @Tags Appointments
+// @Security BearerAuth
 // @Param patientId path string true "Patient ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param cursor query string false "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response"
+// @Param withTotal query bool false "Also count total rows in cursor mode" default(false)
+// @Param status query string false "Filter by status (pending, done, cancelled). Also status[in]=pending,done"
+// @Param scheduleAt[gte] query string false "Scheduled at or after (YYYY-MM-DD or RFC3339)"
+// @Param scheduleAt[lte] query string false "Scheduled at or before (YYYY-MM-DD or RFC3339)"
+// @Param createdAt[gte] query string false "Created at or after (YYYY-MM-DD or RFC3339)"
+// @Param createdAt[lte] query string false "Created at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, status, scheduleAt, createdAt, updatedAt)" default(-scheduleAt)
 // @Produce json
-// @Success 200 {array} dto.AppointmentWithPatientResponse
+// @Success 200 {object} dto.PaginatedAppointmentsWithPatientResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
-// @Router /api/appointments/appointmentPatient/{patientId} [get]
-func GetAppointmentsByPatientID(c *gin.Context) {
-	patientID := c.Param("patientId")
-	var appointments []models.Appointment
+// @Router /api/appointments/appoinmentPatient/{patientId} [get]
+func (ac *AppointmentController) GetAppointmentsByPatientID(c *gin.Context) {
+	resource := policy.Resource{Type: policy.Appointment, PatientID: c.Param("patientId")}
+	if !authorize(c, ac.policy, policy.List, resource) {
+		return
+	}
+
+	query, ok := parseListQuery(c, repositories.AppointmentHistorySpec)
+	if !ok {
+		return
+	}
 
 	// Preload Patient untuk menampilkan info mini pasien
-	if err := database.DB.Preload("Patient").
-		Where("patient_id = ?", patientID).Find(&appointments).Error; err != nil {
+	appointments, pageInfo, err := ac.service.GetByPatientID(resource.PatientID, *query)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error: "Failed to fetch appointments",
 		})
@@ -321,34 +357,52 @@ func GetAppointmentsByPatientID(c *gin.Context) {
 			Description: a.Notes,
 			CreatedAt:   a.CreatedAt.Format(time.RFC3339),
 			UpdatedAt:   a.UpdatedAt.Format(time.RFC3339),
-			Patient: dto.PatientMiniResponse{
-				ID:        a.Patient.ID,
-				FullName:  a.Patient.FullName,
-				Gender:    a.Patient.Gender,
-				BirthDate: a.Patient.BirthDate,
-			},
+			Patient:     toPatientMiniResponse(&a.Patient),
 		})
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, dto.PaginatedAppointmentsWithPatientResponse{
+		Data:       response,
+		Pagination: newPagination(query, pageInfo),
+	})
 }
 
 // GetAppointmentsByUserID godoc
 // @Summary Get appointments by user ID
-// @Description Mengambil semua janji temu berdasarkan ID user (dokter/staff), beserta informasi user yang membuat janji temu
+// @Description Mengambil semua janji temu berdasarkan ID user (dokter/staff), beserta informasi user yang membuat janji temu. Dokter hanya bisa melihat janji temunya sendiri.
 // @Tags Appointments
+// @Security BearerAuth
 // @Param userId path string true "User ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param cursor query string false "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response"
+// @Param withTotal query bool false "Also count total rows in cursor mode" default(false)
+// @Param status query string false "Filter by status (pending, done, cancelled). Also status[in]=pending,done"
+// @Param scheduleAt[gte] query string false "Scheduled at or after (YYYY-MM-DD or RFC3339)"
+// @Param scheduleAt[lte] query string false "Scheduled at or before (YYYY-MM-DD or RFC3339)"
+// @Param createdAt[gte] query string false "Created at or after (YYYY-MM-DD or RFC3339)"
+// @Param createdAt[lte] query string false "Created at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, status, scheduleAt, createdAt, updatedAt)" default(-scheduleAt)
 // @Produce json
-// @Success 200 {array} dto.AppointmentWithUserResponse
+// @Success 200 {object} dto.PaginatedAppointmentsWithUserResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/appointments/appoinmentUser/{userId} [get]
-func GetAppointmentsByUserID(c *gin.Context) {
-	userID := c.Param("userId")
-	var appointments []models.Appointment
+func (ac *AppointmentController) GetAppointmentsByUserID(c *gin.Context) {
+	resource := policy.Resource{Type: policy.Appointment, OwnerID: c.Param("userId")}
+	if !authorize(c, ac.policy, policy.List, resource) {
+		return
+	}
+
+	query, ok := parseListQuery(c, repositories.AppointmentHistorySpec)
+	if !ok {
+		return
+	}
 
 	// Preload User karena kita mau info user (bukan patient)
-	if err := database.DB.Preload("User").
-		Where("user_id = ?", userID).Find(&appointments).Error; err != nil {
+	appointments, pageInfo, err := ac.service.GetByUserID(resource.OwnerID, *query)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error: "Failed to fetch appointments",
 		})
@@ -375,7 +429,10 @@ func GetAppointmentsByUserID(c *gin.Context) {
 		})
 	}
 
-	c.JSON(http.StatusOK, responses)
+	c.JSON(http.StatusOK, dto.PaginatedAppointmentsWithUserResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
 }
 
 // ChangeAppointmentStatus godoc
@@ -389,29 +446,102 @@ func GetAppointmentsByUserID(c *gin.Context) {
 // @Param body body dto.ChangeAppointmentStatusRequest true "Status janji temu baru"
 // @Success 200 {object} dto.MessageUpdateStatusAppoinmentResponse
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/appointments/{id}/statusAppoinment [patch]
-func ChangeAppointmentStatus(c *gin.Context) {
-	id := c.Param("id")
-
+func (ac *AppointmentController) ChangeAppointmentStatus(c *gin.Context) {
 	var body dto.ChangeAppointmentStatusRequest
 	if err := c.ShouldBindJSON(&body); err != nil {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	var appointment models.Appointment
-	if err := database.DB.First(&appointment, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Appointment not found"})
+	appointment, ok := ac.loadAppointment(c)
+	if !ok {
+		return
+	}
+	if !authorize(c, ac.policy, policy.Update, appointmentResource(appointment)) {
 		return
 	}
 
-	appointment.Status = body.Status
-	if err := database.DB.Save(&appointment).Error; err != nil {
+	if err := ac.service.ChangeStatus(appointment.ID, body.Status, c.GetString("userId")); err != nil {
+		if errors.Is(err, services.ErrAppointmentNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Appointment not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to change status"})
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.MessageUpdateStatusAppoinmentResponse{Message: "Status updated"})
 }
+
+// GetAppointmentTrash godoc
+// @Summary List deleted appointments
+// @Description Get paginated list of soft-deleted appointments (trash), most recently deleted first. Only accessible by admin.
+// @Tags Appointments
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param patientId query string false "Filter by patient ID"
+// @Param userId query string false "Filter by doctor/staff user ID"
+// @Param deletedAt[gte] query string false "Deleted at or after (YYYY-MM-DD or RFC3339)"
+// @Param deletedAt[lte] query string false "Deleted at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, status, scheduleAt, createdAt, updatedAt, deletedAt)" default(-deletedAt)
+// @Success 200 {object} dto.PaginatedAppointmentTrashResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/appointments/trash [get]
+func (ac *AppointmentController) GetAppointmentTrash(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.AppointmentTrashSpec)
+	if !ok {
+		return
+	}
+
+	appointments, pageInfo, err := ac.service.GetTrash(*query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to fetch deleted appointments"})
+		return
+	}
+
+	responses := make([]dto.AppointmentTrashResponse, 0, len(appointments))
+	for i := range appointments {
+		responses = append(responses, dto.AppointmentTrashResponse{
+			AppointmentResponse: toAppointmentResponse(&appointments[i]),
+			DeletedAt:           appointments[i].DeletedAt.Time,
+		})
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedAppointmentTrashResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// RestoreAppointment godoc
+// @Summary Restore a deleted appointment
+// @Description Restore an appointment from the trash. Fails with 409 if its patient or doctor/staff is deleted; restore them first. Only accessible by admin.
+// @Tags Appointments
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Appointment ID"
+// @Success 200 {object} dto.RestoreResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/appointments/{id}/restore [post]
+func (ac *AppointmentController) RestoreAppointment(c *gin.Context) {
+	_, result, err := ac.service.Restore(c.Param("id"))
+	if err != nil {
+		writeRestoreError(c, err, services.ErrAppointmentNotFound, "Appointment not found in trash", "Failed to restore appointment")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RestoreResponse{Message: "Appointment restored successfully", Restored: result})
+}
@@ -2,19 +2,90 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/datatypes"
 
-	"mental-klinik-backend/databases"
 	"mental-klinik-backend/dto"
 	"mental-klinik-backend/models"
-	"mental-klinik-backend/utils"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
 )
 
+type AssessmentController struct {
+	service services.AssessmentService
+	policy  *policy.Policy
+	audit   services.AuditService
+}
+
+func NewAssessmentController(service services.AssessmentService, policy *policy.Policy, audit services.AuditService) *AssessmentController {
+	return &AssessmentController{service: service, policy: policy, audit: audit}
+}
+
+// recordAssessmentReads mencatat akses baca untuk setiap assessment di list
+func (ac *AssessmentController) recordAssessmentReads(c *gin.Context, assessments []models.Assessment) bool {
+	events := make([]services.AuditEvent, 0, len(assessments))
+	for _, a := range assessments {
+		events = append(events, auditEvent(c, models.AuditActionRead, policy.Assessment, a.ID, a.PatientID))
+	}
+	return recordReads(c, ac.audit, events...)
+}
+
+func assessmentResource(assessment *models.Assessment) policy.Resource {
+	return policy.Resource{Type: policy.Assessment, ID: assessment.ID, PatientID: assessment.PatientID}
+}
+
+// toAssessmentResponse membentuk response assessment beserta prediksinya.
+// Error hanya jika jawaban assessment bukan JSON yang valid.
+func toAssessmentResponse(assessment *models.Assessment) (dto.AssessmentResponse, error) {
+	var prediction *dto.PredictionResponse
+	if assessment.Prediction != nil {
+		prediction = &dto.PredictionResponse{
+			ID:               assessment.Prediction.ID,
+			AssessmentID:     assessment.Prediction.AssessmentID,
+			ResultLabel:      assessment.Prediction.ResultLabel,
+			ProbabilityScore: assessment.Prediction.ProbabilityScore,
+		}
+	}
+
+	var answersMap map[string]interface{}
+	if err := json.Unmarshal(assessment.Answers, &answersMap); err != nil {
+		return dto.AssessmentResponse{}, err
+	}
+
+	return dto.AssessmentResponse{
+		ID:        assessment.ID,
+		PatientID: assessment.PatientID,
+		Date:      assessment.Date,
+		Answers:   answersMap,
+		CreatedAt: assessment.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: assessment.UpdatedAt.Format(time.RFC3339),
+		Patient: dto.PatientMiniResponse{
+			ID:       assessment.Patient.ID,
+			FullName: assessment.Patient.FullName,
+		},
+		Prediction: prediction,
+	}, nil
+}
+
+// loadAssessment mengambil assessment untuk dicek policy-nya. Response error
+// langsung dikirim jika gagal.
+func (ac *AssessmentController) loadAssessment(c *gin.Context) (*models.Assessment, bool) {
+	assessment, err := ac.service.GetByID(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrAssessmentNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Assessment tidak ditemukan"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal mengambil data assessment"})
+		return nil, false
+	}
+	return assessment, true
+}
+
 // @Summary Buat assessment baru
 // @Description Membuat data assessment baru untuk pasien tertentu
 // @Tags Assessments
@@ -24,9 +95,10 @@ import (
 // @Param request body dto.CreateAssessmentRequest true "Data assessment baru"
 // @Success 201 {object} dto.CreateAssessmentSuccessResponse
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/assessments [post]
-func CreateAssessment(c *gin.Context) {
+func (ac *AssessmentController) CreateAssessment(c *gin.Context) {
 	var req dto.CreateAssessmentRequest
 
 	// Validasi body
@@ -35,22 +107,17 @@ func CreateAssessment(c *gin.Context) {
 		return
 	}
 
-	// Marshal jawaban ke datatypes.JSON (tipe []byte)
-	jsonAnswers := datatypes.JSON(utils.MarshalToJSON(req.Answers))
-
-	// Buat objek assessment
-	assessment := models.Assessment{
-		ID:        utils.GenerateCustomAssessmentID(1),
-		PatientID: req.PatientID,
-		Date:      req.Date,
-		Answers:   jsonAnswers,
+	if !authorize(c, ac.policy, policy.Create, policy.Resource{Type: policy.Assessment, PatientID: req.PatientID}) {
+		return
 	}
 
 	// Simpan ke database
-	if err := database.DB.Create(&assessment).Error; err != nil {
+	assessment, err := ac.service.Create(req)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal membuat assessment"})
 		return
 	}
+	recordWrite(ac.audit, auditEvent(c, models.AuditActionCreate, policy.Assessment, assessment.ID, assessment.PatientID))
 
 	// Uraikan jawaban assessment ke map[string]interface{} agar bisa dikirim sebagai respons
 	var answersMap map[string]interface{}
@@ -75,83 +142,63 @@ func CreateAssessment(c *gin.Context) {
 
 // GetAllAssessments godoc
 // @Summary Get all assessments
-// @Description Get paginated list of assessments, with optional filter by patient ID
+// @Description Get paginated list of assessments, with optional filter by patient ID. Doctors only see assessments of patients on their care team.
 // @Tags Assessments
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
+// @Param cursor query string false "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response"
+// @Param withTotal query bool false "Also count total rows in cursor mode" default(false)
 // @Param patientId query string false "Filter by patient ID"
+// @Param date[gte] query string false "Assessment date on or after (YYYY-MM-DD or RFC3339)"
+// @Param date[lte] query string false "Assessment date on or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, date, createdAt, updatedAt)" default(-createdAt)
 // @Success 200 {object} dto.PaginatedAssessmentsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/assessments [get]
-func GetAllAssessments(c *gin.Context) {
-	var assessments []models.Assessment
-	var total int64
-
-	patientId := c.Query("patientId")
-	page := c.DefaultQuery("page", "1")
-	limit := c.DefaultQuery("limit", "10")
-
-	pageInt := ParseInt(page, 1)
-	limitInt := ParseInt(limit, 10)
-	offset := (pageInt - 1) * limitInt
-
-	tx := database.DB.Model(&models.Assessment{}).Preload("Patient").Preload("Prediction")
+func (ac *AssessmentController) GetAllAssessments(c *gin.Context) {
+	if !authorize(c, ac.policy, policy.List, policy.Resource{Type: policy.Assessment}) {
+		return
+	}
 
-	if patientId != "" {
-		tx = tx.Where("patient_id = ?", patientId)
+	query, ok := parseListQuery(c, repositories.AssessmentListSpec)
+	if !ok {
+		return
 	}
 
-	if err := tx.Count(&total).Limit(limitInt).Offset(offset).Order("created_at DESC").Find(&assessments).Error; err != nil {
+	scope := ac.policy.ListScope(currentSubject(c), policy.Assessment)
+	assessments, pageInfo, err := ac.service.GetAll(repositories.AssessmentFilter{
+		CareTeamOf:         scope.CareTeamOf,
+		EmergencyPatientID: scope.EmergencyPatientID,
+		Query:              *query,
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal mengambil data assessment"})
 		return
 	}
+	if !ac.recordAssessmentReads(c, assessments) {
+		return
+	}
 
 	// Mapping ke DTO
 	var responses []dto.AssessmentResponse
-	for _, a := range assessments {
-		var prediction *dto.PredictionResponse
-		if a.Prediction != nil {
-			prediction = &dto.PredictionResponse{
-				ID:               a.Prediction.ID,
-				AssessmentID:     a.Prediction.AssessmentID,
-				ResultLabel:      a.Prediction.ResultLabel,
-				ProbabilityScore: a.Prediction.ProbabilityScore,
-			}
-		}
-
-		// Unmarshal JSON ke map
-		var answersMap map[string]interface{}
-		if err := json.Unmarshal(a.Answers, &answersMap); err != nil {
+	for i := range assessments {
+		response, err := toAssessmentResponse(&assessments[i])
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal menguraikan jawaban assessment"})
 			return
 		}
-
-		responses = append(responses, dto.AssessmentResponse{
-			ID:        a.ID,
-			PatientID: a.PatientID,
-			Date:      a.Date,
-			Answers:   answersMap,
-			CreatedAt: a.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-			UpdatedAt: a.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-			Patient: dto.PatientMiniResponse{
-				ID:       a.Patient.ID,
-				FullName: a.Patient.FullName,
-			},
-			Prediction: prediction,
-		})
+		responses = append(responses, response)
 	}
 
 	c.JSON(http.StatusOK, dto.PaginatedAssessmentsResponse{
 		Data:       responses,
-		Total:      int(total),
-		Page:       pageInt,
-		Limit:      limitInt,
-		TotalPages: int((total + int64(limitInt) - 1) / int64(limitInt)),
+		Pagination: newPagination(query, pageInfo),
 	})
 }
 
@@ -165,16 +212,17 @@ func GetAllAssessments(c *gin.Context) {
 // @Success 200 {object} dto.GetAssessmentByIDSuccessResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Router /api/assessments/{id} [get]
-func GetAssessmentByID(c *gin.Context) {
-	id := c.Param("id")
-	var assessment models.Assessment
-
-	if err := database.DB.
-		Preload("Patient").
-		Preload("Prediction").
-		First(&assessment, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Assessment tidak ditemukan"})
+func (ac *AssessmentController) GetAssessmentByID(c *gin.Context) {
+	assessment, ok := ac.loadAssessment(c)
+	if !ok {
+		return
+	}
+	if !authorize(c, ac.policy, policy.Read, assessmentResource(assessment)) {
+		return
+	}
+	if !recordReads(c, ac.audit, auditEvent(c, models.AuditActionRead, policy.Assessment, assessment.ID, assessment.PatientID)) {
 		return
 	}
 
@@ -192,12 +240,7 @@ func GetAssessmentByID(c *gin.Context) {
 		Answers:   answersMap, // pakai hasil unmarshal
 		CreatedAt: assessment.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt: assessment.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		Patient: dto.PatientMiniResponse{
-			ID:        assessment.Patient.ID,
-			FullName:  assessment.Patient.FullName,
-			Gender:    assessment.Patient.Gender,
-			BirthDate: assessment.Patient.BirthDate,
-		},
+		Patient:   toPatientMiniResponse(&assessment.Patient),
 	}
 
 	if assessment.Prediction != nil {
@@ -226,10 +269,10 @@ func GetAssessmentByID(c *gin.Context) {
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Router /api/assessments/{id} [put]
 // @Security BearerAuth
-func UpdateAssessment(c *gin.Context) {
-	id := c.Param("id")
+func (ac *AssessmentController) UpdateAssessment(c *gin.Context) {
 	var req dto.UpdateAssessmentRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -237,21 +280,26 @@ func UpdateAssessment(c *gin.Context) {
 		return
 	}
 
-	var assessment models.Assessment
-	if err := database.DB.First(&assessment, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Assessment tidak ditemukan"})
+	current, ok := ac.loadAssessment(c)
+	if !ok {
+		return
+	}
+	if !authorize(c, ac.policy, policy.Update, assessmentResource(current)) {
 		return
 	}
 
-	// Update field
-	assessment.Date = req.Date
-	assessment.Answers = utils.MarshalToJSON(req.Answers)
-	assessment.UpdatedAt = time.Now()
-
-	if err := database.DB.Save(&assessment).Error; err != nil {
+	assessment, updatedFields, err := ac.service.Update(current.ID, req)
+	if err != nil {
+		if errors.Is(err, services.ErrAssessmentNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Assessment tidak ditemukan"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal mengupdate assessment"})
 		return
 	}
+	event := auditEvent(c, models.AuditActionUpdate, policy.Assessment, assessment.ID, assessment.PatientID)
+	event.Changes = updatedFields
+	recordWrite(ac.audit, event)
 
 	// Unmarshal untuk response
 	var answersMap map[string]interface{}
@@ -279,15 +327,24 @@ func UpdateAssessment(c *gin.Context) {
 // @Param id path string true "Assessment ID"
 // @Success 200 {object} dto.MessageDeleteAssesmentResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/assessments/{id} [delete]
-func DeleteAssessment(c *gin.Context) {
-	id := c.Param("id")
-	if err := database.DB.Delete(&models.Assessment{}, "id = ?", id).Error; err != nil {
+func (ac *AssessmentController) DeleteAssessment(c *gin.Context) {
+	assessment, ok := ac.loadAssessment(c)
+	if !ok {
+		return
+	}
+	if !authorize(c, ac.policy, policy.Delete, assessmentResource(assessment)) {
+		return
+	}
+
+	if err := ac.service.Delete(assessment.ID); err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal menghapus assessment"})
 		return
 	}
+	recordWrite(ac.audit, auditEvent(c, models.AuditActionDelete, policy.Assessment, assessment.ID, assessment.PatientID))
 	c.JSON(http.StatusOK, dto.MessageDeleteAssesmentResponse{Message: "Assessment berhasil dihapus"})
 }
 
@@ -297,22 +354,43 @@ func DeleteAssessment(c *gin.Context) {
 // @Tags Assessments
 // @Security BearerAuth
 // @Param patientId path string true "Patient ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param cursor query string false "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response"
+// @Param withTotal query bool false "Also count total rows in cursor mode" default(false)
+// @Param date[gte] query string false "Assessment date on or after (YYYY-MM-DD or RFC3339)"
+// @Param date[lte] query string false "Assessment date on or before (YYYY-MM-DD or RFC3339)"
+// @Param createdAt[gte] query string false "Created at or after (YYYY-MM-DD or RFC3339)"
+// @Param createdAt[lte] query string false "Created at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, date, createdAt, updatedAt)" default(-createdAt)
 // @Produce json
-// @Success 200 {array} dto.AssessmentResponse
+// @Success 200 {object} dto.PaginatedAssessmentsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
-// @Router /api/assessments/patient/{patientId} [get]
-func GetAssessmentsByPatientID(c *gin.Context) {
-	patientId := c.Param("patientId")
-	var assessments []models.Assessment
-
-	// Ambil semua assessment + relasi prediction + relasi patient
-	if err := database.DB.Preload("Prediction").Preload("Patient").
-		Where("patient_id = ?", patientId).Find(&assessments).Error; err != nil {
+// @Router /api/assessments/byPatient/{patientId} [get]
+func (ac *AssessmentController) GetAssessmentsByPatientID(c *gin.Context) {
+	resource := policy.Resource{Type: policy.Assessment, PatientID: c.Param("patientId")}
+	if !authorize(c, ac.policy, policy.List, resource) {
+		return
+	}
+
+	query, ok := parseListQuery(c, repositories.AssessmentListSpec)
+	if !ok {
+		return
+	}
+
+	// Ambil assessment + relasi prediction + relasi patient
+	assessments, pageInfo, err := ac.service.GetByPatientID(resource.PatientID, *query)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error: "Gagal mengambil assessment pasien",
 		})
 		return
 	}
+	if !ac.recordAssessmentReads(c, assessments) {
+		return
+	}
 
 	var responses []dto.AssessmentResponse
 	for _, a := range assessments {
@@ -336,30 +414,100 @@ func GetAssessmentsByPatientID(c *gin.Context) {
 		}
 
 		responses = append(responses, dto.AssessmentResponse{
-			ID:        a.ID,
-			PatientID: a.PatientID,
-			Date:      a.Date,
-			Answers:   answersMap, // sudah dikonversi
-			CreatedAt: a.CreatedAt.Format(time.RFC3339),
-			UpdatedAt: a.UpdatedAt.Format(time.RFC3339),
-			Patient: dto.PatientMiniResponse{
-				ID:        a.Patient.ID,
-				FullName:  a.Patient.FullName,
-				Gender:    a.Patient.Gender,
-				BirthDate: a.Patient.BirthDate,
-			},
+			ID:         a.ID,
+			PatientID:  a.PatientID,
+			Date:       a.Date,
+			Answers:    answersMap, // sudah dikonversi
+			CreatedAt:  a.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:  a.UpdatedAt.Format(time.RFC3339),
+			Patient:    toPatientMiniResponse(&a.Patient),
 			Prediction: prediction,
 		})
 	}
 
-	c.JSON(http.StatusOK, responses)
+	c.JSON(http.StatusOK, dto.PaginatedAssessmentsResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
 }
 
-// ParseInt helper
-func ParseInt(s string, fallback int) int {
-	val, err := strconv.Atoi(s)
+// GetAssessmentTrash godoc
+// @Summary Daftar assessment yang sudah dihapus
+// @Description Mengambil daftar assessment yang sudah di-soft delete (trash), yang terakhir dihapus lebih dulu. Hanya untuk admin.
+// @Tags Assessments
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param patientId query string false "Filter by patient ID"
+// @Param deletedAt[gte] query string false "Deleted at or after (YYYY-MM-DD or RFC3339)"
+// @Param deletedAt[lte] query string false "Deleted at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, date, createdAt, updatedAt, deletedAt)" default(-deletedAt)
+// @Success 200 {object} dto.PaginatedAssessmentTrashResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/assessments/trash [get]
+func (ac *AssessmentController) GetAssessmentTrash(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.AssessmentTrashSpec)
+	if !ok {
+		return
+	}
+
+	assessments, pageInfo, err := ac.service.GetTrash(*query)
 	if err != nil {
-		return fallback
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal mengambil data assessment yang dihapus"})
+		return
+	}
+	if !ac.recordAssessmentReads(c, assessments) {
+		return
 	}
-	return val
+
+	responses := make([]dto.AssessmentTrashResponse, 0, len(assessments))
+	for i := range assessments {
+		response, err := toAssessmentResponse(&assessments[i])
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal menguraikan jawaban assessment"})
+			return
+		}
+		responses = append(responses, dto.AssessmentTrashResponse{AssessmentResponse: response, DeletedAt: assessments[i].DeletedAt.Time})
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedAssessmentTrashResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// RestoreAssessment godoc
+// @Summary Memulihkan assessment yang sudah dihapus
+// @Description Memulihkan assessment dari trash. Gagal (409) jika pasiennya sudah dihapus; pulihkan pasien lebih dulu. Dengan cascade=true, prediksi yang terhapus bersama assessment ikut dipulihkan. Hanya untuk admin.
+// @Tags Assessments
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Assessment ID"
+// @Param cascade query bool false "Ikut pulihkan prediksi yang terhapus bersama assessment" default(false)
+// @Success 200 {object} dto.RestoreResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/assessments/{id}/restore [post]
+func (ac *AssessmentController) RestoreAssessment(c *gin.Context) {
+	cascade, ok := parseCascade(c)
+	if !ok {
+		return
+	}
+
+	assessment, result, err := ac.service.Restore(c.Param("id"), cascade)
+	if err != nil {
+		writeRestoreError(c, err, services.ErrAssessmentNotFound, "Assessment tidak ditemukan di trash", "Gagal memulihkan assessment")
+		return
+	}
+	recordWrite(ac.audit, restoreAuditEvent(c, policy.Assessment, assessment.ID, assessment.PatientID, result))
+
+	c.JSON(http.StatusOK, dto.RestoreResponse{Message: "Assessment berhasil dipulihkan", Restored: result})
 }
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/services"
+)
+
+// auditEvent menyusun event audit untuk user yang login dan request saat ini.
+// Akses ke pasien lewat token akses darurat diberi reason
+// emergency-access:<id> sehingga bisa ditelusuri dari laporan review.
+func auditEvent(c *gin.Context, action string, resourceType policy.ResourceType, resourceID string, patientID string) services.AuditEvent {
+	event := services.AuditEvent{
+		ActorID:      c.GetString("userId"),
+		ActorRole:    c.GetString("role"),
+		SessionID:    c.GetString("sessionId"),
+		Action:       action,
+		ResourceType: string(resourceType),
+		ResourceID:   resourceID,
+		PatientID:    patientID,
+		IPAddress:    c.ClientIP(),
+		UserAgent:    c.Request.UserAgent(),
+	}
+	if patientID != "" && patientID == c.GetString("emergencyPatientId") {
+		event.Reason = emergencyAuditReason(c.GetString("emergencyAccessId"))
+	}
+	return event
+}
+
+func emergencyAuditReason(accessID string) string {
+	return "emergency-access:" + accessID
+}
+
+// recordReads mencatat akses baca sebelum data dikirim. Jika audit log gagal
+// ditulis, data tidak dikirim: response 500 langsung dikirim dan ok bernilai
+// false.
+func recordReads(c *gin.Context, audit services.AuditService, events ...services.AuditEvent) bool {
+	if err := audit.Record(events...); err != nil {
+		log.Printf("audit: failed to record %d read event(s): %v", len(events), err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to write audit log"})
+		return false
+	}
+	return true
+}
+
+// recordWrite mencatat perubahan yang sudah tersimpan. Perubahan tidak bisa
+// dibatalkan lagi, jadi kegagalan audit hanya dicatat ke log server.
+func recordWrite(audit services.AuditService, event services.AuditEvent) {
+	if err := audit.Record(event); err != nil {
+		log.Printf("audit: failed to record %s %s %s by %s: %v",
+			event.Action, event.ResourceType, event.ResourceID, event.ActorID, err)
+	}
+}
@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
+)
+
+type AuditController struct {
+	service services.AuditService
+}
+
+func NewAuditController(service services.AuditService) *AuditController {
+	return &AuditController{service: service}
+}
+
+// GetAuditLogs godoc
+// @Summary Get audit logs
+// @Description Get paginated PHI access audit log (reads and writes of patients, assessments, predictions and medical records). Only accessible by admin.
+// @Tags Audit
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page (max 500)" default(10)
+// @Param actorId query string false "Filter by actor user ID"
+// @Param actorRole query string false "Filter by actor role"
+// @Param action query string false "Filter by action (create, read, update, delete, reveal, break_glass, export, erase, restore, merge, unmerge). Also action[in]=update,delete"
+// @Param resourceType query string false "Filter by resource type (patient, assessment, prediction, medical_record, consent, data_export, erasure_request, legal_hold)"
+// @Param resourceId query string false "Filter by resource ID"
+// @Param patientId query string false "Filter by patient ID"
+// @Param ipAddress query string false "Filter by client IP"
+// @Param reason query string false "Filter by reason, e.g. emergency-access:<id> for every access made with one break-the-glass grant"
+// @Param occurredAt[gte] query string false "Occurred at or after (YYYY-MM-DD or RFC3339)"
+// @Param occurredAt[lte] query string false "Occurred at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, occurredAt)" default(-id)
+// @Success 200 {object} dto.PaginatedAuditLogsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/audit-logs/ [get]
+func (ac *AuditController) GetAuditLogs(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.AuditLogListSpec)
+	if !ok {
+		return
+	}
+
+	entries, pageInfo, err := ac.service.GetAll(*query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve audit logs"})
+		return
+	}
+
+	responses := make([]dto.AuditLogResponse, 0, len(entries))
+	for i := range entries {
+		responses = append(responses, toAuditLogResponse(&entries[i]))
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedAuditLogsResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// ExportAuditLogs godoc
+// @Summary Export audit logs as CSV
+// @Description Download every audit entry matching the filters (same filters as the list endpoint, without paging) as CSV, oldest first, including the hash chain columns. Only accessible by admin.
+// @Tags Audit
+// @Security BearerAuth
+// @Produce text/csv
+// @Param actorId query string false "Filter by actor user ID"
+// @Param action query string false "Filter by action (create, read, update, delete)"
+// @Param resourceType query string false "Filter by resource type"
+// @Param resourceId query string false "Filter by resource ID"
+// @Param patientId query string false "Filter by patient ID"
+// @Param occurredAt[gte] query string false "Occurred at or after (YYYY-MM-DD or RFC3339)"
+// @Param occurredAt[lte] query string false "Occurred at or before (YYYY-MM-DD or RFC3339)"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/audit-logs/export [get]
+func (ac *AuditController) ExportAuditLogs(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.AuditLogListSpec)
+	if !ok {
+		return
+	}
+
+	filename := fmt.Sprintf("audit-logs-%s.csv", time.Now().Format("20060102-150405"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write([]string{
+		"id", "occurred_at", "actor_id", "actor_role", "session_id", "action",
+		"resource_type", "resource_id", "patient_id", "changed_fields", "reason",
+		"ip_address", "user_agent", "prev_hash", "hash",
+	})
+
+	// Header sudah terkirim, jadi error di tengah export hanya bisa dicatat
+	// ke log server (file CSV akan terpotong)
+	err := ac.service.Export(*query, func(entry *models.AuditLog) error {
+		return w.Write([]string{
+			strconv.FormatInt(entry.ID, 10),
+			entry.OccurredAt.UTC().Format(time.RFC3339Nano),
+			entry.ActorID,
+			entry.ActorRole,
+			entry.SessionID,
+			entry.Action,
+			entry.ResourceType,
+			entry.ResourceID,
+			entry.PatientID,
+			strings.Join(entry.Fields(), ";"),
+			entry.Reason,
+			entry.IPAddress,
+			entry.UserAgent,
+			entry.PrevHash,
+			entry.Hash,
+		})
+	})
+	w.Flush()
+	if err == nil {
+		err = w.Error()
+	}
+	if err != nil {
+		log.Printf("audit: export failed: %v", err)
+	}
+}
+
+// VerifyAuditChain godoc
+// @Summary Verify audit log hash chain
+// @Description Recompute the hash of every audit entry and check that each one links to the previous entry. Reports the first broken entry if the log was modified. Only accessible by admin.
+// @Tags Audit
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.AuditVerificationResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/audit-logs/verify [get]
+func (ac *AuditController) VerifyAuditChain(c *gin.Context) {
+	result, err := ac.service.Verify()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to verify audit log"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.AuditVerificationResponse{
+		Valid:    result.Valid,
+		Checked:  result.Checked,
+		BrokenAt: result.BrokenAt,
+		Reason:   result.Reason,
+		LastHash: result.LastHash,
+	})
+}
+
+func toAuditLogResponse(entry *models.AuditLog) dto.AuditLogResponse {
+	fields := entry.Fields()
+	if fields == nil {
+		fields = []string{}
+	}
+	return dto.AuditLogResponse{
+		ID:            entry.ID,
+		OccurredAt:    entry.OccurredAt,
+		ActorID:       entry.ActorID,
+		ActorRole:     entry.ActorRole,
+		SessionID:     entry.SessionID,
+		Action:        entry.Action,
+		ResourceType:  entry.ResourceType,
+		ResourceID:    entry.ResourceID,
+		PatientID:     entry.PatientID,
+		ChangedFields: fields,
+		Reason:        entry.Reason,
+		IPAddress:     entry.IPAddress,
+		UserAgent:     entry.UserAgent,
+		PrevHash:      entry.PrevHash,
+		Hash:          entry.Hash,
+	}
+}
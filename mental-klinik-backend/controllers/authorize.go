@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/policy"
+)
+
+// currentSubject membaca user yang login dari context (diisi AuthMiddleware)
+func currentSubject(c *gin.Context) policy.Subject {
+	return policy.Subject{
+		UserID:             c.GetString("userId"),
+		Role:               c.GetString("role"),
+		EmergencyPatientID: c.GetString("emergencyPatientId"),
+	}
+}
+
+// authorize menanyakan policy apakah user yang login boleh melakukan action
+// terhadap resource. Jika tidak, response 403 langsung dikirim dan ok bernilai
+// false.
+func authorize(c *gin.Context, p *policy.Policy, action policy.Action, resource policy.Resource) bool {
+	err := p.Authorize(currentSubject(c), action, resource)
+	if err == nil {
+		return true
+	}
+
+	var denied *policy.DeniedError
+	if errors.As(err, &denied) {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "Forbidden - " + denied.Reason})
+		return false
+	}
+	c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to check access"})
+	return false
+}
@@ -0,0 +1,398 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
+	"mental-klinik-backend/utils"
+)
+
+type CareTeamController struct {
+	service services.CareTeamService
+	policy  *policy.Policy
+}
+
+func NewCareTeamController(service services.CareTeamService, policy *policy.Policy) *CareTeamController {
+	return &CareTeamController{service: service, policy: policy}
+}
+
+// careTeamResource: pemilik penugasan adalah user yang ditugaskan
+func careTeamResource(assignment *models.CareAssignment) policy.Resource {
+	return policy.Resource{
+		Type:      policy.CareAssignment,
+		ID:        assignment.ID,
+		PatientID: assignment.PatientID,
+		OwnerID:   assignment.UserID,
+	}
+}
+
+// loadAssignment mengambil penugasan untuk dicek policy-nya. Response error
+// langsung dikirim jika gagal.
+func (cc *CareTeamController) loadAssignment(c *gin.Context) (*models.CareAssignment, bool) {
+	assignment, err := cc.service.GetByID(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrCareAssignmentNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Care assignment not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to fetch care assignment"})
+		return nil, false
+	}
+	return assignment, true
+}
+
+// writeCareTeamError memetakan error service tim perawatan ke status HTTP
+func writeCareTeamError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrCareAssignmentNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Care assignment not found"})
+	case errors.Is(err, services.ErrPatientNotFound):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Patient not found"})
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "User not found"})
+	case errors.Is(err, services.ErrCareRoleMismatch),
+		errors.Is(err, services.ErrInvalidCareDates),
+		errors.Is(err, services.ErrSameCareUser):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrAlreadyOnCareTeam),
+		errors.Is(err, services.ErrPrimaryClinicianExists),
+		errors.Is(err, services.ErrCareAssignmentEnded):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: fallback})
+	}
+}
+
+// AssignCareTeam godoc
+// @Summary Assign care team member
+// @Description Add a doctor (primary or secondary clinician) or staff member to a patient's care team. A patient has at most one primary clinician at a time. Accessible by admin and staff.
+// @Tags Care Team
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.AssignCareTeamRequest true "Care assignment input"
+// @Success 201 {object} dto.CareAssignmentMessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/care-team/ [post]
+func (cc *CareTeamController) AssignCareTeam(c *gin.Context) {
+	var input dto.AssignCareTeamRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resource := policy.Resource{Type: policy.CareAssignment, PatientID: input.PatientID, OwnerID: input.UserID}
+	if !authorize(c, cc.policy, policy.Create, resource) {
+		return
+	}
+
+	assignment, err := cc.service.Assign(input, c.GetString("userId"))
+	if err != nil {
+		writeCareTeamError(c, err, "Failed to assign care team member")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CareAssignmentMessageResponse{
+		Message:    "Care team member assigned",
+		Assignment: toCareAssignmentResponse(assignment, utils.Today()),
+	})
+}
+
+// GetAllCareAssignments godoc
+// @Summary Get care team assignments
+// @Description Get paginated list of care team assignments. Doctors only see assignments of patients on their care team.
+// @Tags Care Team
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param patientId query string false "Filter by patient ID"
+// @Param userId query string false "Filter by assigned user ID"
+// @Param role query string false "Filter by care role (primary, secondary, staff)"
+// @Param active query bool false "true: only assignments active today, false: ended or upcoming"
+// @Param startDate[gte] query string false "Started on or after (YYYY-MM-DD)"
+// @Param startDate[lte] query string false "Started on or before (YYYY-MM-DD)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, role, startDate, endDate, createdAt)" default(-startDate)
+// @Success 200 {object} dto.PaginatedCareAssignmentsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/care-team/ [get]
+func (cc *CareTeamController) GetAllCareAssignments(c *gin.Context) {
+	if !authorize(c, cc.policy, policy.List, policy.Resource{Type: policy.CareAssignment}) {
+		return
+	}
+
+	query, ok := parseListQuery(c, repositories.CareAssignmentListSpec)
+	if !ok {
+		return
+	}
+
+	var active *bool
+	if raw := c.Query("active"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.QueryErrorResponse{Error: "active must be true or false", Param: "active"})
+			return
+		}
+		active = &value
+	}
+
+	scope := cc.policy.ListScope(currentSubject(c), policy.CareAssignment)
+	assignments, pageInfo, err := cc.service.GetAll(repositories.CareAssignmentFilter{
+		Active:             active,
+		CareTeamOf:         scope.CareTeamOf,
+		EmergencyPatientID: scope.EmergencyPatientID,
+		Query:              *query,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve care assignments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedCareAssignmentsResponse{
+		Data:       toCareAssignmentResponses(assignments),
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// GetMyCaseload godoc
+// @Summary Get my caseload
+// @Description Get the patients currently assigned to the logged-in user (care assignments active today), based on the user ID in the JWT.
+// @Tags Care Team
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param role query string false "Filter by care role (primary, secondary, staff)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (role, startDate)" default(-startDate)
+// @Success 200 {object} dto.PaginatedCareAssignmentsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/care-team/caseload [get]
+func (cc *CareTeamController) GetMyCaseload(c *gin.Context) {
+	userID := c.GetString("userId")
+	if !authorize(c, cc.policy, policy.List, policy.Resource{Type: policy.CareAssignment, OwnerID: userID}) {
+		return
+	}
+
+	query, ok := parseListQuery(c, repositories.CaseloadSpec)
+	if !ok {
+		return
+	}
+
+	assignments, pageInfo, err := cc.service.Caseload(userID, *query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve caseload"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedCareAssignmentsResponse{
+		Data:       toCareAssignmentResponses(assignments),
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// GetCareAssignmentByID godoc
+// @Summary Get care assignment by ID
+// @Description Retrieve a care team assignment. Doctors can see their own assignments and those of patients on their care team.
+// @Tags Care Team
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Care assignment ID"
+// @Success 200 {object} dto.CareAssignmentResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/care-team/{id} [get]
+func (cc *CareTeamController) GetCareAssignmentByID(c *gin.Context) {
+	assignment, ok := cc.loadAssignment(c)
+	if !ok {
+		return
+	}
+	if !authorize(c, cc.policy, policy.Read, careTeamResource(assignment)) {
+		return
+	}
+
+	c.JSON(http.StatusOK, toCareAssignmentResponse(assignment, utils.Today()))
+}
+
+// TransferCareAssignment godoc
+// @Summary Transfer care assignment
+// @Description End the assignment on the effective date (default today) and give the same care role to another user from that date. If the target is already a secondary clinician and the primary role is transferred, they are promoted. Accessible by admin and staff.
+// @Tags Care Team
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Care assignment ID"
+// @Param request body dto.TransferCareRequest true "Transfer input"
+// @Success 200 {object} dto.CareAssignmentMessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/care-team/{id}/transfer [post]
+func (cc *CareTeamController) TransferCareAssignment(c *gin.Context) {
+	var input dto.TransferCareRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	assignment, ok := cc.loadAssignment(c)
+	if !ok {
+		return
+	}
+	if !authorize(c, cc.policy, policy.Update, careTeamResource(assignment)) {
+		return
+	}
+
+	next, err := cc.service.Transfer(assignment.ID, input, c.GetString("userId"))
+	if err != nil {
+		writeCareTeamError(c, err, "Failed to transfer care assignment")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CareAssignmentMessageResponse{
+		Message:    "Care assignment transferred",
+		Assignment: toCareAssignmentResponse(next, utils.Today()),
+	})
+}
+
+// EndCareAssignment godoc
+// @Summary End care assignment
+// @Description End a care relationship on the given date (default today, exclusive). Accessible by admin and staff.
+// @Tags Care Team
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Care assignment ID"
+// @Param request body dto.EndCareRequest true "End input"
+// @Success 200 {object} dto.CareAssignmentMessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/care-team/{id}/end [post]
+func (cc *CareTeamController) EndCareAssignment(c *gin.Context) {
+	var input dto.EndCareRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	assignment, ok := cc.loadAssignment(c)
+	if !ok {
+		return
+	}
+	if !authorize(c, cc.policy, policy.Update, careTeamResource(assignment)) {
+		return
+	}
+
+	ended, err := cc.service.End(assignment.ID, input, c.GetString("userId"))
+	if err != nil {
+		writeCareTeamError(c, err, "Failed to end care assignment")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CareAssignmentMessageResponse{
+		Message:    "Care assignment ended",
+		Assignment: toCareAssignmentResponse(ended, utils.Today()),
+	})
+}
+
+// HandoverCaseload godoc
+// @Summary Bulk handover caseload
+// @Description Transfer every assignment of one user that has not ended (optionally only for the given patients) to another user in a single transaction, e.g. when a clinician leaves. Accessible by admin and staff.
+// @Tags Care Team
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CareHandoverRequest true "Handover input"
+// @Success 200 {object} dto.CareHandoverResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/care-team/handover [post]
+func (cc *CareTeamController) HandoverCaseload(c *gin.Context) {
+	var input dto.CareHandoverRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resource := policy.Resource{Type: policy.CareAssignment, OwnerID: input.FromUserID}
+	if !authorize(c, cc.policy, policy.Update, resource) {
+		return
+	}
+
+	transferred, assignments, err := cc.service.Handover(input, c.GetString("userId"))
+	if err != nil {
+		writeCareTeamError(c, err, "Failed to hand over caseload")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CareHandoverResponse{
+		Message:     "Caseload handed over",
+		Transferred: transferred,
+		Assignments: toCareAssignmentResponses(assignments),
+	})
+}
+
+func toCareAssignmentResponses(assignments []models.CareAssignment) []dto.CareAssignmentResponse {
+	today := utils.Today()
+	responses := make([]dto.CareAssignmentResponse, 0, len(assignments))
+	for i := range assignments {
+		responses = append(responses, toCareAssignmentResponse(&assignments[i], today))
+	}
+	return responses
+}
+
+func toCareAssignmentResponse(assignment *models.CareAssignment, today time.Time) dto.CareAssignmentResponse {
+	response := dto.CareAssignmentResponse{
+		ID:           assignment.ID,
+		PatientID:    assignment.PatientID,
+		UserID:       assignment.UserID,
+		Role:         assignment.Role,
+		Status:       assignment.Status(today),
+		StartDate:    assignment.StartDate.Format(utils.DateLayout),
+		EndReason:    assignment.EndReason,
+		AssignedByID: assignment.AssignedByID,
+		EndedByID:    assignment.EndedByID,
+		Patient:      toPatientMiniResponse(&assignment.Patient),
+		User: dto.UserMiniResponse{
+			ID:       assignment.User.ID,
+			FullName: assignment.User.FullName,
+			Role:     assignment.User.Role,
+			Email:    assignment.User.Email,
+		},
+	}
+	if assignment.EndDate != nil {
+		endDate := assignment.EndDate.Format(utils.DateLayout)
+		response.EndDate = &endDate
+	}
+	return response
+}
@@ -0,0 +1,410 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
+)
+
+type ConsentController struct {
+	service services.ConsentService
+	policy  *policy.Policy
+	audit   services.AuditService
+}
+
+func NewConsentController(service services.ConsentService, policy *policy.Policy, audit services.AuditService) *ConsentController {
+	return &ConsentController{service: service, policy: policy, audit: audit}
+}
+
+func consentResource(patientID string, id string) policy.Resource {
+	return policy.Resource{Type: policy.Consent, ID: id, PatientID: patientID}
+}
+
+// writeConsentError memetakan error service persetujuan ke status HTTP
+func writeConsentError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrPatientNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Patient not found"})
+	case errors.Is(err, services.ErrConsentNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Consent not found"})
+	case errors.Is(err, services.ErrConsentDocumentNotFound):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Consent document not found"})
+	case errors.Is(err, services.ErrInvalidConsentDate),
+		errors.Is(err, services.ErrGuardianRequired),
+		errors.Is(err, services.ErrInvalidGuardianContact):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrConsentDocumentOutdated),
+		errors.Is(err, services.ErrConsentAlreadyGranted),
+		errors.Is(err, services.ErrConsentWithdrawn):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: fallback})
+	}
+}
+
+// PublishConsentDocument godoc
+// @Summary Publish consent document version
+// @Description Publish a new version of the consent text for one consent type (treatment, ai_prediction, research, data_sharing). The version number is assigned automatically. Published documents cannot be edited. Existing consents stay valid until withdrawn; new consents must use the latest version. Accessible by admin only.
+// @Tags Consents
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateConsentDocumentRequest true "Consent document"
+// @Success 201 {object} dto.ConsentDocumentMessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/consent-documents/ [post]
+func (cc *ConsentController) PublishConsentDocument(c *gin.Context) {
+	var input dto.CreateConsentDocumentRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	document, err := cc.service.PublishDocument(input, c.GetString("userId"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to publish consent document"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ConsentDocumentMessageResponse{
+		Message:  "Consent document published",
+		Document: toConsentDocumentResponse(document),
+	})
+}
+
+// GetConsentDocuments godoc
+// @Summary Get consent documents
+// @Description Get paginated consent document versions. Use current=true to get only the latest version of each type.
+// @Tags Consents
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param current query bool false "Only the latest version of each type"
+// @Param type query string false "Filter by consent type (treatment, ai_prediction, research, data_sharing)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, type, version, createdAt)" default(type,-version)
+// @Success 200 {object} dto.PaginatedConsentDocumentsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/consent-documents/ [get]
+func (cc *ConsentController) GetConsentDocuments(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.ConsentDocumentListSpec)
+	if !ok {
+		return
+	}
+
+	var current bool
+	if raw := c.Query("current"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.QueryErrorResponse{Error: "current must be true or false", Param: "current"})
+			return
+		}
+		current = value
+	}
+
+	documents, pageInfo, err := cc.service.GetDocuments(repositories.ConsentDocumentFilter{
+		Current: current,
+		Query:   *query,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve consent documents"})
+		return
+	}
+
+	responses := make([]dto.ConsentDocumentResponse, 0, len(documents))
+	for i := range documents {
+		responses = append(responses, toConsentDocumentResponse(&documents[i]))
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedConsentDocumentsResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// GetConsentDocumentByID godoc
+// @Summary Get consent document by ID
+// @Description Retrieve one consent document version, including its full text.
+// @Tags Consents
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Consent document ID"
+// @Success 200 {object} dto.ConsentDocumentResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/consent-documents/{id} [get]
+func (cc *ConsentController) GetConsentDocumentByID(c *gin.Context) {
+	document, err := cc.service.GetDocumentByID(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrConsentDocumentNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Consent document not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve consent document"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toConsentDocumentResponse(document))
+}
+
+// GrantConsent godoc
+// @Summary Record patient consent
+// @Description Record that the patient agreed to the latest version of a consent document. The logged-in user is stored as the one who captured it. An active consent of the same type on an older version is withdrawn automatically (superseded). For patients under 18 at the grant time, a guardian is required: guardianContactId (one of the patient's contacts marked as legal guardian; its name is used when guardianName is empty) or guardianName. Accessible by admin, staff and doctors on the patient's care team.
+// @Tags Consents
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Param request body dto.GrantConsentRequest true "Consent input"
+// @Success 201 {object} dto.PatientConsentMessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/{id}/consents [post]
+func (cc *ConsentController) GrantConsent(c *gin.Context) {
+	patientID := c.Param("id")
+	if !authorize(c, cc.policy, policy.Create, consentResource(patientID, "")) {
+		return
+	}
+
+	var input dto.GrantConsentRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	consent, err := cc.service.Grant(patientID, input, c.GetString("userId"))
+	if err != nil {
+		writeConsentError(c, err, "Failed to record consent")
+		return
+	}
+	recordWrite(cc.audit, auditEvent(c, models.AuditActionCreate, policy.Consent, consent.ID, patientID))
+
+	c.JSON(http.StatusCreated, dto.PatientConsentMessageResponse{
+		Message: "Consent recorded",
+		Consent: toPatientConsentResponse(consent),
+	})
+}
+
+// GetPatientConsents godoc
+// @Summary Get patient consent history
+// @Description Get the patient's consent records, including withdrawn ones. Accessible by admin, staff and doctors on the patient's care team.
+// @Tags Consents
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param active query bool false "true: only active consents, false: only withdrawn consents"
+// @Param type query string false "Filter by consent type"
+// @Param grantedAt[gte] query string false "Granted at or after (YYYY-MM-DD or RFC3339)"
+// @Param grantedAt[lte] query string false "Granted at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, type, grantedAt, withdrawnAt)" default(-grantedAt)
+// @Success 200 {object} dto.PaginatedPatientConsentsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/{id}/consents [get]
+func (cc *ConsentController) GetPatientConsents(c *gin.Context) {
+	patientID := c.Param("id")
+	if !authorize(c, cc.policy, policy.List, consentResource(patientID, "")) {
+		return
+	}
+
+	query, ok := parseListQuery(c, repositories.PatientConsentListSpec)
+	if !ok {
+		return
+	}
+
+	var active *bool
+	if raw := c.Query("active"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.QueryErrorResponse{Error: "active must be true or false", Param: "active"})
+			return
+		}
+		active = &value
+	}
+
+	consents, pageInfo, err := cc.service.GetAll(repositories.PatientConsentFilter{
+		PatientID: patientID,
+		Active:    active,
+		Query:     *query,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve consents"})
+		return
+	}
+
+	events := make([]services.AuditEvent, 0, len(consents))
+	for _, consent := range consents {
+		events = append(events, auditEvent(c, models.AuditActionRead, policy.Consent, consent.ID, patientID))
+	}
+	if !recordReads(c, cc.audit, events...) {
+		return
+	}
+
+	responses := make([]dto.PatientConsentResponse, 0, len(consents))
+	for i := range consents {
+		responses = append(responses, toPatientConsentResponse(&consents[i]))
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedPatientConsentsResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// GetConsentSummary godoc
+// @Summary Get patient consent status
+// @Description Get the current status of every consent type for the patient: whether an active consent exists, on which document version, and whether that is the latest version. Accessible by admin, staff and doctors on the patient's care team.
+// @Tags Consents
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Success 200 {object} dto.ConsentSummaryResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/{id}/consents/summary [get]
+func (cc *ConsentController) GetConsentSummary(c *gin.Context) {
+	patientID := c.Param("id")
+	if !authorize(c, cc.policy, policy.Read, consentResource(patientID, "")) {
+		return
+	}
+
+	statuses, err := cc.service.Summary(patientID)
+	if err != nil {
+		writeConsentError(c, err, "Failed to retrieve consent status")
+		return
+	}
+
+	var events []services.AuditEvent
+	response := dto.ConsentSummaryResponse{PatientID: patientID, Consents: make([]dto.ConsentStatusResponse, 0, len(statuses))}
+	for _, status := range statuses {
+		item := dto.ConsentStatusResponse{Type: status.Type, CurrentVersion: status.CurrentVersion}
+		if consent := status.Consent; consent != nil {
+			grantedAt := consent.GrantedAt
+			item.Granted = true
+			item.ConsentID = consent.ID
+			item.Version = consent.Document.Version
+			item.UpToDate = consent.Document.Version == status.CurrentVersion
+			item.GrantedAt = &grantedAt
+			events = append(events, auditEvent(c, models.AuditActionRead, policy.Consent, consent.ID, patientID))
+		}
+		response.Consents = append(response.Consents, item)
+	}
+	if !recordReads(c, cc.audit, events...) {
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// WithdrawConsent godoc
+// @Summary Withdraw patient consent
+// @Description Withdraw an active consent. The record is kept as history with the withdrawal time, reason and the user who recorded it. Features that need this consent (e.g. AI prediction) are refused from then on. Accessible by admin, staff and doctors on the patient's care team.
+// @Tags Consents
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Param consentId path string true "Consent ID"
+// @Param request body dto.WithdrawConsentRequest true "Withdrawal reason"
+// @Success 200 {object} dto.PatientConsentMessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/{id}/consents/{consentId}/withdraw [post]
+func (cc *ConsentController) WithdrawConsent(c *gin.Context) {
+	patientID := c.Param("id")
+	if !authorize(c, cc.policy, policy.Update, consentResource(patientID, c.Param("consentId"))) {
+		return
+	}
+
+	var input dto.WithdrawConsentRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	consent, err := cc.service.GetByID(c.Param("consentId"))
+	if err == nil && consent.PatientID != patientID {
+		err = services.ErrConsentNotFound
+	}
+	if err == nil {
+		consent, err = cc.service.Withdraw(consent.ID, input, c.GetString("userId"))
+	}
+	if err != nil {
+		writeConsentError(c, err, "Failed to withdraw consent")
+		return
+	}
+	event := auditEvent(c, models.AuditActionUpdate, policy.Consent, consent.ID, patientID)
+	event.Reason = consent.WithdrawalReason
+	recordWrite(cc.audit, event)
+
+	c.JSON(http.StatusOK, dto.PatientConsentMessageResponse{
+		Message: "Consent withdrawn",
+		Consent: toPatientConsentResponse(consent),
+	})
+}
+
+func toConsentDocumentResponse(document *models.ConsentDocument) dto.ConsentDocumentResponse {
+	return dto.ConsentDocumentResponse{
+		ID:          document.ID,
+		Type:        document.Type,
+		Version:     document.Version,
+		Title:       document.Title,
+		Body:        document.Body,
+		CreatedByID: document.CreatedByID,
+		CreatedAt:   document.CreatedAt,
+	}
+}
+
+func toPatientConsentResponse(consent *models.PatientConsent) dto.PatientConsentResponse {
+	return dto.PatientConsentResponse{
+		ID:                consent.ID,
+		PatientID:         consent.PatientID,
+		Type:              consent.Type,
+		Status:            consent.Status(),
+		DocumentID:        consent.DocumentID,
+		DocumentVersion:   consent.Document.Version,
+		DocumentTitle:     consent.Document.Title,
+		Method:            consent.Method,
+		Notes:             consent.Notes,
+		GuardianName:      consent.GuardianName,
+		GuardianContactID: consent.GuardianContactID,
+		GrantedAt:         consent.GrantedAt,
+		CapturedBy: dto.UserMiniResponse{
+			ID:       consent.CapturedBy.ID,
+			FullName: consent.CapturedBy.FullName,
+			Role:     consent.CapturedBy.Role,
+			Email:    consent.CapturedBy.Email,
+		},
+		WithdrawnAt:      consent.WithdrawnAt,
+		WithdrawnByID:    consent.WithdrawnByID,
+		WithdrawalReason: consent.WithdrawalReason,
+	}
+}
@@ -0,0 +1,338 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
+)
+
+type ContactController struct {
+	service services.ContactService
+	policy  *policy.Policy
+	audit   services.AuditService
+}
+
+func NewContactController(service services.ContactService, policy *policy.Policy, audit services.AuditService) *ContactController {
+	return &ContactController{service: service, policy: policy, audit: audit}
+}
+
+func contactResource(patientID string, id string) policy.Resource {
+	return policy.Resource{Type: policy.PatientContact, ID: id, PatientID: patientID}
+}
+
+// writeContactError memetakan error service kontak ke status HTTP
+func writeContactError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrPatientNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Patient not found"})
+	case errors.Is(err, services.ErrContactNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Contact not found"})
+	case errors.Is(err, services.ErrNoFieldsToUpdate):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "No fields to update"})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: fallback})
+	}
+}
+
+// CreateContact godoc
+// @Summary Add patient contact
+// @Description Add an emergency contact or guardian to the patient. priority 1 is contacted first; when omitted the contact is placed after the last one. mayReceiveClinicalInfo marks contacts that may be told clinical details (e.g. in a crisis alert); legalGuardian marks the legal guardian, who consents for patients under 18 and always receives clinical details while the patient is a minor. Accessible by admin, staff and doctors on the patient's care team.
+// @Tags Patient Contacts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Param request body dto.CreateContactRequest true "Contact input"
+// @Success 201 {object} dto.ContactMessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/{id}/contacts [post]
+func (cc *ContactController) CreateContact(c *gin.Context) {
+	patientID := c.Param("id")
+	if !authorize(c, cc.policy, policy.Create, contactResource(patientID, "")) {
+		return
+	}
+
+	var input dto.CreateContactRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	contact, err := cc.service.Create(patientID, input)
+	if err != nil {
+		writeContactError(c, err, "Failed to create contact")
+		return
+	}
+	recordWrite(cc.audit, auditEvent(c, models.AuditActionCreate, policy.PatientContact, contact.ID, patientID))
+
+	response, err := toContactResponse(newFieldShaper(c, cc.policy, nil), contact)
+	if err != nil {
+		writeShapeError(c)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ContactMessageResponse{
+		Message: "Contact created",
+		Contact: response,
+	})
+}
+
+// GetContacts godoc
+// @Summary Get patient contacts
+// @Description Get the patient's emergency contacts and guardians in the order they should be contacted. The phone is masked for admin; use reveal with a reason to see it in full, which is written to the audit log. Accessible by admin, staff and doctors on the patient's care team or with emergency access to the patient.
+// @Tags Patient Contacts
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param relationship query string false "Filter by relationship (parent, spouse, child, sibling, relative, friend, caregiver, other)"
+// @Param mayReceiveClinicalInfo query bool false "Only contacts that may (true) or may not (false) receive clinical information"
+// @Param legalGuardian query bool false "Only legal guardians (true) or other contacts (false)"
+// @Param reveal query string false "Comma separated fields to show in full (phone)"
+// @Param reason query string false "Justification for reveal (required with reveal, at least 10 characters)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, fullName, relationship, priority, createdAt)" default(priority,createdAt)
+// @Success 200 {object} dto.PaginatedContactsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/{id}/contacts [get]
+func (cc *ContactController) GetContacts(c *gin.Context) {
+	patientID := c.Param("id")
+	if !authorize(c, cc.policy, policy.List, contactResource(patientID, "")) {
+		return
+	}
+
+	query, ok := parseListQuery(c, repositories.PatientContactListSpec)
+	if !ok {
+		return
+	}
+	filter := repositories.PatientContactFilter{PatientID: patientID, Query: *query}
+	if filter.MayReceiveClinicalInfo, ok = parseBoolParam(c, "mayReceiveClinicalInfo"); !ok {
+		return
+	}
+	if filter.LegalGuardian, ok = parseBoolParam(c, "legalGuardian"); !ok {
+		return
+	}
+	reveal, ok := parseReveal(c, policy.PatientContact)
+	if !ok {
+		return
+	}
+
+	contacts, pageInfo, err := cc.service.GetAll(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve contacts"})
+		return
+	}
+
+	if !recordReads(c, cc.audit, contactReadEvents(c, contacts, reveal)...) {
+		return
+	}
+
+	shaper := newFieldShaper(c, cc.policy, reveal)
+	responses := make([]dto.ContactResponse, 0, len(contacts))
+	for i := range contacts {
+		response, err := toContactResponse(shaper, &contacts[i])
+		if err != nil {
+			writeShapeError(c)
+			return
+		}
+		responses = append(responses, response)
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedContactsResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// GetContactByID godoc
+// @Summary Get patient contact by ID
+// @Description Retrieve one emergency contact or guardian of the patient. Accessible by admin, staff and doctors on the patient's care team or with emergency access to the patient.
+// @Tags Patient Contacts
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Param contactId path string true "Contact ID"
+// @Param reveal query string false "Comma separated fields to show in full (phone)"
+// @Param reason query string false "Justification for reveal (required with reveal, at least 10 characters)"
+// @Success 200 {object} dto.ContactResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/{id}/contacts/{contactId} [get]
+func (cc *ContactController) GetContactByID(c *gin.Context) {
+	patientID := c.Param("id")
+	if !authorize(c, cc.policy, policy.Read, contactResource(patientID, c.Param("contactId"))) {
+		return
+	}
+	reveal, ok := parseReveal(c, policy.PatientContact)
+	if !ok {
+		return
+	}
+
+	contact, err := cc.service.GetByID(patientID, c.Param("contactId"))
+	if err != nil {
+		writeContactError(c, err, "Failed to retrieve contact")
+		return
+	}
+	if !recordReads(c, cc.audit, contactReadEvents(c, []models.PatientContact{*contact}, reveal)...) {
+		return
+	}
+
+	response, err := toContactResponse(newFieldShaper(c, cc.policy, reveal), contact)
+	if err != nil {
+		writeShapeError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateContact godoc
+// @Summary Update patient contact
+// @Description Update an emergency contact or guardian. Only fields in the request body are changed. Accessible by admin, staff and doctors on the patient's care team.
+// @Tags Patient Contacts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Param contactId path string true "Contact ID"
+// @Param request body dto.UpdateContactRequest true "Contact fields to update"
+// @Success 200 {object} dto.UpdateContactResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/{id}/contacts/{contactId} [put]
+func (cc *ContactController) UpdateContact(c *gin.Context) {
+	patientID := c.Param("id")
+	if !authorize(c, cc.policy, policy.Update, contactResource(patientID, c.Param("contactId"))) {
+		return
+	}
+
+	var input dto.UpdateContactRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	contact, updatedFields, err := cc.service.Update(patientID, c.Param("contactId"), input)
+	if err != nil {
+		writeContactError(c, err, "Failed to update contact")
+		return
+	}
+	event := auditEvent(c, models.AuditActionUpdate, policy.PatientContact, contact.ID, patientID)
+	event.Changes = updatedFields
+	recordWrite(cc.audit, event)
+
+	response, err := toContactResponse(newFieldShaper(c, cc.policy, nil), contact)
+	if err != nil {
+		writeShapeError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UpdateContactResponse{
+		Message:       "Contact updated",
+		UpdatedFields: updatedFields,
+		Contact:       response,
+	})
+}
+
+// DeleteContact godoc
+// @Summary Delete patient contact
+// @Description Permanently delete an emergency contact or guardian. Consents given by this guardian keep the guardian's name. Accessible by admin and staff.
+// @Tags Patient Contacts
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Param contactId path string true "Contact ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/{id}/contacts/{contactId} [delete]
+func (cc *ContactController) DeleteContact(c *gin.Context) {
+	patientID := c.Param("id")
+	if !authorize(c, cc.policy, policy.Delete, contactResource(patientID, c.Param("contactId"))) {
+		return
+	}
+
+	if err := cc.service.Delete(patientID, c.Param("contactId")); err != nil {
+		writeContactError(c, err, "Failed to delete contact")
+		return
+	}
+	recordWrite(cc.audit, auditEvent(c, models.AuditActionDelete, policy.PatientContact, c.Param("contactId"), patientID))
+
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "Contact deleted"})
+}
+
+// parseBoolParam membaca query parameter boolean opsional. Jika nilainya
+// tidak valid, response 400 langsung dikirim.
+func parseBoolParam(c *gin.Context, name string) (*bool, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, true
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.QueryErrorResponse{Error: name + " must be true or false", Param: name})
+		return nil, false
+	}
+	return &value, true
+}
+
+// contactReadEvents adalah entry audit baca (dan reveal) untuk kontak yang
+// dikembalikan
+func contactReadEvents(c *gin.Context, contacts []models.PatientContact, reveal *revealRequest) []services.AuditEvent {
+	events := make([]services.AuditEvent, 0, len(contacts))
+	for _, contact := range contacts {
+		events = append(events, auditEvent(c, models.AuditActionRead, policy.PatientContact, contact.ID, contact.PatientID))
+		if reveal != nil {
+			event := auditEvent(c, models.AuditActionReveal, policy.PatientContact, contact.ID, contact.PatientID)
+			event.Fields = reveal.Fields
+			event.Reason = reveal.Reason
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// toContactResponse membentuk response kontak; telepon disamarkan sesuai role
+// user yang login
+func toContactResponse(shaper *fieldShaper, contact *models.PatientContact) (dto.ContactResponse, error) {
+	response := dto.ContactResponse{
+		ID:                     contact.ID,
+		PatientID:              contact.PatientID,
+		FullName:               contact.FullName,
+		Relationship:           contact.Relationship,
+		Phone:                  contact.Phone,
+		Priority:               contact.Priority,
+		MayReceiveClinicalInfo: contact.MayReceiveClinicalInfo,
+		LegalGuardian:          contact.LegalGuardian,
+		CreatedAt:              contact.CreatedAt,
+		UpdatedAt:              contact.UpdatedAt,
+	}
+	resource := contactResource(contact.PatientID, contact.ID)
+	if err := shaper.shape(resource, policy.FieldPhone, &response.Phone, &response.RedactedFields); err != nil {
+		return response, err
+	}
+	return response, nil
+}
@@ -0,0 +1,590 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
+)
+
+// DataSubjectController menangani hak subjek data (UU PDP): export data
+// pasien, permintaan penghapusan / anonimisasi dan legal hold
+type DataSubjectController struct {
+	exports  services.DataExportService
+	erasures services.ErasureService
+	holds    services.LegalHoldService
+	audit    services.AuditService
+}
+
+func NewDataSubjectController(
+	exports services.DataExportService,
+	erasures services.ErasureService,
+	holds services.LegalHoldService,
+	audit services.AuditService,
+) *DataSubjectController {
+	return &DataSubjectController{exports: exports, erasures: erasures, holds: holds, audit: audit}
+}
+
+// writeDataSubjectError memetakan error service hak subjek data ke status HTTP
+func writeDataSubjectError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrPatientNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Patient not found"})
+	case errors.Is(err, services.ErrDataExportNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Data export not found"})
+	case errors.Is(err, services.ErrErasureRequestNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Erasure request not found"})
+	case errors.Is(err, services.ErrLegalHoldNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Legal hold not found"})
+	case errors.Is(err, services.ErrErasureSelfApproval):
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrDataExportExpired):
+		c.JSON(http.StatusGone, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrDataExportNotReady),
+		errors.Is(err, services.ErrErasurePending),
+		errors.Is(err, services.ErrErasureNotPending),
+		errors.Is(err, services.ErrErasureNotCompleted),
+		errors.Is(err, services.ErrPatientErased),
+		errors.Is(err, services.ErrLegalHoldActive),
+		errors.Is(err, services.ErrLegalHoldReleased):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: fallback})
+	}
+}
+
+// RequestDataExport godoc
+// @Summary Request patient data export
+// @Description Start a background job that builds a zip bundle with everything tied to the patient (data.json, machine-readable, format mental-klinik-export/v2, and summary.pdf), including soft-deleted rows and the access log. Poll GET /api/data-exports/{id} until status is completed, then download it. Bundles expire after 7 days. Accessible by admin only.
+// @Tags Data Subject Rights
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Success 202 {object} dto.DataExportMessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/{id}/data-exports [post]
+func (dc *DataSubjectController) RequestDataExport(c *gin.Context) {
+	export, err := dc.exports.Request(c.Param("id"), c.GetString("userId"))
+	if err != nil {
+		writeDataSubjectError(c, err, "Failed to request data export")
+		return
+	}
+	recordWrite(dc.audit, auditEvent(c, models.AuditActionCreate, policy.DataExport, export.ID, export.PatientID))
+
+	c.JSON(http.StatusAccepted, dto.DataExportMessageResponse{
+		Message: "Data export started",
+		Export:  toDataExportResponse(export),
+	})
+}
+
+// GetDataExports godoc
+// @Summary Get data exports
+// @Description Paginated list of data export jobs, newest first. Accessible by admin only.
+// @Tags Data Subject Rights
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param patientId query string false "Filter by patient ID"
+// @Param requestedById query string false "Filter by requesting user ID"
+// @Param status query string false "Filter by status (pending, processing, completed, failed)"
+// @Param requestedAt[gte] query string false "Requested at or after (YYYY-MM-DD or RFC3339)"
+// @Param requestedAt[lte] query string false "Requested at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, requestedAt)" default(-requestedAt)
+// @Success 200 {object} dto.PaginatedDataExportsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/data-exports/ [get]
+func (dc *DataSubjectController) GetDataExports(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.DataExportListSpec)
+	if !ok {
+		return
+	}
+
+	exports, pageInfo, err := dc.exports.GetAll(*query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve data exports"})
+		return
+	}
+
+	responses := make([]dto.DataExportResponse, 0, len(exports))
+	for i := range exports {
+		responses = append(responses, toDataExportResponse(&exports[i]))
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedDataExportsResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// GetDataExportByID godoc
+// @Summary Get data export by ID
+// @Description Retrieve the status of one data export job. Accessible by admin only.
+// @Tags Data Subject Rights
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Data export ID"
+// @Success 200 {object} dto.DataExportResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/data-exports/{id} [get]
+func (dc *DataSubjectController) GetDataExportByID(c *gin.Context) {
+	export, err := dc.exports.GetByID(c.Param("id"))
+	if err != nil {
+		writeDataSubjectError(c, err, "Failed to retrieve data export")
+		return
+	}
+
+	c.JSON(http.StatusOK, toDataExportResponse(export))
+}
+
+// DownloadDataExport godoc
+// @Summary Download data export bundle
+// @Description Download the zip bundle of a completed export. The X-Checksum-SHA256 header carries the bundle checksum. Every download is written to the audit log with action export. Accessible by admin only.
+// @Tags Data Subject Rights
+// @Security BearerAuth
+// @Produce application/zip
+// @Param id path string true "Data export ID"
+// @Success 200 {file} file
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 410 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/data-exports/{id}/download [get]
+func (dc *DataSubjectController) DownloadDataExport(c *gin.Context) {
+	export, err := dc.exports.Download(c.Param("id"))
+	if err != nil {
+		writeDataSubjectError(c, err, "Failed to download data export")
+		return
+	}
+	if !recordReads(c, dc.audit, auditEvent(c, models.AuditActionExport, policy.DataExport, export.ID, export.PatientID)) {
+		return
+	}
+
+	filename := fmt.Sprintf("patient-%s-export-%s.zip", export.PatientID, export.RequestedAt.Format("20060102"))
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Header("X-Checksum-SHA256", export.Checksum)
+	c.Data(http.StatusOK, "application/zip", export.Bundle)
+}
+
+// RequestErasure godoc
+// @Summary Request erasure of patient data
+// @Description Record a patient's request to erase (mode erase) or anonymize (mode anonymize) their personal data. Nothing is deleted until another admin approves it. Only one pending request per patient. Accessible by admin and staff.
+// @Tags Data Subject Rights
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Param request body dto.CreateErasureRequest true "Erasure request"
+// @Success 201 {object} dto.ErasureRequestMessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/{id}/erasure-requests [post]
+func (dc *DataSubjectController) RequestErasure(c *gin.Context) {
+	var input dto.CreateErasureRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	request, err := dc.erasures.Request(c.Param("id"), c.GetString("userId"), input)
+	if err != nil {
+		writeDataSubjectError(c, err, "Failed to request erasure")
+		return
+	}
+	recordWrite(dc.audit, auditEvent(c, models.AuditActionCreate, policy.ErasureRequest, request.ID, request.PatientID))
+
+	c.JSON(http.StatusCreated, dto.ErasureRequestMessageResponse{
+		Message: "Erasure request recorded, waiting for admin approval",
+		Request: toErasureRequestResponse(request),
+	})
+}
+
+// GetErasureRequests godoc
+// @Summary Get erasure requests
+// @Description Paginated list of erasure requests, newest first. Use status=pending for the approval queue. Accessible by admin only.
+// @Tags Data Subject Rights
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param patientId query string false "Filter by patient ID"
+// @Param mode query string false "Filter by mode (erase, anonymize)"
+// @Param status query string false "Filter by status (pending, rejected, completed)"
+// @Param requestedById query string false "Filter by requesting user ID"
+// @Param requestedAt[gte] query string false "Requested at or after (YYYY-MM-DD or RFC3339)"
+// @Param requestedAt[lte] query string false "Requested at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, requestedAt, completedAt)" default(-requestedAt)
+// @Success 200 {object} dto.PaginatedErasureRequestsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/erasure-requests/ [get]
+func (dc *DataSubjectController) GetErasureRequests(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.ErasureRequestListSpec)
+	if !ok {
+		return
+	}
+
+	requests, pageInfo, err := dc.erasures.GetAll(*query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve erasure requests"})
+		return
+	}
+
+	responses := make([]dto.ErasureRequestResponse, 0, len(requests))
+	for i := range requests {
+		responses = append(responses, toErasureRequestResponse(&requests[i]))
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedErasureRequestsResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// GetErasureRequestByID godoc
+// @Summary Get erasure request by ID
+// @Description Retrieve one erasure request. Accessible by admin only.
+// @Tags Data Subject Rights
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Erasure request ID"
+// @Success 200 {object} dto.ErasureRequestResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/erasure-requests/{id} [get]
+func (dc *DataSubjectController) GetErasureRequestByID(c *gin.Context) {
+	request, err := dc.erasures.GetByID(c.Param("id"))
+	if err != nil {
+		writeDataSubjectError(c, err, "Failed to retrieve erasure request")
+		return
+	}
+
+	c.JSON(http.StatusOK, toErasureRequestResponse(request))
+}
+
+// ApproveErasure godoc
+// @Summary Approve and execute erasure request
+// @Description Approve a pending erasure request and execute it in one transaction. Both modes remove the patient's identity (name, NIK, phone, address, emergency contact; birth date reduced to the year), soft-delete the patient, delete export bundles and withdraw active consents. Mode erase also permanently deletes appointments, assessments, predictions and care team assignments. Medical records (retention duty), audit logs, emergency accesses and consent history are always retained. Must be approved by an admin other than the requester and fails while the patient is under an active legal hold. Produces a certificate of completion. Accessible by admin only.
+// @Tags Data Subject Rights
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Erasure request ID"
+// @Param request body dto.ApproveErasureRequest false "Approval notes"
+// @Success 200 {object} dto.ErasureRequestMessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/erasure-requests/{id}/approve [post]
+func (dc *DataSubjectController) ApproveErasure(c *gin.Context) {
+	var input dto.ApproveErasureRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	request, err := dc.erasures.Approve(c.Param("id"), c.GetString("userId"), input)
+	if err != nil {
+		writeDataSubjectError(c, err, "Failed to execute erasure")
+		return
+	}
+	recordWrite(dc.audit, auditEvent(c, models.AuditActionErase, policy.ErasureRequest, request.ID, request.PatientID))
+
+	c.JSON(http.StatusOK, dto.ErasureRequestMessageResponse{
+		Message: "Erasure completed",
+		Request: toErasureRequestResponse(request),
+	})
+}
+
+// RejectErasure godoc
+// @Summary Reject erasure request
+// @Description Reject a pending erasure request with a reason. Accessible by admin only.
+// @Tags Data Subject Rights
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Erasure request ID"
+// @Param request body dto.RejectErasureRequest true "Rejection reason"
+// @Success 200 {object} dto.ErasureRequestMessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/erasure-requests/{id}/reject [post]
+func (dc *DataSubjectController) RejectErasure(c *gin.Context) {
+	var input dto.RejectErasureRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	request, err := dc.erasures.Reject(c.Param("id"), c.GetString("userId"), input)
+	if err != nil {
+		writeDataSubjectError(c, err, "Failed to reject erasure request")
+		return
+	}
+	recordWrite(dc.audit, auditEvent(c, models.AuditActionUpdate, policy.ErasureRequest, request.ID, request.PatientID))
+
+	c.JSON(http.StatusOK, dto.ErasureRequestMessageResponse{
+		Message: "Erasure request rejected",
+		Request: toErasureRequestResponse(request),
+	})
+}
+
+// GetErasureCertificate godoc
+// @Summary Get erasure certificate
+// @Description Certificate of completion of an executed erasure request: what was erased, anonymized and retained (with the legal basis), who requested and approved it and when. format=json (default) returns the stored certificate and its SHA-256 hash; format=pdf returns a printable PDF. Accessible by admin only.
+// @Tags Data Subject Rights
+// @Security BearerAuth
+// @Produce json
+// @Produce application/pdf
+// @Param id path string true "Erasure request ID"
+// @Param format query string false "json or pdf" default(json)
+// @Success 200 {object} dto.ErasureCertificateResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/erasure-requests/{id}/certificate [get]
+func (dc *DataSubjectController) GetErasureCertificate(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "pdf" {
+		c.JSON(http.StatusBadRequest, dto.QueryErrorResponse{Error: "format must be json or pdf", Param: "format"})
+		return
+	}
+
+	request, err := dc.erasures.Certificate(c.Param("id"))
+	if err != nil {
+		writeDataSubjectError(c, err, "Failed to retrieve erasure certificate")
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, dto.ErasureCertificateResponse{
+			Certificate: []byte(request.Certificate),
+			Hash:        request.CertificateHash,
+		})
+		return
+	}
+
+	document, err := dc.erasures.CertificatePDF(request)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to render erasure certificate"})
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="erasure-certificate-`+request.ID+`.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", document)
+}
+
+// PlaceLegalHold godoc
+// @Summary Place legal hold on patient data
+// @Description Block erasure of a patient's data, e.g. during litigation or a law enforcement request. Erasure requests can still be recorded but cannot be approved until every hold is released. Accessible by admin only.
+// @Tags Data Subject Rights
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Param request body dto.PlaceLegalHoldRequest true "Legal hold"
+// @Success 201 {object} dto.LegalHoldMessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/{id}/legal-holds [post]
+func (dc *DataSubjectController) PlaceLegalHold(c *gin.Context) {
+	var input dto.PlaceLegalHoldRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	hold, err := dc.holds.Place(c.Param("id"), c.GetString("userId"), input)
+	if err != nil {
+		writeDataSubjectError(c, err, "Failed to place legal hold")
+		return
+	}
+	recordWrite(dc.audit, auditEvent(c, models.AuditActionCreate, policy.LegalHold, hold.ID, hold.PatientID))
+
+	c.JSON(http.StatusCreated, dto.LegalHoldMessageResponse{
+		Message: "Legal hold placed",
+		Hold:    toLegalHoldResponse(hold),
+	})
+}
+
+// GetLegalHolds godoc
+// @Summary Get legal holds
+// @Description Paginated list of legal holds, newest first. Accessible by admin only.
+// @Tags Data Subject Rights
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param active query bool false "true: only active holds, false: only released holds"
+// @Param patientId query string false "Filter by patient ID"
+// @Param placedById query string false "Filter by user who placed the hold"
+// @Param placedAt[gte] query string false "Placed at or after (YYYY-MM-DD or RFC3339)"
+// @Param placedAt[lte] query string false "Placed at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, placedAt, releasedAt)" default(-placedAt)
+// @Success 200 {object} dto.PaginatedLegalHoldsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/legal-holds/ [get]
+func (dc *DataSubjectController) GetLegalHolds(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.LegalHoldListSpec)
+	if !ok {
+		return
+	}
+
+	var active *bool
+	if raw := c.Query("active"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.QueryErrorResponse{Error: "active must be true or false", Param: "active"})
+			return
+		}
+		active = &value
+	}
+
+	holds, pageInfo, err := dc.holds.GetAll(repositories.LegalHoldFilter{Active: active, Query: *query})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve legal holds"})
+		return
+	}
+
+	responses := make([]dto.LegalHoldResponse, 0, len(holds))
+	for i := range holds {
+		responses = append(responses, toLegalHoldResponse(&holds[i]))
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedLegalHoldsResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// ReleaseLegalHold godoc
+// @Summary Release legal hold
+// @Description Release a legal hold with a reason. Accessible by admin only.
+// @Tags Data Subject Rights
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Legal hold ID"
+// @Param request body dto.ReleaseLegalHoldRequest true "Release reason"
+// @Success 200 {object} dto.LegalHoldMessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/legal-holds/{id}/release [post]
+func (dc *DataSubjectController) ReleaseLegalHold(c *gin.Context) {
+	var input dto.ReleaseLegalHoldRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	hold, err := dc.holds.Release(c.Param("id"), c.GetString("userId"), input)
+	if err != nil {
+		writeDataSubjectError(c, err, "Failed to release legal hold")
+		return
+	}
+	recordWrite(dc.audit, auditEvent(c, models.AuditActionUpdate, policy.LegalHold, hold.ID, hold.PatientID))
+
+	c.JSON(http.StatusOK, dto.LegalHoldMessageResponse{
+		Message: "Legal hold released",
+		Hold:    toLegalHoldResponse(hold),
+	})
+}
+
+func toDataExportResponse(export *models.DataExport) dto.DataExportResponse {
+	return dto.DataExportResponse{
+		ID:            export.ID,
+		PatientID:     export.PatientID,
+		RequestedByID: export.RequestedByID,
+		Status:        export.Status,
+		Error:         export.Error,
+		Checksum:      export.Checksum,
+		Size:          export.Size,
+		RequestedAt:   export.RequestedAt,
+		CompletedAt:   export.CompletedAt,
+		ExpiresAt:     export.ExpiresAt,
+	}
+}
+
+func toErasureRequestResponse(request *models.ErasureRequest) dto.ErasureRequestResponse {
+	return dto.ErasureRequestResponse{
+		ID:              request.ID,
+		PatientID:       request.PatientID,
+		Mode:            request.Mode,
+		Reason:          request.Reason,
+		Status:          request.Status,
+		RequestedByID:   request.RequestedByID,
+		RequestedAt:     request.RequestedAt,
+		ReviewedByID:    request.ReviewedByID,
+		ReviewedAt:      request.ReviewedAt,
+		ReviewNotes:     request.ReviewNotes,
+		CompletedAt:     request.CompletedAt,
+		CertificateHash: request.CertificateHash,
+	}
+}
+
+func toLegalHoldResponse(hold *models.LegalHold) dto.LegalHoldResponse {
+	status := "released"
+	if hold.Active() {
+		status = "active"
+	}
+	return dto.LegalHoldResponse{
+		ID:            hold.ID,
+		PatientID:     hold.PatientID,
+		Reason:        hold.Reason,
+		Reference:     hold.Reference,
+		Status:        status,
+		PlacedByID:    hold.PlacedByID,
+		PlacedAt:      hold.PlacedAt,
+		ReleasedAt:    hold.ReleasedAt,
+		ReleasedByID:  hold.ReleasedByID,
+		ReleaseReason: hold.ReleaseReason,
+	}
+}
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
+)
+
+// writeDeleteError mengirim response untuk error penghapusan yang memakai
+// kebijakan relasi. notFound adalah error service jika data tidak ada.
+func writeDeleteError(c *gin.Context, err error, notFound error, notFoundMessage string, failMessage string) {
+	var blocked *repositories.DeleteBlockedError
+	switch {
+	case errors.Is(err, notFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: notFoundMessage})
+	case errors.Is(err, services.ErrInvalidReassignTarget):
+		c.JSON(http.StatusBadRequest, dto.QueryErrorResponse{Error: err.Error(), Param: "reassignTo"})
+	case errors.Is(err, services.ErrDeleteRestricted) && errors.As(err, &blocked):
+		response := dto.DeleteBlockedResponse{Error: err.Error()}
+		for _, blocker := range blocked.Blockers {
+			response.Blockers = append(response.Blockers, dto.DeleteBlocker{
+				Relation: blocker.Relation,
+				Policy:   blocker.Policy,
+				Count:    blocker.Count,
+				IDs:      blocker.IDs,
+			})
+		}
+		c.JSON(http.StatusConflict, response)
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: failMessage})
+	}
+}
+
+// deleteAuditEvent menyusun event audit penghapusan; jumlah data yang ikut
+// dihapus dan dipindahkan per relasi dicatat sebagai changes
+func deleteAuditEvent(c *gin.Context, resourceType policy.ResourceType, resourceID string, patientID string, result *repositories.DeleteResult) services.AuditEvent {
+	event := auditEvent(c, models.AuditActionDelete, resourceType, resourceID, patientID)
+	event.Changes = append(event.Changes, countChanges("deleted.", result.Deleted)...)
+	event.Changes = append(event.Changes, countChanges("reassigned.", result.Reassigned)...)
+	return event
+}
+
+// countChanges mengubah jumlah baris per tabel / relasi menjadi daftar
+// changes audit, urut menurut nama
+func countChanges(prefix string, counts map[string]int64) []dto.UpdatedField {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	changes := make([]dto.UpdatedField, 0, len(names))
+	for _, name := range names {
+		changes = append(changes, dto.UpdatedField{Field: prefix + name, Value: counts[name]})
+	}
+	return changes
+}
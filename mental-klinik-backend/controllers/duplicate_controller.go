@@ -0,0 +1,455 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
+)
+
+type DuplicateController struct {
+	service services.DuplicateService
+	policy  *policy.Policy
+	audit   services.AuditService
+}
+
+func NewDuplicateController(service services.DuplicateService, p *policy.Policy, audit services.AuditService) *DuplicateController {
+	return &DuplicateController{service: service, policy: p, audit: audit}
+}
+
+// StartDuplicateScan godoc
+// @Summary Start duplicate patient scan
+// @Description Scan all active patients for likely duplicates in the background. Pairs are scored on name similarity, birth date, phone number and mistyped NIKs; pairs at or above the configured threshold are added to the review queue. Dismissed and merged pairs are not raised again. Only one scan can run at a time. Accessible by admin only.
+// @Tags Patient Duplicates
+// @Security BearerAuth
+// @Produce json
+// @Success 202 {object} dto.DuplicateScanMessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/duplicates/scans [post]
+func (dc *DuplicateController) StartDuplicateScan(c *gin.Context) {
+	userID := c.GetString("userId")
+	scan, err := dc.service.Scan(models.DuplicateScanManual, &userID)
+	if err != nil {
+		if errors.Is(err, services.ErrDuplicateScanRunning) {
+			c.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to start duplicate scan"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.DuplicateScanMessageResponse{
+		Message: "Duplicate scan started",
+		Scan:    toDuplicateScanResponse(scan),
+	})
+}
+
+// GetDuplicateScans godoc
+// @Summary Get duplicate scan history
+// @Description Paginated history of duplicate patient scans, newest first. Accessible by admin only.
+// @Tags Patient Duplicates
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param trigger query string false "Filter by trigger (scheduled, manual)"
+// @Param status query string false "Filter by status (running, completed, failed)"
+// @Param startedAt[gte] query string false "Started at or after (YYYY-MM-DD or RFC3339)"
+// @Param startedAt[lte] query string false "Started at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, startedAt)" default(-startedAt)
+// @Success 200 {object} dto.PaginatedDuplicateScansResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/duplicates/scans [get]
+func (dc *DuplicateController) GetDuplicateScans(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.DuplicateScanListSpec)
+	if !ok {
+		return
+	}
+
+	scans, pageInfo, err := dc.service.GetScans(*query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve duplicate scans"})
+		return
+	}
+
+	responses := make([]dto.DuplicateScanResponse, 0, len(scans))
+	for i := range scans {
+		responses = append(responses, toDuplicateScanResponse(&scans[i]))
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedDuplicateScansResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// GetDuplicateCandidates godoc
+// @Summary Get duplicate review queue
+// @Description Paginated list of likely duplicate patient pairs with both patients side by side, highest score first. Pending pairs where one patient has since been deleted are hidden. Accessible by admin only.
+// @Tags Patient Duplicates
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param cursor query string false "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response"
+// @Param withTotal query bool false "Also count total rows in cursor mode" default(false)
+// @Param status query string false "Filter by status (pending, dismissed, merged)"
+// @Param patientId query string false "Only pairs involving this patient"
+// @Param score[gte] query number false "Minimum score (0-1)"
+// @Param detectedAt[gte] query string false "Detected at or after (YYYY-MM-DD or RFC3339)"
+// @Param detectedAt[lte] query string false "Detected at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, score, detectedAt)" default(-score)
+// @Success 200 {object} dto.PaginatedDuplicateCandidatesResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/duplicates [get]
+func (dc *DuplicateController) GetDuplicateCandidates(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.DuplicateCandidateListSpec)
+	if !ok {
+		return
+	}
+
+	candidates, pageInfo, err := dc.service.GetCandidates(repositories.DuplicateCandidateFilter{
+		PatientID: c.Query("patientId"),
+		Query:     *query,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve duplicate candidates"})
+		return
+	}
+
+	events := make([]services.AuditEvent, 0, 2*len(candidates))
+	for _, candidate := range candidates {
+		events = append(events, candidateReadEvents(c, &candidate)...)
+	}
+	if !recordReads(c, dc.audit, events...) {
+		return
+	}
+
+	shaper := newFieldShaper(c, dc.policy, nil)
+	responses := make([]dto.DuplicateCandidateResponse, 0, len(candidates))
+	for i := range candidates {
+		response, err := toDuplicateCandidateResponse(shaper, &candidates[i])
+		if err != nil {
+			writeShapeError(c)
+			return
+		}
+		responses = append(responses, response)
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedDuplicateCandidatesResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// GetDuplicateCandidateByID godoc
+// @Summary Get duplicate candidate by ID
+// @Description Retrieve one pair from the duplicate review queue with both patients. Accessible by admin only.
+// @Tags Patient Duplicates
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Duplicate candidate ID"
+// @Success 200 {object} dto.DuplicateCandidateResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/duplicates/{id} [get]
+func (dc *DuplicateController) GetDuplicateCandidateByID(c *gin.Context) {
+	candidate, err := dc.service.GetCandidate(c.Param("id"))
+	if err != nil {
+		writeDuplicateError(c, err, "Failed to retrieve duplicate candidate")
+		return
+	}
+	if !recordReads(c, dc.audit, candidateReadEvents(c, candidate)...) {
+		return
+	}
+
+	response, err := toDuplicateCandidateResponse(newFieldShaper(c, dc.policy, nil), candidate)
+	if err != nil {
+		writeShapeError(c)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// DismissDuplicateCandidate godoc
+// @Summary Dismiss duplicate candidate
+// @Description Mark a pending pair as different patients. The pair will not be raised again by later scans. Accessible by admin only.
+// @Tags Patient Duplicates
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Duplicate candidate ID"
+// @Success 200 {object} dto.DuplicateCandidateMessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/duplicates/{id}/dismiss [post]
+func (dc *DuplicateController) DismissDuplicateCandidate(c *gin.Context) {
+	candidate, err := dc.service.Dismiss(c.Param("id"), c.GetString("userId"))
+	if err != nil {
+		writeDuplicateError(c, err, "Failed to dismiss duplicate candidate")
+		return
+	}
+
+	response, err := toDuplicateCandidateResponse(newFieldShaper(c, dc.policy, nil), candidate)
+	if err != nil {
+		writeShapeError(c)
+		return
+	}
+	c.JSON(http.StatusOK, dto.DuplicateCandidateMessageResponse{
+		Message:   "Duplicate candidate dismissed",
+		Candidate: response,
+	})
+}
+
+// MergePatients godoc
+// @Summary Merge duplicate patients
+// @Description Merge mergedId into survivorId in one transaction. Appointments, assessments (with their predictions), medical records and emergency contacts of the merged patient, including deleted ones, are moved to the survivor; the merged patient is moved to the trash and can only come back by undoing the merge. The survivor's own details are not changed. Consents, care team assignments and emergency access stay with the merged patient. A pending review queue entry for the pair is marked as merged. Fails with 409 if either patient is under a legal hold or has been anonymized. Accessible by admin only.
+// @Tags Patient Duplicates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.MergePatientsRequest true "Patients to merge"
+// @Success 200 {object} dto.PatientMergeMessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/merges [post]
+func (dc *DuplicateController) MergePatients(c *gin.Context) {
+	var input dto.MergePatientsRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	merge, err := dc.service.Merge(input, c.GetString("userId"))
+	if err != nil {
+		writeDuplicateError(c, err, "Failed to merge patients")
+		return
+	}
+
+	response := toPatientMergeResponse(merge)
+	moved := map[string]int64{}
+	for table, ids := range response.Moved {
+		moved[table] = int64(len(ids))
+	}
+	survivorEvent := auditEvent(c, models.AuditActionMerge, policy.Patient, merge.SurvivorID, merge.SurvivorID)
+	survivorEvent.Changes = append(countChanges("moved.", moved), dto.UpdatedField{Field: "mergedId", Value: merge.MergedID})
+	recordWrite(dc.audit, survivorEvent)
+	mergedEvent := auditEvent(c, models.AuditActionMerge, policy.Patient, merge.MergedID, merge.MergedID)
+	mergedEvent.Changes = []dto.UpdatedField{{Field: "mergedIntoId", Value: merge.SurvivorID}}
+	recordWrite(dc.audit, mergedEvent)
+
+	c.JSON(http.StatusOK, dto.PatientMergeMessageResponse{
+		Message: "Patients merged successfully",
+		Merge:   response,
+	})
+}
+
+// UndoPatientMerge godoc
+// @Summary Undo patient merge
+// @Description Undo a merge: rows moved by the merge that still belong to the survivor are moved back, the merged patient is restored from the trash and the review queue entry becomes pending again. Rows created on the survivor after the merge stay with the survivor. Fails with 409 if the merge was already undone, the merged patient's NIK is now used by another active patient, either patient is under a legal hold, or the merged patient has been anonymized or changed since. Accessible by admin only.
+// @Tags Patient Duplicates
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Patient merge ID"
+// @Success 200 {object} dto.PatientMergeMessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/merges/{id}/undo [post]
+func (dc *DuplicateController) UndoPatientMerge(c *gin.Context) {
+	merge, result, err := dc.service.UndoMerge(c.Param("id"), c.GetString("userId"))
+	if err != nil {
+		writeDuplicateError(c, err, "Failed to undo patient merge")
+		return
+	}
+
+	survivorEvent := auditEvent(c, models.AuditActionUnmerge, policy.Patient, merge.SurvivorID, merge.SurvivorID)
+	survivorEvent.Changes = append(countChanges("movedBack.", result), dto.UpdatedField{Field: "mergedId", Value: merge.MergedID})
+	recordWrite(dc.audit, survivorEvent)
+	mergedEvent := auditEvent(c, models.AuditActionUnmerge, policy.Patient, merge.MergedID, merge.MergedID)
+	mergedEvent.Changes = []dto.UpdatedField{{Field: "mergedIntoId", Value: nil}}
+	recordWrite(dc.audit, mergedEvent)
+
+	c.JSON(http.StatusOK, dto.PatientMergeMessageResponse{
+		Message:  "Patient merge undone successfully",
+		Merge:    toPatientMergeResponse(merge),
+		Restored: result,
+	})
+}
+
+// GetPatientMerges godoc
+// @Summary Get patient merge history
+// @Description Paginated history of patient merges, newest first, including undone merges. Accessible by admin only.
+// @Tags Patient Duplicates
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param cursor query string false "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response"
+// @Param withTotal query bool false "Also count total rows in cursor mode" default(false)
+// @Param survivorId query string false "Filter by surviving patient ID"
+// @Param mergedId query string false "Filter by merged patient ID"
+// @Param mergedAt[gte] query string false "Merged at or after (YYYY-MM-DD or RFC3339)"
+// @Param mergedAt[lte] query string false "Merged at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, mergedAt)" default(-mergedAt)
+// @Success 200 {object} dto.PaginatedPatientMergesResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/merges [get]
+func (dc *DuplicateController) GetPatientMerges(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.PatientMergeListSpec)
+	if !ok {
+		return
+	}
+
+	merges, pageInfo, err := dc.service.GetMerges(*query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve patient merges"})
+		return
+	}
+
+	responses := make([]dto.PatientMergeResponse, 0, len(merges))
+	for i := range merges {
+		responses = append(responses, toPatientMergeResponse(&merges[i]))
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedPatientMergesResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// GetPatientMergeByID godoc
+// @Summary Get patient merge by ID
+// @Description Retrieve one patient merge with the IDs of the moved rows per table. Accessible by admin only.
+// @Tags Patient Duplicates
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Patient merge ID"
+// @Success 200 {object} dto.PatientMergeResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/merges/{id} [get]
+func (dc *DuplicateController) GetPatientMergeByID(c *gin.Context) {
+	merge, err := dc.service.GetMerge(c.Param("id"))
+	if err != nil {
+		writeDuplicateError(c, err, "Failed to retrieve patient merge")
+		return
+	}
+	c.JSON(http.StatusOK, toPatientMergeResponse(merge))
+}
+
+// writeDuplicateError mengirim response untuk error review duplikat dan
+// penggabungan pasien
+func writeDuplicateError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrDuplicateCandidateNotFound),
+		errors.Is(err, services.ErrPatientMergeNotFound),
+		errors.Is(err, services.ErrPatientNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrSamePatientMerge):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrDuplicateCandidateReviewed),
+		errors.Is(err, services.ErrPatientMergeUndone),
+		errors.Is(err, services.ErrPatientMergeConflict),
+		errors.Is(err, services.ErrLegalHoldActive):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: fallback})
+	}
+}
+
+// candidateReadEvents mencatat pembacaan data kedua pasien di satu kandidat
+func candidateReadEvents(c *gin.Context, candidate *models.DuplicateCandidate) []services.AuditEvent {
+	return []services.AuditEvent{
+		auditEvent(c, models.AuditActionRead, policy.Patient, candidate.PatientAID, candidate.PatientAID),
+		auditEvent(c, models.AuditActionRead, policy.Patient, candidate.PatientBID, candidate.PatientBID),
+	}
+}
+
+func toDuplicateScanResponse(scan *models.DuplicateScan) dto.DuplicateScanResponse {
+	return dto.DuplicateScanResponse{
+		ID:            scan.ID,
+		Trigger:       scan.Trigger,
+		TriggeredByID: scan.TriggeredByID,
+		Status:        scan.Status,
+		Error:         scan.Error,
+		Threshold:     scan.Threshold,
+		Patients:      scan.Patients,
+		Candidates:    scan.Candidates,
+		Created:       scan.Created,
+		StartedAt:     scan.StartedAt,
+		FinishedAt:    scan.FinishedAt,
+	}
+}
+
+func toDuplicateCandidateResponse(shaper *fieldShaper, candidate *models.DuplicateCandidate) (dto.DuplicateCandidateResponse, error) {
+	reasons := []string{}
+	if len(candidate.Reasons) > 0 {
+		_ = json.Unmarshal(candidate.Reasons, &reasons)
+	}
+	response := dto.DuplicateCandidateResponse{
+		ID:           candidate.ID,
+		Score:        candidate.Score,
+		Reasons:      reasons,
+		Status:       candidate.Status,
+		DetectedAt:   candidate.DetectedAt,
+		ReviewedByID: candidate.ReviewedByID,
+		ReviewedAt:   candidate.ReviewedAt,
+		MergeID:      candidate.MergeID,
+	}
+	var err error
+	if response.PatientA, err = toPatientResponse(shaper, &candidate.PatientA); err != nil {
+		return response, err
+	}
+	response.PatientB, err = toPatientResponse(shaper, &candidate.PatientB)
+	return response, err
+}
+
+func toPatientMergeResponse(merge *models.PatientMerge) dto.PatientMergeResponse {
+	moved := map[string][]string{}
+	if len(merge.Moved) > 0 {
+		_ = json.Unmarshal(merge.Moved, &moved)
+	}
+	return dto.PatientMergeResponse{
+		ID:          merge.ID,
+		SurvivorID:  merge.SurvivorID,
+		MergedID:    merge.MergedID,
+		CandidateID: merge.CandidateID,
+		Moved:       moved,
+		MergedByID:  merge.MergedByID,
+		MergedAt:    merge.MergedAt,
+		UndoneByID:  merge.UndoneByID,
+		UndoneAt:    merge.UndoneAt,
+	}
+}
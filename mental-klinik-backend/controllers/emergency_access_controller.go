@@ -0,0 +1,270 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
+)
+
+type EmergencyAccessController struct {
+	service services.EmergencyAccessService
+	audit   services.AuditService
+}
+
+func NewEmergencyAccessController(service services.EmergencyAccessService, audit services.AuditService) *EmergencyAccessController {
+	return &EmergencyAccessController{service: service, audit: audit}
+}
+
+// writeEmergencyAccessError memetakan error service akses darurat ke status HTTP
+func writeEmergencyAccessError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrEmergencyAccessNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Emergency access not found"})
+	case errors.Is(err, services.ErrPatientNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Patient not found"})
+	case errors.Is(err, services.ErrAlreadyOnCareTeam),
+		errors.Is(err, services.ErrEmergencyAccessInactive),
+		errors.Is(err, services.ErrEmergencyAccessReviewed):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: fallback})
+	}
+}
+
+// BreakGlass godoc
+// @Summary Break-the-glass access to a patient
+// @Description Emergency read access for a doctor who is not on the patient's care team. A written reason is required. Returns a separate access token scoped to this patient that is valid for a limited time (auth.breakGlassTtl, default 1 hour); use it instead of the normal token for requests about this patient. Every grant is written to the audit log and queued for admin review, and every access made with the token is audited with reason emergency-access:<id>.
+// @Tags Emergency Access
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Param request body dto.BreakGlassRequest true "Emergency reason"
+// @Success 201 {object} dto.BreakGlassResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/{id}/break-glass [post]
+func (ec *EmergencyAccessController) BreakGlass(c *gin.Context) {
+	var input dto.BreakGlassRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	access, token, err := ec.service.Grant(services.EmergencyGrant{
+		UserID:    c.GetString("userId"),
+		Role:      c.GetString("role"),
+		SessionID: c.GetString("sessionId"),
+		PatientID: c.Param("id"),
+		Reason:    input.Reason,
+		Client: services.ClientInfo{
+			UserAgent: c.Request.UserAgent(),
+			IPAddress: c.ClientIP(),
+		},
+	})
+	if err != nil {
+		writeEmergencyAccessError(c, err, "Failed to grant emergency access")
+		return
+	}
+	event := auditEvent(c, models.AuditActionBreakGlass, policy.Patient, access.PatientID, access.PatientID)
+	event.Reason = access.Reason
+	recordWrite(ec.audit, event)
+
+	// Relasi belum dimuat setelah create
+	if loaded, err := ec.service.GetByID(access.ID); err == nil {
+		access = loaded
+	}
+
+	c.JSON(http.StatusCreated, dto.BreakGlassResponse{
+		Message:     "Emergency access granted",
+		AccessToken: token,
+		ExpiresAt:   access.ExpiresAt,
+		Access:      toEmergencyAccessResponse(access, time.Now()),
+	})
+}
+
+// GetEmergencyAccesses godoc
+// @Summary Emergency access review report
+// @Description Paginated report of break-the-glass grants for admin review, newest first. pendingReview counts every grant not yet reviewed. Accessible by admin only.
+// @Tags Emergency Access
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param reviewed query bool false "false: only the pending review queue, true: only reviewed grants"
+// @Param userId query string false "Filter by user who used emergency access"
+// @Param patientId query string false "Filter by patient ID"
+// @Param reviewOutcome query string false "Filter by review outcome (justified, unjustified)"
+// @Param grantedAt[gte] query string false "Granted at or after (YYYY-MM-DD or RFC3339)"
+// @Param grantedAt[lte] query string false "Granted at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, grantedAt, expiresAt, reviewedAt)" default(-grantedAt)
+// @Success 200 {object} dto.PaginatedEmergencyAccessResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/emergency-access/ [get]
+func (ec *EmergencyAccessController) GetEmergencyAccesses(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.EmergencyAccessListSpec)
+	if !ok {
+		return
+	}
+
+	var reviewed *bool
+	if raw := c.Query("reviewed"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.QueryErrorResponse{Error: "reviewed must be true or false", Param: "reviewed"})
+			return
+		}
+		reviewed = &value
+	}
+
+	accesses, pageInfo, pending, err := ec.service.GetAll(repositories.EmergencyAccessFilter{
+		Reviewed: reviewed,
+		Query:    *query,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve emergency accesses"})
+		return
+	}
+
+	now := time.Now()
+	responses := make([]dto.EmergencyAccessResponse, 0, len(accesses))
+	for i := range accesses {
+		responses = append(responses, toEmergencyAccessResponse(&accesses[i], now))
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedEmergencyAccessResponse{
+		Data:          responses,
+		PendingReview: pending,
+		Pagination:    newPagination(query, pageInfo),
+	})
+}
+
+// GetEmergencyAccessByID godoc
+// @Summary Get emergency access by ID
+// @Description Retrieve one break-the-glass grant. Use auditReason as the reason filter on /api/audit-logs to see every access made with it. Accessible by admin only.
+// @Tags Emergency Access
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Emergency access ID"
+// @Success 200 {object} dto.EmergencyAccessResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/emergency-access/{id} [get]
+func (ec *EmergencyAccessController) GetEmergencyAccessByID(c *gin.Context) {
+	access, err := ec.service.GetByID(c.Param("id"))
+	if err != nil {
+		writeEmergencyAccessError(c, err, "Failed to retrieve emergency access")
+		return
+	}
+
+	c.JSON(http.StatusOK, toEmergencyAccessResponse(access, time.Now()))
+}
+
+// ReviewEmergencyAccess godoc
+// @Summary Review emergency access
+// @Description Record the admin review outcome (justified or unjustified) of a break-the-glass grant. A grant can only be reviewed once; an unjustified outcome does not revoke access by itself. Accessible by admin only.
+// @Tags Emergency Access
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Emergency access ID"
+// @Param request body dto.ReviewEmergencyAccessRequest true "Review outcome"
+// @Success 200 {object} dto.EmergencyAccessMessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/emergency-access/{id}/review [post]
+func (ec *EmergencyAccessController) ReviewEmergencyAccess(c *gin.Context) {
+	var input dto.ReviewEmergencyAccessRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	access, err := ec.service.Review(c.Param("id"), c.GetString("userId"), input)
+	if err != nil {
+		writeEmergencyAccessError(c, err, "Failed to review emergency access")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.EmergencyAccessMessageResponse{
+		Message: "Emergency access reviewed",
+		Access:  toEmergencyAccessResponse(access, time.Now()),
+	})
+}
+
+// RevokeEmergencyAccess godoc
+// @Summary Revoke emergency access
+// @Description End a break-the-glass grant before it expires. The emergency token is rejected from the next request. Accessible by admin only.
+// @Tags Emergency Access
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Emergency access ID"
+// @Success 200 {object} dto.EmergencyAccessMessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/emergency-access/{id}/revoke [post]
+func (ec *EmergencyAccessController) RevokeEmergencyAccess(c *gin.Context) {
+	access, err := ec.service.Revoke(c.Param("id"), c.GetString("userId"))
+	if err != nil {
+		writeEmergencyAccessError(c, err, "Failed to revoke emergency access")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.EmergencyAccessMessageResponse{
+		Message: "Emergency access revoked",
+		Access:  toEmergencyAccessResponse(access, time.Now()),
+	})
+}
+
+func toEmergencyAccessResponse(access *models.EmergencyAccess, now time.Time) dto.EmergencyAccessResponse {
+	return dto.EmergencyAccessResponse{
+		ID:           access.ID,
+		UserID:       access.UserID,
+		PatientID:    access.PatientID,
+		Reason:       access.Reason,
+		Status:       access.Status(now),
+		GrantedAt:    access.GrantedAt,
+		ExpiresAt:    access.ExpiresAt,
+		RevokedAt:    access.RevokedAt,
+		RevokedByID:  access.RevokedByID,
+		IPAddress:    access.IPAddress,
+		UserAgent:    access.UserAgent,
+		ReviewStatus: access.ReviewStatus(),
+		ReviewedAt:   access.ReviewedAt,
+		ReviewedByID: access.ReviewedByID,
+		ReviewNotes:  access.ReviewNotes,
+		AuditReason:  emergencyAuditReason(access.ID),
+		Patient:      toPatientMiniResponse(&access.Patient),
+		User: dto.UserMiniResponse{
+			ID:       access.User.ID,
+			FullName: access.User.FullName,
+			Role:     access.User.Role,
+			Email:    access.User.Email,
+		},
+	}
+}
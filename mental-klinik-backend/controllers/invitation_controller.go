@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
+)
+
+type InvitationController struct {
+	service services.InvitationService
+}
+
+func NewInvitationController(service services.InvitationService) *InvitationController {
+	return &InvitationController{service: service}
+}
+
+// CreateInvitation godoc
+// @Summary Create invitation
+// @Description Issue a single-use invitation token that lets the invited email register with a fixed role. The token is only returned once. Only accessible by admin.
+// @Tags Invitations
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateInvitationRequest true "Invitation input"
+// @Success 201 {object} dto.CreateInvitationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/invitations/ [post]
+func (ic *InvitationController) CreateInvitation(c *gin.Context) {
+	var input dto.CreateInvitationRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	invitation, token, err := ic.service.Create(input, c.GetString("userId"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to Create Invitation"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CreateInvitationResponse{
+		Message:    "Invitation Created",
+		Token:      token,
+		Invitation: toInvitationResponse(invitation, time.Now()),
+	})
+}
+
+// GetAllInvitations godoc
+// @Summary Get all invitations
+// @Description Get paginated list of invitations with their status (pending, used, revoked, expired). Only accessible by admin.
+// @Tags Invitations
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param email[ilike] query string false "Filter email containing text"
+// @Param role query string false "Filter by role (admin, doctor, staff)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, email, role, expiresAt, createdAt)" default(-createdAt)
+// @Success 200 {object} dto.PaginatedInvitationsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/invitations/ [get]
+func (ic *InvitationController) GetAllInvitations(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.InvitationListSpec)
+	if !ok {
+		return
+	}
+
+	invitations, pageInfo, err := ic.service.GetAll(*query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to Retrieve Invitations"})
+		return
+	}
+
+	now := time.Now()
+	responses := make([]dto.InvitationResponse, 0, len(invitations))
+	for i := range invitations {
+		responses = append(responses, toInvitationResponse(&invitations[i], now))
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedInvitationsResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// RevokeInvitation godoc
+// @Summary Revoke invitation
+// @Description Revoke a pending invitation so its token can no longer be used. Only accessible by admin.
+// @Tags Invitations
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/invitations/{id} [delete]
+func (ic *InvitationController) RevokeInvitation(c *gin.Context) {
+	if err := ic.service.Revoke(c.Param("id")); err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvitationNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Invitation Not Found"})
+		case errors.Is(err, services.ErrInvitationNotPending):
+			c.JSON(http.StatusConflict, dto.ErrorResponse{Error: "Invitation Already Used or Revoked"})
+		default:
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to Revoke Invitation"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "Invitation Revoked"})
+}
+
+func toInvitationResponse(invitation *models.Invitation, now time.Time) dto.InvitationResponse {
+	return dto.InvitationResponse{
+		ID:          invitation.ID,
+		Email:       invitation.Email,
+		Role:        invitation.Role,
+		Status:      invitation.Status(now),
+		ExpiresAt:   invitation.ExpiresAt,
+		CreatedByID: invitation.CreatedByID,
+		UsedAt:      invitation.UsedAt,
+		UsedByID:    invitation.UsedByID,
+		RevokedAt:   invitation.RevokedAt,
+		CreatedAt:   invitation.CreatedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+)
+
+// parseListQuery mem-parse filter, sort dan paging sesuai spec. Untuk parameter
+// yang tidak dikenal atau tidak valid, response 400 langsung dikirim dan ok
+// bernilai false.
+func parseListQuery(c *gin.Context, spec listquery.Spec) (*listquery.Query, bool) {
+	query, err := listquery.Parse(c.Request.URL.Query(), spec)
+	if err != nil {
+		var queryErr *listquery.Error
+		if errors.As(err, &queryErr) {
+			c.JSON(http.StatusBadRequest, dto.QueryErrorResponse{Error: queryErr.Error(), Param: queryErr.Param})
+			return nil, false
+		}
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return nil, false
+	}
+	return query, true
+}
+
+// newPagination menyusun metadata paging response list dari hasil query
+func newPagination(query *listquery.Query, page listquery.Page) dto.Pagination {
+	pagination := dto.Pagination{
+		Total:      page.Total,
+		Limit:      query.Limit,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}
+	if !query.Cursor && page.Total != nil {
+		current, totalPages := query.Page, query.TotalPages(*page.Total)
+		pagination.Page = &current
+		pagination.TotalPages = &totalPages
+	}
+	return pagination
+}
@@ -1,19 +1,87 @@
 package controllers
 
 import (
-	"mental-klinik-backend/databases"
-	"mental-klinik-backend/models"
-	"mental-klinik-backend/utils"
+	"errors"
 	"mental-klinik-backend/dto"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+type MedicalRecordController struct {
+	service services.MedicalRecordService
+	policy  *policy.Policy
+	audit   services.AuditService
+}
+
+func NewMedicalRecordController(service services.MedicalRecordService, policy *policy.Policy, audit services.AuditService) *MedicalRecordController {
+	return &MedicalRecordController{service: service, policy: policy, audit: audit}
+}
+
+// medicalRecordResource: pemilik rekam medis adalah penulisnya (UserID)
+func medicalRecordResource(record *models.MedicalRecord) policy.Resource {
+	return policy.Resource{
+		Type:      policy.MedicalRecord,
+		ID:        record.ID,
+		PatientID: record.PatientID,
+		OwnerID:   record.UserID,
+	}
+}
+
+// toMedicalRecordResponse membentuk response rekam medis; diagnosis dan
+// treatment hanya terlihat oleh dokter yang merawat pasien
+func toMedicalRecordResponse(shaper *fieldShaper, record *models.MedicalRecord) (dto.MedicalRecordResponse, error) {
+	response := dto.MedicalRecordResponse{
+		ID:        record.ID,
+		PatientID: record.PatientID,
+		UserID:    record.UserID,
+		Diagnosis: record.Diagnosis,
+		Treatment: record.Treatment,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
+		Patient: dto.MedicalRecordMiniPatient{
+			ID:       record.Patient.ID,
+			FullName: record.Patient.FullName,
+		},
+		User: dto.MedicalRecordMiniUser{
+			ID:       record.User.ID,
+			FullName: record.User.FullName,
+			Role:     record.User.Role,
+		},
+	}
+
+	resource := medicalRecordResource(record)
+	if err := shaper.shape(resource, policy.FieldDiagnosis, &response.Diagnosis, &response.RedactedFields); err != nil {
+		return response, err
+	}
+	if err := shaper.shape(resource, policy.FieldTreatment, &response.Treatment, &response.RedactedFields); err != nil {
+		return response, err
+	}
+	return response, nil
+}
+
+// loadMedicalRecord mengambil rekam medis untuk dicek policy-nya. Response
+// error langsung dikirim jika gagal.
+func (mc *MedicalRecordController) loadMedicalRecord(c *gin.Context) (*models.MedicalRecord, bool) {
+	record, err := mc.service.GetByID(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrMedicalRecordNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Medical record not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve medical record"})
+		return nil, false
+	}
+	return record, true
+}
+
 // CreateMedicalRecord godoc
 // @Summary Create a new medical record
-// @Description Adds a new medical record linked to a patient and user
+// @Description Adds a new medical record linked to a patient and user. Doctors can only author records as themselves for patients on their care team.
 // @Tags MedicalRecords
 // @Security BearerAuth
 // @Accept json
@@ -21,132 +89,116 @@ import (
 // @Param request body dto.CreateMedicalRecordRequest true "Medical Record input data"
 // @Success 201 {object} dto.CreateMedicalRecordResponse
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/medical-records [post]
-func CreateMedicalRecord(c *gin.Context) {
+func (mc *MedicalRecordController) CreateMedicalRecord(c *gin.Context) {
 	var input dto.CreateMedicalRecordRequest
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	// Validasi Patient
-	var patient models.Patient
-	if err := database.DB.First(&patient, "id = ?", input.PatientID).Error; err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Patient not found"})
+	resource := policy.Resource{Type: policy.MedicalRecord, PatientID: input.PatientID, OwnerID: input.UserID}
+	if !authorize(c, mc.policy, policy.Create, resource) {
 		return
 	}
 
-	// Validasi User
-	var user models.User
-	if err := database.DB.First(&user, "id = ?", input.UserID).Error; err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "User not found"})
+	record, err := mc.service.Create(input)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPatientNotFound):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Patient not found"})
+		case errors.Is(err, services.ErrUserNotFound):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "User not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to create medical record"})
+		}
 		return
 	}
+	recordWrite(mc.audit, auditEvent(c, models.AuditActionCreate, policy.MedicalRecord, record.ID, record.PatientID))
 
-	// Generate custom ID
-	var count int64
-	database.DB.Model(&models.MedicalRecord{}).Count(&count)
-	newID := utils.GenerateCustomMedicalRecordID(int(count + 1))
-
-	record := models.MedicalRecord{
-		ID:        newID,
-		PatientID: input.PatientID,
-		UserID:    input.UserID,
-		Diagnosis: input.Diagnosis,
-		Treatment: input.Treatment,
-	}
-
-	if err := database.DB.Create(&record).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to create medical record"})
+	response, err := toMedicalRecordResponse(newFieldShaper(c, mc.policy, nil), record)
+	if err != nil {
+		writeShapeError(c)
 		return
 	}
-
 	c.JSON(http.StatusCreated, dto.CreateMedicalRecordResponse{
-		Message: "Medical record created",
-		MedicalRecord: dto.MedicalRecordResponse{
-			ID:        record.ID,
-			PatientID: record.PatientID,
-			UserID:    record.UserID,
-			Diagnosis: record.Diagnosis,
-			Treatment: record.Treatment,
-			CreatedAt: record.CreatedAt,
-			UpdatedAt: record.UpdatedAt,
-		},
+		Message:       "Medical record created",
+		MedicalRecord: response,
 	})
 }
 
 // GetAllMedicalRecords godoc
 // @Summary Get all medical records (with pagination and optional filters)
-// @Description Retrieve a paginated list of medical records. Supports filtering by patientId and userId.
+// @Description Retrieve a paginated list of medical records. Supports filtering by patientId and userId. Doctors only see records of patients on their care team.
 // @Tags MedicalRecords
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
+// @Param cursor query string false "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response"
+// @Param withTotal query bool false "Also count total rows in cursor mode" default(false)
 // @Param patientId query string false "Filter by patient ID"
 // @Param userId query string false "Filter by user ID"
+// @Param createdAt[gte] query string false "Created at or after (YYYY-MM-DD or RFC3339)"
+// @Param createdAt[lte] query string false "Created at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, createdAt, updatedAt)" default(-createdAt)
 // @Success 200 {object} dto.PaginatedMedicalRecordsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/medical-records [get]
-func GetAllMedicalRecords(c *gin.Context) {
-	page := ParseInt(c.Query("page"), 1)
-	limit := ParseInt(c.Query("limit"), 10)
-	offset := (page - 1) * limit
-
-	var records []models.MedicalRecord
-	query := database.DB.Preload("Patient").Preload("User")
-
-	if pid := c.Query("patientId"); pid != "" {
-		query = query.Where("patient_id = ?", pid)
+func (mc *MedicalRecordController) GetAllMedicalRecords(c *gin.Context) {
+	if !authorize(c, mc.policy, policy.List, policy.Resource{Type: policy.MedicalRecord}) {
+		return
 	}
-	if uid := c.Query("userId"); uid != "" {
-		query = query.Where("user_id = ?", uid)
+
+	query, ok := parseListQuery(c, repositories.MedicalRecordListSpec)
+	if !ok {
+		return
 	}
 
-	if err := query.Offset(offset).Limit(limit).Find(&records).Error; err != nil {
+	scope := mc.policy.ListScope(currentSubject(c), policy.MedicalRecord)
+	records, pageInfo, err := mc.service.GetAll(repositories.MedicalRecordFilter{
+		CareTeamOf:         scope.CareTeamOf,
+		EmergencyPatientID: scope.EmergencyPatientID,
+		Query:              *query,
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve medical records"})
 		return
 	}
 
-	var total int64
-	database.DB.Model(&models.MedicalRecord{}).Count(&total)
+	events := make([]services.AuditEvent, 0, len(records))
+	for _, record := range records {
+		events = append(events, auditEvent(c, models.AuditActionRead, policy.MedicalRecord, record.ID, record.PatientID))
+	}
+	if !recordReads(c, mc.audit, events...) {
+		return
+	}
 
+	shaper := newFieldShaper(c, mc.policy, nil)
 	var responses []dto.MedicalRecordResponse
-	for _, record := range records {
-		responses = append(responses, dto.MedicalRecordResponse{
-			ID:        record.ID,
-			PatientID: record.PatientID,
-			UserID:    record.UserID,
-			Diagnosis: record.Diagnosis,
-			Treatment: record.Treatment,
-			CreatedAt: record.CreatedAt,
-			UpdatedAt: record.UpdatedAt,
-			Patient: dto.MedicalRecordMiniPatient{
-				ID:       record.Patient.ID,
-				FullName: record.Patient.FullName,
-			},
-			User: dto.MedicalRecordMiniUser{
-				ID:       record.User.ID,
-				FullName: record.User.FullName,
-				Role:     record.User.Role,
-			},
-		})
+	for i := range records {
+		response, err := toMedicalRecordResponse(shaper, &records[i])
+		if err != nil {
+			writeShapeError(c)
+			return
+		}
+		responses = append(responses, response)
 	}
 
 	c.JSON(http.StatusOK, dto.PaginatedMedicalRecordsResponse{
 		Data:       responses,
-		Total:      int(total),
-		Page:       page,
-		Limit:      limit,
-		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+		Pagination: newPagination(query, pageInfo),
 	})
 }
 
 // GetMedicalRecordByID godoc
 // @Summary Get medical record by ID
-// @Description Retrieve detailed information of a medical record by its ID, including patient and user (doctor/staff) info.
+// @Description Retrieve detailed information of a medical record by its ID, including patient and user (doctor/staff) info. Diagnosis and treatment are only returned to the treating doctor (care team or author); for other roles they are omitted and listed in redactedFields.
 // @Tags MedicalRecords
 // @Security BearerAuth
 // @Accept json
@@ -155,33 +207,24 @@ func GetAllMedicalRecords(c *gin.Context) {
 // @Success 200 {object} dto.MedicalRecordResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Router /api/medical-records/{id} [get]
-func GetMedicalRecordByID(c *gin.Context) {
-	id := c.Param("id")
-	var record models.MedicalRecord
-
-	if err := database.DB.Preload("Patient").Preload("User").First(&record, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Medical record not found"})
+func (mc *MedicalRecordController) GetMedicalRecordByID(c *gin.Context) {
+	record, ok := mc.loadMedicalRecord(c)
+	if !ok {
+		return
+	}
+	if !authorize(c, mc.policy, policy.Read, medicalRecordResource(record)) {
+		return
+	}
+	if !recordReads(c, mc.audit, auditEvent(c, models.AuditActionRead, policy.MedicalRecord, record.ID, record.PatientID)) {
 		return
 	}
 
-	response := dto.MedicalRecordResponse{
-		ID:        record.ID,
-		PatientID: record.PatientID,
-		UserID:    record.UserID,
-		Diagnosis: record.Diagnosis,
-		Treatment: record.Treatment,
-		CreatedAt: record.CreatedAt,
-		UpdatedAt: record.UpdatedAt,
-		Patient: dto.MedicalRecordMiniPatient{
-			ID:       record.Patient.ID,
-			FullName: record.Patient.FullName,
-		},
-		User: dto.MedicalRecordMiniUser{
-			ID:       record.User.ID,
-			FullName: record.User.FullName,
-			Role:     record.User.Role,
-		},
+	response, err := toMedicalRecordResponse(newFieldShaper(c, mc.policy, nil), record)
+	if err != nil {
+		writeShapeError(c)
+		return
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -189,7 +232,7 @@ func GetMedicalRecordByID(c *gin.Context) {
 
 // UpdateMedicalRecord godoc
 // @Summary Update medical record by ID
-// @Description Update a medical record's patient, user, diagnosis, or treatment.
+// @Description Update a medical record's patient, user, diagnosis, or treatment. Doctors can only edit records they authored.
 // @Tags MedicalRecords
 // @Security BearerAuth
 // @Accept json
@@ -198,65 +241,48 @@ func GetMedicalRecordByID(c *gin.Context) {
 // @Param data body dto.UpdateMedicalRecordRequest true "Medical Record Data"
 // @Success 200 {object} dto.UpdateMedicalRecordResponse
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/medical-records/{id} [put]
-func UpdateMedicalRecord(c *gin.Context) {
-	id := c.Param("id")
-	var record models.MedicalRecord
-
-	if err := database.DB.First(&record, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Medical record not found"})
-		return
-	}
-
+func (mc *MedicalRecordController) UpdateMedicalRecord(c *gin.Context) {
 	var input dto.UpdateMedicalRecordRequest
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	var updatedFields []dto.UpdatedField
-
-	if record.PatientID != input.PatientID {
-		record.PatientID = input.PatientID
-		updatedFields = append(updatedFields, dto.UpdatedField{
-			Field: "patientId",
-			Value: input.PatientID,
-		})
-	}
-	if record.UserID != input.UserID {
-		record.UserID = input.UserID
-		updatedFields = append(updatedFields, dto.UpdatedField{
-			Field: "userId",
-			Value: input.UserID,
-		})
-	}
-	if record.Diagnosis != input.Diagnosis {
-		record.Diagnosis = input.Diagnosis
-		updatedFields = append(updatedFields, dto.UpdatedField{
-			Field: "diagnosis",
-			Value: input.Diagnosis,
-		})
-	}
-	if record.Treatment != input.Treatment {
-		record.Treatment = input.Treatment
-		updatedFields = append(updatedFields, dto.UpdatedField{
-			Field: "treatment",
-			Value: input.Treatment,
-		})
+	record, ok := mc.loadMedicalRecord(c)
+	if !ok {
+		return
 	}
-
-	if len(updatedFields) == 0 {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "No fields to update"})
+	if !authorize(c, mc.policy, policy.Update, medicalRecordResource(record)) {
 		return
 	}
+	// Memindahkan rekam medis ke pasien/penulis lain sama dengan menulis
+	// rekam medis baru untuk target tersebut
+	if input.PatientID != record.PatientID || input.UserID != record.UserID {
+		target := policy.Resource{Type: policy.MedicalRecord, PatientID: input.PatientID, OwnerID: input.UserID}
+		if !authorize(c, mc.policy, policy.Create, target) {
+			return
+		}
+	}
 
-	record.UpdatedAt = time.Now()
-	if err := database.DB.Save(&record).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to update medical record"})
+	updatedFields, err := mc.service.Update(record.ID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrMedicalRecordNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Medical record not found"})
+		case errors.Is(err, services.ErrNoFieldsToUpdate):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "No fields to update"})
+		default:
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to update medical record"})
+		}
 		return
 	}
+	event := auditEvent(c, models.AuditActionUpdate, policy.MedicalRecord, record.ID, record.PatientID)
+	event.Changes = updatedFields
+	recordWrite(mc.audit, event)
 
 	c.JSON(http.StatusOK, dto.UpdateMedicalRecordResponse{
 		Message:       "Medical record updated",
@@ -272,22 +298,109 @@ func UpdateMedicalRecord(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Medical Record ID"
 // @Success 200 {object} dto.MessageDeleteMedicalRecordResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/medical-records/{id} [delete]
-func DeleteMedicalRecord(c *gin.Context) {
-	id := c.Param("id")
-	var record models.MedicalRecord
-
-	if err := database.DB.First(&record, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Medical record not found"})
+func (mc *MedicalRecordController) DeleteMedicalRecord(c *gin.Context) {
+	record, ok := mc.loadMedicalRecord(c)
+	if !ok {
+		return
+	}
+	if !authorize(c, mc.policy, policy.Delete, medicalRecordResource(record)) {
 		return
 	}
 
-	if err := database.DB.Delete(&record).Error; err != nil {
+	if err := mc.service.Delete(record.ID); err != nil {
+		if errors.Is(err, services.ErrMedicalRecordNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Medical record not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to delete medical record"})
 		return
 	}
+	recordWrite(mc.audit, auditEvent(c, models.AuditActionDelete, policy.MedicalRecord, record.ID, record.PatientID))
 
 	c.JSON(http.StatusOK, dto.MessageDeleteMedicalRecordResponse{Message: "Medical record deleted successfully"})
 }
+
+// GetMedicalRecordTrash godoc
+// @Summary List deleted medical records
+// @Description Get paginated list of soft-deleted medical records (trash), most recently deleted first. Diagnosis and treatment are masked like the normal list. Only accessible by admin.
+// @Tags MedicalRecords
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param patientId query string false "Filter by patient ID"
+// @Param userId query string false "Filter by user ID"
+// @Param deletedAt[gte] query string false "Deleted at or after (YYYY-MM-DD or RFC3339)"
+// @Param deletedAt[lte] query string false "Deleted at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, createdAt, updatedAt, deletedAt)" default(-deletedAt)
+// @Success 200 {object} dto.PaginatedMedicalRecordTrashResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/medical-records/trash [get]
+func (mc *MedicalRecordController) GetMedicalRecordTrash(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.MedicalRecordTrashSpec)
+	if !ok {
+		return
+	}
+
+	records, pageInfo, err := mc.service.GetTrash(*query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve deleted medical records"})
+		return
+	}
+
+	events := make([]services.AuditEvent, 0, len(records))
+	for _, record := range records {
+		events = append(events, auditEvent(c, models.AuditActionRead, policy.MedicalRecord, record.ID, record.PatientID))
+	}
+	if !recordReads(c, mc.audit, events...) {
+		return
+	}
+
+	shaper := newFieldShaper(c, mc.policy, nil)
+	responses := make([]dto.MedicalRecordTrashResponse, 0, len(records))
+	for i := range records {
+		response, err := toMedicalRecordResponse(shaper, &records[i])
+		if err != nil {
+			writeShapeError(c)
+			return
+		}
+		responses = append(responses, dto.MedicalRecordTrashResponse{MedicalRecordResponse: response, DeletedAt: records[i].DeletedAt.Time})
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedMedicalRecordTrashResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// RestoreMedicalRecord godoc
+// @Summary Restore a deleted medical record
+// @Description Restore a medical record from the trash. Fails with 409 if its patient is deleted; restore the patient first. Only accessible by admin.
+// @Tags MedicalRecords
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Medical Record ID"
+// @Success 200 {object} dto.RestoreResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/medical-records/{id}/restore [post]
+func (mc *MedicalRecordController) RestoreMedicalRecord(c *gin.Context) {
+	record, result, err := mc.service.Restore(c.Param("id"))
+	if err != nil {
+		writeRestoreError(c, err, services.ErrMedicalRecordNotFound, "Medical record not found in trash", "Failed to restore medical record")
+		return
+	}
+	recordWrite(mc.audit, restoreAuditEvent(c, policy.MedicalRecord, record.ID, record.PatientID, result))
+
+	c.JSON(http.StatusOK, dto.RestoreResponse{Message: "Medical record restored successfully", Restored: result})
+}
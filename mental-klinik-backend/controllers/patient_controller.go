@@ -1,190 +1,354 @@
 package controllers
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 
-	"mental-klinik-backend/databases"
 	"mental-klinik-backend/dto"
 	"mental-klinik-backend/models"
-	"mental-klinik-backend/utils" 
+	"mental-klinik-backend/nik"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
+	"mental-klinik-backend/utils"
 )
 
+type PatientController struct {
+	service services.PatientService
+	policy  *policy.Policy
+	audit   services.AuditService
+}
+
+func NewPatientController(service services.PatientService, policy *policy.Policy, audit services.AuditService) *PatientController {
+	return &PatientController{service: service, policy: policy, audit: audit}
+}
+
+func patientResource(id string) policy.Resource {
+	return policy.Resource{Type: policy.Patient, ID: id, PatientID: id}
+}
+
+// toPatientResponse membentuk response pasien; NIK, telepon, alamat dan kontak
+// darurat disamarkan sesuai role user yang login. Kontak darurat adalah
+// telepon kontak prioritas tertinggi.
+func toPatientResponse(shaper *fieldShaper, patient *models.Patient) (dto.PatientResponse, error) {
+	response := dto.PatientResponse{
+		ID:        patient.ID,
+		FullName:  patient.FullName,
+		NIK:       patient.NIK,
+		BirthDate: utils.FormatDate(patient.BirthDate),
+		Gender:    patient.Gender,
+		Phone:     patient.Phone,
+		Address:   patient.Address,
+	}
+	if contact := patient.PrimaryContact(); contact != nil {
+		response.EmergencyContact = contact.Phone
+	}
+	response.Age, response.IsMinor = patientAge(patient)
+
+	resource := patientResource(patient.ID)
+	sensitive := []struct {
+		field string
+		value *string
+	}{
+		{policy.FieldNIK, &response.NIK},
+		{policy.FieldPhone, &response.Phone},
+		{policy.FieldAddress, &response.Address},
+		{policy.FieldEmergencyContact, &response.EmergencyContact},
+	}
+	for _, f := range sensitive {
+		if err := shaper.shape(resource, f.field, f.value, &response.RedactedFields); err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
+
+// writeValidationError mengirim 400 dengan kesalahan per field dari
+// *nik.ValidationError yang dibungkus err
+func writeValidationError(c *gin.Context, err error) {
+	response := dto.ValidationErrorResponse{Error: services.ErrInvalidPatientData.Error()}
+	var invalid *nik.ValidationError
+	if errors.As(err, &invalid) {
+		for _, fieldErr := range invalid.Errors {
+			response.Fields = append(response.Fields, dto.FieldError{Field: fieldErr.Field, Message: fieldErr.Message})
+		}
+	}
+	c.JSON(http.StatusBadRequest, response)
+}
+
+// patientAge menghitung usia pasien hari ini (nil jika tanggal lahir belum
+// diketahui) dan apakah pasien di bawah umur
+func patientAge(patient *models.Patient) (*int, bool) {
+	today := utils.Today()
+	age, ok := patient.Age(today)
+	if !ok {
+		return nil, false
+	}
+	return &age, patient.IsMinor(today)
+}
+
+// toPatientMiniResponse membentuk data ringkas pasien untuk response resource
+// lain (appointment, assessment, tim perawatan)
+func toPatientMiniResponse(patient *models.Patient) dto.PatientMiniResponse {
+	response := dto.PatientMiniResponse{
+		ID:        patient.ID,
+		FullName:  patient.FullName,
+		Gender:    patient.Gender,
+		BirthDate: utils.FormatDate(patient.BirthDate),
+	}
+	response.Age, response.IsMinor = patientAge(patient)
+	return response
+}
+
 // CreatePatient godoc
 // @Summary Create a new patient
-// @Description Register a new patient with full name, NIK, birth date, gender, phone, address, and emergency contact
+// @Description Register a new patient with full name, NIK, birth date, gender, phone, address, and emergency contacts
+// @Description `contacts` creates the patient's emergency contacts and guardians; the older `emergencyContact` (a single phone number) is still accepted and stored as a contact with relationship `other`. Manage contacts afterwards with /api/patients/{id}/contacts. `emergencyContact` in the response is the phone of the highest-priority contact.
+// @Description The NIK must be 16 digits with a known region code and an encoded birth date (day + 40 for females) that matches `birthDate` and `gender`.
 // @Tags Patients
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param request body dto.CreatePatientRequest true "Patient input data"
 // @Success 201 {object} dto.CreatePatientResponse
-// @Failure 400 {object} dto.ErrorResponse
+// @Failure 400 {object} dto.ValidationErrorResponse "fields is set for NIK, birth date and gender errors"
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/patients [post]
-func CreatePatient(c *gin.Context) {
+func (pc *PatientController) CreatePatient(c *gin.Context) {
+	if !authorize(c, pc.policy, policy.Create, policy.Resource{Type: policy.Patient}) {
+		return
+	}
+
 	var input dto.CreatePatientRequest
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	// Cek apakah NIK sudah terdaftar
-	var existing models.Patient
-	if err := database.DB.Where("nik = ?", input.NIK).First(&existing).Error; err != gorm.ErrRecordNotFound {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "NIK already registered"})
+	patient, err := pc.service.Create(input)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidPatientData) {
+			writeValidationError(c, err)
+			return
+		}
+		if errors.Is(err, services.ErrNIKAlreadyRegistered) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "NIK already registered"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to create patient"})
+		return
+	}
+	recordWrite(pc.audit, auditEvent(c, models.AuditActionCreate, policy.Patient, patient.ID, patient.ID))
+
+	// Response
+	patientResponse, err := toPatientResponse(newFieldShaper(c, pc.policy, nil), patient)
+	if err != nil {
+		writeShapeError(c)
 		return
 	}
+	response := dto.CreatePatientResponse{
+		Message: "Patient created successfully",
+		Patient: patientResponse,
+	}
 
-	// Generate ID custom
-	count := int64(0)
-	database.DB.Model(&models.Patient{}).Count(&count)
-	newID := utils.GenerateCustomPatientID(int(count + 1))
+	c.JSON(http.StatusCreated, response)
+}
 
-	// Simpan ke database
-	patient := models.Patient{
-		ID:               newID,
-		FullName:         input.FullName,
-		NIK:              input.NIK,
-		BirthDate:        input.BirthDate,
-		Gender:           input.Gender,
-		Phone:            input.Phone,
-		Address:          input.Address,
-		EmergencyContact: input.EmergencyContact,
+// DecodeNIK godoc
+// @Summary Decode a NIK
+// @Description Decode the region, birth date and gender encoded in a NIK to pre-fill the patient intake form. The century of the birth year is inferred so that the date is not in the future.
+// @Tags Patients
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.DecodeNIKRequest true "NIK to decode"
+// @Success 200 {object} dto.DecodeNIKResponse
+// @Failure 400 {object} dto.ValidationErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/patients/nik/decode [post]
+func (pc *PatientController) DecodeNIK(c *gin.Context) {
+	if !authorize(c, pc.policy, policy.Create, policy.Resource{Type: policy.Patient}) {
+		return
 	}
 
-	if err := database.DB.Create(&patient).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to create patient"})
+	var input dto.DecodeNIKRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	// Response
-	response := dto.CreatePatientResponse{
-		Message: "Patient created successfully",
-		Patient: dto.PatientResponse{
-			ID:               patient.ID,
-			FullName:         patient.FullName,
-			NIK:              patient.NIK,
-			BirthDate:        patient.BirthDate,
-			Gender:           patient.Gender,
-			Phone:            patient.Phone,
-			Address:          patient.Address,
-			EmergencyContact: patient.EmergencyContact,
-		},
+	info, err := pc.service.DecodeNIK(input.NIK)
+	if err != nil {
+		writeValidationError(c, err)
+		return
 	}
 
-	c.JSON(http.StatusCreated, response)
+	c.JSON(http.StatusOK, dto.DecodeNIKResponse{
+		Province:  dto.RegionResponse{Code: info.Province.Code, Name: info.Province.Name},
+		Regency:   dto.RegionResponse{Code: info.Regency.Code, Name: info.Regency.Name},
+		District:  dto.RegionResponse{Code: info.District.Code, Name: info.District.Name},
+		BirthDate: info.BirthDate.Format(utils.DateLayout),
+		Gender:    info.Gender,
+	})
 }
 
 // GetAllPatients godoc
 // @Summary Get all patients
-// @Description Get paginated list of patients with optional search, gender filter, and sorting
+// @Description Get paginated list of patients with optional search, gender filter, and sorting. Doctors only see patients on their care team.
 // @Tags Patients
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
-// @Param search query string false "Search by full name or NIK"
-// @Param gender query string false "Filter by gender (male, female, other)"
-// @Param sort query string false "Sort by field (e.g. full_name, created_at)" default(created_at)
-// @Param order query string false "Sort order (asc or desc)" default(desc)
+// @Param cursor query string false "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response"
+// @Param withTotal query bool false "Also count total rows in cursor mode" default(false)
+// @Param search query string false "Search by full name (partial) or NIK (exact match, NIK is stored encrypted)"
+// @Param gender query string false "Filter by gender (male, female, other). Also gender[in]=male,female"
+// @Param fullName[ilike] query string false "Filter full name containing text"
+// @Param minAge query int false "Minimum age in full years (patients without a known birth date are excluded)"
+// @Param maxAge query int false "Maximum age in full years (patients without a known birth date are excluded)"
+// @Param birthDate[gte] query string false "Born on or after (YYYY-MM-DD)"
+// @Param birthDate[lte] query string false "Born on or before (YYYY-MM-DD)"
+// @Param createdAt[gte] query string false "Created at or after (YYYY-MM-DD or RFC3339)"
+// @Param createdAt[lte] query string false "Created at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, fullName, gender, createdAt, updatedAt)" default(-createdAt)
+// @Param order query string false "Default sort order for fields without prefix (asc or desc)"
 // @Success 200 {object} dto.PaginatedPatientsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
 // @Router /api/patients [get]
-func GetAllPatients(c *gin.Context) {
-	page := c.DefaultQuery("page", "1")
-	limit := c.DefaultQuery("limit", "10")
-	search := c.Query("search")
-	gender := c.Query("gender")
-	sort := c.DefaultQuery("sort", "created_at")
-	order := c.DefaultQuery("order", "desc")
-
-	pageInt, _ := strconv.Atoi(page)
-	limitInt, _ := strconv.Atoi(limit)
-	offset := (pageInt - 1) * limitInt
-
-	var patients []models.Patient
-	query := database.DB.Model(&models.Patient{})
+func (pc *PatientController) GetAllPatients(c *gin.Context) {
+	if !authorize(c, pc.policy, policy.List, policy.Resource{Type: policy.Patient}) {
+		return
+	}
 
-	// Filtering
-	if search != "" {
-		query = query.Where("full_name ILIKE ? OR nik ILIKE ?", "%"+search+"%", "%"+search+"%")
+	query, ok := parseListQuery(c, repositories.PatientListSpec)
+	if !ok {
+		return
 	}
-	if gender != "" {
-		query = query.Where("gender = ?", gender)
+	minAge, ok := parseAge(c, "minAge")
+	if !ok {
+		return
 	}
-
-	// Sorting
-	if sort != "" && (order == "asc" || order == "desc") {
-		query = query.Order(fmt.Sprintf("%s %s", sort, order))
+	maxAge, ok := parseAge(c, "maxAge")
+	if !ok {
+		return
+	}
+	if minAge != nil && maxAge != nil && *minAge > *maxAge {
+		c.JSON(http.StatusBadRequest, dto.QueryErrorResponse{Error: "maxAge must not be less than minAge", Param: "maxAge"})
+		return
 	}
 
-	var total int64
-	query.Count(&total)
+	scope := pc.policy.ListScope(currentSubject(c), policy.Patient)
+	patients, pageInfo, err := pc.service.GetAll(repositories.PatientFilter{
+		Search:             c.Query("search"),
+		CareTeamOf:         scope.CareTeamOf,
+		EmergencyPatientID: scope.EmergencyPatientID,
+		MinAge:             minAge,
+		MaxAge:             maxAge,
+		Query:              *query,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve patients"})
+		return
+	}
 
-	// Fetch paginated data
-	query = query.Offset(offset).Limit(limitInt).Find(&patients)
+	events := make([]services.AuditEvent, 0, len(patients))
+	for _, p := range patients {
+		events = append(events, auditEvent(c, models.AuditActionRead, policy.Patient, p.ID, p.ID))
+	}
+	if !recordReads(c, pc.audit, events...) {
+		return
+	}
 
 	// Convert to DTO
+	shaper := newFieldShaper(c, pc.policy, nil)
 	var responses []dto.PatientResponse
-	for _, p := range patients {
-		responses = append(responses, dto.PatientResponse{
-			ID:               p.ID,
-			FullName:         p.FullName,
-			NIK:              p.NIK,
-			BirthDate:        p.BirthDate,
-			Gender:           p.Gender,
-			Phone:            p.Phone,
-			Address:          p.Address,
-			EmergencyContact: p.EmergencyContact,
-		})
+	for i := range patients {
+		response, err := toPatientResponse(shaper, &patients[i])
+		if err != nil {
+			writeShapeError(c)
+			return
+		}
+		responses = append(responses, response)
 	}
 
 	c.JSON(http.StatusOK, dto.PaginatedPatientsResponse{
 		Data:       responses,
-		Total:      int(total),
-		Page:       pageInt,
-		Limit:      limitInt,
-		TotalPages: int((total + int64(limitInt) - 1) / int64(limitInt)),
+		Pagination: newPagination(query, pageInfo),
 	})
 }
 
+// parseAge membaca parameter usia (bilangan bulat >= 0), nil jika kosong.
+// Response 400 langsung dikirim jika nilainya tidak valid.
+func parseAge(c *gin.Context, param string) (*int, bool) {
+	raw := c.Query(param)
+	if raw == "" {
+		return nil, true
+	}
+	age, err := strconv.Atoi(raw)
+	if err != nil || age < 0 {
+		c.JSON(http.StatusBadRequest, dto.QueryErrorResponse{Error: param + " must be a non-negative integer", Param: param})
+		return nil, false
+	}
+	return &age, true
+}
+
 // GetPatientByID godoc
 // @Summary Get patient by ID
-// @Description Retrieve detailed information of a patient by their ID
+// @Description Retrieve detailed information of a patient by their ID. Sensitive fields are masked by role (admin and staff see a masked NIK, admin does not see the address, doctors outside the care team see nothing sensitive); masked or omitted fields are listed in redactedFields. Use reveal with a reason to see full values, which is written to the audit log.
 // @Tags Patients
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path string true "Patient ID"
+// @Param reveal query string false "Comma separated fields to show in full (nik, phone, address, emergencyContact)"
+// @Param reason query string false "Justification for reveal (required with reveal, at least 10 characters)"
 // @Success 200 {object} dto.PatientResponse
+// @Failure 400 {object} dto.QueryErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Router /api/patients/{id} [get]
-func GetPatientByID(c *gin.Context) {
-	id := c.Param("id")
-	var patient models.Patient
+func (pc *PatientController) GetPatientByID(c *gin.Context) {
+	if !authorize(c, pc.policy, policy.Read, patientResource(c.Param("id"))) {
+		return
+	}
+	reveal, ok := parseReveal(c, policy.Patient)
+	if !ok {
+		return
+	}
 
-	if err := database.DB.First(&patient, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Patient not found"})
+	patient, err := pc.service.GetByID(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrPatientNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Patient not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve patient"})
+		return
+	}
+	events := []services.AuditEvent{auditEvent(c, models.AuditActionRead, policy.Patient, patient.ID, patient.ID)}
+	if reveal != nil {
+		event := auditEvent(c, models.AuditActionReveal, policy.Patient, patient.ID, patient.ID)
+		event.Fields = reveal.Fields
+		event.Reason = reveal.Reason
+		events = append(events, event)
+	}
+	if !recordReads(c, pc.audit, events...) {
 		return
 	}
 
-	response := dto.PatientResponse{
-		ID:               patient.ID,
-		FullName:         patient.FullName,
-		NIK:              patient.NIK,
-		BirthDate:        patient.BirthDate,
-		Gender:           patient.Gender,
-		Phone:            patient.Phone,
-		Address:          patient.Address,
-		EmergencyContact: patient.EmergencyContact,
+	response, err := toPatientResponse(newFieldShaper(c, pc.policy, reveal), patient)
+	if err != nil {
+		writeShapeError(c)
+		return
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -193,6 +357,8 @@ func GetPatientByID(c *gin.Context) {
 // UpdatePatient godoc
 // @Summary Update patient data
 // @Description Update a patient’s information by ID. Only fields in the request body will be updated.
+// @Description When the NIK, birth date or gender changes, the resulting NIK is validated against the resulting birth date and gender.
+// @Description `emergencyContact` (deprecated) replaces the phone of the highest-priority contact, or creates a contact if the patient has none.
 // @Tags Patients
 // @Security BearerAuth
 // @Accept json
@@ -200,16 +366,13 @@ func GetPatientByID(c *gin.Context) {
 // @Param id path string true "Patient ID"
 // @Param request body dto.UpdatePatientInput true "Patient fields to update"
 // @Success 200 {object} dto.PatientResponse
-// @Failure 400 {object} dto.ErrorResponse
+// @Failure 400 {object} dto.ValidationErrorResponse "fields is set for NIK, birth date and gender errors"
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/patients/{id} [put]
-func UpdatePatient(c *gin.Context) {
-	id := c.Param("id")
-	var patient models.Patient
-
-	if err := database.DB.First(&patient, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Patient not found"})
+func (pc *PatientController) UpdatePatient(c *gin.Context) {
+	if !authorize(c, pc.policy, policy.Update, patientResource(c.Param("id"))) {
 		return
 	}
 
@@ -219,81 +382,201 @@ func UpdatePatient(c *gin.Context) {
 		return
 	}
 
-	// Check if NIK is changing and already exists
-	if input.NIK != "" && input.NIK != patient.NIK {
-		var existing models.Patient
-		if err := database.DB.Where("nik = ?", input.NIK).First(&existing).Error; err != gorm.ErrRecordNotFound {
+	patient, updatedFields, err := pc.service.Update(c.Param("id"), input)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPatientNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Patient not found"})
+		case errors.Is(err, services.ErrInvalidPatientData):
+			writeValidationError(c, err)
+		case errors.Is(err, services.ErrNIKAlreadyRegistered):
 			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "NIK already used"})
-			return
+		default:
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to update patient"})
 		}
-		patient.NIK = input.NIK
+		return
 	}
+	event := auditEvent(c, models.AuditActionUpdate, policy.Patient, patient.ID, patient.ID)
+	event.Changes = updatedFields
+	recordWrite(pc.audit, event)
 
-	// Update fields if provided
-	if input.FullName != "" {
-		patient.FullName = input.FullName
+	response, err := toPatientResponse(newFieldShaper(c, pc.policy, nil), patient)
+	if err != nil {
+		writeShapeError(c)
+		return
 	}
-	if input.BirthDate != "" {
-		patient.BirthDate = input.BirthDate
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeletePatient godoc
+// @Summary Delete a patient
+// @Description Delete a patient record by their ID. Only accessible by authorized roles.
+// @Description Appointments, assessments (with their predictions) and medical records of the patient are soft-deleted in the same transaction and can be restored together with `cascade=true`.
+// @Tags Patients
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Success 200 {object} dto.MessageDeletePatientResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.DeleteBlockedResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/{id} [delete]
+func (pc *PatientController) DeletePatient(c *gin.Context) {
+	if !authorize(c, pc.policy, policy.Delete, patientResource(c.Param("id"))) {
+		return
 	}
-	if input.Gender != "" {
-		patient.Gender = input.Gender
+
+	result, err := pc.service.Delete(c.Param("id"))
+	if err != nil {
+		writeDeleteError(c, err, services.ErrPatientNotFound, "Patient not found", "Failed to delete patient")
+		return
 	}
-	if input.Phone != "" {
-		patient.Phone = input.Phone
+	recordWrite(pc.audit, deleteAuditEvent(c, policy.Patient, c.Param("id"), c.Param("id"), result))
+
+	c.JSON(http.StatusOK, dto.MessageDeletePatientResponse{Message: "Patient deleted successfully", Deleted: result.Deleted})
+}
+
+// GetPatientTrash godoc
+// @Summary List deleted patients
+// @Description Get paginated list of soft-deleted patients (trash), most recently deleted first. Sensitive fields are masked like the normal list. Only accessible by admin.
+// @Tags Patients
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param gender query string false "Filter by gender (male, female, other)"
+// @Param deletedAt[gte] query string false "Deleted at or after (YYYY-MM-DD or RFC3339)"
+// @Param deletedAt[lte] query string false "Deleted at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, fullName, gender, createdAt, updatedAt, deletedAt)" default(-deletedAt)
+// @Success 200 {object} dto.PaginatedPatientTrashResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/trash [get]
+func (pc *PatientController) GetPatientTrash(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.PatientTrashSpec)
+	if !ok {
+		return
 	}
-	if input.Address != "" {
-		patient.Address = input.Address
+
+	patients, pageInfo, err := pc.service.GetTrash(*query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve deleted patients"})
+		return
+	}
+
+	events := make([]services.AuditEvent, 0, len(patients))
+	for _, p := range patients {
+		events = append(events, auditEvent(c, models.AuditActionRead, policy.Patient, p.ID, p.ID))
 	}
-	if input.EmergencyContact != "" {
-		patient.EmergencyContact = input.EmergencyContact
+	if !recordReads(c, pc.audit, events...) {
+		return
 	}
 
-	if err := database.DB.Save(&patient).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to update patient"})
+	shaper := newFieldShaper(c, pc.policy, nil)
+	responses := make([]dto.PatientTrashResponse, 0, len(patients))
+	for i := range patients {
+		response, err := toPatientResponse(shaper, &patients[i])
+		if err != nil {
+			writeShapeError(c)
+			return
+		}
+		responses = append(responses, dto.PatientTrashResponse{PatientResponse: response, DeletedAt: patients[i].DeletedAt.Time})
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedPatientTrashResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// GetLegacyBirthDates godoc
+// @Summary List unconverted legacy birth dates
+// @Description Get paginated list of patients whose old free-text birth date could not be converted to a date during migration. suggestedBirthDate is decoded from the patient's NIK when it is valid. Fix a row by updating the patient's birthDate. Only accessible by admin.
+// @Tags Patients
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param cursor query string false "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response"
+// @Param withTotal query bool false "Also count total rows in cursor mode" default(false)
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, fullName, createdAt)" default(createdAt)
+// @Success 200 {object} dto.PaginatedLegacyBirthDatesResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/legacy-birth-dates [get]
+func (pc *PatientController) GetLegacyBirthDates(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.PatientLegacyBirthDateSpec)
+	if !ok {
 		return
 	}
 
-	response := dto.PatientResponse{
-		ID:               patient.ID,
-		FullName:         patient.FullName,
-		NIK:              patient.NIK,
-		BirthDate:        patient.BirthDate,
-		Gender:           patient.Gender,
-		Phone:            patient.Phone,
-		Address:          patient.Address,
-		EmergencyContact: patient.EmergencyContact,
+	results, pageInfo, err := pc.service.GetLegacyBirthDates(*query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve legacy birth dates"})
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	events := make([]services.AuditEvent, 0, len(results))
+	for _, result := range results {
+		events = append(events, auditEvent(c, models.AuditActionRead, policy.Patient, result.Patient.ID, result.Patient.ID))
+	}
+	if !recordReads(c, pc.audit, events...) {
+		return
+	}
+
+	responses := make([]dto.LegacyBirthDateResponse, 0, len(results))
+	for _, result := range results {
+		response := dto.LegacyBirthDateResponse{
+			ID:       result.Patient.ID,
+			FullName: result.Patient.FullName,
+		}
+		if result.Patient.BirthDateLegacy != nil {
+			response.LegacyValue = *result.Patient.BirthDateLegacy
+		}
+		response.SuggestedBirthDate = utils.FormatDate(result.SuggestedBirthDate)
+		responses = append(responses, response)
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedLegacyBirthDatesResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
 }
 
-// DeletePatient godoc
-// @Summary Delete a patient
-// @Description Delete a patient record by their ID. Only accessible by authorized roles.
+// RestorePatient godoc
+// @Summary Restore a deleted patient
+// @Description Restore a patient from the trash. Fails with 409 if the NIK has since been registered to another patient or the patient has been anonymized. With cascade=true, appointments, assessments (with their predictions) and medical records deleted together with the patient are restored as well. Only accessible by admin.
 // @Tags Patients
 // @Security BearerAuth
 // @Produce json
 // @Param id path string true "Patient ID"
-// @Success 200 {object} dto.MessageDeletePatientResponse
+// @Param cascade query bool false "Also restore data deleted together with the patient" default(false)
+// @Success 200 {object} dto.RestoreResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
-// @Router /api/patients/{id} [delete]
-func DeletePatient(c *gin.Context) {
-	id := c.Param("id")
-
-	// Cek apakah patient ada dulu
-	var patient models.Patient
-	if err := database.DB.First(&patient, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Patient not found"})
+// @Router /api/patients/{id}/restore [post]
+func (pc *PatientController) RestorePatient(c *gin.Context) {
+	cascade, ok := parseCascade(c)
+	if !ok {
 		return
 	}
 
-	// Hapus soft delete
-	if err := database.DB.Delete(&patient).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to delete patient"})
+	_, result, err := pc.service.Restore(c.Param("id"), cascade)
+	if err != nil {
+		writeRestoreError(c, err, services.ErrPatientNotFound, "Patient not found in trash", "Failed to restore patient")
 		return
 	}
+	recordWrite(pc.audit, restoreAuditEvent(c, policy.Patient, c.Param("id"), c.Param("id"), result))
 
-	c.JSON(http.StatusOK, dto.MessageDeletePatientResponse{Message: "Patient deleted successfully"})
-}
\ No newline at end of file
+	c.JSON(http.StatusOK, dto.RestoreResponse{Message: "Patient restored successfully", Restored: result})
+}
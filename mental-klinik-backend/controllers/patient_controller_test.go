@@ -0,0 +1,494 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/controllers"
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/nik"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/routes"
+	"mental-klinik-backend/services"
+	"mental-klinik-backend/utils"
+)
+
+// fakePatientRepository adalah PatientRepository di memori; method yang tidak
+// dipakai handler pasien tidak diimplementasikan
+type fakePatientRepository struct {
+	repositories.PatientRepository
+	patients map[string]*models.Patient
+	careTeam map[string][]string // patientID -> user di tim perawatan
+}
+
+func newFakePatientRepository() *fakePatientRepository {
+	return &fakePatientRepository{patients: map[string]*models.Patient{}, careTeam: map[string][]string{}}
+}
+
+func (r *fakePatientRepository) Create(patient *models.Patient) error {
+	stored := *patient
+	r.patients[patient.ID] = &stored
+	return nil
+}
+
+func (r *fakePatientRepository) FindByID(id string) (*models.Patient, error) {
+	patient, ok := r.patients[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+	found := *patient
+	return &found, nil
+}
+
+func (r *fakePatientRepository) FindByNIK(nik string) (*models.Patient, error) {
+	for _, patient := range r.patients {
+		if patient.NIK == nik {
+			found := *patient
+			return &found, nil
+		}
+	}
+	return nil, repositories.ErrNotFound
+}
+
+func (r *fakePatientRepository) FindAll(filter repositories.PatientFilter) ([]models.Patient, listquery.Page, error) {
+	var patients []models.Patient
+	for _, patient := range r.patients {
+		if filter.CareTeamOf != "" && !r.IsOnCareTeamOf(filter.CareTeamOf, patient.ID) {
+			continue
+		}
+		if filter.Search != "" && !strings.Contains(strings.ToLower(patient.FullName), strings.ToLower(filter.Search)) {
+			continue
+		}
+		patients = append(patients, *patient)
+	}
+	sort.Slice(patients, func(i, j int) bool { return patients[i].ID < patients[j].ID })
+	total := int64(len(patients))
+	return patients, listquery.Page{Total: &total}, nil
+}
+
+func (r *fakePatientRepository) IsOnCareTeamOf(userID string, patientID string) bool {
+	for _, member := range r.careTeam[patientID] {
+		if member == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *fakePatientRepository) Update(patient *models.Patient) error {
+	if _, ok := r.patients[patient.ID]; !ok {
+		return repositories.ErrNotFound
+	}
+	stored := *patient
+	r.patients[patient.ID] = &stored
+	return nil
+}
+
+// fakeCareTeam menjawab keanggotaan tim perawatan dari repository palsu
+type fakeCareTeam struct {
+	patients *fakePatientRepository
+}
+
+func (c fakeCareTeam) IsOnCareTeam(userID string, patientID string) (bool, error) {
+	return c.patients.IsOnCareTeamOf(userID, patientID), nil
+}
+
+// fakeAuditService menyimpan event audit di memori
+type fakeAuditService struct {
+	services.AuditService
+	events []services.AuditEvent
+}
+
+func (a *fakeAuditService) Record(events ...services.AuditEvent) error {
+	a.events = append(a.events, events...)
+	return nil
+}
+
+const testRegions = `code,name
+32,Jawa Barat
+32.01,Kabupaten Bogor
+32.01.01,Cibinong
+`
+
+type patientTestServer struct {
+	router   *gin.Engine
+	patients *fakePatientRepository
+	audit    *fakeAuditService
+}
+
+func newPatientTestServer(t *testing.T) *patientTestServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	regions, err := nik.LoadRegions(strings.NewReader(testRegions))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids, err := utils.NewIDGenerator(utils.IDFormatULID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	patients := newFakePatientRepository()
+	audit := &fakeAuditService{}
+	service := services.NewPatientService(patients, nil, ids, nik.NewValidator(regions))
+	controller := controllers.NewPatientController(service, policy.New(fakeCareTeam{patients: patients}), audit)
+
+	// Pengganti middleware JWT: user, role dan akses darurat diambil dari header
+	auth := func(c *gin.Context) {
+		c.Set("userId", c.GetHeader("X-User-ID"))
+		c.Set("role", c.GetHeader("X-Role"))
+		if accessID := c.GetHeader("X-Emergency-Access-ID"); accessID != "" {
+			c.Set("emergencyAccessId", accessID)
+			c.Set("emergencyPatientId", c.GetHeader("X-Emergency-Patient-ID"))
+		}
+		c.Next()
+	}
+	router := gin.New()
+	routes.PatientRoutes(router, controller, auth)
+	return &patientTestServer{router: router, patients: patients, audit: audit}
+}
+
+func (s *patientTestServer) do(t *testing.T, method string, path string, userID string, role string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", userID)
+	req.Header.Set("X-Role", role)
+	recorder := httptest.NewRecorder()
+	s.router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func decode[T any](t *testing.T, recorder *httptest.ResponseRecorder) T {
+	t.Helper()
+	var value T
+	if err := json.Unmarshal(recorder.Body.Bytes(), &value); err != nil {
+		t.Fatalf("decode %s: %v", recorder.Body.String(), err)
+	}
+	return value
+}
+
+func date(t *testing.T, raw string) *time.Time {
+	t.Helper()
+	value, err := time.Parse(utils.DateLayout, raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &value
+}
+
+func validPatientRequest() dto.CreatePatientRequest {
+	return dto.CreatePatientRequest{
+		FullName:  "Budi Santoso",
+		NIK:       "3201011508900001",
+		BirthDate: "1990-08-15",
+		Gender:    "male",
+		Phone:     "081234567890",
+		Address:   "Jl. Merdeka No. 10",
+	}
+}
+
+func TestCreatePatient(t *testing.T) {
+	s := newPatientTestServer(t)
+
+	recorder := s.do(t, http.MethodPost, "/api/patients/", "staff-001-aaaaaaaa", policy.RoleStaff, validPatientRequest())
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("status = %d, body %s", recorder.Code, recorder.Body.String())
+	}
+	created := decode[dto.CreatePatientResponse](t, recorder)
+	if !strings.HasPrefix(created.Patient.ID, utils.EntityPatient+"-") {
+		t.Errorf("id = %q, want patient prefix", created.Patient.ID)
+	}
+	if created.Patient.NIK != "3201********0001" || created.Patient.Phone != "081234567890" {
+		t.Errorf("staff sees nik %q phone %q, want masked NIK and full phone", created.Patient.NIK, created.Patient.Phone)
+	}
+	if _, ok := s.patients.patients[created.Patient.ID]; !ok {
+		t.Errorf("patient %s was not stored", created.Patient.ID)
+	}
+	if len(s.audit.events) != 1 || s.audit.events[0].Action != models.AuditActionCreate {
+		t.Errorf("audit events = %+v, want one create", s.audit.events)
+	}
+
+	recorder = s.do(t, http.MethodPost, "/api/patients/", "staff-001-aaaaaaaa", policy.RoleStaff, validPatientRequest())
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("duplicate NIK status = %d, want 400", recorder.Code)
+	}
+}
+
+func TestCreatePatientValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(input *dto.CreatePatientRequest)
+		fields []string
+	}{
+		{"unknown district", func(input *dto.CreatePatientRequest) { input.NIK = "3201991508900001" }, []string{"nik"}},
+		{"birth date mismatch", func(input *dto.CreatePatientRequest) { input.BirthDate = "1990-08-16" }, []string{"birthDate"}},
+		{"gender mismatch", func(input *dto.CreatePatientRequest) { input.Gender = "female" }, []string{"gender"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newPatientTestServer(t)
+			input := validPatientRequest()
+			tt.modify(&input)
+
+			recorder := s.do(t, http.MethodPost, "/api/patients/", "staff-001-aaaaaaaa", policy.RoleStaff, input)
+			if recorder.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, body %s", recorder.Code, recorder.Body.String())
+			}
+			response := decode[dto.ValidationErrorResponse](t, recorder)
+			var fields []string
+			for _, fieldErr := range response.Fields {
+				fields = append(fields, fieldErr.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(tt.fields, ",") {
+				t.Errorf("fields = %v, want %v", fields, tt.fields)
+			}
+			if len(s.patients.patients) != 0 {
+				t.Errorf("invalid patient was stored")
+			}
+		})
+	}
+}
+
+func TestCreatePatientForbidden(t *testing.T) {
+	s := newPatientTestServer(t)
+	recorder := s.do(t, http.MethodPost, "/api/patients/", "doctor-001-bbbbbbbb", policy.RoleDoctor, validPatientRequest())
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403", recorder.Code)
+	}
+	if len(s.patients.patients) != 0 || len(s.audit.events) != 0 {
+		t.Errorf("forbidden request stored a patient or an audit event")
+	}
+}
+
+func TestGetPatientByID(t *testing.T) {
+	s := newPatientTestServer(t)
+	birthDate := date(t, "1990-08-15")
+	s.patients.patients["patient-001"] = &models.Patient{
+		ID: "patient-001", FullName: "Budi Santoso", NIK: "3201011508900001", BirthDate: birthDate,
+		Gender: "male", Phone: "081234567890", Address: "Jl. Merdeka No. 10",
+	}
+	s.patients.careTeam["patient-001"] = []string{"doctor-001-bbbbbbbb"}
+
+	tests := []struct {
+		name    string
+		userID  string
+		role    string
+		path    string
+		status  int
+		address string
+	}{
+		{"staff", "staff-001-aaaaaaaa", policy.RoleStaff, "/api/patients/patient-001", http.StatusOK, "Jl. Merdeka No. 10"},
+		{"admin does not see the address", "admin-001-cccccccc", policy.RoleAdmin, "/api/patients/patient-001", http.StatusOK, ""},
+		{"doctor on care team", "doctor-001-bbbbbbbb", policy.RoleDoctor, "/api/patients/patient-001", http.StatusOK, "Jl. Merdeka No. 10"},
+		{"doctor off care team", "doctor-002-dddddddd", policy.RoleDoctor, "/api/patients/patient-001", http.StatusForbidden, ""},
+		{"not found", "staff-001-aaaaaaaa", policy.RoleStaff, "/api/patients/patient-404", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := s.do(t, http.MethodGet, tt.path, tt.userID, tt.role, nil)
+			if recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d, body %s", recorder.Code, tt.status, recorder.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			response := decode[dto.PatientResponse](t, recorder)
+			if response.Address != tt.address {
+				t.Errorf("address = %q, want %q", response.Address, tt.address)
+			}
+			if response.NIK != "3201********0001" {
+				t.Errorf("nik = %q, want masked", response.NIK)
+			}
+			if response.Age == nil || *response.Age < 35 || response.IsMinor {
+				t.Errorf("age = %v minor = %v", response.Age, response.IsMinor)
+			}
+		})
+	}
+}
+
+func TestGetAllPatientsScopedToCareTeam(t *testing.T) {
+	s := newPatientTestServer(t)
+	for _, id := range []string{"patient-001", "patient-002", "patient-003"} {
+		s.patients.patients[id] = &models.Patient{ID: id, FullName: "Pasien " + id, Gender: "female"}
+	}
+	s.patients.careTeam["patient-002"] = []string{"doctor-001-bbbbbbbb"}
+
+	tests := []struct {
+		name   string
+		userID string
+		role   string
+		want   []string
+	}{
+		{"staff sees everyone", "staff-001-aaaaaaaa", policy.RoleStaff, []string{"patient-001", "patient-002", "patient-003"}},
+		{"doctor sees own care team", "doctor-001-bbbbbbbb", policy.RoleDoctor, []string{"patient-002"}},
+		{"doctor without patients", "doctor-002-dddddddd", policy.RoleDoctor, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.audit.events = nil
+			recorder := s.do(t, http.MethodGet, "/api/patients/?limit=10", tt.userID, tt.role, nil)
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("status = %d, body %s", recorder.Code, recorder.Body.String())
+			}
+			response := decode[dto.PaginatedPatientsResponse](t, recorder)
+			var got []string
+			for _, patient := range response.Data {
+				got = append(got, patient.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("patients = %v, want %v", got, tt.want)
+			}
+			if response.Total == nil || *response.Total != int64(len(tt.want)) {
+				t.Errorf("total = %v, want %d", response.Total, len(tt.want))
+			}
+			if len(s.audit.events) != len(tt.want) {
+				t.Errorf("audit events = %d, want one read per patient", len(s.audit.events))
+			}
+		})
+	}
+
+	recorder := s.do(t, http.MethodGet, "/api/patients/?sort=nik", "staff-001-aaaaaaaa", policy.RoleStaff, nil)
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("sort by unknown field status = %d, want 400", recorder.Code)
+	}
+}
+
+func TestUpdatePatient(t *testing.T) {
+	s := newPatientTestServer(t)
+	birthDate := date(t, "1990-08-15")
+	s.patients.patients["patient-001"] = &models.Patient{
+		ID: "patient-001", FullName: "Budi Santoso", NIK: "3201011508900001", BirthDate: birthDate, Gender: "male",
+	}
+
+	recorder := s.do(t, http.MethodPut, "/api/patients/patient-001", "staff-001-aaaaaaaa", policy.RoleStaff, dto.UpdatePatientInput{FullName: "Budi Santosa"})
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", recorder.Code, recorder.Body.String())
+	}
+	if name := s.patients.patients["patient-001"].FullName; name != "Budi Santosa" {
+		t.Errorf("stored name = %q", name)
+	}
+	if len(s.audit.events) != 1 || len(s.audit.events[0].Changes) != 1 || s.audit.events[0].Changes[0].Field != "fullName" {
+		t.Errorf("audit events = %+v, want one update of fullName", s.audit.events)
+	}
+
+	recorder = s.do(t, http.MethodPut, "/api/patients/patient-001", "staff-001-aaaaaaaa", policy.RoleStaff, dto.UpdatePatientInput{Gender: "female"})
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("gender not matching NIK status = %d, want 400", recorder.Code)
+	}
+}
+
+func TestGetPatientReveal(t *testing.T) {
+	birthDate := date(t, "1990-08-15")
+	tests := []struct {
+		name     string
+		userID   string
+		role     string
+		query    string
+		status   int
+		param    string
+		nik      string
+		address  string
+		redacted []string
+	}{
+		{"without justification", "admin-001-cccccccc", policy.RoleAdmin, "?reveal=nik", http.StatusBadRequest, "reason", "", "", nil},
+		{"justification too short", "admin-001-cccccccc", policy.RoleAdmin, "?reveal=nik&reason=cek", http.StatusBadRequest, "reason", "", "", nil},
+		{"field cannot be revealed", "admin-001-cccccccc", policy.RoleAdmin, "?reveal=diagnosis&reason=verifikasi+BPJS", http.StatusBadRequest, "reveal", "", "", nil},
+		{"doctor off care team", "doctor-002-dddddddd", policy.RoleDoctor, "?reveal=nik&reason=verifikasi+BPJS", http.StatusForbidden, "", "", "", nil},
+		{"revealed fields", "admin-001-cccccccc", policy.RoleAdmin, "?reveal=nik,address&reason=verifikasi+BPJS", http.StatusOK, "", "3201011508900001", "Jl. Merdeka No. 10", []string{"phone"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newPatientTestServer(t)
+			s.patients.patients["patient-001"] = &models.Patient{
+				ID: "patient-001", FullName: "Budi Santoso", NIK: "3201011508900001", BirthDate: birthDate,
+				Gender: "male", Phone: "081234567890", Address: "Jl. Merdeka No. 10",
+			}
+
+			recorder := s.do(t, http.MethodGet, "/api/patients/patient-001"+tt.query, tt.userID, tt.role, nil)
+			if recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d, body %s", recorder.Code, tt.status, recorder.Body.String())
+			}
+			switch tt.status {
+			case http.StatusBadRequest:
+				if response := decode[dto.QueryErrorResponse](t, recorder); response.Param != tt.param {
+					t.Errorf("param = %q, want %q", response.Param, tt.param)
+				}
+				fallthrough
+			case http.StatusForbidden:
+				if len(s.audit.events) != 0 {
+					t.Errorf("rejected reveal wrote audit events %+v", s.audit.events)
+				}
+				return
+			}
+
+			response := decode[dto.PatientResponse](t, recorder)
+			if response.NIK != tt.nik || response.Address != tt.address || strings.Join(response.RedactedFields, ",") != strings.Join(tt.redacted, ",") {
+				t.Errorf("nik %q address %q redacted %v", response.NIK, response.Address, response.RedactedFields)
+			}
+			if len(s.audit.events) != 2 {
+				t.Fatalf("audit events = %+v, want read and reveal", s.audit.events)
+			}
+			reveal := s.audit.events[1]
+			if reveal.Action != models.AuditActionReveal || reveal.ActorID != tt.userID || reveal.PatientID != "patient-001" ||
+				strings.Join(reveal.Fields, ",") != "nik,address" || reveal.Reason != "verifikasi BPJS" {
+				t.Errorf("reveal event = %+v", reveal)
+			}
+		})
+	}
+}
+
+func TestGetPatientEmergencyAccess(t *testing.T) {
+	s := newPatientTestServer(t)
+	for _, id := range []string{"patient-001", "patient-002"} {
+		s.patients.patients[id] = &models.Patient{ID: id, FullName: "Pasien " + id, Gender: "female", Address: "Jl. Merdeka No. 10"}
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-User-ID", "doctor-002-dddddddd")
+		req.Header.Set("X-Role", policy.RoleDoctor)
+		req.Header.Set("X-Emergency-Access-ID", "access-001")
+		req.Header.Set("X-Emergency-Patient-ID", "patient-001")
+		recorder := httptest.NewRecorder()
+		s.router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	recorder := get("/api/patients/patient-001")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", recorder.Code, recorder.Body.String())
+	}
+	if response := decode[dto.PatientResponse](t, recorder); response.Address != "Jl. Merdeka No. 10" {
+		t.Errorf("address = %q, want the full address for emergency access", response.Address)
+	}
+	if len(s.audit.events) != 1 || s.audit.events[0].Reason != "emergency-access:access-001" {
+		t.Errorf("audit events = %+v, want a read tagged with the emergency access", s.audit.events)
+	}
+
+	// Akses darurat hanya berlaku untuk satu pasien
+	s.audit.events = nil
+	if recorder := get("/api/patients/patient-002"); recorder.Code != http.StatusForbidden {
+		t.Errorf("other patient status = %d, want 403", recorder.Code)
+	}
+	if len(s.audit.events) != 0 {
+		t.Errorf("denied read wrote audit events %+v", s.audit.events)
+	}
+}
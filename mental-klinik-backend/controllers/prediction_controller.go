@@ -1,28 +1,78 @@
 package controllers
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"time"
-	"io"
-
 	"errors"
-	"gorm.io/gorm"
+	"net/http"
 
-	"mental-klinik-backend/databases"
 	"mental-klinik-backend/dto"
 	"mental-klinik-backend/models"
-	// "mental-klinik-backend/utils"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
+type PredictionController struct {
+	service     services.PredictionService
+	assessments services.AssessmentService
+	policy      *policy.Policy
+	audit       services.AuditService
+}
+
+func NewPredictionController(
+	service services.PredictionService,
+	assessments services.AssessmentService,
+	policy *policy.Policy,
+	audit services.AuditService,
+) *PredictionController {
+	return &PredictionController{service: service, assessments: assessments, policy: policy, audit: audit}
+}
+
+// predictionResource: pasien prediksi diambil dari assessment-nya
+func predictionResource(prediction *models.Prediction) policy.Resource {
+	resource := policy.Resource{Type: policy.Prediction, ID: prediction.ID}
+	if prediction.Assessment != nil {
+		resource.PatientID = prediction.Assessment.PatientID
+	}
+	return resource
+}
+
+// predictionAuditEvent menyusun event audit untuk prediksi, pasiennya diambil
+// dari assessment
+func predictionAuditEvent(c *gin.Context, action string, prediction *models.Prediction) services.AuditEvent {
+	return auditEvent(c, action, policy.Prediction, prediction.ID, predictionResource(prediction).PatientID)
+}
+
+func toPredictionResponse(prediction *models.Prediction) dto.PredictionResponse {
+	return dto.PredictionResponse{
+		ID:               prediction.ID,
+		AssessmentID:     prediction.AssessmentID,
+		ResultLabel:      prediction.ResultLabel,
+		ProbabilityScore: prediction.ProbabilityScore,
+		CreatedAt:        prediction.CreatedAt,
+		UpdatedAt:        prediction.UpdatedAt,
+	}
+}
+
+// loadPrediction mengambil prediksi untuk dicek policy-nya. Response error
+// langsung dikirim jika gagal.
+func (pc *PredictionController) loadPrediction(c *gin.Context) (*models.Prediction, bool) {
+	prediction, err := pc.service.GetByID(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrPredictionNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Prediksi tidak ditemukan"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal mengambil data prediksi"})
+		return nil, false
+	}
+	return prediction, true
+}
+
 // PredictMentalHealth godoc
 // @Summary Membuat prediksi kesehatan mental dari assessment
-// @Description Mengirim data assessment ke model ML, lalu menyimpan hasil prediksi ke database
+// @Description Mengirim data assessment ke model ML, lalu menyimpan hasil prediksi ke database. Ditolak (403) jika pasien belum memberikan atau sudah menarik persetujuan ai_prediction.
 // @Tags Predictions
 // @Accept json
 // @Produce json
@@ -30,92 +80,45 @@ import (
 // @Param id path string true "Assessment ID"
 // @Success 201 {object} dto.PredictionResponse
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/predictions/{id} [post]
-func PredictMentalHealth(c *gin.Context) {
-	assessmentID := c.Param("id")
-
-	// Cek apakah assessment ada
-	var assessment models.Assessment
-	if err := database.DB.Preload("Patient").First(&assessment, "id = ?", assessmentID).Error; err != nil {
-		fmt.Println("DB error:", err)
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Assessment tidak ditemukan"})
-		return
-	}
-
-	// Decode jawaban ke dalam struct
-	var answers dto.AssessmentAnswers
-	err := json.Unmarshal(assessment.Answers, &answers)
+func (pc *PredictionController) PredictMentalHealth(c *gin.Context) {
+	assessment, err := pc.assessments.GetByID(c.Param("id"))
 	if err != nil {
-		fmt.Println("Unmarshal error:", err)
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal decode data jawaban assessment"})
+		if errors.Is(err, services.ErrAssessmentNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Assessment tidak ditemukan"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal mengambil data assessment"})
 		return
 	}
-
-	// Kirim data ke model Python
-	payload := map[string]interface{}{
-		"schizophrenia_share":   answers.SchizophreniaShare,
-		"anxiety_share":         answers.AnxietyShare,
-		"bipolar_share":         answers.BipolarShare,
-		"eating_disorder_share": answers.EatingDisorderShare,
-		"DALYs":                 answers.DALYs,
-		"suicide_rate":          answers.SuicideRate,
-		"depression_dalys":      answers.DepressionDALYs,
-		"schizophrenia_dalys":   answers.SchizophreniaDALYs,
-		"bipolar_dalys":         answers.BipolarDALYs,
-		"eating_dalys":          answers.EatingDALYs,
-		"anxiety_dalys":         answers.AnxietyDALYs,
-	}
-
-	payloadBytes, err := json.Marshal(payload)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal mempersiapkan data prediksi"})
+	if !authorize(c, pc.policy, policy.Create, policy.Resource{Type: policy.Prediction, PatientID: assessment.PatientID}) {
 		return
 	}
 
-	client := &http.Client{Timeout: 5 * time.Second}
-	resp, err := client.Post("http://localhost:8000/predict", "application/json", bytes.NewBuffer(payloadBytes))
+	prediction, err := pc.service.Predict(assessment.ID)
 	if err != nil {
-		fmt.Println("HTTP error:", err)
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal menghubungi service prediksi"})
-		return
-	}
-	defer resp.Body.Close()
-
-	// Baca body sekali saja
-	bodyBytes, _ := io.ReadAll(resp.Body)
-	fmt.Println("ML response raw:", string(bodyBytes))
-
-	if resp.StatusCode != http.StatusOK {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Service prediksi mengembalikan status error"})
-		return
-	}
-
-	var predictionResult struct {
-		ResultLabel      string  `json:"resultLabel"`
-		ProbabilityScore float64 `json:"probabilityScore"`
-	}
-	if err := json.Unmarshal(bodyBytes, &predictionResult); err != nil {
-		fmt.Println("Decode error:", err)
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal membaca hasil prediksi"})
-		return
-	}
-
-	// Simpan hasil prediksi
-	prediction := models.Prediction{
-		ID:               uuid.New().String(),
-		AssessmentID:     assessmentID,
-		ResultLabel:      predictionResult.ResultLabel,
-		ProbabilityScore: predictionResult.ProbabilityScore,
-		CreatedAt:        time.Now(),
-		UpdatedAt:        time.Now(),
-	}
-
-	if err := database.DB.Create(&prediction).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal menyimpan hasil prediksi"})
+		switch {
+		case errors.Is(err, services.ErrAssessmentNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Assessment tidak ditemukan"})
+		case errors.Is(err, services.ErrConsentRequired):
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "Pasien belum memberikan atau sudah menarik persetujuan prediksi berbantuan AI (ai_prediction)"})
+		case errors.Is(err, services.ErrInvalidAssessmentAnswers):
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal decode data jawaban assessment"})
+		case errors.Is(err, services.ErrPredictionServiceUnavailable):
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal menghubungi service prediksi"})
+		case errors.Is(err, services.ErrPredictionServiceStatus):
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Service prediksi mengembalikan status error"})
+		case errors.Is(err, services.ErrPredictionResultInvalid):
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal membaca hasil prediksi"})
+		default:
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal menyimpan hasil prediksi"})
+		}
 		return
 	}
+	recordWrite(pc.audit, auditEvent(c, models.AuditActionCreate, policy.Prediction, prediction.ID, assessment.PatientID))
 
 	// Response
 	response := dto.PredictionResponse{
@@ -131,65 +134,64 @@ func PredictMentalHealth(c *gin.Context) {
 
 // GetAllPredictions godoc
 // @Summary Get all predictions
-// @Description Get paginated list of predictions, with optional filters and sorting
+// @Description Get paginated list of predictions, with optional filters and sorting. Doctors only see predictions of patients on their care team.
 // @Tags Predictions
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
-// @Param resultLabel query string false "Filter by result label"
-// @Param sortBy query string false "Sort by field (e.g. created_at, probability_score)" default(created_at)
-// @Param sortOrder query string false "Sort order: asc or desc" default(desc)
+// @Param cursor query string false "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response"
+// @Param withTotal query bool false "Also count total rows in cursor mode" default(false)
+// @Param resultLabel query string false "Filter by result label. Also resultLabel[in]=a,b and resultLabel[ilike]=text"
+// @Param assessmentId query string false "Filter by assessment ID"
+// @Param probabilityScore[gte] query number false "Minimum probability score"
+// @Param probabilityScore[lte] query number false "Maximum probability score"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, resultLabel, probabilityScore, createdAt, updatedAt). sortBy is accepted as an alias" default(-createdAt)
+// @Param order query string false "Default sort order for fields without prefix (asc or desc). sortOrder is accepted as an alias"
 // @Success 200 {object} dto.PaginatedPredictionsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/predictions [get]
-func GetAllPredictions(c *gin.Context) {
-	page := ParseInt(c.DefaultQuery("page", "1"), 1)
-	limit := ParseInt(c.DefaultQuery("limit", "10"), 10)
-	offset := (page - 1) * limit
-
-	sortBy := c.DefaultQuery("sortBy", "created_at")
-	sortOrder := c.DefaultQuery("sortOrder", "desc")
-	resultLabel := c.Query("resultLabel") // optional filter
-
-	var total int64
-	var predictions []models.Prediction
-
-	query := database.DB.Model(&models.Prediction{})
-
-	if resultLabel != "" {
-		query = query.Where("result_label = ?", resultLabel)
+func (pc *PredictionController) GetAllPredictions(c *gin.Context) {
+	if !authorize(c, pc.policy, policy.List, policy.Resource{Type: policy.Prediction}) {
+		return
 	}
 
-	query.Count(&total)
+	query, ok := parseListQuery(c, repositories.PredictionListSpec)
+	if !ok {
+		return
+	}
 
-	if err := query.Order(fmt.Sprintf("%s %s", sortBy, sortOrder)).
-		Limit(limit).Offset(offset).
-		Find(&predictions).Error; err != nil {
+	scope := pc.policy.ListScope(currentSubject(c), policy.Prediction)
+	predictions, pageInfo, err := pc.service.GetAll(repositories.PredictionFilter{
+		CareTeamOf:         scope.CareTeamOf,
+		EmergencyPatientID: scope.EmergencyPatientID,
+		Query:              *query,
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal mengambil data prediksi"})
 		return
 	}
 
+	events := make([]services.AuditEvent, 0, len(predictions))
+	for i := range predictions {
+		events = append(events, predictionAuditEvent(c, models.AuditActionRead, &predictions[i]))
+	}
+	if !recordReads(c, pc.audit, events...) {
+		return
+	}
+
 	var response []dto.PredictionResponse
-	for _, p := range predictions {
-		response = append(response, dto.PredictionResponse{
-			ID:               p.ID,
-			AssessmentID:     p.AssessmentID,
-			ResultLabel:      p.ResultLabel,
-			ProbabilityScore: p.ProbabilityScore,
-			CreatedAt:        p.CreatedAt,
-		})
+	for i := range predictions {
+		response = append(response, toPredictionResponse(&predictions[i]))
 	}
 
 	c.JSON(http.StatusOK, dto.PaginatedPredictionsResponse{
 		Data:       response,
-		Total:      int(total),
-		Page:       page,
-		Limit:      limit,
-		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+		Pagination: newPagination(query, pageInfo),
 	})
 }
 
@@ -202,15 +204,19 @@ func GetAllPredictions(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Prediction ID"
 // @Success 200 {object} dto.PredictionResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/predictions/{id} [get]
-func GetPredictionByID(c *gin.Context) {
-	id := c.Param("id")
-
-	var prediction models.Prediction
-	if err := database.DB.First(&prediction, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Prediksi tidak ditemukan"})
+func (pc *PredictionController) GetPredictionByID(c *gin.Context) {
+	prediction, ok := pc.loadPrediction(c)
+	if !ok {
+		return
+	}
+	if !authorize(c, pc.policy, policy.Read, predictionResource(prediction)) {
+		return
+	}
+	if !recordReads(c, pc.audit, predictionAuditEvent(c, models.AuditActionRead, prediction)) {
 		return
 	}
 
@@ -234,26 +240,32 @@ func GetPredictionByID(c *gin.Context) {
 // @Produce json
 // @Param assessment_id path string true "Assessment ID"
 // @Success 200 {object} dto.PredictionResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/predictions/assessment/{assessment_id} [get]
-func GetPredictionByAssessmentID(c *gin.Context) {
+func (pc *PredictionController) GetPredictionByAssessmentID(c *gin.Context) {
 	assessmentID := c.Param("assessment_id")
 	if assessmentID == "" {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Assessment ID wajib diisi"})
 		return
 	}
 
-	var prediction models.Prediction
-	err := database.DB.Where("assessment_id = ?", assessmentID).First(&prediction).Error
+	prediction, err := pc.service.GetByAssessmentID(assessmentID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, services.ErrPredictionNotFound) {
 			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Prediksi untuk assessment ini tidak ditemukan"})
 			return
 		}
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal mengambil data prediksi"})
 		return	
 	}
+	if !authorize(c, pc.policy, policy.Read, predictionResource(prediction)) {
+		return
+	}
+	if !recordReads(c, pc.audit, predictionAuditEvent(c, models.AuditActionRead, prediction)) {
+		return
+	}
 
 	response := dto.PredictionResponse{
 		ID:               prediction.ID,
@@ -278,12 +290,11 @@ func GetPredictionByAssessmentID(c *gin.Context) {
 // @Success 200 {object} dto.PredictionResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/predictions/{id} [put]
-func UpdatePredictionByID(c *gin.Context) {
-    id := c.Param("id")
-
+func (pc *PredictionController) UpdatePredictionByID(c *gin.Context) {
     // Validasi request
     var req dto.UpdatePredictionRequest
     if err := c.ShouldBindJSON(&req); err != nil {
@@ -291,25 +302,26 @@ func UpdatePredictionByID(c *gin.Context) {
         return
     }
 
-    // Cari prediksi
-    var prediction models.Prediction
-    if err := database.DB.First(&prediction, "id = ?", id).Error; err != nil {
-        if errors.Is(err, gorm.ErrRecordNotFound) {
-            c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Prediksi tidak ditemukan"})
-            return
-        }
-        c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal mengambil data prediksi"})
+    current, ok := pc.loadPrediction(c)
+    if !ok {
+        return
+    }
+    if !authorize(c, pc.policy, policy.Update, predictionResource(current)) {
         return
     }
 
-    // Update field
-    prediction.ResultLabel = req.ResultLabel
-    prediction.ProbabilityScore = req.ProbabilityScore
-
-    if err := database.DB.Save(&prediction).Error; err != nil {
+    prediction, updatedFields, err := pc.service.Update(current.ID, req)
+    if err != nil {
+        if errors.Is(err, services.ErrPredictionNotFound) {
+            c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Prediksi tidak ditemukan"})
+            return
+        }
         c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal memperbarui prediksi"})
         return
     }
+    event := predictionAuditEvent(c, models.AuditActionUpdate, current)
+    event.Changes = updatedFields
+    recordWrite(pc.audit, event)
 
     response := dto.PredictionResponse{
         ID:               prediction.ID,
@@ -331,26 +343,105 @@ func UpdatePredictionByID(c *gin.Context) {
 // @Param id path string true "Prediction ID"
 // @Success 200 {object} dto.MessageDeletePredictionResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/predictions/{id} [delete]
-func DeletePredictionByID(c *gin.Context) {
-	id := c.Param("id")
+func (pc *PredictionController) DeletePredictionByID(c *gin.Context) {
+	prediction, ok := pc.loadPrediction(c)
+	if !ok {
+		return
+	}
+	if !authorize(c, pc.policy, policy.Delete, predictionResource(prediction)) {
+		return
+	}
 
-	var prediction models.Prediction
-	if err := database.DB.First(&prediction, "id = ?", id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+	if err := pc.service.Delete(prediction.ID); err != nil {
+		if errors.Is(err, services.ErrPredictionNotFound) {
 			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Prediksi tidak ditemukan"})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal mengambil data prediksi"})
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal menghapus prediksi"})
 		return
 	}
+	recordWrite(pc.audit, predictionAuditEvent(c, models.AuditActionDelete, prediction))
 
-	if err := database.DB.Delete(&prediction).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal menghapus prediksi"})
+	c.JSON(http.StatusOK, dto.MessageDeletePredictionResponse{Message: "Prediksi berhasil dihapus"})
+}
+
+// GetPredictionTrash godoc
+// @Summary Daftar prediksi yang sudah dihapus
+// @Description Mengambil daftar prediksi yang sudah di-soft delete (trash), yang terakhir dihapus lebih dulu. Hanya untuk admin.
+// @Tags Predictions
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param assessmentId query string false "Filter by assessment ID"
+// @Param deletedAt[gte] query string false "Deleted at or after (YYYY-MM-DD or RFC3339)"
+// @Param deletedAt[lte] query string false "Deleted at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, resultLabel, probabilityScore, createdAt, updatedAt, deletedAt)" default(-deletedAt)
+// @Success 200 {object} dto.PaginatedPredictionTrashResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/predictions/trash [get]
+func (pc *PredictionController) GetPredictionTrash(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.PredictionTrashSpec)
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.MessageDeletePredictionResponse{Message: "Prediksi berhasil dihapus"})
+	predictions, pageInfo, err := pc.service.GetTrash(*query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Gagal mengambil data prediksi yang dihapus"})
+		return
+	}
+
+	events := make([]services.AuditEvent, 0, len(predictions))
+	for i := range predictions {
+		events = append(events, predictionAuditEvent(c, models.AuditActionRead, &predictions[i]))
+	}
+	if !recordReads(c, pc.audit, events...) {
+		return
+	}
+
+	responses := make([]dto.PredictionTrashResponse, 0, len(predictions))
+	for i := range predictions {
+		responses = append(responses, dto.PredictionTrashResponse{
+			PredictionResponse: toPredictionResponse(&predictions[i]),
+			DeletedAt:          predictions[i].DeletedAt.Time,
+		})
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedPredictionTrashResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// RestorePrediction godoc
+// @Summary Memulihkan prediksi yang sudah dihapus
+// @Description Memulihkan prediksi dari trash. Gagal (409) jika assessment-nya sudah dihapus atau sudah punya prediksi lain. Hanya untuk admin.
+// @Tags Predictions
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Prediction ID"
+// @Success 200 {object} dto.RestoreResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/predictions/{id}/restore [post]
+func (pc *PredictionController) RestorePrediction(c *gin.Context) {
+	prediction, result, err := pc.service.Restore(c.Param("id"))
+	if err != nil {
+		writeRestoreError(c, err, services.ErrPredictionNotFound, "Prediksi tidak ditemukan di trash", "Gagal memulihkan prediksi")
+		return
+	}
+	recordWrite(pc.audit, restoreAuditEvent(c, policy.Prediction, prediction.ID, predictionResource(prediction).PatientID, result))
+
+	c.JSON(http.StatusOK, dto.RestoreResponse{Message: "Prediksi berhasil dipulihkan", Restored: result})
 }
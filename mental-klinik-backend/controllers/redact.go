@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/policy"
+)
+
+// minRevealReasonLength adalah panjang minimal alasan untuk membuka field
+const minRevealReasonLength = 10
+
+// revealRequest adalah field yang diminta dibuka lewat ?reveal=nik&reason=...
+type revealRequest struct {
+	Fields []string
+	Reason string
+}
+
+// parseReveal membaca parameter reveal dan reason. Setiap field harus boleh
+// dibuka untuk jenis resource ini dan alasan wajib diisi. Jika tidak valid,
+// response 400 langsung dikirim dan ok bernilai false. Tanpa ?reveal hasilnya
+// nil.
+func parseReveal(c *gin.Context, resourceType policy.ResourceType) (*revealRequest, bool) {
+	raw := strings.TrimSpace(c.Query("reveal"))
+	if raw == "" {
+		return nil, true
+	}
+
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !policy.Revealable(resourceType, field) {
+			c.JSON(http.StatusBadRequest, dto.QueryErrorResponse{
+				Error: "field cannot be revealed, allowed: " + strings.Join(policy.RevealableFields(resourceType), ", "),
+				Param: "reveal",
+			})
+			return nil, false
+		}
+		fields = append(fields, field)
+	}
+
+	reason := strings.TrimSpace(c.Query("reason"))
+	if len(reason) < minRevealReasonLength {
+		c.JSON(http.StatusBadRequest, dto.QueryErrorResponse{
+			Error: "a justification of at least 10 characters is required to reveal fields",
+			Param: "reason",
+		})
+		return nil, false
+	}
+	return &revealRequest{Fields: fields, Reason: reason}, true
+}
+
+// fieldShaper menyamarkan atau mengosongkan field sensitif di response sesuai
+// role user yang login (lihat policy.FieldView), kecuali field yang dibuka
+// lewat reveal
+type fieldShaper struct {
+	view   *policy.FieldView
+	reveal map[string]bool
+}
+
+func newFieldShaper(c *gin.Context, p *policy.Policy, reveal *revealRequest) *fieldShaper {
+	s := &fieldShaper{view: p.FieldView(currentSubject(c)), reveal: map[string]bool{}}
+	if reveal != nil {
+		for _, field := range reveal.Fields {
+			s.reveal[field] = true
+		}
+	}
+	return s
+}
+
+// shape mengubah *value sesuai visibility field. Nama field yang disamarkan
+// atau dikosongkan ditambahkan ke redacted.
+func (s *fieldShaper) shape(resource policy.Resource, field string, value *string, redacted *[]string) error {
+	if *value == "" || s.reveal[field] {
+		return nil
+	}
+	visibility, err := s.view.Visibility(resource, field)
+	if err != nil {
+		return err
+	}
+	switch visibility {
+	case policy.Masked:
+		*value = policy.Mask(*value)
+	case policy.Hidden:
+		*value = ""
+	default:
+		return nil
+	}
+	*redacted = append(*redacted, field)
+	return nil
+}
+
+// writeShapeError dipakai saat visibility field tidak bisa ditentukan
+// (misalnya cek tim perawatan gagal)
+func writeShapeError(c *gin.Context) {
+	c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to check access"})
+}
@@ -0,0 +1,211 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
+)
+
+type RetentionController struct {
+	service services.RetentionService
+}
+
+func NewRetentionController(service services.RetentionService) *RetentionController {
+	return &RetentionController{service: service}
+}
+
+// GetRetentionPolicies godoc
+// @Summary Get retention policies
+// @Description Retention scheduler settings and the configured policies (entity, action, basis, retention period). Policies are read from the configuration file. Accessible by admin only.
+// @Tags Retention
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.RetentionSettingsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/retention/policies [get]
+func (rc *RetentionController) GetRetentionPolicies(c *gin.Context) {
+	settings := rc.service.Settings()
+	policies := make([]dto.RetentionPolicyResponse, 0, len(settings.Policies))
+	for _, p := range settings.Policies {
+		policies = append(policies, dto.RetentionPolicyResponse{
+			Entity:    p.Entity,
+			Action:    p.Action,
+			Basis:     p.Basis,
+			RetainFor: p.RetainFor.String(),
+		})
+	}
+
+	c.JSON(http.StatusOK, dto.RetentionSettingsResponse{
+		Enabled:   settings.Enabled,
+		Interval:  settings.Interval.String(),
+		DryRun:    settings.DryRun,
+		BatchSize: settings.BatchSize,
+		Policies:  policies,
+	})
+}
+
+// StartRetentionRun godoc
+// @Summary Start retention run
+// @Description Run the retention policies now, in the background. A dry-run report (eligible rows per policy, rows held back by legal holds, sample IDs) is always written first and returned here. With dryRun false, the policies are then executed with the same cutoffs as a separate run whose reportRunId points to the report; eligible rows are hard-deleted or anonymized in batches. Only one run can be active at a time. Accessible by admin only.
+// @Tags Retention
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.StartRetentionRunRequest true "Run options"
+// @Success 202 {object} dto.RetentionRunMessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/retention/runs [post]
+func (rc *RetentionController) StartRetentionRun(c *gin.Context) {
+	var input dto.StartRetentionRunRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	userID := c.GetString("userId")
+	report, err := rc.service.Run(models.RetentionTriggerManual, &userID, *input.DryRun)
+	if err != nil {
+		if errors.Is(err, services.ErrRetentionRunning) {
+			c.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to start retention run"})
+		return
+	}
+
+	message := "Retention dry-run started"
+	if !*input.DryRun {
+		message = "Retention report started, policies will be executed after the report completes"
+	}
+	c.JSON(http.StatusAccepted, dto.RetentionRunMessageResponse{
+		Message: message,
+		Run:     toRetentionRunResponse(report),
+	})
+}
+
+// GetRetentionRuns godoc
+// @Summary Get retention run history
+// @Description Paginated history of retention runs (dry-run reports and executed runs), newest first. Accessible by admin only.
+// @Tags Retention
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param dryRun query bool false "true: only dry-run reports, false: only executed runs"
+// @Param trigger query string false "Filter by trigger (scheduled, manual)"
+// @Param status query string false "Filter by status (running, completed, failed)"
+// @Param reportRunId query string false "Executed run of a dry-run report"
+// @Param startedAt[gte] query string false "Started at or after (YYYY-MM-DD or RFC3339)"
+// @Param startedAt[lte] query string false "Started at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, startedAt)" default(-startedAt)
+// @Success 200 {object} dto.PaginatedRetentionRunsResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/retention/runs [get]
+func (rc *RetentionController) GetRetentionRuns(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.RetentionRunListSpec)
+	if !ok {
+		return
+	}
+
+	var dryRun *bool
+	if raw := c.Query("dryRun"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.QueryErrorResponse{Error: "dryRun must be true or false", Param: "dryRun"})
+			return
+		}
+		dryRun = &value
+	}
+
+	runs, pageInfo, err := rc.service.GetRuns(repositories.RetentionRunFilter{DryRun: dryRun, Query: *query})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve retention runs"})
+		return
+	}
+
+	responses := make([]dto.RetentionRunResponse, 0, len(runs))
+	for i := range runs {
+		responses = append(responses, toRetentionRunResponse(&runs[i]))
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedRetentionRunsResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// GetRetentionRunByID godoc
+// @Summary Get retention run by ID
+// @Description Retrieve one retention run with its per-policy results. Accessible by admin only.
+// @Tags Retention
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Retention run ID"
+// @Success 200 {object} dto.RetentionRunResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/retention/runs/{id} [get]
+func (rc *RetentionController) GetRetentionRunByID(c *gin.Context) {
+	run, err := rc.service.GetRun(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrRetentionRunNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Retention run not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve retention run"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toRetentionRunResponse(run))
+}
+
+func toRetentionRunResponse(run *models.RetentionRun) dto.RetentionRunResponse {
+	var results []models.RetentionResult
+	if len(run.Results) > 0 {
+		_ = json.Unmarshal(run.Results, &results)
+	}
+	responses := make([]dto.RetentionResultResponse, 0, len(results))
+	for _, r := range results {
+		responses = append(responses, dto.RetentionResultResponse{
+			Entity:    r.Entity,
+			Action:    r.Action,
+			Basis:     r.Basis,
+			RetainFor: r.RetainFor,
+			Cutoff:    r.Cutoff,
+			Eligible:  r.Eligible,
+			OnHold:    r.OnHold,
+			Processed: r.Processed,
+			SampleIDs: r.SampleIDs,
+		})
+	}
+	return dto.RetentionRunResponse{
+		ID:            run.ID,
+		Trigger:       run.Trigger,
+		TriggeredByID: run.TriggeredByID,
+		DryRun:        run.DryRun,
+		ReportRunID:   run.ReportRunID,
+		Status:        run.Status,
+		Error:         run.Error,
+		StartedAt:     run.StartedAt,
+		FinishedAt:    run.FinishedAt,
+		Results:       responses,
+	}
+}
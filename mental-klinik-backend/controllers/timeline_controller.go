@@ -0,0 +1,222 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
+)
+
+type TimelineController struct {
+	service services.TimelineService
+	policy  *policy.Policy
+	audit   services.AuditService
+}
+
+func NewTimelineController(service services.TimelineService, p *policy.Policy, audit services.AuditService) *TimelineController {
+	return &TimelineController{service: service, policy: p, audit: audit}
+}
+
+// timelineResourceTypes adalah resource yang dicek policy-nya untuk tiap
+// jenis event timeline
+var timelineResourceTypes = map[string]policy.ResourceType{
+	models.TimelineAppointmentScheduled:     policy.Appointment,
+	models.TimelineAppointmentStatusChanged: policy.Appointment,
+	models.TimelineAssessmentTaken:          policy.Assessment,
+	models.TimelinePredictionGenerated:      policy.Prediction,
+	models.TimelineRecordWritten:            policy.MedicalRecord,
+}
+
+// GetPatientTimeline godoc
+// @Summary Get patient clinical timeline
+// @Description One chronologically ordered, paginated stream of a patient's clinical events: appointment scheduled, appointment status changed, assessment taken, prediction generated and medical record written. Newest first by default. Each event carries a short summary and the resource to fetch for details. Event types the user may not list for this patient are left out; diagnosis and treatment are shaped the same way as the medical record endpoints. Deleted records are not shown.
+// @Tags Patients
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param cursor query string false "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response"
+// @Param withTotal query bool false "Also count total rows in cursor mode" default(false)
+// @Param type query string false "Filter by event type (appointment_scheduled, appointment_status_changed, assessment_taken, prediction_generated, medical_record_written); use type[in] for several"
+// @Param occurredAt[gte] query string false "Occurred at or after (YYYY-MM-DD or RFC3339)"
+// @Param occurredAt[lte] query string false "Occurred at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (occurredAt, id)" default(-occurredAt,-id)
+// @Success 200 {object} dto.PaginatedTimelineResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/patients/{id}/timeline [get]
+func (tc *TimelineController) GetPatientTimeline(c *gin.Context) {
+	patientID := c.Param("id")
+	if !authorize(c, tc.policy, policy.Read, patientResource(patientID)) {
+		return
+	}
+
+	query, ok := parseListQuery(c, repositories.PatientTimelineSpec)
+	if !ok {
+		return
+	}
+
+	types, ok := tc.visibleTypes(c, patientID)
+	if !ok {
+		return
+	}
+
+	events, pageInfo, err := tc.service.GetByPatientID(repositories.PatientTimelineFilter{
+		PatientID: patientID,
+		Types:     types,
+		Query:     *query,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrPatientNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Patient not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve patient timeline"})
+		return
+	}
+
+	// Satu entry audit per resource, appointment dengan beberapa event hanya
+	// dicatat sekali
+	var auditEvents []services.AuditEvent
+	seen := map[string]bool{}
+	for i := range events {
+		resourceType, resourceID := timelineResource(&events[i])
+		key := string(resourceType) + ":" + resourceID
+		if !seen[key] {
+			seen[key] = true
+			auditEvents = append(auditEvents, auditEvent(c, models.AuditActionRead, resourceType, resourceID, patientID))
+		}
+	}
+	if !recordReads(c, tc.audit, auditEvents...) {
+		return
+	}
+
+	shaper := newFieldShaper(c, tc.policy, nil)
+	responses := make([]dto.TimelineEventResponse, 0, len(events))
+	for i := range events {
+		response, err := toTimelineEventResponse(shaper, &events[i])
+		if err != nil {
+			writeShapeError(c)
+			return
+		}
+		responses = append(responses, response)
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedTimelineResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// visibleTypes mengembalikan jenis event yang boleh dilihat user untuk pasien
+// ini. Jika keputusan policy gagal, response 500 langsung dikirim.
+func (tc *TimelineController) visibleTypes(c *gin.Context, patientID string) ([]string, bool) {
+	subject := currentSubject(c)
+	var types []string
+	for _, eventType := range models.TimelineEventTypes {
+		resource := policy.Resource{Type: timelineResourceTypes[eventType], PatientID: patientID}
+		err := tc.policy.Authorize(subject, policy.List, resource)
+		if errors.Is(err, policy.ErrDenied) {
+			continue
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to check access"})
+			return nil, false
+		}
+		types = append(types, eventType)
+	}
+	return types, true
+}
+
+// timelineResource adalah resource yang ditunjuk event (perubahan status
+// menunjuk appointment-nya)
+func timelineResource(event *models.TimelineEvent) (policy.ResourceType, string) {
+	switch {
+	case event.AppointmentID != nil:
+		return policy.Appointment, *event.AppointmentID
+	case event.AssessmentID != nil:
+		return policy.Assessment, *event.AssessmentID
+	case event.PredictionID != nil:
+		return policy.Prediction, *event.PredictionID
+	case event.MedicalRecordID != nil:
+		return policy.MedicalRecord, *event.MedicalRecordID
+	}
+	return "", ""
+}
+
+func toTimelineEventResponse(shaper *fieldShaper, event *models.TimelineEvent) (dto.TimelineEventResponse, error) {
+	resourceType, resourceID := timelineResource(event)
+	response := dto.TimelineEventResponse{
+		ID:           event.ID,
+		Type:         event.Type,
+		OccurredAt:   event.OccurredAt,
+		ResourceType: string(resourceType),
+		ResourceID:   resourceID,
+	}
+
+	if appointment := event.Appointment; appointment != nil {
+		response.Appointment = &dto.TimelineAppointmentResponse{
+			ScheduleAt: appointment.ScheduleAt,
+			Status:     appointment.Status,
+			Notes:      appointment.Notes,
+			User: dto.UserMiniResponse{
+				ID:       appointment.User.ID,
+				FullName: appointment.User.FullName,
+				Role:     appointment.User.Role,
+			},
+		}
+	}
+	if change := event.StatusChange; change != nil {
+		response.StatusChange = &dto.TimelineStatusChangeResponse{
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+		}
+		if change.ChangedBy != nil {
+			response.StatusChange.ChangedBy = &dto.MedicalRecordMiniUser{
+				ID:       change.ChangedBy.ID,
+				FullName: change.ChangedBy.FullName,
+				Role:     change.ChangedBy.Role,
+			}
+		}
+	}
+	if assessment := event.Assessment; assessment != nil {
+		response.Assessment = &dto.TimelineAssessmentResponse{Date: assessment.Date}
+	}
+	if prediction := event.Prediction; prediction != nil {
+		response.Prediction = &dto.TimelinePredictionResponse{
+			AssessmentID:     prediction.AssessmentID,
+			ResultLabel:      prediction.ResultLabel,
+			ProbabilityScore: prediction.ProbabilityScore,
+		}
+	}
+	if record := event.MedicalRecord; record != nil {
+		summary := &dto.TimelineMedicalRecordResponse{
+			Diagnosis: record.Diagnosis,
+			Treatment: record.Treatment,
+			User: dto.MedicalRecordMiniUser{
+				ID:       record.User.ID,
+				FullName: record.User.FullName,
+				Role:     record.User.Role,
+			},
+		}
+		resource := medicalRecordResource(record)
+		if err := shaper.shape(resource, policy.FieldDiagnosis, &summary.Diagnosis, &summary.RedactedFields); err != nil {
+			return response, err
+		}
+		if err := shaper.shape(resource, policy.FieldTreatment, &summary.Treatment, &summary.RedactedFields); err != nil {
+			return response, err
+		}
+		response.MedicalRecord = summary
+	}
+	return response, nil
+}
@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
+)
+
+// parseCascade membaca ?cascade=true|false untuk restore (default false).
+// Response 400 langsung dikirim jika nilainya tidak valid.
+func parseCascade(c *gin.Context) (bool, bool) {
+	raw := c.Query("cascade")
+	if raw == "" {
+		return false, true
+	}
+	cascade, err := strconv.ParseBool(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.QueryErrorResponse{Error: "cascade must be true or false", Param: "cascade"})
+		return false, false
+	}
+	return cascade, true
+}
+
+// writeRestoreError mengirim response untuk error restore. notFound adalah
+// error service jika data tidak ada di trash.
+func writeRestoreError(c *gin.Context, err error, notFound error, notFoundMessage string, failMessage string) {
+	switch {
+	case errors.Is(err, notFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: notFoundMessage})
+	case errors.Is(err, services.ErrRestoreConflict):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: failMessage})
+	}
+}
+
+// restoreAuditEvent menyusun event audit restore; jumlah baris yang
+// dipulihkan per tabel dicatat sebagai changes
+func restoreAuditEvent(c *gin.Context, resourceType policy.ResourceType, resourceID string, patientID string, result repositories.RestoreResult) services.AuditEvent {
+	event := auditEvent(c, models.AuditActionRestore, resourceType, resourceID, patientID)
+	event.Changes = countChanges("", result)
+	return event
+}
@@ -1,70 +1,79 @@
 package controllers
 
 import (
-	"fmt"
-	"strconv"
+	"errors"
 
-	"mental-klinik-backend/databases"
-	"mental-klinik-backend/middlewares"
-	"mental-klinik-backend/models"
-	"mental-klinik-backend/utils"
 	"mental-klinik-backend/dto"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/services"
 
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
+type UserController struct {
+	service  services.UserService
+	sessions services.SessionService
+	policy   *policy.Policy
+}
+
+func NewUserController(service services.UserService, sessions services.SessionService, policy *policy.Policy) *UserController {
+	return &UserController{service: service, sessions: sessions, policy: policy}
+}
+
+// userResource: pemilik resource User adalah user itu sendiri
+func userResource(id string) policy.Resource {
+	return policy.Resource{Type: policy.User, ID: id, OwnerID: id}
+}
+
+func toUserResponse(user *models.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:       user.ID,
+		FullName: user.FullName,
+		Email:    user.Email,
+		Role:     user.Role,
+	}
+}
+
 // Register godoc
 // @Summary Register new user
-// @Description Create a new user with full name, email, password, and role
+// @Description Create a new user from an admin-issued invitation. The role comes from the invitation and the email must match it. Without inviteToken registration is rejected unless self-registration is enabled in config, in which case the user is always staff.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param request body dto.RegisterUserRequest true "Register input"
 // @Success 201 {object} dto.RegisterUserResponse
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/users/register [post]
-func Register(c *gin.Context) {
+func (uc *UserController) Register(c *gin.Context) {
 	var input dto.RegisterUserRequest
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	// Cek apakah email sudah digunakan
-	var existing models.User
-	if err := database.DB.Where("email = ?", input.Email).First(&existing).Error; err != gorm.ErrRecordNotFound {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Email Already Used and Registered"})
-		return
-	}
-
-	// Hash password
-	hashedPassword, err := utils.HashPassword(input.Password)
+	user, err := uc.service.Register(input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to hash password"})
+		switch {
+		case errors.Is(err, services.ErrEmailAlreadyUsed):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Email Already Used and Registered"})
+		case errors.Is(err, services.ErrRegistrationClosed):
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "Registration Requires an Invitation"})
+		case errors.Is(err, services.ErrInvalidInvitation):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid, Used or Expired Invitation"})
+		case errors.Is(err, services.ErrInvitationEmailMismatch):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Email Does Not Match the Invitation"})
+		default:
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to register user"})
+		}
 		return
 	}
 
-	// Hitung jumlah user dengan role yang sama
-	var count int64
-	database.DB.Model(&models.User{}).Where("role = ?", input.Role).Count(&count)
-
-	// Generate custom ID
-	newID := utils.GenerateCustomUserID(input.Role, int(count+1))
-
-	// Simpan user
-	user := models.User{
-		ID:       newID,
-		FullName: input.FullName,
-		Email:    input.Email,
-		Password: hashedPassword,
-		Role:     input.Role,
-	}
-	database.DB.Create(&user)
-
 	// Response tanpa password
 	response := dto.RegisterUserResponse{
 		Message: "User Registered",
@@ -80,7 +89,7 @@ func Register(c *gin.Context) {
 
 // Login godoc
 // @Summary Login user
-// @Description Authenticate user and return JWT token
+// @Description Authenticate user and return a short-lived JWT access token plus a rotating refresh token
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -89,33 +98,138 @@ func Register(c *gin.Context) {
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Router /api/users/login [post]
-func Login(c *gin.Context) {
+func (uc *UserController) Login(c *gin.Context) {
 	var input dto.LoginUserRequest
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})	
 		return
 	}
 
-	var user models.User
-	if err := database.DB.Where("email = ?", input.Email).First(&user).Error; err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid Credentials"})
+	tokens, user, err := uc.service.Login(input, services.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidCredentials):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid Credentials"})
+		case errors.Is(err, services.ErrInvalidPassword):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid Password"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to Login"})
+		}
 		return
 	}
 
-	if !utils.CheckPasswordHash(input.Password, user.Password) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid Password"})
+	c.JSON(http.StatusOK, dto.LoginResponse{
+		Token:                 tokens.AccessToken,
+		ExpiresAt:             tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+		Email:                 user.Email,
+		Role:                  user.Role,
+	})
+}
+
+// Refresh godoc
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access token and refresh token. Each refresh token can be used once; reusing an already rotated token revokes the whole session.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/users/refresh [post]
+func (uc *UserController) Refresh(c *gin.Context) {
+	var input dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	token, _ := middlewares.GenerateToken(user.ID, user.Role)
+	tokens, user, err := uc.sessions.Refresh(input.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidRefreshToken):
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "Invalid or Expired Refresh Token"})
+		case errors.Is(err, services.ErrSessionRevoked):
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "Session Has Been Revoked"})
+		case errors.Is(err, services.ErrRefreshTokenReused):
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "Refresh Token Reuse Detected, Session Revoked"})
+		default:
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to Refresh Token"})
+		}
+		return
+	}
 
-	c.JSON(http.StatusOK, dto.LoginResponse{
-		Token: token,
-		Email: user.Email,
-		Role:  user.Role,
+	c.JSON(http.StatusOK, dto.TokenResponse{
+		Token:                 tokens.AccessToken,
+		ExpiresAt:             tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+		Role:                  user.Role,
 	})
 }
 
+// Logout godoc
+// @Summary Logout
+// @Description Revoke the current session. The access token and all refresh tokens of this session stop working immediately.
+// @Tags Users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.MessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/users/logout [post]
+func (uc *UserController) Logout(c *gin.Context) {
+	if err := uc.sessions.Logout(c.GetString("sessionId")); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to Logout"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "Logged Out"})
+}
+
+// RevokeUserSessions godoc
+// @Summary Revoke all sessions of a user
+// @Description Revoke every active session of the user, forcing them to log in again. Admin can revoke any user, other users only their own sessions.
+// @Tags Users
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.RevokeSessionsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/users/{id}/revoke-sessions [post]
+func (uc *UserController) RevokeUserSessions(c *gin.Context) {
+	if !authorize(c, uc.policy, policy.Update, userResource(c.Param("id"))) {
+		return
+	}
+
+	user, err := uc.service.GetByID(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "User Not Found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to Revoke Sessions"})
+		return
+	}
+
+	revoked, err := uc.sessions.RevokeAllForUser(user.ID, services.RevokeReasonAdmin)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to Revoke Sessions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RevokeSessionsResponse{Message: "Sessions Revoked", Revoked: revoked})
+}
+
 // GetAllUsers godoc
 // @Summary Get all users
 // @Description Get paginated list of users with optional search, role filter, and sorting. Only accessible by admin.
@@ -126,75 +240,52 @@ func Login(c *gin.Context) {
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Param search query string false "Search by name or email"
-// @Param role query string false "Filter by role (admin, doctor, staff)"
-// @Param sort query string false "Sort by field (e.g. full_name, email, created_at)" default(created_at)
-// @Param order query string false "Sort order (asc or desc)" default(desc)
+// @Param role query string false "Filter by role (admin, doctor, staff). Also role[in]=doctor,staff"
+// @Param email[ilike] query string false "Filter email containing text"
+// @Param createdAt[gte] query string false "Created at or after (YYYY-MM-DD or RFC3339)"
+// @Param createdAt[lte] query string false "Created at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, fullName, email, role, createdAt, updatedAt)" default(-createdAt)
+// @Param order query string false "Default sort order for fields without prefix (asc or desc)"
 // @Success 200 {object} dto.PaginatedUsersResponse
+// @Failure 400 {object} dto.QueryErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
 // @Router /api/users/ [get]
-func GetAllUsers(c *gin.Context) {
-	db := database.DB
-	var users []models.User
+func (uc *UserController) GetAllUsers(c *gin.Context) {
+	if !authorize(c, uc.policy, policy.List, policy.Resource{Type: policy.User}) {
+		return
+	}
 
 	// Query Params
-	page := c.DefaultQuery("page", "1")
-	limit := c.DefaultQuery("limit", "10")
-	search := c.Query("search")
-	role := c.Query("role")
-	sort := c.DefaultQuery("sort", "created_at")
-	order := c.DefaultQuery("order", "desc")
-
-	// Convert String ke Int
-	pageInt, _ := strconv.Atoi(page)
-	limitInt, _ := strconv.Atoi(limit)
-	offset := (pageInt - 1) * limitInt
-
-	query := db.Model(&models.User{})
-
-	// Filtering
-	if search != "" {
-		query = query.Where("full_name ILIKE ? OR email ILIKE ?", "%"+search+"%", "%"+search+"%")
-	}
-	if role != "" {
-		query = query.Where("role = ?", role)
+	query, ok := parseListQuery(c, repositories.UserListSpec)
+	if !ok {
+		return
 	}
 
-	// Sorting
-	if sort != "" && (order == "asc" || order == "desc") {
-		query = query.Order(fmt.Sprintf("%s %s", sort, order))
+	users, pageInfo, err := uc.service.GetAll(repositories.UserFilter{
+		Search: c.Query("search"),
+		Query:  *query,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve users"})
+		return
 	}
 
-	// Total Count
-	var total int64
-	query.Count(&total)
-
-	// Pagination
-	query = query.Offset(offset).Limit(limitInt).Find(&users)
-
 	// Convert ke dto.UserResponse
 	var userResponses []dto.UserResponse
-	for _, u := range users {
-		userResponses = append(userResponses, dto.UserResponse{
-			ID:       u.ID,
-			FullName: u.FullName,
-			Email:    u.Email,
-			Role:     u.Role,
-		})
+	for i := range users {
+		userResponses = append(userResponses, toUserResponse(&users[i]))
 	}
 
 	c.JSON(http.StatusOK, dto.PaginatedUsersResponse{
 		Data:       userResponses,
-		Total:      int(total),
-		Page:       pageInt,
-		Limit:      limitInt,
-		TotalPages: int((total + int64(limitInt) - 1) / int64(limitInt)),
+		Pagination: newPagination(query, pageInfo),
 	})
 }
 
 // GetUserByID godoc
 // @Summary Get user by ID
-// @Description Retrieve a single user by their ID. Only accessible by admin.
+// @Description Retrieve a single user by their ID. Admin can read any user, other users only themselves.
 // @Tags Users
 // @Security BearerAuth
 // @Accept json
@@ -205,11 +296,18 @@ func GetAllUsers(c *gin.Context) {
 // @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Router /api/users/{id} [get]
-func GetUserByID(c *gin.Context) {
-    id := c.Param("id")
-    var user models.User
-    if err := database.DB.First(&user, "id = ?", id).Error; err != nil {
-        c.JSON(http.StatusNotFound, gin.H{"error": "User Not Found"})
+func (uc *UserController) GetUserByID(c *gin.Context) {
+    if !authorize(c, uc.policy, policy.Read, userResource(c.Param("id"))) {
+        return
+    }
+
+    user, err := uc.service.GetByID(c.Param("id"))
+    if err != nil {
+        if errors.Is(err, services.ErrUserNotFound) {
+            c.JSON(http.StatusNotFound, gin.H{"error": "User Not Found"})
+            return
+        }
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to Retrieve User"})
         return
     }
 
@@ -224,7 +322,7 @@ func GetUserByID(c *gin.Context) {
 
 // UpdateUser godoc
 // @Summary Update user by ID
-// @Description Update user information by ID. Can update fullName, email, password, and role. Requires authentication.
+// @Description Update user information by ID. Can update fullName, email, password, and role. Non-admin users can only update their own account and cannot change role.
 // @Tags Users
 // @Security BearerAuth
 // @Accept json
@@ -238,16 +336,7 @@ func GetUserByID(c *gin.Context) {
 // @Failure 404 {object} dto.ErrorResponse "User Not Found"
 // @Failure 500 {object} dto.ErrorResponse "Failed to Update User"
 // @Router /api/users/{id} [put]
-func UpdateUser(c *gin.Context) {
-    id := c.Param("id")
-    var user models.User
-
-    // Cari user dulu
-    if err := database.DB.Where("id = ?", id).First(&user).Error; err != nil {
-        c.JSON(http.StatusNotFound, gin.H{"error": "User Not Found"})
-        return
-    }
-
+func (uc *UserController) UpdateUser(c *gin.Context) {
     // Bind request body ke struct input
     var input dto.UpdateUserInput
     if err := c.ShouldBindJSON(&input); err != nil {
@@ -255,27 +344,33 @@ func UpdateUser(c *gin.Context) {
         return
     }
 
-    // Optional: Hash New Password if Provided
-    if input.Password != "" {
-        hashed, err := utils.HashPassword(input.Password)
-        if err != nil {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to Hash Password"})
-            return
-        }
-        user.Password = hashed
+    resource := userResource(c.Param("id"))
+    if !authorize(c, uc.policy, policy.Update, resource) {
+        return
     }
 
-    if input.FullName != "" {
-        user.FullName = input.FullName
-    }
-    if input.Email != "" {
-        user.Email = input.Email
-    }
+    // Perubahan role dicek terpisah karena hanya admin yang boleh
     if input.Role != "" {
-        user.Role = input.Role
+        current, err := uc.service.GetByID(resource.ID)
+        if err != nil {
+            if errors.Is(err, services.ErrUserNotFound) {
+                c.JSON(http.StatusNotFound, gin.H{"error": "User Not Found"})
+                return
+            }
+            c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to Update User"})
+            return
+        }
+        if input.Role != current.Role && !authorize(c, uc.policy, policy.AssignRole, resource) {
+            return
+        }
     }
 
-    if err := database.DB.Save(&user).Error; err != nil {
+    user, err := uc.service.Update(resource.ID, input)
+    if err != nil {
+        if errors.Is(err, services.ErrUserNotFound) {
+            c.JSON(http.StatusNotFound, gin.H{"error": "User Not Found"})
+            return
+        }
         c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to Update User"})
         return
     }
@@ -292,22 +387,96 @@ func UpdateUser(c *gin.Context) {
 // DeleteUser godoc
 // @Summary Delete user by ID
 // @Description Delete a user by their ID. Only accessible by admin.
+// @Description Pending appointments of the user must be moved to another active user with the same role via `reassignTo`, and open care team assignments must be transferred first; otherwise the request fails with 409 and the list of blockers.
+// @Description Past appointments and medical records keep referring to the deleted user.
 // @Tags Users
 // @Security BearerAuth
 // @Produce json
 // @Param id path string true "User ID"
+// @Param reassignTo query string false "ID of the user who takes over the pending appointments"
 // @Success 200 {object} dto.MessageDeleteResponse
+// @Failure 400 {object} dto.QueryErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.DeleteBlockedResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/users/{id} [delete]
-func DeleteUser(c *gin.Context) {
-    id := c.Param("id")
+func (uc *UserController) DeleteUser(c *gin.Context) {
+    if !authorize(c, uc.policy, policy.Delete, userResource(c.Param("id"))) {
+        return
+    }
 
-    if err := database.DB.Where("id = ?", id).Delete(&models.User{}).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to Delete User"})
+    result, err := uc.service.Delete(c.Param("id"), c.Query("reassignTo"))
+    if err != nil {
+        writeDeleteError(c, err, services.ErrUserNotFound, "User not found", "Failed to Delete User")
         return
     }
 
-    c.JSON(http.StatusOK, dto.MessageDeleteResponse{Message: "User Deleted Successfully"})
-}
\ No newline at end of file
+    c.JSON(http.StatusOK, dto.MessageDeleteResponse{Message: "User Deleted Successfully", Reassigned: result.Reassigned})
+}
+
+// GetUserTrash godoc
+// @Summary List deleted users
+// @Description Get paginated list of soft-deleted users (trash), most recently deleted first. Only accessible by admin.
+// @Tags Users
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param role query string false "Filter by role (admin, doctor, staff)"
+// @Param deletedAt[gte] query string false "Deleted at or after (YYYY-MM-DD or RFC3339)"
+// @Param deletedAt[lte] query string false "Deleted at or before (YYYY-MM-DD or RFC3339)"
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, fullName, email, role, createdAt, updatedAt, deletedAt)" default(-deletedAt)
+// @Success 200 {object} dto.PaginatedUserTrashResponse
+// @Failure 400 {object} dto.QueryErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/users/trash [get]
+func (uc *UserController) GetUserTrash(c *gin.Context) {
+	query, ok := parseListQuery(c, repositories.UserTrashSpec)
+	if !ok {
+		return
+	}
+
+	users, pageInfo, err := uc.service.GetTrash(*query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to retrieve deleted users"})
+		return
+	}
+
+	responses := make([]dto.UserTrashResponse, 0, len(users))
+	for i := range users {
+		responses = append(responses, dto.UserTrashResponse{UserResponse: toUserResponse(&users[i]), DeletedAt: users[i].DeletedAt.Time})
+	}
+
+	c.JSON(http.StatusOK, dto.PaginatedUserTrashResponse{
+		Data:       responses,
+		Pagination: newPagination(query, pageInfo),
+	})
+}
+
+// RestoreUser godoc
+// @Summary Restore a deleted user
+// @Description Restore a user from the trash. Fails with 409 if the email has since been used by another user. Sessions revoked on deletion stay revoked, so the user has to log in again. Only accessible by admin.
+// @Tags Users
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.RestoreResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/users/{id}/restore [post]
+func (uc *UserController) RestoreUser(c *gin.Context) {
+	_, result, err := uc.service.Restore(c.Param("id"))
+	if err != nil {
+		writeRestoreError(c, err, services.ErrUserNotFound, "User not found in trash", "Failed to restore user")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RestoreResponse{Message: "User restored successfully", Restored: result})
+}
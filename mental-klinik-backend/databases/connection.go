@@ -2,38 +2,39 @@ package database
 
 import (
 	"fmt"
-	"os"
 	"log"
 	"gorm.io/gorm"
 	"gorm.io/driver/postgres"
-	"mental-klinik-backend/models"
+	"mental-klinik-backend/config"
+	"mental-klinik-backend/migrations"
 )
 
-var DB *gorm.DB
-
-func ConnectDB() {
-	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_NAME"),
-		os.Getenv("DB_PORT"),
-	)
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// ConnectDB membuka koneksi ke Postgres dan mengembalikan *gorm.DB supaya bisa
+// di-inject ke repository (tidak lagi disimpan di variabel global).
+func ConnectDB(cfg config.DatabaseConfig) *gorm.DB {
+	db, err := gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{})
 	if err != nil {
 		log.Fatal("Failed to connect to DB:", err)
 	}
 
-	DB = db
+	return db
+}
+
+// EnsureSchemaUpToDate menolak start kalau masih ada migration yang belum
+// dijalankan. Schema tidak lagi dibuat otomatis, jalankan `migrate up` dulu.
+func EnsureSchemaUpToDate(db *gorm.DB) error {
+	migrator, err := migrations.New(db)
+	if err != nil {
+		return err
+	}
 
-	// Migrate all models
-	db.AutoMigrate(
-		&models.User{},
-		&models.Patient{},
-		&models.Assessment{},
-		&models.Prediction{},
-		&models.Appointment{},
-		&models.MedicalRecord{},
-	)
+	pending, err := migrator.Pending()
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("database schema is behind: %d pending migration(s), first is %06d_%s; run `go run main.go migrate up`",
+			len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
 }
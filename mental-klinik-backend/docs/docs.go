@@ -22,7 +22,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get paginated list of appointments with optional search (by patient name), status filter, and sorting.",
+                "description": "Get paginated list of appointments with optional search (by patient name), status filter, and sorting. Doctors only see appointments of patients on their care team.",
                 "consumes": [
                     "application/json"
                 ],
@@ -48,6 +48,19 @@ const docTemplate = `{
                         "name": "limit",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also count total rows in cursor mode",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Search by patient full name",
@@ -56,21 +69,44 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Filter by status (pending, done, cancelled)",
+                        "description": "Filter by status (pending, done, cancelled). Also status[in]=pending,done",
                         "name": "status",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "default": "schedule_at",
-                        "description": "Sort by field (e.g. schedule_at, created_at)",
+                        "description": "Filter by patient ID",
+                        "name": "patientId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by doctor/staff user ID",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Scheduled at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "scheduleAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Scheduled at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "scheduleAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "scheduleAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, status, scheduleAt, createdAt, updatedAt)",
                         "name": "sort",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "default": "asc",
-                        "description": "Sort order (asc or desc)",
+                        "description": "Default sort order for fields without prefix (asc or desc)",
                         "name": "order",
                         "in": "query"
                     }
@@ -82,6 +118,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.PaginatedAppointmentsResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -102,7 +144,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new appointment between patient and doctor/staff.",
+                "description": "Create a new appointment between patient and doctor/staff. Doctors can only create appointments assigned to themselves.",
                 "consumes": [
                     "application/json"
                 ],
@@ -137,6 +179,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -146,33 +194,111 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/appointments/appoinmentUser/{userId}": {
+        "/api/appointments/appoinmentPatient/{patientId}": {
             "get": {
-                "description": "Mengambil semua janji temu berdasarkan ID user (dokter/staff), beserta informasi user yang membuat janji temu",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil semua janji temu berdasarkan ID pasien, beserta informasi pasien yang terkait. Dokter hanya bisa melihat pasien di tim perawatannya.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Appointments"
                 ],
-                "summary": "Get appointments by user ID",
+                "summary": "Get appointments by patient ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userId",
+                        "description": "Patient ID",
+                        "name": "patientId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also count total rows in cursor mode",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (pending, done, cancelled). Also status[in]=pending,done",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Scheduled at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "scheduleAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Scheduled at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "scheduleAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "createdAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "createdAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-scheduleAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, status, scheduleAt, createdAt, updatedAt)",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.AppointmentWithUserResponse"
-                            }
+                            "$ref": "#/definitions/dto.PaginatedAppointmentsWithPatientResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
@@ -184,33 +310,111 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/appointments/appointmentPatient/{patientId}": {
+        "/api/appointments/appoinmentUser/{userId}": {
             "get": {
-                "description": "Mengambil semua janji temu berdasarkan ID pasien, beserta informasi pasien yang terkait",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil semua janji temu berdasarkan ID user (dokter/staff), beserta informasi user yang membuat janji temu. Dokter hanya bisa melihat janji temunya sendiri.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Appointments"
                 ],
-                "summary": "Get appointments by patient ID",
+                "summary": "Get appointments by user ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Patient ID",
-                        "name": "patientId",
+                        "description": "User ID",
+                        "name": "userId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also count total rows in cursor mode",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (pending, done, cancelled). Also status[in]=pending,done",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Scheduled at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "scheduleAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Scheduled at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "scheduleAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "createdAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "createdAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-scheduleAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, status, scheduleAt, createdAt, updatedAt)",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.AppointmentWithPatientResponse"
-                            }
+                            "$ref": "#/definitions/dto.PaginatedAppointmentsWithUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
@@ -222,38 +426,79 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/appointments/{id}": {
+        "/api/appointments/trash": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve detailed information of an appointment by its ID, including patient and doctor/staff info.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get paginated list of soft-deleted appointments (trash), most recently deleted first. Only accessible by admin.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Appointments"
                 ],
-                "summary": "Get appointment by ID",
+                "summary": "List deleted appointments",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Appointment ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by patient ID",
+                        "name": "patientId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by doctor/staff user ID",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deleted at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "deletedAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deleted at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "deletedAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-deletedAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, status, scheduleAt, createdAt, updatedAt, deletedAt)",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.AppointmentResponse"
+                            "$ref": "#/definitions/dto.PaginatedAppointmentTrashResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
                         }
                     },
                     "401": {
@@ -262,21 +507,29 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/appointments/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update appointment schedule or notes by ID.",
+                "description": "Retrieve detailed information of an appointment by its ID, including patient and doctor/staff info.",
                 "consumes": [
                     "application/json"
                 ],
@@ -286,7 +539,7 @@ const docTemplate = `{
                 "tags": [
                     "Appointments"
                 ],
-                "summary": "Update an appointment",
+                "summary": "Get appointment by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -294,15 +547,68 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated appointment data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateAppointmentRequest"
-                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AppointmentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update appointment schedule or notes by ID. Doctors can only update their own appointments.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Appointments"
+                ],
+                "summary": "Update an appointment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Appointment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated appointment data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateAppointmentRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -318,6 +624,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -362,6 +674,82 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.MessageDeleteAppointmentResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/appointments/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore an appointment from the trash. Fails with 409 if its patient or doctor/staff is deleted; restore them first. Only accessible by admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Appointments"
+                ],
+                "summary": "Restore a deleted appointment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Appointment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RestoreResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -420,6 +808,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -442,7 +836,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get paginated list of assessments, with optional filter by patient ID",
+                "description": "Get paginated list of assessments, with optional filter by patient ID. Doctors only see assessments of patients on their care team.",
                 "consumes": [
                     "application/json"
                 ],
@@ -468,11 +862,43 @@ const docTemplate = `{
                         "name": "limit",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also count total rows in cursor mode",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Filter by patient ID",
                         "name": "patientId",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Assessment date on or after (YYYY-MM-DD or RFC3339)",
+                        "name": "date[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Assessment date on or before (YYYY-MM-DD or RFC3339)",
+                        "name": "date[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-createdAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, date, createdAt, updatedAt)",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -482,6 +908,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.PaginatedAssessmentsResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -543,6 +975,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -552,7 +990,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/assessments/patient/{patientId}": {
+        "/api/assessments/byPatient/{patientId}": {
             "get": {
                 "security": [
                     {
@@ -574,16 +1012,83 @@ const docTemplate = `{
                         "name": "patientId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also count total rows in cursor mode",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Assessment date on or after (YYYY-MM-DD or RFC3339)",
+                        "name": "date[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Assessment date on or before (YYYY-MM-DD or RFC3339)",
+                        "name": "date[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "createdAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "createdAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-createdAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, date, createdAt, updatedAt)",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.AssessmentResponse"
-                            }
+                            "$ref": "#/definitions/dto.PaginatedAssessmentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
@@ -595,97 +1100,73 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/assessments/{id}": {
+        "/api/assessments/trash": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil detail satu assessment berdasarkan ID, termasuk data pasien (mini) dan hasil prediksi jika ada",
+                "description": "Mengambil daftar assessment yang sudah di-soft delete (trash), yang terakhir dihapus lebih dulu. Hanya untuk admin.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Assessments"
                 ],
-                "summary": "Mendapatkan assessment berdasarkan ID",
+                "summary": "Daftar assessment yang sudah dihapus",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Assessment ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.GetAssessmentByIDSuccessResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "put": {
-                "security": [
                     {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Memperbarui tanggal dan jawaban assessment, serta memperbarui waktu terakhir diperbarui (updatedAt)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Assessments"
-                ],
-                "summary": "Memperbarui assessment berdasarkan ID",
-                "parameters": [
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "ID Assessment",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by patient ID",
+                        "name": "patientId",
+                        "in": "query"
                     },
                     {
-                        "description": "Data assessment yang diperbarui",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateAssessmentRequest"
-                        }
+                        "type": "string",
+                        "description": "Deleted at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "deletedAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deleted at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "deletedAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-deletedAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, date, createdAt, updatedAt, deletedAt)",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateAssessmentSuccessResponse"
+                            "$ref": "#/definitions/dto.PaginatedAssessmentTrashResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
                         }
                     },
                     "401": {
@@ -694,25 +1175,36 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/assessments/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus assessment dari database berdasarkan ID yang diberikan",
+                "description": "Mengambil detail satu assessment berdasarkan ID, termasuk data pasien (mini) dan hasil prediksi jika ada",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Assessments"
                 ],
-                "summary": "Menghapus assessment berdasarkan ID",
+                "summary": "Mendapatkan assessment berdasarkan ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -726,7 +1218,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageDeleteAssesmentResponse"
+                            "$ref": "#/definitions/dto.GetAssessmentByIDSuccessResponse"
                         }
                     },
                     "401": {
@@ -735,29 +1227,27 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/medical-records": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a paginated list of medical records. Supports filtering by patientId and userId.",
+                "description": "Memperbarui tanggal dan jawaban assessment, serta memperbarui waktu terakhir diperbarui (updatedAt)",
                 "consumes": [
                     "application/json"
                 ],
@@ -765,124 +1255,75 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "MedicalRecords"
+                    "Assessments"
                 ],
-                "summary": "Get all medical records (with pagination and optional filters)",
+                "summary": "Memperbarui assessment berdasarkan ID",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Filter by patient ID",
-                        "name": "patientId",
-                        "in": "query"
+                        "description": "ID Assessment",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by user ID",
-                        "name": "userId",
-                        "in": "query"
+                        "description": "Data assessment yang diperbarui",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateAssessmentRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.PaginatedMedicalRecordsResponse"
+                            "$ref": "#/definitions/dto.UpdateAssessmentSuccessResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Adds a new medical record linked to a patient and user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "MedicalRecords"
-                ],
-                "summary": "Create a new medical record",
-                "parameters": [
-                    {
-                        "description": "Medical Record input data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateMedicalRecordRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateMedicalRecordResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/medical-records/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve detailed information of a medical record by its ID, including patient and user (doctor/staff) info.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Menghapus assessment dari database berdasarkan ID yang diberikan",
                 "tags": [
-                    "MedicalRecords"
+                    "Assessments"
                 ],
-                "summary": "Get medical record by ID",
+                "summary": "Menghapus assessment berdasarkan ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Medical Record ID",
+                        "description": "Assessment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -892,7 +1333,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.MedicalRecordResponse"
+                            "$ref": "#/definitions/dto.MessageDeleteAssesmentResponse"
                         }
                     },
                     "401": {
@@ -901,58 +1342,79 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/assessments/{id}/restore": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a medical record's patient, user, diagnosis, or treatment.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Memulihkan assessment dari trash. Gagal (409) jika pasiennya sudah dihapus; pulihkan pasien lebih dulu. Dengan cascade=true, prediksi yang terhapus bersama assessment ikut dipulihkan. Hanya untuk admin.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "MedicalRecords"
+                    "Assessments"
                 ],
-                "summary": "Update medical record by ID",
+                "summary": "Memulihkan assessment yang sudah dihapus",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Medical Record ID",
+                        "description": "Assessment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Medical Record Data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateMedicalRecordRequest"
-                        }
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Ikut pulihkan prediksi yang terhapus bersama assessment",
+                        "name": "cascade",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateMedicalRecordResponse"
+                            "$ref": "#/definitions/dto.RestoreResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -963,6 +1425,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -970,39 +1438,127 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/audit-logs/": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Soft delete a medical record by its ID",
+                "description": "Get paginated PHI access audit log (reads and writes of patients, assessments, predictions and medical records). Only accessible by admin.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "MedicalRecords"
+                    "Audit"
                 ],
-                "summary": "Delete a medical record",
+                "summary": "Get audit logs",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page (max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Medical Record ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by actor user ID",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by actor role",
+                        "name": "actorRole",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by action (create, read, update, delete, reveal, break_glass, export, erase, restore, merge, unmerge). Also action[in]=update,delete",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by resource type (patient, assessment, prediction, medical_record, consent, data_export, erasure_request, legal_hold)",
+                        "name": "resourceType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by resource ID",
+                        "name": "resourceId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by patient ID",
+                        "name": "patientId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by client IP",
+                        "name": "ipAddress",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by reason, e.g. emergency-access:\u003cid\u003e for every access made with one break-the-glass grant",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Occurred at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "occurredAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Occurred at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "occurredAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-id",
+                        "description": "Comma separated sort fields, prefix - for descending (id, occurredAt)",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageDeleteMedicalRecordResponse"
+                            "$ref": "#/definitions/dto.PaginatedAuditLogsResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1016,63 +1572,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/patients": {
+        "/api/audit-logs/export": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get paginated list of patients with optional search, gender filter, and sorting",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Download every audit entry matching the filters (same filters as the list endpoint, without paging) as CSV, oldest first, including the hash chain columns. Only accessible by admin.",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "Patients"
+                    "Audit"
                 ],
-                "summary": "Get all patients",
+                "summary": "Export audit logs as CSV",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
+                        "type": "string",
+                        "description": "Filter by actor user ID",
+                        "name": "actorId",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "Filter by action (create, read, update, delete)",
+                        "name": "action",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Search by full name or NIK",
-                        "name": "search",
+                        "description": "Filter by resource type",
+                        "name": "resourceType",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by gender (male, female, other)",
-                        "name": "gender",
+                        "description": "Filter by resource ID",
+                        "name": "resourceId",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "default": "created_at",
-                        "description": "Sort by field (e.g. full_name, created_at)",
-                        "name": "sort",
+                        "description": "Filter by patient ID",
+                        "name": "patientId",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "default": "desc",
-                        "description": "Sort order (asc or desc)",
-                        "name": "order",
+                        "description": "Occurred at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "occurredAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Occurred at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "occurredAt[lte]",
                         "in": "query"
                     }
                 ],
@@ -1080,7 +1635,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.PaginatedPatientsResponse"
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
                         }
                     },
                     "401": {
@@ -1096,44 +1657,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/audit-logs/verify": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Register a new patient with full name, NIK, birth date, gender, phone, address, and emergency contact",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Recompute the hash of every audit entry and check that each one links to the previous entry. Reports the first broken entry if the log was modified. Only accessible by admin.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Patients"
+                    "Audit"
                 ],
-                "summary": "Create a new patient",
-                "parameters": [
-                    {
-                        "description": "Patient input data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                "summary": "Verify audit log hash chain",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreatePatientRequest"
+                            "$ref": "#/definitions/dto.AuditVerificationResponse"
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreatePatientResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1147,38 +1702,91 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/patients/{id}": {
+        "/api/care-team/": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve detailed information of a patient by their ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get paginated list of care team assignments. Doctors only see assignments of patients on their care team.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Patients"
+                    "Care Team"
                 ],
-                "summary": "Get patient by ID",
+                "summary": "Get care team assignments",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Patient ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by patient ID",
+                        "name": "patientId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by assigned user ID",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by care role (primary, secondary, staff)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true: only assignments active today, false: ended or upcoming",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Started on or after (YYYY-MM-DD)",
+                        "name": "startDate[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Started on or before (YYYY-MM-DD)",
+                        "name": "startDate[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-startDate",
+                        "description": "Comma separated sort fields, prefix - for descending (id, role, startDate, endDate, createdAt)",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.PatientResponse"
+                            "$ref": "#/definitions/dto.PaginatedCareAssignmentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
                         }
                     },
                     "401": {
@@ -1187,21 +1795,27 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a patient’s information by ID. Only fields in the request body will be updated.",
+                "description": "Add a doctor (primary or secondary clinician) or staff member to a patient's care team. A patient has at most one primary clinician at a time. Accessible by admin and staff.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1209,32 +1823,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Patients"
+                    "Care Team"
                 ],
-                "summary": "Update patient data",
+                "summary": "Assign care team member",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Patient ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Patient fields to update",
+                        "description": "Care assignment input",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdatePatientInput"
+                            "$ref": "#/definitions/dto.AssignCareTeamRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.PatientResponse"
+                            "$ref": "#/definitions/dto.CareAssignmentMessageResponse"
                         }
                     },
                     "400": {
@@ -1243,52 +1850,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Delete a patient record by their ID. Only accessible by authorized roles.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Patients"
-                ],
-                "summary": "Delete a patient",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Patient ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.MessageDeletePatientResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1302,24 +1877,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/predictions": {
+        "/api/care-team/caseload": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get paginated list of predictions, with optional filters and sorting",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the patients currently assigned to the logged-in user (care assignments active today), based on the user ID in the JWT.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Predictions"
+                    "Care Team"
                 ],
-                "summary": "Get all predictions",
+                "summary": "Get my caseload",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1337,22 +1909,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Filter by result label",
-                        "name": "resultLabel",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "default": "created_at",
-                        "description": "Sort by field (e.g. created_at, probability_score)",
-                        "name": "sortBy",
+                        "description": "Filter by care role (primary, secondary, staff)",
+                        "name": "role",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "default": "desc",
-                        "description": "Sort order: asc or desc",
-                        "name": "sortOrder",
+                        "default": "-startDate",
+                        "description": "Comma separated sort fields, prefix - for descending (role, startDate)",
+                        "name": "sort",
                         "in": "query"
                     }
                 ],
@@ -1360,7 +1925,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.PaginatedPredictionsResponse"
+                            "$ref": "#/definitions/dto.PaginatedCareAssignmentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
                         }
                     },
                     "401": {
@@ -1384,14 +1955,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/predictions/assessment/{assessment_id}": {
-            "get": {
+        "/api/care-team/handover": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Ambil hasil prediksi berdasarkan assessment ID",
+                "description": "Transfer every assignment of one user that has not ended (optionally only for the given patients) to another user in a single transaction, e.g. when a clinician leaves. Accessible by admin and staff.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1399,27 +1970,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Predictions"
+                    "Care Team"
                 ],
-                "summary": "Get prediction by assessment ID",
+                "summary": "Bulk handover caseload",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Assessment ID",
-                        "name": "assessment_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Handover input",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CareHandoverRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.PredictionResponse"
+                            "$ref": "#/definitions/dto.CareHandoverResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1433,28 +2024,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/predictions/{id}": {
+        "/api/care-team/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Ambil hasil prediksi berdasarkan prediction ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve a care team assignment. Doctors can see their own assignments and those of patients on their care team.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Predictions"
+                    "Care Team"
                 ],
-                "summary": "Get prediction by ID",
+                "summary": "Get care assignment by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Prediction ID",
+                        "description": "Care assignment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1464,7 +2052,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.PredictionResponse"
+                            "$ref": "#/definitions/dto.CareAssignmentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
@@ -1480,14 +2080,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/care-team/{id}/end": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui data prediksi (result label dan skor probabilitas) berdasarkan ID",
+                "description": "End a care relationship on the given date (default today, exclusive). Accessible by admin and staff.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1495,24 +2097,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Predictions"
+                    "Care Team"
                 ],
-                "summary": "Update prediksi berdasarkan ID",
+                "summary": "End care assignment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Prediction ID",
+                        "description": "Care assignment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Data prediksi yang diperbarui",
+                        "description": "End input",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdatePredictionRequest"
+                            "$ref": "#/definitions/dto.EndCareRequest"
                         }
                     }
                 ],
@@ -1520,7 +2122,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.PredictionResponse"
+                            "$ref": "#/definitions/dto.CareAssignmentMessageResponse"
                         }
                     },
                     "400": {
@@ -1535,12 +2137,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1548,14 +2162,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/care-team/{id}/transfer": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengirim data assessment ke model ML, lalu menyimpan hasil prediksi ke database",
+                "description": "End the assignment on the effective date (default today) and give the same care role to another user from that date. If the target is already a secondary clinician and the primary role is transferred, they are promoted. Accessible by admin and staff.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1563,23 +2179,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Predictions"
+                    "Care Team"
                 ],
-                "summary": "Membuat prediksi kesehatan mental dari assessment",
+                "summary": "Transfer care assignment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Assessment ID",
+                        "description": "Care assignment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Transfer input",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferCareRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.PredictionResponse"
+                            "$ref": "#/definitions/dto.CareAssignmentMessageResponse"
                         }
                     },
                     "400": {
@@ -1588,55 +2213,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Menghapus data prediksi dari database berdasarkan ID yang diberikan",
-                "tags": [
-                    "Predictions"
-                ],
-                "summary": "Menghapus prediksi berdasarkan ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Prediction ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.MessageDeletePredictionResponse"
-                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1650,24 +2246,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/": {
+        "/api/consent-documents/": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get paginated list of users with optional search, role filter, and sorting. Only accessible by admin.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get paginated consent document versions. Use current=true to get only the latest version of each type.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Consents"
                 ],
-                "summary": "Get all users",
+                "summary": "Get consent documents",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1684,37 +2277,36 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Search by name or email",
-                        "name": "search",
+                        "type": "boolean",
+                        "description": "Only the latest version of each type",
+                        "name": "current",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by role (admin, doctor, staff)",
-                        "name": "role",
+                        "description": "Filter by consent type (treatment, ai_prediction, research, data_sharing)",
+                        "name": "type",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "default": "created_at",
-                        "description": "Sort by field (e.g. full_name, email, created_at)",
+                        "default": "type,-version",
+                        "description": "Comma separated sort fields, prefix - for descending (id, type, version, createdAt)",
                         "name": "sort",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "default": "desc",
-                        "description": "Sort order (asc or desc)",
-                        "name": "order",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.PaginatedUsersResponse"
+                            "$ref": "#/definitions/dto.PaginatedConsentDocumentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
                         }
                     },
                     "401": {
@@ -1723,18 +2315,21 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/users/login": {
+            },
             "post": {
-                "description": "Authenticate user and return JWT token",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publish a new version of the consent text for one consent type (treatment, ai_prediction, research, data_sharing). The version number is assigned automatically. Published documents cannot be edited. Existing consents stay valid until withdrawn; new consents must use the latest version. Accessible by admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1742,25 +2337,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Consents"
                 ],
-                "summary": "Login user",
+                "summary": "Publish consent document version",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "credentials",
+                        "description": "Consent document",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginUserRequest"
+                            "$ref": "#/definitions/dto.CreateConsentDocumentRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginResponse"
+                            "$ref": "#/definitions/dto.ConsentDocumentMessageResponse"
                         }
                     },
                     "400": {
@@ -1774,43 +2369,163 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/api/users/register": {
-            "post": {
-                "description": "Create a new user with full name, email, password, and role",
-                "consumes": [
-                    "application/json"
-                ],
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/consent-documents/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve one consent document version, including its full text.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Consents"
                 ],
-                "summary": "Register new user",
+                "summary": "Get consent document by ID",
                 "parameters": [
                     {
-                        "description": "Register input",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "Consent document ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.RegisterUserRequest"
+                            "$ref": "#/definitions/dto.ConsentDocumentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
+                }
+            }
+        },
+        "/api/data-exports/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Paginated list of data export jobs, newest first. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Subject Rights"
+                ],
+                "summary": "Get data exports",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by patient ID",
+                        "name": "patientId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by requesting user ID",
+                        "name": "requestedById",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (pending, processing, completed, failed)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Requested at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "requestedAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Requested at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "requestedAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-requestedAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, requestedAt)",
+                        "name": "sort",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.RegisterUserResponse"
+                            "$ref": "#/definitions/dto.PaginatedDataExportsResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1824,28 +2539,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/{id}": {
+        "/api/data-exports/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a single user by their ID. Only accessible by admin.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve the status of one data export job. Accessible by admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Data Subject Rights"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Get data export by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Data export ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1855,7 +2567,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.UserResponse"
+                            "$ref": "#/definitions/dto.DataExportResponse"
                         }
                     },
                     "401": {
@@ -1875,55 +2587,45 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/data-exports/{id}/download": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update user information by ID. Can update fullName, email, password, and role. Requires authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Download the zip bundle of a completed export. The X-Checksum-SHA256 header carries the bundle checksum. Every download is written to the audit log with action export. Accessible by admin only.",
                 "produces": [
-                    "application/json"
+                    "application/zip"
                 ],
                 "tags": [
-                    "Users"
+                    "Data Subject Rights"
                 ],
-                "summary": "Update user by ID",
+                "summary": "Download data export bundle",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Data export ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated user data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateUserInput"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.UserResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "type": "file"
                         }
                     },
                     "401": {
@@ -1939,47 +2641,117 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "User Not Found",
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to Update User",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/emergency-access/": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a user by their ID. Only accessible by admin.",
+                "description": "Paginated report of break-the-glass grants for admin review, newest first. pendingReview counts every grant not yet reviewed. Accessible by admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Emergency Access"
                 ],
-                "summary": "Delete user by ID",
+                "summary": "Emergency access review report",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "false: only the pending review queue, true: only reviewed grants",
+                        "name": "reviewed",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by user who used emergency access",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by patient ID",
+                        "name": "patientId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by review outcome (justified, unjustified)",
+                        "name": "reviewOutcome",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Granted at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "grantedAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Granted at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "grantedAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-grantedAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, grantedAt, expiresAt, reviewedAt)",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageDeleteResponse"
+                            "$ref": "#/definitions/dto.PaginatedEmergencyAccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
                         }
                     },
                     "401": {
@@ -2002,306 +2774,8578 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.AppointmentResponse": {
-            "type": "object",
-            "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "notes": {
-                    "type": "string"
+        },
+        "/api/emergency-access/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve one break-the-glass grant. Use auditReason as the reason filter on /api/audit-logs to see every access made with it. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Emergency Access"
+                ],
+                "summary": "Get emergency access by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Emergency access ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmergencyAccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/emergency-access/{id}/review": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record the admin review outcome (justified or unjustified) of a break-the-glass grant. A grant can only be reviewed once; an unjustified outcome does not revoke access by itself. Accessible by admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Emergency Access"
+                ],
+                "summary": "Review emergency access",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Emergency access ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review outcome",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReviewEmergencyAccessRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmergencyAccessMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/emergency-access/{id}/revoke": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "End a break-the-glass grant before it expires. The emergency token is rejected from the next request. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Emergency Access"
+                ],
+                "summary": "Revoke emergency access",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Emergency access ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmergencyAccessMessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/erasure-requests/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Paginated list of erasure requests, newest first. Use status=pending for the approval queue. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Subject Rights"
+                ],
+                "summary": "Get erasure requests",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by patient ID",
+                        "name": "patientId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by mode (erase, anonymize)",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (pending, rejected, completed)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by requesting user ID",
+                        "name": "requestedById",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Requested at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "requestedAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Requested at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "requestedAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-requestedAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, requestedAt, completedAt)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedErasureRequestsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/erasure-requests/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve one erasure request. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Subject Rights"
+                ],
+                "summary": "Get erasure request by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Erasure request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErasureRequestResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/erasure-requests/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve a pending erasure request and execute it in one transaction. Both modes remove the patient's identity (name, NIK, phone, address, emergency contact; birth date reduced to the year), soft-delete the patient, delete export bundles and withdraw active consents. Mode erase also permanently deletes appointments, assessments, predictions and care team assignments. Medical records (retention duty), audit logs, emergency accesses and consent history are always retained. Must be approved by an admin other than the requester and fails while the patient is under an active legal hold. Produces a certificate of completion. Accessible by admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Subject Rights"
+                ],
+                "summary": "Approve and execute erasure request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Erasure request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Approval notes",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApproveErasureRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErasureRequestMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/erasure-requests/{id}/certificate": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Certificate of completion of an executed erasure request: what was erased, anonymized and retained (with the legal basis), who requested and approved it and when. format=json (default) returns the stored certificate and its SHA-256 hash; format=pdf returns a printable PDF. Accessible by admin only.",
+                "produces": [
+                    "application/json",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Data Subject Rights"
+                ],
+                "summary": "Get erasure certificate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Erasure request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "json",
+                        "description": "json or pdf",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErasureCertificateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/erasure-requests/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reject a pending erasure request with a reason. Accessible by admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Subject Rights"
+                ],
+                "summary": "Reject erasure request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Erasure request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RejectErasureRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErasureRequestMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/invitations/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of invitations with their status (pending, used, revoked, expired). Only accessible by admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitations"
+                ],
+                "summary": "Get all invitations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter email containing text",
+                        "name": "email[ilike]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by role (admin, doctor, staff)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-createdAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, email, role, expiresAt, createdAt)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedInvitationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a single-use invitation token that lets the invited email register with a fixed role. The token is only returned once. Only accessible by admin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitations"
+                ],
+                "summary": "Create invitation",
+                "parameters": [
+                    {
+                        "description": "Invitation input",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateInvitationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/invitations/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke a pending invitation so its token can no longer be used. Only accessible by admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitations"
+                ],
+                "summary": "Revoke invitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/legal-holds/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Paginated list of legal holds, newest first. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Subject Rights"
+                ],
+                "summary": "Get legal holds",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true: only active holds, false: only released holds",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by patient ID",
+                        "name": "patientId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by user who placed the hold",
+                        "name": "placedById",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Placed at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "placedAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Placed at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "placedAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-placedAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, placedAt, releasedAt)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedLegalHoldsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/legal-holds/{id}/release": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Release a legal hold with a reason. Accessible by admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Subject Rights"
+                ],
+                "summary": "Release legal hold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Legal hold ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Release reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReleaseLegalHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LegalHoldMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/medical-records": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated list of medical records. Supports filtering by patientId and userId. Doctors only see records of patients on their care team.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MedicalRecords"
+                ],
+                "summary": "Get all medical records (with pagination and optional filters)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also count total rows in cursor mode",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by patient ID",
+                        "name": "patientId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by user ID",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "createdAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "createdAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-createdAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, createdAt, updatedAt)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedMedicalRecordsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a new medical record linked to a patient and user. Doctors can only author records as themselves for patients on their care team.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MedicalRecords"
+                ],
+                "summary": "Create a new medical record",
+                "parameters": [
+                    {
+                        "description": "Medical Record input data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateMedicalRecordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateMedicalRecordResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/medical-records/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of soft-deleted medical records (trash), most recently deleted first. Diagnosis and treatment are masked like the normal list. Only accessible by admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MedicalRecords"
+                ],
+                "summary": "List deleted medical records",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by patient ID",
+                        "name": "patientId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by user ID",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deleted at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "deletedAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deleted at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "deletedAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-deletedAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, createdAt, updatedAt, deletedAt)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedMedicalRecordTrashResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/medical-records/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve detailed information of a medical record by its ID, including patient and user (doctor/staff) info. Diagnosis and treatment are only returned to the treating doctor (care team or author); for other roles they are omitted and listed in redactedFields.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MedicalRecords"
+                ],
+                "summary": "Get medical record by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medical Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MedicalRecordResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a medical record's patient, user, diagnosis, or treatment. Doctors can only edit records they authored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MedicalRecords"
+                ],
+                "summary": "Update medical record by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medical Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Medical Record Data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateMedicalRecordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateMedicalRecordResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete a medical record by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MedicalRecords"
+                ],
+                "summary": "Delete a medical record",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medical Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageDeleteMedicalRecordResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/medical-records/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore a medical record from the trash. Fails with 409 if its patient is deleted; restore the patient first. Only accessible by admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MedicalRecords"
+                ],
+                "summary": "Restore a deleted medical record",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medical Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RestoreResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of patients with optional search, gender filter, and sorting. Doctors only see patients on their care team.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Get all patients",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also count total rows in cursor mode",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by full name (partial) or NIK (exact match, NIK is stored encrypted)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by gender (male, female, other). Also gender[in]=male,female",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter full name containing text",
+                        "name": "fullName[ilike]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum age in full years (patients without a known birth date are excluded)",
+                        "name": "minAge",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum age in full years (patients without a known birth date are excluded)",
+                        "name": "maxAge",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Born on or after (YYYY-MM-DD)",
+                        "name": "birthDate[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Born on or before (YYYY-MM-DD)",
+                        "name": "birthDate[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "createdAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "createdAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-createdAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, fullName, gender, createdAt, updatedAt)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Default sort order for fields without prefix (asc or desc)",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedPatientsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a new patient with full name, NIK, birth date, gender, phone, address, and emergency contacts\n` + "`" + `contacts` + "`" + ` creates the patient's emergency contacts and guardians; the older ` + "`" + `emergencyContact` + "`" + ` (a single phone number) is still accepted and stored as a contact with relationship ` + "`" + `other` + "`" + `. Manage contacts afterwards with /api/patients/{id}/contacts. ` + "`" + `emergencyContact` + "`" + ` in the response is the phone of the highest-priority contact.\nThe NIK must be 16 digits with a known region code and an encoded birth date (day + 40 for females) that matches ` + "`" + `birthDate` + "`" + ` and ` + "`" + `gender` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Create a new patient",
+                "parameters": [
+                    {
+                        "description": "Patient input data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreatePatientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreatePatientResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "fields is set for NIK, birth date and gender errors",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Paginated list of likely duplicate patient pairs with both patients side by side, highest score first. Pending pairs where one patient has since been deleted are hidden. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient Duplicates"
+                ],
+                "summary": "Get duplicate review queue",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also count total rows in cursor mode",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (pending, dismissed, merged)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only pairs involving this patient",
+                        "name": "patientId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum score (0-1)",
+                        "name": "score[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Detected at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "detectedAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Detected at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "detectedAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-score",
+                        "description": "Comma separated sort fields, prefix - for descending (id, score, detectedAt)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedDuplicateCandidatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/duplicates/scans": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Paginated history of duplicate patient scans, newest first. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient Duplicates"
+                ],
+                "summary": "Get duplicate scan history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by trigger (scheduled, manual)",
+                        "name": "trigger",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (running, completed, failed)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Started at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "startedAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Started at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "startedAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-startedAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, startedAt)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedDuplicateScansResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Scan all active patients for likely duplicates in the background. Pairs are scored on name similarity, birth date, phone number and mistyped NIKs; pairs at or above the configured threshold are added to the review queue. Dismissed and merged pairs are not raised again. Only one scan can run at a time. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient Duplicates"
+                ],
+                "summary": "Start duplicate patient scan",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DuplicateScanMessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/duplicates/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve one pair from the duplicate review queue with both patients. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient Duplicates"
+                ],
+                "summary": "Get duplicate candidate by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Duplicate candidate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DuplicateCandidateResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/duplicates/{id}/dismiss": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a pending pair as different patients. The pair will not be raised again by later scans. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient Duplicates"
+                ],
+                "summary": "Dismiss duplicate candidate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Duplicate candidate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DuplicateCandidateMessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/legacy-birth-dates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of patients whose old free-text birth date could not be converted to a date during migration. suggestedBirthDate is decoded from the patient's NIK when it is valid. Fix a row by updating the patient's birthDate. Only accessible by admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "List unconverted legacy birth dates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also count total rows in cursor mode",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "createdAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, fullName, createdAt)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedLegacyBirthDatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/merges": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Paginated history of patient merges, newest first, including undone merges. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient Duplicates"
+                ],
+                "summary": "Get patient merge history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also count total rows in cursor mode",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by surviving patient ID",
+                        "name": "survivorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by merged patient ID",
+                        "name": "mergedId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merged at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "mergedAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merged at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "mergedAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-mergedAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, mergedAt)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedPatientMergesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Merge mergedId into survivorId in one transaction. Appointments, assessments (with their predictions), medical records and emergency contacts of the merged patient, including deleted ones, are moved to the survivor; the merged patient is moved to the trash and can only come back by undoing the merge. The survivor's own details are not changed. Consents, care team assignments and emergency access stay with the merged patient. A pending review queue entry for the pair is marked as merged. Fails with 409 if either patient is under a legal hold or has been anonymized. Accessible by admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient Duplicates"
+                ],
+                "summary": "Merge duplicate patients",
+                "parameters": [
+                    {
+                        "description": "Patients to merge",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MergePatientsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatientMergeMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/merges/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve one patient merge with the IDs of the moved rows per table. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient Duplicates"
+                ],
+                "summary": "Get patient merge by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient merge ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatientMergeResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/merges/{id}/undo": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Undo a merge: rows moved by the merge that still belong to the survivor are moved back, the merged patient is restored from the trash and the review queue entry becomes pending again. Rows created on the survivor after the merge stay with the survivor. Fails with 409 if the merge was already undone, the merged patient's NIK is now used by another active patient, either patient is under a legal hold, or the merged patient has been anonymized or changed since. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient Duplicates"
+                ],
+                "summary": "Undo patient merge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient merge ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatientMergeMessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/nik/decode": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Decode the region, birth date and gender encoded in a NIK to pre-fill the patient intake form. The century of the birth year is inferred so that the date is not in the future.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Decode a NIK",
+                "parameters": [
+                    {
+                        "description": "NIK to decode",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DecodeNIKRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DecodeNIKResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of soft-deleted patients (trash), most recently deleted first. Sensitive fields are masked like the normal list. Only accessible by admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "List deleted patients",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by gender (male, female, other)",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deleted at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "deletedAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deleted at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "deletedAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-deletedAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, fullName, gender, createdAt, updatedAt, deletedAt)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedPatientTrashResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve detailed information of a patient by their ID. Sensitive fields are masked by role (admin and staff see a masked NIK, admin does not see the address, doctors outside the care team see nothing sensitive); masked or omitted fields are listed in redactedFields. Use reveal with a reason to see full values, which is written to the audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Get patient by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to show in full (nik, phone, address, emergencyContact)",
+                        "name": "reveal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Justification for reveal (required with reveal, at least 10 characters)",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatientResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a patient’s information by ID. Only fields in the request body will be updated.\nWhen the NIK, birth date or gender changes, the resulting NIK is validated against the resulting birth date and gender.\n` + "`" + `emergencyContact` + "`" + ` (deprecated) replaces the phone of the highest-priority contact, or creates a contact if the patient has none.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Update patient data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Patient fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePatientInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatientResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "fields is set for NIK, birth date and gender errors",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a patient record by their ID. Only accessible by authorized roles.\nAppointments, assessments (with their predictions) and medical records of the patient are soft-deleted in the same transaction and can be restored together with ` + "`" + `cascade=true` + "`" + `.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Delete a patient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageDeletePatientResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeleteBlockedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/{id}/break-glass": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Emergency read access for a doctor who is not on the patient's care team. A written reason is required. Returns a separate access token scoped to this patient that is valid for a limited time (auth.breakGlassTtl, default 1 hour); use it instead of the normal token for requests about this patient. Every grant is written to the audit log and queued for admin review, and every access made with the token is audited with reason emergency-access:\u003cid\u003e.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Emergency Access"
+                ],
+                "summary": "Break-the-glass access to a patient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Emergency reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BreakGlassRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BreakGlassResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/{id}/consents": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the patient's consent records, including withdrawn ones. Accessible by admin, staff and doctors on the patient's care team.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Consents"
+                ],
+                "summary": "Get patient consent history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true: only active consents, false: only withdrawn consents",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by consent type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Granted at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "grantedAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Granted at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "grantedAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-grantedAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, type, grantedAt, withdrawnAt)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedPatientConsentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record that the patient agreed to the latest version of a consent document. The logged-in user is stored as the one who captured it. An active consent of the same type on an older version is withdrawn automatically (superseded). For patients under 18 at the grant time, a guardian is required: guardianContactId (one of the patient's contacts marked as legal guardian; its name is used when guardianName is empty) or guardianName. Accessible by admin, staff and doctors on the patient's care team.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Consents"
+                ],
+                "summary": "Record patient consent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Consent input",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GrantConsentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatientConsentMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/{id}/consents/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the current status of every consent type for the patient: whether an active consent exists, on which document version, and whether that is the latest version. Accessible by admin, staff and doctors on the patient's care team.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Consents"
+                ],
+                "summary": "Get patient consent status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConsentSummaryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/{id}/consents/{consentId}/withdraw": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Withdraw an active consent. The record is kept as history with the withdrawal time, reason and the user who recorded it. Features that need this consent (e.g. AI prediction) are refused from then on. Accessible by admin, staff and doctors on the patient's care team.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Consents"
+                ],
+                "summary": "Withdraw patient consent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Consent ID",
+                        "name": "consentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Withdrawal reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WithdrawConsentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatientConsentMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/{id}/contacts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the patient's emergency contacts and guardians in the order they should be contacted. The phone is masked for admin; use reveal with a reason to see it in full, which is written to the audit log. Accessible by admin, staff and doctors on the patient's care team or with emergency access to the patient.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient Contacts"
+                ],
+                "summary": "Get patient contacts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by relationship (parent, spouse, child, sibling, relative, friend, caregiver, other)",
+                        "name": "relationship",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only contacts that may (true) or may not (false) receive clinical information",
+                        "name": "mayReceiveClinicalInfo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only legal guardians (true) or other contacts (false)",
+                        "name": "legalGuardian",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to show in full (phone)",
+                        "name": "reveal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Justification for reveal (required with reveal, at least 10 characters)",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "priority,createdAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, fullName, relationship, priority, createdAt)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedContactsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add an emergency contact or guardian to the patient. priority 1 is contacted first; when omitted the contact is placed after the last one. mayReceiveClinicalInfo marks contacts that may be told clinical details (e.g. in a crisis alert); legalGuardian marks the legal guardian, who consents for patients under 18 and always receives clinical details while the patient is a minor. Accessible by admin, staff and doctors on the patient's care team.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient Contacts"
+                ],
+                "summary": "Add patient contact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Contact input",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateContactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ContactMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/{id}/contacts/{contactId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve one emergency contact or guardian of the patient. Accessible by admin, staff and doctors on the patient's care team or with emergency access to the patient.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient Contacts"
+                ],
+                "summary": "Get patient contact by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contact ID",
+                        "name": "contactId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to show in full (phone)",
+                        "name": "reveal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Justification for reveal (required with reveal, at least 10 characters)",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ContactResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an emergency contact or guardian. Only fields in the request body are changed. Accessible by admin, staff and doctors on the patient's care team.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient Contacts"
+                ],
+                "summary": "Update patient contact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contact ID",
+                        "name": "contactId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Contact fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateContactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateContactResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permanently delete an emergency contact or guardian. Consents given by this guardian keep the guardian's name. Accessible by admin and staff.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient Contacts"
+                ],
+                "summary": "Delete patient contact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contact ID",
+                        "name": "contactId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/{id}/data-exports": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start a background job that builds a zip bundle with everything tied to the patient (data.json, machine-readable, format mental-klinik-export/v2, and summary.pdf), including soft-deleted rows and the access log. Poll GET /api/data-exports/{id} until status is completed, then download it. Bundles expire after 7 days. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Subject Rights"
+                ],
+                "summary": "Request patient data export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DataExportMessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/{id}/erasure-requests": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a patient's request to erase (mode erase) or anonymize (mode anonymize) their personal data. Nothing is deleted until another admin approves it. Only one pending request per patient. Accessible by admin and staff.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Subject Rights"
+                ],
+                "summary": "Request erasure of patient data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Erasure request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateErasureRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErasureRequestMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/{id}/legal-holds": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Block erasure of a patient's data, e.g. during litigation or a law enforcement request. Erasure requests can still be recorded but cannot be approved until every hold is released. Accessible by admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Subject Rights"
+                ],
+                "summary": "Place legal hold on patient data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Legal hold",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlaceLegalHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LegalHoldMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore a patient from the trash. Fails with 409 if the NIK has since been registered to another patient or the patient has been anonymized. With cascade=true, appointments, assessments (with their predictions) and medical records deleted together with the patient are restored as well. Only accessible by admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Restore a deleted patient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also restore data deleted together with the patient",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RestoreResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/patients/{id}/timeline": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "One chronologically ordered, paginated stream of a patient's clinical events: appointment scheduled, appointment status changed, assessment taken, prediction generated and medical record written. Newest first by default. Each event carries a short summary and the resource to fetch for details. Event types the user may not list for this patient are left out; diagnosis and treatment are shaped the same way as the medical record endpoints. Deleted records are not shown.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Get patient clinical timeline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also count total rows in cursor mode",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by event type (appointment_scheduled, appointment_status_changed, assessment_taken, prediction_generated, medical_record_written); use type[in] for several",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Occurred at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "occurredAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Occurred at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "occurredAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-occurredAt,-id",
+                        "description": "Comma separated sort fields, prefix - for descending (occurredAt, id)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedTimelineResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/predictions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of predictions, with optional filters and sorting. Doctors only see predictions of patients on their care team.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Predictions"
+                ],
+                "summary": "Get all predictions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opt-in keyset pagination: send empty for the first page, then nextCursor or prevCursor from the previous response",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also count total rows in cursor mode",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by result label. Also resultLabel[in]=a,b and resultLabel[ilike]=text",
+                        "name": "resultLabel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by assessment ID",
+                        "name": "assessmentId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum probability score",
+                        "name": "probabilityScore[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum probability score",
+                        "name": "probabilityScore[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-createdAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, resultLabel, probabilityScore, createdAt, updatedAt). sortBy is accepted as an alias",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Default sort order for fields without prefix (asc or desc). sortOrder is accepted as an alias",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedPredictionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/predictions/assessment/{assessment_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ambil hasil prediksi berdasarkan assessment ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Predictions"
+                ],
+                "summary": "Get prediction by assessment ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Assessment ID",
+                        "name": "assessment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PredictionResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/predictions/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil daftar prediksi yang sudah di-soft delete (trash), yang terakhir dihapus lebih dulu. Hanya untuk admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Predictions"
+                ],
+                "summary": "Daftar prediksi yang sudah dihapus",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by assessment ID",
+                        "name": "assessmentId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deleted at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "deletedAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deleted at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "deletedAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-deletedAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, resultLabel, probabilityScore, createdAt, updatedAt, deletedAt)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedPredictionTrashResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/predictions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ambil hasil prediksi berdasarkan prediction ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Predictions"
+                ],
+                "summary": "Get prediction by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Prediction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PredictionResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memperbarui data prediksi (result label dan skor probabilitas) berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Predictions"
+                ],
+                "summary": "Update prediksi berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Prediction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data prediksi yang diperbarui",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePredictionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PredictionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengirim data assessment ke model ML, lalu menyimpan hasil prediksi ke database. Ditolak (403) jika pasien belum memberikan atau sudah menarik persetujuan ai_prediction.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Predictions"
+                ],
+                "summary": "Membuat prediksi kesehatan mental dari assessment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Assessment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PredictionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus data prediksi dari database berdasarkan ID yang diberikan",
+                "tags": [
+                    "Predictions"
+                ],
+                "summary": "Menghapus prediksi berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Prediction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageDeletePredictionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/predictions/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memulihkan prediksi dari trash. Gagal (409) jika assessment-nya sudah dihapus atau sudah punya prediksi lain. Hanya untuk admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Predictions"
+                ],
+                "summary": "Memulihkan prediksi yang sudah dihapus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Prediction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RestoreResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/retention/policies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retention scheduler settings and the configured policies (entity, action, basis, retention period). Policies are read from the configuration file. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Retention"
+                ],
+                "summary": "Get retention policies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RetentionSettingsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/retention/runs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Paginated history of retention runs (dry-run reports and executed runs), newest first. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Retention"
+                ],
+                "summary": "Get retention run history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true: only dry-run reports, false: only executed runs",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by trigger (scheduled, manual)",
+                        "name": "trigger",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (running, completed, failed)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Executed run of a dry-run report",
+                        "name": "reportRunId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Started at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "startedAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Started at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "startedAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-startedAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, startedAt)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedRetentionRunsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Run the retention policies now, in the background. A dry-run report (eligible rows per policy, rows held back by legal holds, sample IDs) is always written first and returned here. With dryRun false, the policies are then executed with the same cutoffs as a separate run whose reportRunId points to the report; eligible rows are hard-deleted or anonymized in batches. Only one run can be active at a time. Accessible by admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Retention"
+                ],
+                "summary": "Start retention run",
+                "parameters": [
+                    {
+                        "description": "Run options",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StartRetentionRunRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RetentionRunMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/retention/runs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve one retention run with its per-policy results. Accessible by admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Retention"
+                ],
+                "summary": "Get retention run by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Retention run ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RetentionRunResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of users with optional search, role filter, and sorting. Only accessible by admin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get all users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by name or email",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by role (admin, doctor, staff). Also role[in]=doctor,staff",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter email containing text",
+                        "name": "email[ilike]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "createdAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "createdAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-createdAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, fullName, email, role, createdAt, updatedAt)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Default sort order for fields without prefix (asc or desc)",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedUsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/login": {
+            "post": {
+                "description": "Authenticate user and return a short-lived JWT access token plus a rotating refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke the current session. The access token and all refresh tokens of this session stop working immediately.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Logout",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token and refresh token. Each refresh token can be used once; reusing an already rotated token revokes the whole session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/register": {
+            "post": {
+                "description": "Create a new user from an admin-issued invitation. The role comes from the invitation and the email must match it. Without inviteToken registration is rejected unless self-registration is enabled in config, in which case the user is always staff.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Register new user",
+                "parameters": [
+                    {
+                        "description": "Register input",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of soft-deleted users (trash), most recently deleted first. Only accessible by admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List deleted users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by role (admin, doctor, staff)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deleted at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "deletedAt[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deleted at or before (YYYY-MM-DD or RFC3339)",
+                        "name": "deletedAt[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-deletedAt",
+                        "description": "Comma separated sort fields, prefix - for descending (id, fullName, email, role, createdAt, updatedAt, deletedAt)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedUserTrashResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a single user by their ID. Admin can read any user, other users only themselves.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update user information by ID. Can update fullName, email, password, and role. Non-admin users can only update their own account and cannot change role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated user data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateUserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to Update User",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a user by their ID. Only accessible by admin.\nPending appointments of the user must be moved to another active user with the same role via ` + "`" + `reassignTo` + "`" + `, and open care team assignments must be transferred first; otherwise the request fails with 409 and the list of blockers.\nPast appointments and medical records keep referring to the deleted user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the user who takes over the pending appointments",
+                        "name": "reassignTo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageDeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QueryErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeleteBlockedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore a user from the trash. Fails with 409 if the email has since been used by another user. Sessions revoked on deletion stay revoked, so the user has to log in again. Only accessible by admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Restore a deleted user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RestoreResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/revoke-sessions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke every active session of the user, forcing them to log in again. Admin can revoke any user, other users only their own sessions.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Revoke all sessions of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RevokeSessionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AppointmentResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "patient": {
+                    "$ref": "#/definitions/dto.PatientMiniResponse"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "scheduleAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/dto.UserMiniResponse"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AppointmentTrashResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "patient": {
+                    "$ref": "#/definitions/dto.PatientMiniResponse"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "scheduleAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/dto.UserMiniResponse"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AppointmentWithPatientResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "patient": {
+                    "$ref": "#/definitions/dto.PatientMiniResponse"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AppointmentWithUserResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "scheduleAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "🔧 tambahkan ini",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user": {
+                    "description": "🔧 ubah dari struct anonymous jadi struct yang sudah ada",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.UserMiniResponse"
+                        }
+                    ]
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ApproveErasureRequest": {
+            "type": "object",
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "example": "Identitas pemohon sudah diverifikasi"
+                }
+            }
+        },
+        "dto.AssessmentResponse": {
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "patient": {
+                    "description": "dari relasi",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PatientMiniResponse"
+                        }
+                    ]
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "prediction": {
+                    "description": "nullable",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PredictionResponse"
+                        }
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AssessmentTrashResponse": {
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "patient": {
+                    "description": "dari relasi",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PatientMiniResponse"
+                        }
+                    ]
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "prediction": {
+                    "description": "nullable",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PredictionResponse"
+                        }
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AssessmentUpdateResponse": {
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-07-17T15:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "d72f8239-09e7-4b23-a897-3ef6211f6b0a"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-07-17T15:05:30Z"
+                }
+            }
+        },
+        "dto.AssignCareTeamRequest": {
+            "type": "object",
+            "required": [
+                "patientId",
+                "role",
+                "userId"
+            ],
+            "properties": {
+                "endDate": {
+                    "type": "string",
+                    "example": "2025-06-30"
+                },
+                "patientId": {
+                    "type": "string",
+                    "example": "patient-001-ABC12345"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "primary",
+                        "secondary",
+                        "staff"
+                    ],
+                    "example": "primary"
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "doctor-001-XyZ98765"
+                }
+            }
+        },
+        "dto.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "read"
+                },
+                "actorId": {
+                    "type": "string",
+                    "example": "doctor-001-XyZ98765"
+                },
+                "actorRole": {
+                    "type": "string",
+                    "example": "doctor"
+                },
+                "changedFields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "diagnosis",
+                        "treatment"
+                    ]
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "ipAddress": {
+                    "type": "string",
+                    "example": "10.0.0.5"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "patientId": {
+                    "type": "string",
+                    "example": "patient-001-ABC12345"
+                },
+                "prevHash": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "resourceId": {
+                    "type": "string",
+                    "example": "record-001-AbC12345"
+                },
+                "resourceType": {
+                    "type": "string",
+                    "example": "medical_record"
+                },
+                "sessionId": {
+                    "type": "string"
+                },
+                "userAgent": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AuditVerificationResponse": {
+            "type": "object",
+            "properties": {
+                "brokenAt": {
+                    "type": "integer"
+                },
+                "checked": {
+                    "type": "integer",
+                    "example": 1024
+                },
+                "lastHash": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.BreakGlassRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "minLength": 20,
+                    "example": "Pasien datang ke IGD dengan risiko bunuh diri, dokter jaga perlu riwayat terapi"
+                }
+            }
+        },
+        "dto.BreakGlassResponse": {
+            "type": "object",
+            "properties": {
+                "access": {
+                    "$ref": "#/definitions/dto.EmergencyAccessResponse"
+                },
+                "accessToken": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CareAssignmentMessageResponse": {
+            "type": "object",
+            "properties": {
+                "assignment": {
+                    "$ref": "#/definitions/dto.CareAssignmentResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CareAssignmentResponse": {
+            "type": "object",
+            "properties": {
+                "assignedById": {
+                    "type": "string"
+                },
+                "endDate": {
+                    "type": "string",
+                    "example": "2025-06-30"
+                },
+                "endReason": {
+                    "type": "string"
+                },
+                "endedById": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "care-001-AbC12345"
+                },
+                "patient": {
+                    "$ref": "#/definitions/dto.PatientMiniResponse"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "primary"
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "user": {
+                    "$ref": "#/definitions/dto.UserMiniResponse"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CareHandoverRequest": {
+            "type": "object",
+            "required": [
+                "fromUserId",
+                "reason",
+                "toUserId"
+            ],
+            "properties": {
+                "effectiveDate": {
+                    "type": "string",
+                    "example": "2025-02-01"
+                },
+                "fromUserId": {
+                    "type": "string",
+                    "example": "doctor-001-XyZ98765"
+                },
+                "patientIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Dokter resign"
+                },
+                "toUserId": {
+                    "type": "string",
+                    "example": "doctor-002-AbC12345"
+                }
+            }
+        },
+        "dto.CareHandoverResponse": {
+            "type": "object",
+            "properties": {
+                "assignments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CareAssignmentResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "transferred": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "dto.ChangeAppointmentStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "done",
+                        "cancelled"
+                    ]
+                }
+            }
+        },
+        "dto.ConsentDocumentMessageResponse": {
+            "type": "object",
+            "properties": {
+                "document": {
+                    "$ref": "#/definitions/dto.ConsentDocumentResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ConsentDocumentResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "consentdoc-001-AbC12345"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "ai_prediction"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.ConsentStatusResponse": {
+            "type": "object",
+            "properties": {
+                "consentId": {
+                    "type": "string"
+                },
+                "currentVersion": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "granted": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "grantedAt": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "ai_prediction"
+                },
+                "upToDate": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.ConsentSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "consents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ConsentStatusResponse"
+                    }
+                },
+                "patientId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ContactMessageResponse": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "$ref": "#/definitions/dto.ContactResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Contact created"
+                }
+            }
+        },
+        "dto.ContactResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "fullName": {
+                    "type": "string",
+                    "example": "Siti Aminah"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "contact-001-AbC12345"
+                },
+                "legalGuardian": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "mayReceiveClinicalInfo": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "patientId": {
+                    "type": "string",
+                    "example": "patient-001-ABC12345"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "08198765432"
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "redactedFields": {
+                    "description": "RedactedFields adalah field yang disamarkan atau dikosongkan untuk role ini",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "phone"
+                    ]
+                },
+                "relationship": {
+                    "type": "string",
+                    "example": "parent"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateAppointmentRequest": {
+            "type": "object",
+            "required": [
+                "patientId",
+                "scheduleAt",
+                "userId"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "scheduleAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateAppointmentResponse": {
+            "type": "object",
+            "properties": {
+                "appointment": {
+                    "$ref": "#/definitions/dto.AppointmentResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateAssessmentRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "patientId"
+            ],
+            "properties": {
+                "answers": {
+                    "description": "Raw JSON",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "date": {
+                    "description": "sesuai format date ISO",
+                    "type": "string"
+                },
+                "patientId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateAssessmentSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.AssessmentResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateConsentDocumentRequest": {
+            "type": "object",
+            "required": [
+                "body",
+                "title",
+                "type"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "Jawaban asesmen Anda akan dikirim ke layanan prediksi AI untuk membantu dokter..."
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Persetujuan Prediksi Berbantuan AI"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "treatment",
+                        "ai_prediction",
+                        "research",
+                        "data_sharing"
+                    ],
+                    "example": "ai_prediction"
+                }
+            }
+        },
+        "dto.CreateContactRequest": {
+            "type": "object",
+            "required": [
+                "fullName",
+                "phone",
+                "relationship"
+            ],
+            "properties": {
+                "fullName": {
+                    "type": "string",
+                    "example": "Siti Aminah"
+                },
+                "legalGuardian": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "mayReceiveClinicalInfo": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "08198765432"
+                },
+                "priority": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "relationship": {
+                    "type": "string",
+                    "enum": [
+                        "parent",
+                        "spouse",
+                        "child",
+                        "sibling",
+                        "relative",
+                        "friend",
+                        "caregiver",
+                        "other"
+                    ],
+                    "example": "parent"
+                }
+            }
+        },
+        "dto.CreateErasureRequest": {
+            "type": "object",
+            "required": [
+                "mode",
+                "reason"
+            ],
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "erase",
+                        "anonymize"
+                    ],
+                    "example": "erase"
+                },
+                "reason": {
+                    "type": "string",
+                    "minLength": 10,
+                    "example": "Permintaan tertulis pasien tanggal 10 Januari 2025"
+                }
+            }
+        },
+        "dto.CreateInvitationRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "dokter@example.com"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "doctor",
+                        "staff"
+                    ],
+                    "example": "doctor"
+                }
+            }
+        },
+        "dto.CreateInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "invitation": {
+                    "$ref": "#/definitions/dto.InvitationResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Invitation Created"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "opaque-invite-token"
+                }
+            }
+        },
+        "dto.CreateMedicalRecordRequest": {
+            "type": "object",
+            "required": [
+                "diagnosis",
+                "patientId",
+                "treatment",
+                "userId"
+            ],
+            "properties": {
+                "diagnosis": {
+                    "type": "string"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "treatment": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateMedicalRecordResponse": {
+            "type": "object",
+            "properties": {
+                "medicalRecord": {
+                    "$ref": "#/definitions/dto.MedicalRecordResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreatePatientRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "birthDate",
+                "fullName",
+                "gender",
+                "nik",
+                "phone"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Merdeka No. 10"
+                },
+                "birthDate": {
+                    "type": "string",
+                    "example": "2000-01-01"
+                },
+                "contacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CreateContactRequest"
+                    }
+                },
+                "emergencyContact": {
+                    "description": "deprecated, pakai contacts",
+                    "type": "string",
+                    "example": "08198765432"
+                },
+                "fullName": {
+                    "type": "string",
+                    "example": "Andi Saputra"
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "other"
+                    ],
+                    "example": "male"
+                },
+                "nik": {
+                    "type": "string",
+                    "example": "3201010101000001"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "08123456789"
+                }
+            }
+        },
+        "dto.CreatePatientResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Patient created successfully"
+                },
+                "patient": {
+                    "$ref": "#/definitions/dto.PatientResponse"
+                }
+            }
+        },
+        "dto.DataExportMessageResponse": {
+            "type": "object",
+            "properties": {
+                "export": {
+                    "$ref": "#/definitions/dto.DataExportResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DataExportResponse": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "type": "string"
+                },
+                "completedAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5f0c2b9e-3f0a-4f3e-9a55-1b2c3d4e5f60"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "requestedAt": {
+                    "type": "string"
+                },
+                "requestedById": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 48213
+                },
+                "status": {
+                    "type": "string",
+                    "example": "completed"
+                }
+            }
+        },
+        "dto.DecodeNIKRequest": {
+            "type": "object",
+            "required": [
+                "nik"
+            ],
+            "properties": {
+                "nik": {
+                    "type": "string",
+                    "example": "3201014101900001"
+                }
+            }
+        },
+        "dto.DecodeNIKResponse": {
+            "type": "object",
+            "properties": {
+                "birthDate": {
+                    "type": "string",
+                    "example": "1990-01-01"
+                },
+                "district": {
+                    "$ref": "#/definitions/dto.RegionResponse"
+                },
+                "gender": {
+                    "type": "string",
+                    "example": "female"
+                },
+                "province": {
+                    "$ref": "#/definitions/dto.RegionResponse"
+                },
+                "regency": {
+                    "$ref": "#/definitions/dto.RegionResponse"
+                }
+            }
+        },
+        "dto.DeleteBlockedResponse": {
+            "type": "object",
+            "properties": {
+                "blockers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DeleteBlocker"
+                    }
+                },
+                "error": {
+                    "type": "string",
+                    "example": "cannot delete: still referenced by 2 appointments"
+                }
+            }
+        },
+        "dto.DeleteBlocker": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "ids": {
+                    "description": "paling banyak 10 ID contoh",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "policy": {
+                    "description": "Policy restrict: data harus dihapus / dipindahkan dulu; reassign: kirim\nreassignTo untuk memindahkannya",
+                    "type": "string",
+                    "example": "reassign"
+                },
+                "relation": {
+                    "type": "string",
+                    "example": "appointments"
+                }
+            }
+        },
+        "dto.DuplicateCandidateMessageResponse": {
+            "type": "object",
+            "properties": {
+                "candidate": {
+                    "$ref": "#/definitions/dto.DuplicateCandidateResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DuplicateCandidateResponse": {
+            "type": "object",
+            "properties": {
+                "detectedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "8c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f"
+                },
+                "mergeId": {
+                    "type": "string"
+                },
+                "patientA": {
+                    "$ref": "#/definitions/dto.PatientResponse"
+                },
+                "patientB": {
+                    "$ref": "#/definitions/dto.PatientResponse"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "name",
+                        "birth_date"
+                    ]
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "reviewedById": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.92
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
+        "dto.DuplicateScanMessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "scan": {
+                    "$ref": "#/definitions/dto.DuplicateScanResponse"
+                }
+            }
+        },
+        "dto.DuplicateScanResponse": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "created": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "4b5c6d7e-8f90-4a1b-8c2d-3e4f5a6b7c8d"
+                },
+                "patients": {
+                    "type": "integer",
+                    "example": 1250
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "completed"
+                },
+                "threshold": {
+                    "type": "number",
+                    "example": 0.75
+                },
+                "trigger": {
+                    "type": "string",
+                    "example": "manual"
+                },
+                "triggeredById": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.EmergencyAccessMessageResponse": {
+            "type": "object",
+            "properties": {
+                "access": {
+                    "$ref": "#/definitions/dto.EmergencyAccessResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.EmergencyAccessResponse": {
+            "type": "object",
+            "properties": {
+                "auditReason": {
+                    "type": "string",
+                    "example": "emergency-access:0b8f3c1e-6a4d-4a57-9f6e-2f1d8c7b5a90"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "grantedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0b8f3c1e-6a4d-4a57-9f6e-2f1d8c7b5a90"
+                },
+                "ipAddress": {
+                    "type": "string"
+                },
+                "patient": {
+                    "$ref": "#/definitions/dto.PatientMiniResponse"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reviewNotes": {
+                    "type": "string"
+                },
+                "reviewStatus": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "reviewedById": {
+                    "type": "string"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "revokedById": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "user": {
+                    "$ref": "#/definitions/dto.UserMiniResponse"
+                },
+                "userAgent": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.EndCareRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "endDate": {
+                    "type": "string",
+                    "example": "2025-02-01"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Terapi selesai"
+                }
+            }
+        },
+        "dto.ErasureCertificateResponse": {
+            "type": "object",
+            "properties": {
+                "certificate": {
+                    "type": "object"
+                },
+                "hash": {
+                    "type": "string",
+                    "example": "3b1f...e9"
+                }
+            }
+        },
+        "dto.ErasureRequestMessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "request": {
+                    "$ref": "#/definitions/dto.ErasureRequestResponse"
+                }
+            }
+        },
+        "dto.ErasureRequestResponse": {
+            "type": "object",
+            "properties": {
+                "certificateHash": {
+                    "type": "string"
+                },
+                "completedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "8a7b6c5d-4e3f-4a2b-9c1d-0e9f8a7b6c5d"
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "erase"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "requestedAt": {
+                    "type": "string"
+                },
+                "requestedById": {
+                    "type": "string"
+                },
+                "reviewNotes": {
+                    "type": "string"
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "reviewedById": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Invalid input"
+                }
+            }
+        },
+        "dto.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "birthDate"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "does not match the birth date encoded in nik (DDMMYY 010100)"
+                }
+            }
+        },
+        "dto.GetAssessmentByIDSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.AssessmentResponse"
+                }
+            }
+        },
+        "dto.GrantConsentRequest": {
+            "type": "object",
+            "required": [
+                "documentId",
+                "method"
+            ],
+            "properties": {
+                "documentId": {
+                    "type": "string",
+                    "example": "consentdoc-001-AbC12345"
+                },
+                "grantedAt": {
+                    "type": "string",
+                    "example": "2025-01-15T09:30:00+07:00"
+                },
+                "guardianContactId": {
+                    "type": "string",
+                    "example": "contact-001-AbC12345"
+                },
+                "guardianName": {
+                    "type": "string",
+                    "example": "Siti Aminah (ibu)"
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "written",
+                        "verbal",
+                        "electronic"
+                    ],
+                    "example": "written"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Ditandatangani di ruang pendaftaran"
+                }
+            }
+        },
+        "dto.InvitationResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "string",
+                    "example": "admin-001-a1b2c3d4"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "dokter@example.com"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01j9z3x8k2m4n6p8q0r2s4t6v8"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "doctor"
+                },
+                "status": {
+                    "description": "pending, used, revoked, expired",
+                    "type": "string",
+                    "example": "pending"
+                },
+                "usedAt": {
+                    "type": "string"
+                },
+                "usedById": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LegacyBirthDateResponse": {
+            "type": "object",
+            "properties": {
+                "fullName": {
+                    "type": "string",
+                    "example": "Siti Aminah"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "patient-001-AbC12345"
+                },
+                "legacyValue": {
+                    "type": "string",
+                    "example": "15/08/1990"
+                },
+                "suggestedBirthDate": {
+                    "type": "string",
+                    "example": "1990-08-15"
+                }
+            }
+        },
+        "dto.LegalHoldMessageResponse": {
+            "type": "object",
+            "properties": {
+                "hold": {
+                    "$ref": "#/definitions/dto.LegalHoldResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LegalHoldResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "placedAt": {
+                    "type": "string"
+                },
+                "placedById": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "releaseReason": {
+                    "type": "string"
+                },
+                "releasedAt": {
+                    "type": "string"
+                },
+                "releasedById": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                }
+            }
+        },
+        "dto.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "refreshToken": {
+                    "type": "string",
+                    "example": "opaque-refresh-token"
+                },
+                "refreshTokenExpiresAt": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "jwt-token"
+                }
+            }
+        },
+        "dto.LoginUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "dto.MedicalRecordMiniPatient": {
+            "type": "object",
+            "properties": {
+                "fullName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MedicalRecordMiniUser": {
+            "type": "object",
+            "properties": {
+                "fullName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MedicalRecordResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "diagnosis": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "patient": {
+                    "$ref": "#/definitions/dto.MedicalRecordMiniPatient"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "redactedFields": {
+                    "description": "RedactedFields berisi diagnosis dan treatment jika user bukan dokter yang merawat",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "diagnosis",
+                        "treatment"
+                    ]
+                },
+                "treatment": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/dto.MedicalRecordMiniUser"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MedicalRecordTrashResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "diagnosis": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "patient": {
+                    "$ref": "#/definitions/dto.MedicalRecordMiniPatient"
+                },
+                "patientId": {
+                    "type": "string"
+                },
+                "redactedFields": {
+                    "description": "RedactedFields berisi diagnosis dan treatment jika user bukan dokter yang merawat",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "diagnosis",
+                        "treatment"
+                    ]
+                },
+                "treatment": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/dto.MedicalRecordMiniUser"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MergePatientsRequest": {
+            "type": "object",
+            "required": [
+                "mergedId",
+                "survivorId"
+            ],
+            "properties": {
+                "mergedId": {
+                    "type": "string",
+                    "example": "patient-042-XyZ98765"
+                },
+                "survivorId": {
+                    "type": "string",
+                    "example": "patient-001-AbC12345"
+                }
+            }
+        },
+        "dto.MessageDeleteAppointmentResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MessageDeleteAssesmentResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MessageDeleteMedicalRecordResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MessageDeletePatientResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "description": "Deleted adalah jumlah data per relasi yang ikut dihapus bersama pasien",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Patient deleted successfully"
+                }
+            }
+        },
+        "dto.MessageDeletePredictionResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MessageDeleteResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "User Deleted Successfully"
+                },
+                "reassigned": {
+                    "description": "Reassigned adalah jumlah data per relasi yang dipindahkan ke reassignTo",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.MessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Logged Out"
+                }
+            }
+        },
+        "dto.MessageUpdateStatusAppoinmentResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PaginatedAppointmentTrashResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AppointmentTrashResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedAppointmentsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AppointmentResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedAppointmentsWithPatientResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AppointmentWithPatientResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedAppointmentsWithUserResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AppointmentWithUserResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedAssessmentTrashResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AssessmentTrashResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedAssessmentsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AssessmentResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedAuditLogsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AuditLogResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedCareAssignmentsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CareAssignmentResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedConsentDocumentsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ConsentDocumentResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedContactsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ContactResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedDataExportsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DataExportResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedDuplicateCandidatesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DuplicateCandidateResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedDuplicateScansResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DuplicateScanResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedEmergencyAccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.EmergencyAccessResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pendingReview": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedErasureRequestsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ErasureRequestResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedInvitationsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.InvitationResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedLegacyBirthDatesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LegacyBirthDateResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedLegalHoldsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LegalHoldResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedMedicalRecordTrashResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MedicalRecordTrashResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedMedicalRecordsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MedicalRecordResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedPatientConsentsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PatientConsentResponse"
+                    }
                 },
-                "patient": {
-                    "$ref": "#/definitions/dto.PatientMiniResponse"
+                "limit": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "patientId": {
-                    "type": "string"
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
                 },
-                "scheduleAt": {
-                    "type": "string"
+                "page": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "status": {
+                "prevCursor": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "total": {
+                    "type": "integer",
+                    "example": 100
                 },
-                "user": {
-                    "$ref": "#/definitions/dto.UserMiniResponse"
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedPatientMergesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PatientMergeResponse"
+                    }
                 },
-                "userId": {
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
                 }
             }
         },
-        "dto.AppointmentWithPatientResponse": {
+        "dto.PaginatedPatientTrashResponse": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PatientTrashResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
                     "type": "string"
                 },
-                "date": {
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedPatientsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PatientResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
                     "type": "string"
                 },
-                "description": {
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedPredictionTrashResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PredictionTrashResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
                     "type": "string"
                 },
-                "id": {
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedPredictionsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PredictionResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
                     "type": "string"
                 },
-                "patient": {
-                    "$ref": "#/definitions/dto.PatientMiniResponse"
+                "total": {
+                    "type": "integer",
+                    "example": 100
                 },
-                "patientId": {
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedRetentionRunsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RetentionRunResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
                     "type": "string"
                 },
-                "time": {
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedTimelineResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TimelineEventResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.PaginatedUserTrashResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UserTrashResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prevCursor": {
                     "type": "string"
                 },
-                "userId": {
-                    "type": "string"
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
                 }
             }
         },
-        "dto.AppointmentWithUserResponse": {
+        "dto.PaginatedUsersResponse": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "notes": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UserResponse"
+                    }
                 },
-                "patientId": {
-                    "type": "string"
+                "limit": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "scheduleAt": {
-                    "type": "string"
+                "nextCursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"
                 },
-                "status": {
-                    "description": "🔧 tambahkan ini",
-                    "type": "string"
+                "page": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "updatedAt": {
+                "prevCursor": {
                     "type": "string"
                 },
-                "user": {
-                    "description": "🔧 ubah dari struct anonymous jadi struct yang sudah ada",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.UserMiniResponse"
-                        }
-                    ]
+                "total": {
+                    "type": "integer",
+                    "example": 100
                 },
-                "userId": {
-                    "type": "string"
+                "totalPages": {
+                    "type": "integer",
+                    "example": 10
                 }
             }
         },
-        "dto.AssessmentResponse": {
+        "dto.PatientConsentMessageResponse": {
             "type": "object",
             "properties": {
-                "answers": {
-                    "type": "object",
-                    "additionalProperties": true
+                "consent": {
+                    "$ref": "#/definitions/dto.PatientConsentResponse"
                 },
-                "createdAt": {
+                "message": {
                     "type": "string"
+                }
+            }
+        },
+        "dto.PatientConsentResponse": {
+            "type": "object",
+            "properties": {
+                "capturedBy": {
+                    "$ref": "#/definitions/dto.UserMiniResponse"
                 },
-                "date": {
+                "documentId": {
                     "type": "string"
                 },
-                "id": {
+                "documentTitle": {
                     "type": "string"
                 },
-                "patient": {
-                    "description": "dari relasi",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.PatientMiniResponse"
-                        }
-                    ]
+                "documentVersion": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "patientId": {
+                "grantedAt": {
                     "type": "string"
                 },
-                "prediction": {
-                    "description": "nullable",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.PredictionResponse"
-                        }
-                    ]
-                },
-                "updatedAt": {
+                "guardianContactId": {
                     "type": "string"
-                }
-            }
-        },
-        "dto.AssessmentUpdateResponse": {
-            "type": "object",
-            "properties": {
-                "answers": {
-                    "type": "object",
-                    "additionalProperties": true
                 },
-                "date": {
-                    "type": "string",
-                    "example": "2025-07-17T15:00:00Z"
+                "guardianName": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "string",
-                    "example": "d72f8239-09e7-4b23-a897-3ef6211f6b0a"
+                    "example": "consent-001-AbC12345"
                 },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2025-07-17T15:05:30Z"
-                }
-            }
-        },
-        "dto.ChangeAppointmentStatusRequest": {
-            "type": "object",
-            "required": [
-                "status"
-            ],
-            "properties": {
-                "status": {
+                "method": {
                     "type": "string",
-                    "enum": [
-                        "pending",
-                        "done",
-                        "cancelled"
-                    ]
-                }
-            }
-        },
-        "dto.CreateAppointmentRequest": {
-            "type": "object",
-            "required": [
-                "patientId",
-                "scheduleAt",
-                "userId"
-            ],
-            "properties": {
+                    "example": "written"
+                },
                 "notes": {
                     "type": "string"
                 },
                 "patientId": {
                     "type": "string"
                 },
-                "scheduleAt": {
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "ai_prediction"
+                },
+                "withdrawalReason": {
                     "type": "string"
                 },
-                "userId": {
+                "withdrawnAt": {
+                    "type": "string"
+                },
+                "withdrawnById": {
                     "type": "string"
                 }
             }
         },
-        "dto.CreateAppointmentResponse": {
+        "dto.PatientMergeMessageResponse": {
             "type": "object",
             "properties": {
-                "appointment": {
-                    "$ref": "#/definitions/dto.AppointmentResponse"
+                "merge": {
+                    "$ref": "#/definitions/dto.PatientMergeResponse"
                 },
                 "message": {
                     "type": "string"
+                },
+                "restored": {
+                    "description": "Restored adalah jumlah baris per tabel yang dikembalikan saat undo",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "dto.CreateAssessmentRequest": {
+        "dto.PatientMergeResponse": {
             "type": "object",
-            "required": [
-                "date",
-                "patientId"
-            ],
             "properties": {
-                "answers": {
-                    "description": "Raw JSON",
-                    "type": "object",
-                    "additionalProperties": true
+                "candidateId": {
+                    "type": "string"
                 },
-                "date": {
-                    "description": "sesuai format date ISO",
+                "id": {
+                    "type": "string",
+                    "example": "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+                },
+                "mergedAt": {
                     "type": "string"
                 },
-                "patientId": {
+                "mergedById": {
                     "type": "string"
-                }
-            }
-        },
-        "dto.CreateAssessmentSuccessResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/dto.AssessmentResponse"
                 },
-                "message": {
+                "mergedId": {
+                    "type": "string",
+                    "example": "patient-042-XyZ98765"
+                },
+                "moved": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "survivorId": {
+                    "type": "string",
+                    "example": "patient-001-AbC12345"
+                },
+                "undoneAt": {
+                    "type": "string"
+                },
+                "undoneById": {
                     "type": "string"
                 }
             }
         },
-        "dto.CreateMedicalRecordRequest": {
+        "dto.PatientMiniResponse": {
             "type": "object",
-            "required": [
-                "diagnosis",
-                "patientId",
-                "treatment",
-                "userId"
-            ],
             "properties": {
-                "diagnosis": {
-                    "type": "string"
+                "age": {
+                    "type": "integer"
                 },
-                "patientId": {
+                "birthDate": {
                     "type": "string"
                 },
-                "treatment": {
+                "fullName": {
                     "type": "string"
                 },
-                "userId": {
+                "gender": {
                     "type": "string"
-                }
-            }
-        },
-        "dto.CreateMedicalRecordResponse": {
-            "type": "object",
-            "properties": {
-                "medicalRecord": {
-                    "$ref": "#/definitions/dto.MedicalRecordResponse"
                 },
-                "message": {
+                "id": {
                     "type": "string"
+                },
+                "isMinor": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.CreatePatientRequest": {
+        "dto.PatientResponse": {
             "type": "object",
-            "required": [
-                "address",
-                "birthDate",
-                "emergencyContact",
-                "fullName",
-                "gender",
-                "nik",
-                "phone"
-            ],
             "properties": {
                 "address": {
                     "type": "string",
                     "example": "Jl. Merdeka No. 10"
                 },
+                "age": {
+                    "description": "Age adalah usia dalam tahun penuh, null jika tanggal lahir belum diketahui",
+                    "type": "integer",
+                    "example": 25
+                },
                 "birthDate": {
                     "type": "string",
                     "example": "2000-01-01"
@@ -2316,454 +11360,628 @@ const docTemplate = `{
                 },
                 "gender": {
                     "type": "string",
-                    "enum": [
-                        "male",
-                        "female",
-                        "other"
-                    ],
                     "example": "male"
                 },
-                "nik": {
-                    "type": "string",
-                    "example": "3201012345678900"
-                },
-                "phone": {
-                    "type": "string",
-                    "example": "08123456789"
-                }
-            }
-        },
-        "dto.CreatePatientResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
+                "id": {
                     "type": "string",
-                    "example": "Patient created successfully"
+                    "example": "patient-001-ABC12345"
                 },
-                "patient": {
-                    "$ref": "#/definitions/dto.PatientResponse"
-                }
-            }
-        },
-        "dto.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "Invalid input"
-                }
-            }
-        },
-        "dto.GetAssessmentByIDSuccessResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/dto.AssessmentResponse"
+                "isMinor": {
+                    "description": "IsMinor bernilai true untuk pasien di bawah 18 tahun, persetujuannya\ndiberikan oleh wali",
+                    "type": "boolean",
+                    "example": false
+                },
+                "nik": {
+                    "type": "string",
+                    "example": "3201010101000001"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "08123456789"
+                },
+                "redactedFields": {
+                    "description": "RedactedFields adalah field yang disamarkan atau dikosongkan untuk role ini",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "nik"
+                    ]
                 }
             }
         },
-        "dto.LoginResponse": {
+        "dto.PatientTrashResponse": {
             "type": "object",
             "properties": {
-                "email": {
+                "address": {
                     "type": "string",
-                    "example": "john@example.com"
+                    "example": "Jl. Merdeka No. 10"
                 },
-                "role": {
+                "age": {
+                    "description": "Age adalah usia dalam tahun penuh, null jika tanggal lahir belum diketahui",
+                    "type": "integer",
+                    "example": 25
+                },
+                "birthDate": {
                     "type": "string",
-                    "example": "admin"
+                    "example": "2000-01-01"
                 },
-                "token": {
+                "deletedAt": {
+                    "type": "string"
+                },
+                "emergencyContact": {
                     "type": "string",
-                    "example": "jwt-token"
+                    "example": "08198765432"
+                },
+                "fullName": {
+                    "type": "string",
+                    "example": "Andi Saputra"
+                },
+                "gender": {
+                    "type": "string",
+                    "example": "male"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "patient-001-ABC12345"
+                },
+                "isMinor": {
+                    "description": "IsMinor bernilai true untuk pasien di bawah 18 tahun, persetujuannya\ndiberikan oleh wali",
+                    "type": "boolean",
+                    "example": false
+                },
+                "nik": {
+                    "type": "string",
+                    "example": "3201010101000001"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "08123456789"
+                },
+                "redactedFields": {
+                    "description": "RedactedFields adalah field yang disamarkan atau dikosongkan untuk role ini",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "nik"
+                    ]
                 }
             }
         },
-        "dto.LoginUserRequest": {
+        "dto.PlaceLegalHoldRequest": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "reason"
             ],
             "properties": {
-                "email": {
+                "reason": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "Sengketa medis yang sedang berjalan"
                 },
-                "password": {
+                "reference": {
                     "type": "string",
-                    "example": "password123"
+                    "example": "No. 123/Pdt.G/2025/PN.Jkt.Sel"
                 }
             }
         },
-        "dto.MedicalRecordMiniPatient": {
+        "dto.PredictionResponse": {
             "type": "object",
             "properties": {
-                "fullName": {
+                "assessmentId": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.MedicalRecordMiniUser": {
-            "type": "object",
-            "properties": {
-                "fullName": {
+                "createdAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "role": {
+                "probabilityScore": {
+                    "type": "number"
+                },
+                "resultLabel": {
+                    "type": "string"
+                },
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "dto.MedicalRecordResponse": {
+        "dto.PredictionTrashResponse": {
             "type": "object",
             "properties": {
+                "assessmentId": {
+                    "type": "string"
+                },
                 "createdAt": {
                     "type": "string"
                 },
-                "diagnosis": {
+                "deletedAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "patient": {
-                    "$ref": "#/definitions/dto.MedicalRecordMiniPatient"
-                },
-                "patientId": {
-                    "type": "string"
+                "probabilityScore": {
+                    "type": "number"
                 },
-                "treatment": {
+                "resultLabel": {
                     "type": "string"
                 },
                 "updatedAt": {
                     "type": "string"
-                },
-                "user": {
-                    "$ref": "#/definitions/dto.MedicalRecordMiniUser"
-                },
-                "userId": {
-                    "type": "string"
                 }
             }
         },
-        "dto.MessageDeleteAppointmentResponse": {
+        "dto.QueryErrorResponse": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "error": {
+                    "type": "string",
+                    "example": "invalid query parameter: unknown sort field"
+                },
+                "param": {
+                    "type": "string",
+                    "example": "sort"
                 }
             }
         },
-        "dto.MessageDeleteAssesmentResponse": {
+        "dto.RefreshTokenRequest": {
             "type": "object",
+            "required": [
+                "refreshToken"
+            ],
             "properties": {
-                "message": {
-                    "type": "string"
+                "refreshToken": {
+                    "type": "string",
+                    "example": "opaque-refresh-token"
                 }
             }
         },
-        "dto.MessageDeleteMedicalRecordResponse": {
+        "dto.RegionResponse": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "code": {
+                    "type": "string",
+                    "example": "3201"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Kabupaten Bogor"
                 }
             }
         },
-        "dto.MessageDeletePatientResponse": {
+        "dto.RegisterUserRequest": {
             "type": "object",
+            "required": [
+                "email",
+                "fullName",
+                "password"
+            ],
             "properties": {
-                "message": {
+                "email": {
                     "type": "string",
-                    "example": "Patient deleted successfully"
+                    "example": "john@example.com"
+                },
+                "fullName": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "inviteToken": {
+                    "type": "string",
+                    "example": "opaque-invite-token"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "password123"
                 }
             }
         },
-        "dto.MessageDeletePredictionResponse": {
+        "dto.RegisterUserResponse": {
             "type": "object",
             "properties": {
                 "message": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "User Registered"
+                },
+                "user": {
+                    "$ref": "#/definitions/dto.UserResponse"
                 }
             }
         },
-        "dto.MessageDeleteResponse": {
+        "dto.RejectErasureRequest": {
             "type": "object",
+            "required": [
+                "notes"
+            ],
             "properties": {
-                "message": {
+                "notes": {
                     "type": "string",
-                    "example": "User Deleted Successfully"
+                    "example": "Pemohon bukan pasien atau walinya"
                 }
             }
         },
-        "dto.MessageUpdateStatusAppoinmentResponse": {
+        "dto.ReleaseLegalHoldRequest": {
             "type": "object",
+            "required": [
+                "reason"
+            ],
             "properties": {
-                "message": {
-                    "type": "string"
+                "reason": {
+                    "type": "string",
+                    "example": "Perkara sudah berkekuatan hukum tetap"
                 }
             }
         },
-        "dto.PaginatedAppointmentsResponse": {
+        "dto.RestoreResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.AppointmentResponse"
-                    }
-                },
-                "limit": {
-                    "type": "integer"
-                },
-                "page": {
-                    "type": "integer"
-                },
-                "total": {
-                    "type": "integer"
+                "message": {
+                    "type": "string",
+                    "example": "Patient restored successfully"
                 },
-                "totalPages": {
-                    "type": "integer"
+                "restored": {
+                    "description": "Restored adalah jumlah baris yang dipulihkan per tabel, termasuk data\nanak jika cascade",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "dto.PaginatedAssessmentsResponse": {
+        "dto.RetentionPolicyResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.AssessmentResponse"
-                    }
-                },
-                "limit": {
-                    "type": "integer"
+                "action": {
+                    "type": "string",
+                    "example": "purge"
                 },
-                "page": {
-                    "type": "integer"
+                "basis": {
+                    "type": "string",
+                    "example": "deleted"
                 },
-                "total": {
-                    "type": "integer"
+                "entity": {
+                    "type": "string",
+                    "example": "appointment"
                 },
-                "totalPages": {
-                    "type": "integer"
+                "retainFor": {
+                    "type": "string",
+                    "example": "2y"
                 }
             }
         },
-        "dto.PaginatedMedicalRecordsResponse": {
+        "dto.RetentionResultResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.MedicalRecordResponse"
-                    }
-                },
-                "limit": {
-                    "type": "integer"
-                },
-                "page": {
-                    "type": "integer"
+                "action": {
+                    "type": "string",
+                    "example": "purge"
                 },
-                "total": {
-                    "type": "integer"
+                "basis": {
+                    "type": "string",
+                    "example": "deleted"
                 },
-                "totalPages": {
-                    "type": "integer"
-                }
-            }
-        },
-        "dto.PaginatedPatientsResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.PatientResponse"
-                    }
+                "cutoff": {
+                    "type": "string"
                 },
-                "limit": {
+                "eligible": {
                     "type": "integer",
-                    "example": 10
+                    "example": 42
                 },
-                "page": {
+                "entity": {
+                    "type": "string",
+                    "example": "appointment"
+                },
+                "onHold": {
                     "type": "integer",
                     "example": 1
                 },
-                "total": {
+                "processed": {
                     "type": "integer",
-                    "example": 100
+                    "example": 42
                 },
-                "totalPages": {
-                    "type": "integer",
-                    "example": 10
+                "retainFor": {
+                    "type": "string",
+                    "example": "2y"
+                },
+                "sampleIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.RetentionRunMessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "run": {
+                    "$ref": "#/definitions/dto.RetentionRunResponse"
                 }
             }
         },
-        "dto.PaginatedPredictionsResponse": {
+        "dto.RetentionRunResponse": {
             "type": "object",
             "properties": {
-                "data": {
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "4b5c6d7e-8f90-4a1b-8c2d-3e4f5a6b7c8d"
+                },
+                "reportRunId": {
+                    "type": "string"
+                },
+                "results": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.PredictionResponse"
+                        "$ref": "#/definitions/dto.RetentionResultResponse"
                     }
                 },
-                "limit": {
-                    "type": "integer"
+                "startedAt": {
+                    "type": "string"
                 },
-                "page": {
-                    "type": "integer"
+                "status": {
+                    "type": "string",
+                    "example": "completed"
                 },
-                "total": {
-                    "type": "integer"
+                "trigger": {
+                    "type": "string",
+                    "example": "scheduled"
                 },
-                "totalPages": {
-                    "type": "integer"
+                "triggeredById": {
+                    "type": "string"
                 }
             }
         },
-        "dto.PaginatedUsersResponse": {
+        "dto.RetentionSettingsResponse": {
             "type": "object",
             "properties": {
-                "data": {
+                "batchSize": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "24h0m0s"
+                },
+                "policies": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.UserResponse"
+                        "$ref": "#/definitions/dto.RetentionPolicyResponse"
                     }
+                }
+            }
+        },
+        "dto.ReviewEmergencyAccessRequest": {
+            "type": "object",
+            "required": [
+                "outcome"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "example": "Sesuai catatan IGD malam itu"
                 },
-                "limit": {
-                    "type": "integer",
-                    "example": 10
-                },
-                "page": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "total": {
-                    "type": "integer",
-                    "example": 100
+                "outcome": {
+                    "type": "string",
+                    "enum": [
+                        "justified",
+                        "unjustified"
+                    ],
+                    "example": "justified"
+                }
+            }
+        },
+        "dto.RevokeSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Sessions Revoked"
                 },
-                "totalPages": {
+                "revoked": {
                     "type": "integer",
-                    "example": 10
+                    "example": 2
                 }
             }
         },
-        "dto.PatientMiniResponse": {
+        "dto.StartRetentionRunRequest": {
             "type": "object",
+            "required": [
+                "dryRun"
+            ],
             "properties": {
-                "birthDate": {
+                "dryRun": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.TimelineAppointmentResponse": {
+            "type": "object",
+            "properties": {
+                "notes": {
                     "type": "string"
                 },
-                "fullName": {
+                "scheduleAt": {
                     "type": "string"
                 },
-                "gender": {
-                    "type": "string"
+                "status": {
+                    "type": "string",
+                    "example": "pending"
                 },
-                "id": {
+                "user": {
+                    "$ref": "#/definitions/dto.UserMiniResponse"
+                }
+            }
+        },
+        "dto.TimelineAssessmentResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
                     "type": "string"
                 }
             }
         },
-        "dto.PatientResponse": {
+        "dto.TimelineEventResponse": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string",
-                    "example": "Jl. Merdeka No. 10"
+                "appointment": {
+                    "$ref": "#/definitions/dto.TimelineAppointmentResponse"
                 },
-                "birthDate": {
-                    "type": "string",
-                    "example": "2000-01-01"
+                "assessment": {
+                    "$ref": "#/definitions/dto.TimelineAssessmentResponse"
                 },
-                "emergencyContact": {
+                "id": {
                     "type": "string",
-                    "example": "08198765432"
+                    "example": "assessment_taken:ASM-20250101-0001"
                 },
-                "fullName": {
-                    "type": "string",
-                    "example": "Andi Saputra"
+                "medicalRecord": {
+                    "$ref": "#/definitions/dto.TimelineMedicalRecordResponse"
                 },
-                "gender": {
-                    "type": "string",
-                    "example": "male"
+                "occurredAt": {
+                    "type": "string"
                 },
-                "id": {
+                "prediction": {
+                    "$ref": "#/definitions/dto.TimelinePredictionResponse"
+                },
+                "resourceId": {
                     "type": "string",
-                    "example": "patient-001-ABC12345"
+                    "example": "ASM-20250101-0001"
                 },
-                "nik": {
+                "resourceType": {
                     "type": "string",
-                    "example": "3201012345678900"
+                    "example": "assessment"
                 },
-                "phone": {
+                "statusChange": {
+                    "$ref": "#/definitions/dto.TimelineStatusChangeResponse"
+                },
+                "type": {
                     "type": "string",
-                    "example": "08123456789"
+                    "example": "assessment_taken"
                 }
             }
         },
-        "dto.PredictionResponse": {
+        "dto.TimelineMedicalRecordResponse": {
             "type": "object",
             "properties": {
-                "assessmentId": {
+                "diagnosis": {
                     "type": "string"
                 },
-                "createdAt": {
-                    "type": "string"
+                "redactedFields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "id": {
+                "treatment": {
                     "type": "string"
                 },
+                "user": {
+                    "$ref": "#/definitions/dto.MedicalRecordMiniUser"
+                }
+            }
+        },
+        "dto.TimelinePredictionResponse": {
+            "type": "object",
+            "properties": {
+                "assessmentId": {
+                    "type": "string",
+                    "example": "ASM-20250101-0001"
+                },
                 "probabilityScore": {
-                    "type": "number"
+                    "type": "number",
+                    "example": 0.82
                 },
                 "resultLabel": {
-                    "type": "string"
-                },
-                "updatedAt": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "moderate"
                 }
             }
         },
-        "dto.RegisterUserRequest": {
+        "dto.TimelineStatusChangeResponse": {
             "type": "object",
             "properties": {
-                "email": {
+                "changedBy": {
+                    "description": "kosong untuk riwayat lama",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.MedicalRecordMiniUser"
+                        }
+                    ]
+                },
+                "fromStatus": {
                     "type": "string",
-                    "example": "john@example.com"
+                    "example": "pending"
                 },
-                "fullName": {
+                "toStatus": {
                     "type": "string",
-                    "example": "John Doe"
+                    "example": "done"
+                }
+            }
+        },
+        "dto.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
                 },
-                "password": {
+                "refreshToken": {
                     "type": "string",
-                    "example": "password123"
+                    "example": "opaque-refresh-token"
+                },
+                "refreshTokenExpiresAt": {
+                    "type": "string"
                 },
                 "role": {
                     "type": "string",
                     "example": "admin"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "jwt-token"
                 }
             }
         },
-        "dto.RegisterUserResponse": {
+        "dto.TransferCareRequest": {
             "type": "object",
+            "required": [
+                "reason",
+                "toUserId"
+            ],
             "properties": {
-                "message": {
+                "effectiveDate": {
                     "type": "string",
-                    "example": "User Registered"
+                    "example": "2025-02-01"
                 },
-                "user": {
-                    "$ref": "#/definitions/dto.UserResponse"
+                "reason": {
+                    "type": "string",
+                    "example": "Pasien minta ganti dokter"
+                },
+                "toUserId": {
+                    "type": "string",
+                    "example": "doctor-002-AbC12345"
                 }
             }
         },
@@ -2821,6 +12039,64 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.UpdateContactRequest": {
+            "type": "object",
+            "properties": {
+                "fullName": {
+                    "type": "string",
+                    "example": "Siti Aminah"
+                },
+                "legalGuardian": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "mayReceiveClinicalInfo": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "08198765432"
+                },
+                "priority": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                },
+                "relationship": {
+                    "type": "string",
+                    "enum": [
+                        "parent",
+                        "spouse",
+                        "child",
+                        "sibling",
+                        "relative",
+                        "friend",
+                        "caregiver",
+                        "other"
+                    ],
+                    "example": "parent"
+                }
+            }
+        },
+        "dto.UpdateContactResponse": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "$ref": "#/definitions/dto.ContactResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Contact updated"
+                },
+                "updatedFields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UpdatedField"
+                    }
+                }
+            }
+        },
         "dto.UpdateMedicalRecordRequest": {
             "type": "object",
             "required": [
@@ -2870,6 +12146,7 @@ const docTemplate = `{
                     "example": "1980-12-31"
                 },
                 "emergencyContact": {
+                    "description": "deprecated, pakai endpoint kontak",
                     "type": "string",
                     "example": "081987654321"
                 },
@@ -2883,7 +12160,7 @@ const docTemplate = `{
                 },
                 "nik": {
                     "type": "string",
-                    "example": "3201012345678901"
+                    "example": "3201013112800001"
                 },
                 "phone": {
                     "type": "string",
@@ -2978,6 +12255,57 @@ const docTemplate = `{
                     "example": "admin"
                 }
             }
+        },
+        "dto.UserTrashResponse": {
+            "type": "object",
+            "properties": {
+                "deletedAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "fullName": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "admin-001-a1b2c3d4"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "dto.ValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid patient data"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldError"
+                    }
+                }
+            }
+        },
+        "dto.WithdrawConsentRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Pasien tidak ingin data dikirim ke layanan AI"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -23,11 +23,8 @@ type CreateAppointmentResponse struct {
 }
 
 type PaginatedAppointmentsResponse struct {
-	Data       []AppointmentResponse `json:"data"`
-	Total      int                   `json:"total"`
-	Page       int                   `json:"page"`
-	Limit      int                   `json:"limit"`
-	TotalPages int                   `json:"totalPages"`
+	Data []AppointmentResponse `json:"data"`
+	Pagination
 }
 
 type UpdatedField struct {
@@ -52,6 +49,11 @@ type AppointmentWithUserResponse struct {
 	User       UserMiniResponse `json:"user"` // 🔧 ubah dari struct anonymous jadi struct yang sudah ada
 }
 
+type PaginatedAppointmentsWithUserResponse struct {
+	Data []AppointmentWithUserResponse `json:"data"`
+	Pagination
+}
+
 type AppointmentWithPatientResponse struct {
 	ID          string                `json:"id"`
 	PatientID   string                `json:"patientId"`
@@ -64,3 +66,7 @@ type AppointmentWithPatientResponse struct {
 	Patient     PatientMiniResponse   `json:"patient"`
 }
 
+type PaginatedAppointmentsWithPatientResponse struct {
+	Data []AppointmentWithPatientResponse `json:"data"`
+	Pagination
+}
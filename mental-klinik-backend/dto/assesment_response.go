@@ -24,11 +24,8 @@ type CreateAssessmentSuccessResponse struct {
 
 // Untuk list paginated response
 type PaginatedAssessmentsResponse struct {
-	Data       []AssessmentResponse `json:"data"`
-	Total      int                  `json:"total"`
-	Page       int                  `json:"page"`
-	Limit      int                  `json:"limit"`
-	TotalPages int                  `json:"totalPages"`
+	Data []AssessmentResponse `json:"data"`
+	Pagination
 }
 
 type GetAssessmentByIDSuccessResponse struct {
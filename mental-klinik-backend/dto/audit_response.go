@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+type AuditLogResponse struct {
+	ID            int64     `json:"id" example:"42"`
+	OccurredAt    time.Time `json:"occurredAt"`
+	ActorID       string    `json:"actorId" example:"doctor-001-XyZ98765"`
+	ActorRole     string    `json:"actorRole" example:"doctor"`
+	SessionID     string    `json:"sessionId"`
+	Action        string    `json:"action" example:"read"`
+	ResourceType  string    `json:"resourceType" example:"medical_record"`
+	ResourceID    string    `json:"resourceId" example:"record-001-AbC12345"`
+	PatientID     string    `json:"patientId" example:"patient-001-ABC12345"`
+	ChangedFields []string  `json:"changedFields" example:"diagnosis,treatment"`
+	Reason        string    `json:"reason,omitempty"`
+	IPAddress     string    `json:"ipAddress" example:"10.0.0.5"`
+	UserAgent     string    `json:"userAgent"`
+	PrevHash      string    `json:"prevHash"`
+	Hash          string    `json:"hash"`
+}
+
+type PaginatedAuditLogsResponse struct {
+	Data []AuditLogResponse `json:"data"`
+	Pagination
+}
+
+type AuditVerificationResponse struct {
+	Valid    bool   `json:"valid" example:"true"`
+	Checked  int64  `json:"checked" example:"1024"`
+	BrokenAt *int64 `json:"brokenAt,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	LastHash string `json:"lastHash"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+// AssignCareTeamRequest menambahkan user ke tim perawatan pasien. Klinisi
+// (primary/secondary) harus dokter, role staff harus user staff. Tanggal
+// kosong berarti mulai hari ini dan tanpa tanggal selesai.
+type AssignCareTeamRequest struct {
+	PatientID string `json:"patientId" example:"patient-001-ABC12345" binding:"required"`
+	UserID    string `json:"userId" example:"doctor-001-XyZ98765" binding:"required"`
+	Role      string `json:"role" example:"primary" binding:"required,oneof=primary secondary staff"`
+	StartDate string `json:"startDate" example:"2025-01-01" binding:"omitempty,datetime=2006-01-02"`
+	EndDate   string `json:"endDate" example:"2025-06-30" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// TransferCareRequest memindahkan penugasan ke user lain mulai tanggal efektif
+type TransferCareRequest struct {
+	ToUserID      string `json:"toUserId" example:"doctor-002-AbC12345" binding:"required"`
+	EffectiveDate string `json:"effectiveDate" example:"2025-02-01" binding:"omitempty,datetime=2006-01-02"`
+	Reason        string `json:"reason" example:"Pasien minta ganti dokter" binding:"required"`
+}
+
+// EndCareRequest mengakhiri penugasan, endDate kosong berarti hari ini
+type EndCareRequest struct {
+	EndDate string `json:"endDate" example:"2025-02-01" binding:"omitempty,datetime=2006-01-02"`
+	Reason  string `json:"reason" example:"Terapi selesai" binding:"required"`
+}
+
+// CareHandoverRequest memindahkan semua penugasan yang belum berakhir milik
+// satu user ke user lain, misalnya saat klinisi keluar. PatientIDs opsional
+// untuk membatasi ke pasien tertentu.
+type CareHandoverRequest struct {
+	FromUserID    string   `json:"fromUserId" example:"doctor-001-XyZ98765" binding:"required"`
+	ToUserID      string   `json:"toUserId" example:"doctor-002-AbC12345" binding:"required"`
+	PatientIDs    []string `json:"patientIds"`
+	EffectiveDate string   `json:"effectiveDate" example:"2025-02-01" binding:"omitempty,datetime=2006-01-02"`
+	Reason        string   `json:"reason" example:"Dokter resign" binding:"required"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+type CareAssignmentResponse struct {
+	ID           string              `json:"id" example:"care-001-AbC12345"`
+	PatientID    string              `json:"patientId"`
+	UserID       string              `json:"userId"`
+	Role         string              `json:"role" example:"primary"`
+	Status       string              `json:"status" example:"active"`
+	StartDate    string              `json:"startDate" example:"2025-01-01"`
+	EndDate      *string             `json:"endDate" example:"2025-06-30"`
+	EndReason    string              `json:"endReason,omitempty"`
+	AssignedByID string              `json:"assignedById"`
+	EndedByID    *string             `json:"endedById,omitempty"`
+	Patient      PatientMiniResponse `json:"patient"`
+	User         UserMiniResponse    `json:"user"`
+}
+
+type CareAssignmentMessageResponse struct {
+	Message    string                 `json:"message"`
+	Assignment CareAssignmentResponse `json:"assignment"`
+}
+
+type PaginatedCareAssignmentsResponse struct {
+	Data []CareAssignmentResponse `json:"data"`
+	Pagination
+}
+
+type CareHandoverResponse struct {
+	Message     string                   `json:"message"`
+	Transferred int                      `json:"transferred" example:"12"`
+	Assignments []CareAssignmentResponse `json:"assignments"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+// CreateConsentDocumentRequest menerbitkan versi baru dokumen persetujuan.
+// Versi diisi otomatis (versi terakhir jenis tersebut + 1).
+type CreateConsentDocumentRequest struct {
+	Type  string `json:"type" example:"ai_prediction" binding:"required,oneof=treatment ai_prediction research data_sharing"`
+	Title string `json:"title" example:"Persetujuan Prediksi Berbantuan AI" binding:"required"`
+	Body  string `json:"body" example:"Jawaban asesmen Anda akan dikirim ke layanan prediksi AI untuk membantu dokter..." binding:"required"`
+}
+
+// GrantConsentRequest mencatat persetujuan pasien atas versi terbaru sebuah
+// dokumen. GrantedAt kosong berarti sekarang; isi jika persetujuan tertulis
+// ditandatangani lebih dulu. Jika pasien masih di bawah umur pada saat
+// persetujuan diberikan, wali wajib diisi: guardianContactId (kontak pasien
+// yang ditandai wali sah, namanya dipakai jika guardianName kosong) atau
+// guardianName.
+type GrantConsentRequest struct {
+	DocumentID        string `json:"documentId" example:"consentdoc-001-AbC12345" binding:"required"`
+	Method            string `json:"method" example:"written" binding:"required,oneof=written verbal electronic"`
+	GrantedAt         string `json:"grantedAt" example:"2025-01-15T09:30:00+07:00" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Notes             string `json:"notes" example:"Ditandatangani di ruang pendaftaran"`
+	GuardianName      string `json:"guardianName" example:"Siti Aminah (ibu)"`
+	GuardianContactID string `json:"guardianContactId" example:"contact-001-AbC12345"`
+}
+
+// WithdrawConsentRequest menarik persetujuan pasien
+type WithdrawConsentRequest struct {
+	Reason string `json:"reason" example:"Pasien tidak ingin data dikirim ke layanan AI" binding:"required"`
+}
@@ -0,0 +1,70 @@
+package dto
+
+import "time"
+
+type ConsentDocumentResponse struct {
+	ID          string    `json:"id" example:"consentdoc-001-AbC12345"`
+	Type        string    `json:"type" example:"ai_prediction"`
+	Version     int       `json:"version" example:"2"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	CreatedByID string    `json:"createdById"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type ConsentDocumentMessageResponse struct {
+	Message  string                  `json:"message"`
+	Document ConsentDocumentResponse `json:"document"`
+}
+
+type PaginatedConsentDocumentsResponse struct {
+	Data []ConsentDocumentResponse `json:"data"`
+	Pagination
+}
+
+type PatientConsentResponse struct {
+	ID                string           `json:"id" example:"consent-001-AbC12345"`
+	PatientID         string           `json:"patientId"`
+	Type              string           `json:"type" example:"ai_prediction"`
+	Status            string           `json:"status" example:"active"`
+	DocumentID        string           `json:"documentId"`
+	DocumentVersion   int              `json:"documentVersion" example:"2"`
+	DocumentTitle     string           `json:"documentTitle"`
+	Method            string           `json:"method" example:"written"`
+	Notes             string           `json:"notes,omitempty"`
+	GuardianName      string           `json:"guardianName,omitempty"`
+	GuardianContactID *string          `json:"guardianContactId,omitempty"`
+	GrantedAt         time.Time        `json:"grantedAt"`
+	CapturedBy        UserMiniResponse `json:"capturedBy"`
+	WithdrawnAt       *time.Time       `json:"withdrawnAt,omitempty"`
+	WithdrawnByID     *string          `json:"withdrawnById,omitempty"`
+	WithdrawalReason  string           `json:"withdrawalReason,omitempty"`
+}
+
+type PatientConsentMessageResponse struct {
+	Message string                 `json:"message"`
+	Consent PatientConsentResponse `json:"consent"`
+}
+
+type PaginatedPatientConsentsResponse struct {
+	Data []PatientConsentResponse `json:"data"`
+	Pagination
+}
+
+// ConsentStatusResponse adalah status satu jenis persetujuan pasien.
+// UpToDate bernilai false jika persetujuan aktif diberikan atas versi dokumen
+// yang sudah digantikan (tetap berlaku sampai ditarik).
+type ConsentStatusResponse struct {
+	Type           string     `json:"type" example:"ai_prediction"`
+	Granted        bool       `json:"granted" example:"true"`
+	ConsentID      string     `json:"consentId,omitempty"`
+	Version        int        `json:"version,omitempty" example:"1"`
+	CurrentVersion int        `json:"currentVersion" example:"2"`
+	UpToDate       bool       `json:"upToDate" example:"false"`
+	GrantedAt      *time.Time `json:"grantedAt,omitempty"`
+}
+
+type ConsentSummaryResponse struct {
+	PatientID string                  `json:"patientId"`
+	Consents  []ConsentStatusResponse `json:"consents"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+// CreateContactRequest menambahkan kontak darurat atau wali pasien. Priority
+// kosong berarti setelah kontak terakhir; legalGuardian menandai wali sah
+// yang menyetujui tindakan untuk pasien di bawah umur.
+type CreateContactRequest struct {
+	FullName               string `json:"fullName" example:"Siti Aminah" binding:"required"`
+	Relationship           string `json:"relationship" example:"parent" binding:"required,oneof=parent spouse child sibling relative friend caregiver other"`
+	Phone                  string `json:"phone" example:"08198765432" binding:"required"`
+	Priority               int    `json:"priority" example:"1" binding:"omitempty,min=1"`
+	MayReceiveClinicalInfo bool   `json:"mayReceiveClinicalInfo" example:"true"`
+	LegalGuardian          bool   `json:"legalGuardian" example:"true"`
+}
+
+// UpdateContactRequest mengubah field kontak yang diisi saja
+type UpdateContactRequest struct {
+	FullName               string `json:"fullName" example:"Siti Aminah"`
+	Relationship           string `json:"relationship" example:"parent" binding:"omitempty,oneof=parent spouse child sibling relative friend caregiver other"`
+	Phone                  string `json:"phone" example:"08198765432"`
+	Priority               *int   `json:"priority" example:"2" binding:"omitempty,min=1"`
+	MayReceiveClinicalInfo *bool  `json:"mayReceiveClinicalInfo" example:"false"`
+	LegalGuardian          *bool  `json:"legalGuardian" example:"false"`
+}
@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+type ContactResponse struct {
+	ID                     string    `json:"id" example:"contact-001-AbC12345"`
+	PatientID              string    `json:"patientId" example:"patient-001-ABC12345"`
+	FullName               string    `json:"fullName" example:"Siti Aminah"`
+	Relationship           string    `json:"relationship" example:"parent"`
+	Phone                  string    `json:"phone" example:"08198765432"`
+	Priority               int       `json:"priority" example:"1"`
+	MayReceiveClinicalInfo bool      `json:"mayReceiveClinicalInfo" example:"true"`
+	LegalGuardian          bool      `json:"legalGuardian" example:"true"`
+	CreatedAt              time.Time `json:"createdAt"`
+	UpdatedAt              time.Time `json:"updatedAt"`
+	// RedactedFields adalah field yang disamarkan atau dikosongkan untuk role ini
+	RedactedFields []string `json:"redactedFields,omitempty" example:"phone"`
+}
+
+type ContactMessageResponse struct {
+	Message string          `json:"message" example:"Contact created"`
+	Contact ContactResponse `json:"contact"`
+}
+
+type UpdateContactResponse struct {
+	Message       string          `json:"message" example:"Contact updated"`
+	UpdatedFields []UpdatedField  `json:"updatedFields"`
+	Contact       ContactResponse `json:"contact"`
+}
+
+type PaginatedContactsResponse struct {
+	Data []ContactResponse `json:"data"`
+	Pagination
+}
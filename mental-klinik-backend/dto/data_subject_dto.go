@@ -0,0 +1,30 @@
+package dto
+
+// CreateErasureRequest mencatat permintaan penghapusan data dari pasien.
+// Mode erase menghapus permanen data yang tidak wajib disimpan, anonymize
+// hanya menghapus identitas dan menyimpan data klinis untuk statistik.
+type CreateErasureRequest struct {
+	Mode   string `json:"mode" example:"erase" binding:"required,oneof=erase anonymize"`
+	Reason string `json:"reason" example:"Permintaan tertulis pasien tanggal 10 Januari 2025" binding:"required,min=10"`
+}
+
+// ApproveErasureRequest menyetujui dan langsung menjalankan penghapusan
+type ApproveErasureRequest struct {
+	Notes string `json:"notes" example:"Identitas pemohon sudah diverifikasi"`
+}
+
+// RejectErasureRequest menolak permintaan penghapusan
+type RejectErasureRequest struct {
+	Notes string `json:"notes" example:"Pemohon bukan pasien atau walinya" binding:"required"`
+}
+
+// PlaceLegalHoldRequest menahan data pasien dari penghapusan
+type PlaceLegalHoldRequest struct {
+	Reason    string `json:"reason" example:"Sengketa medis yang sedang berjalan" binding:"required"`
+	Reference string `json:"reference" example:"No. 123/Pdt.G/2025/PN.Jkt.Sel"`
+}
+
+// ReleaseLegalHoldRequest melepas legal hold
+type ReleaseLegalHoldRequest struct {
+	Reason string `json:"reason" example:"Perkara sudah berkekuatan hukum tetap" binding:"required"`
+}
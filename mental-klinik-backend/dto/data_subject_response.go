@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type DataExportResponse struct {
+	ID            string     `json:"id" example:"5f0c2b9e-3f0a-4f3e-9a55-1b2c3d4e5f60"`
+	PatientID     string     `json:"patientId"`
+	RequestedByID string     `json:"requestedById"`
+	Status        string     `json:"status" example:"completed"`
+	Error         string     `json:"error,omitempty"`
+	Checksum      string     `json:"checksum,omitempty"`
+	Size          int64      `json:"size" example:"48213"`
+	RequestedAt   time.Time  `json:"requestedAt"`
+	CompletedAt   *time.Time `json:"completedAt,omitempty"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty"`
+}
+
+type DataExportMessageResponse struct {
+	Message string             `json:"message"`
+	Export  DataExportResponse `json:"export"`
+}
+
+type PaginatedDataExportsResponse struct {
+	Data []DataExportResponse `json:"data"`
+	Pagination
+}
+
+type ErasureRequestResponse struct {
+	ID              string     `json:"id" example:"8a7b6c5d-4e3f-4a2b-9c1d-0e9f8a7b6c5d"`
+	PatientID       string     `json:"patientId"`
+	Mode            string     `json:"mode" example:"erase"`
+	Reason          string     `json:"reason"`
+	Status          string     `json:"status" example:"pending"`
+	RequestedByID   string     `json:"requestedById"`
+	RequestedAt     time.Time  `json:"requestedAt"`
+	ReviewedByID    *string    `json:"reviewedById,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewedAt,omitempty"`
+	ReviewNotes     string     `json:"reviewNotes,omitempty"`
+	CompletedAt     *time.Time `json:"completedAt,omitempty"`
+	CertificateHash string     `json:"certificateHash,omitempty"`
+}
+
+type ErasureRequestMessageResponse struct {
+	Message string                 `json:"message"`
+	Request ErasureRequestResponse `json:"request"`
+}
+
+type PaginatedErasureRequestsResponse struct {
+	Data []ErasureRequestResponse `json:"data"`
+	Pagination
+}
+
+// ErasureCertificateResponse adalah sertifikat penyelesaian penghapusan.
+// Hash adalah SHA-256 dari isi certificate persis seperti yang disimpan.
+type ErasureCertificateResponse struct {
+	Certificate json.RawMessage `json:"certificate" swaggertype:"object"`
+	Hash        string          `json:"hash" example:"3b1f...e9"`
+}
+
+type LegalHoldResponse struct {
+	ID            string     `json:"id" example:"1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f"`
+	PatientID     string     `json:"patientId"`
+	Reason        string     `json:"reason"`
+	Reference     string     `json:"reference,omitempty"`
+	Status        string     `json:"status" example:"active"`
+	PlacedByID    string     `json:"placedById"`
+	PlacedAt      time.Time  `json:"placedAt"`
+	ReleasedAt    *time.Time `json:"releasedAt,omitempty"`
+	ReleasedByID  *string    `json:"releasedById,omitempty"`
+	ReleaseReason string     `json:"releaseReason,omitempty"`
+}
+
+type LegalHoldMessageResponse struct {
+	Message string            `json:"message"`
+	Hold    LegalHoldResponse `json:"hold"`
+}
+
+type PaginatedLegalHoldsResponse struct {
+	Data []LegalHoldResponse `json:"data"`
+	Pagination
+}
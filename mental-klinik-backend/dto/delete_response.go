@@ -0,0 +1,18 @@
+package dto
+
+// DeleteBlocker adalah relasi yang masih menahan penghapusan
+type DeleteBlocker struct {
+	Relation string `json:"relation" example:"appointments"`
+	// Policy restrict: data harus dihapus / dipindahkan dulu; reassign: kirim
+	// reassignTo untuk memindahkannya
+	Policy string   `json:"policy" example:"reassign"`
+	Count  int64    `json:"count" example:"2"`
+	IDs    []string `json:"ids"` // paling banyak 10 ID contoh
+}
+
+// DeleteBlockedResponse dikembalikan dengan status 409 jika penghapusan
+// ditolak karena kebijakan relasi
+type DeleteBlockedResponse struct {
+	Error    string          `json:"error" example:"cannot delete: still referenced by 2 appointments"`
+	Blockers []DeleteBlocker `json:"blockers"`
+}
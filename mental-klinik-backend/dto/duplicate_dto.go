@@ -0,0 +1,8 @@
+package dto
+
+// MergePatientsRequest menggabungkan pasien MergedID ke SurvivorID. Data
+// klinis pasien yang digabung dipindahkan dan pasiennya masuk trash.
+type MergePatientsRequest struct {
+	SurvivorID string `json:"survivorId" example:"patient-001-AbC12345" binding:"required"`
+	MergedID   string `json:"mergedId" example:"patient-042-XyZ98765" binding:"required"`
+}
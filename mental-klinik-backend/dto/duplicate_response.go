@@ -0,0 +1,85 @@
+package dto
+
+import "time"
+
+// DuplicateSettingsResponse adalah pengaturan job deteksi duplikat
+type DuplicateSettingsResponse struct {
+	Enabled   bool    `json:"enabled"`
+	Interval  string  `json:"interval" example:"24h0m0s"`
+	Threshold float64 `json:"threshold" example:"0.75"`
+}
+
+type DuplicateScanResponse struct {
+	ID            string     `json:"id" example:"4b5c6d7e-8f90-4a1b-8c2d-3e4f5a6b7c8d"`
+	Trigger       string     `json:"trigger" example:"manual"`
+	TriggeredByID *string    `json:"triggeredById,omitempty"`
+	Status        string     `json:"status" example:"completed"`
+	Error         string     `json:"error,omitempty"`
+	Threshold     float64    `json:"threshold" example:"0.75"`
+	Patients      int64      `json:"patients" example:"1250"`
+	Candidates    int64      `json:"candidates" example:"7"`
+	Created       int64      `json:"created" example:"3"`
+	StartedAt     time.Time  `json:"startedAt"`
+	FinishedAt    *time.Time `json:"finishedAt,omitempty"`
+}
+
+type DuplicateScanMessageResponse struct {
+	Message string                `json:"message"`
+	Scan    DuplicateScanResponse `json:"scan"`
+}
+
+type PaginatedDuplicateScansResponse struct {
+	Data []DuplicateScanResponse `json:"data"`
+	Pagination
+}
+
+// DuplicateCandidateResponse adalah satu pasangan di antrean review beserta
+// data kedua pasien untuk dibandingkan
+type DuplicateCandidateResponse struct {
+	ID           string          `json:"id" example:"8c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f"`
+	Score        float64         `json:"score" example:"0.92"`
+	Reasons      []string        `json:"reasons" example:"name,birth_date"`
+	Status       string          `json:"status" example:"pending"`
+	DetectedAt   time.Time       `json:"detectedAt"`
+	ReviewedByID *string         `json:"reviewedById,omitempty"`
+	ReviewedAt   *time.Time      `json:"reviewedAt,omitempty"`
+	MergeID      *string         `json:"mergeId,omitempty"`
+	PatientA     PatientResponse `json:"patientA"`
+	PatientB     PatientResponse `json:"patientB"`
+}
+
+type DuplicateCandidateMessageResponse struct {
+	Message   string                     `json:"message"`
+	Candidate DuplicateCandidateResponse `json:"candidate"`
+}
+
+type PaginatedDuplicateCandidatesResponse struct {
+	Data []DuplicateCandidateResponse `json:"data"`
+	Pagination
+}
+
+// PatientMergeResponse adalah catatan penggabungan. Moved berisi ID baris
+// yang dipindahkan per tabel.
+type PatientMergeResponse struct {
+	ID          string              `json:"id" example:"0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"`
+	SurvivorID  string              `json:"survivorId" example:"patient-001-AbC12345"`
+	MergedID    string              `json:"mergedId" example:"patient-042-XyZ98765"`
+	CandidateID *string             `json:"candidateId,omitempty"`
+	Moved       map[string][]string `json:"moved"`
+	MergedByID  string              `json:"mergedById"`
+	MergedAt    time.Time           `json:"mergedAt"`
+	UndoneByID  *string             `json:"undoneById,omitempty"`
+	UndoneAt    *time.Time          `json:"undoneAt,omitempty"`
+}
+
+type PatientMergeMessageResponse struct {
+	Message string               `json:"message"`
+	Merge   PatientMergeResponse `json:"merge"`
+	// Restored adalah jumlah baris per tabel yang dikembalikan saat undo
+	Restored map[string]int64 `json:"restored,omitempty"`
+}
+
+type PaginatedPatientMergesResponse struct {
+	Data []PatientMergeResponse `json:"data"`
+	Pagination
+}
@@ -0,0 +1,13 @@
+package dto
+
+// BreakGlassRequest membuka akses darurat ke pasien di luar tim perawatan.
+// Alasan wajib diisi dan masuk ke laporan review admin.
+type BreakGlassRequest struct {
+	Reason string `json:"reason" example:"Pasien datang ke IGD dengan risiko bunuh diri, dokter jaga perlu riwayat terapi" binding:"required,min=20"`
+}
+
+// ReviewEmergencyAccessRequest adalah hasil review admin atas akses darurat
+type ReviewEmergencyAccessRequest struct {
+	Outcome string `json:"outcome" example:"justified" binding:"required,oneof=justified unjustified"`
+	Notes   string `json:"notes" example:"Sesuai catatan IGD malam itu"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+type EmergencyAccessResponse struct {
+	ID           string              `json:"id" example:"0b8f3c1e-6a4d-4a57-9f6e-2f1d8c7b5a90"`
+	UserID       string              `json:"userId"`
+	PatientID    string              `json:"patientId"`
+	Reason       string              `json:"reason"`
+	Status       string              `json:"status" example:"active"`
+	GrantedAt    time.Time           `json:"grantedAt"`
+	ExpiresAt    time.Time           `json:"expiresAt"`
+	RevokedAt    *time.Time          `json:"revokedAt,omitempty"`
+	RevokedByID  *string             `json:"revokedById,omitempty"`
+	IPAddress    string              `json:"ipAddress"`
+	UserAgent    string              `json:"userAgent"`
+	ReviewStatus string              `json:"reviewStatus" example:"pending"`
+	ReviewedAt   *time.Time          `json:"reviewedAt,omitempty"`
+	ReviewedByID *string             `json:"reviewedById,omitempty"`
+	ReviewNotes  string              `json:"reviewNotes,omitempty"`
+	AuditReason  string              `json:"auditReason" example:"emergency-access:0b8f3c1e-6a4d-4a57-9f6e-2f1d8c7b5a90"`
+	Patient      PatientMiniResponse `json:"patient"`
+	User         UserMiniResponse    `json:"user"`
+}
+
+// BreakGlassResponse berisi token akses darurat. Token hanya berlaku untuk
+// pasien ini sampai expiresAt; token biasa tetap dipakai untuk request lain.
+type BreakGlassResponse struct {
+	Message     string                  `json:"message"`
+	AccessToken string                  `json:"accessToken"`
+	ExpiresAt   time.Time               `json:"expiresAt"`
+	Access      EmergencyAccessResponse `json:"access"`
+}
+
+type EmergencyAccessMessageResponse struct {
+	Message string                  `json:"message"`
+	Access  EmergencyAccessResponse `json:"access"`
+}
+
+// PaginatedEmergencyAccessResponse adalah laporan review akses darurat.
+// PendingReview menghitung semua akses yang belum di-review (tanpa filter).
+type PaginatedEmergencyAccessResponse struct {
+	Data          []EmergencyAccessResponse `json:"data"`
+	PendingReview int64                     `json:"pendingReview" example:"3"`
+	Pagination
+}
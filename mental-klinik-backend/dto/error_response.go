@@ -2,4 +2,24 @@ package dto
 
 type ErrorResponse struct {
 	Error string `json:"error" example:"Invalid input"`
-}
\ No newline at end of file
+}
+
+// QueryErrorResponse dikembalikan list endpoint untuk parameter query yang
+// tidak dikenal atau tidak valid
+type QueryErrorResponse struct {
+	Error string `json:"error" example:"invalid query parameter: unknown sort field"`
+	Param string `json:"param" example:"sort"`
+}
+
+// FieldError adalah kesalahan validasi pada satu field request
+type FieldError struct {
+	Field   string `json:"field" example:"birthDate"`
+	Message string `json:"message" example:"does not match the birth date encoded in nik (DDMMYY 010100)"`
+}
+
+// ValidationErrorResponse dikembalikan jika isi request tidak lolos validasi,
+// dengan daftar kesalahan per field
+type ValidationErrorResponse struct {
+	Error  string       `json:"error" example:"invalid patient data"`
+	Fields []FieldError `json:"fields"`
+}
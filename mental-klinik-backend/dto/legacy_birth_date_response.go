@@ -0,0 +1,15 @@
+package dto
+
+// LegacyBirthDateResponse adalah pasien yang tanggal lahir lamanya tidak bisa
+// dikonversi. Koreksi dengan PUT /api/patients/{id} mengisi birthDate.
+type LegacyBirthDateResponse struct {
+	ID                 string `json:"id" example:"patient-001-AbC12345"`
+	FullName           string `json:"fullName" example:"Siti Aminah"`
+	LegacyValue        string `json:"legacyValue" example:"15/08/1990"`
+	SuggestedBirthDate string `json:"suggestedBirthDate,omitempty" example:"1990-08-15"`
+}
+
+type PaginatedLegacyBirthDatesResponse struct {
+	Data []LegacyBirthDateResponse `json:"data"`
+	Pagination
+}
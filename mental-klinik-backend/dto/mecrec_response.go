@@ -6,12 +6,14 @@ type MedicalRecordResponse struct {
 	ID        string                 `json:"id"`
 	PatientID string                 `json:"patientId"`
 	UserID    string                 `json:"userId"`
-	Diagnosis string                 `json:"diagnosis"`
-	Treatment string                 `json:"treatment"`
+	Diagnosis string                 `json:"diagnosis,omitempty"`
+	Treatment string                 `json:"treatment,omitempty"`
 	CreatedAt time.Time              `json:"createdAt"`
 	UpdatedAt time.Time              `json:"updatedAt"`
 	Patient   MedicalRecordMiniPatient `json:"patient"`
 	User      MedicalRecordMiniUser    `json:"user"`
+	// RedactedFields berisi diagnosis dan treatment jika user bukan dokter yang merawat
+	RedactedFields []string `json:"redactedFields,omitempty" example:"diagnosis,treatment"`
 }
 
 type CreateMedicalRecordResponse struct {
@@ -20,11 +22,8 @@ type CreateMedicalRecordResponse struct {
 }
 
 type PaginatedMedicalRecordsResponse struct {
-	Data       []MedicalRecordResponse `json:"data"`
-	Total      int                     `json:"total"`
-	Page       int                     `json:"page"`
-	Limit      int                     `json:"limit"`
-	TotalPages int                     `json:"totalPages"`
+	Data []MedicalRecordResponse `json:"data"`
+	Pagination
 }
 
 type UpdateMedicalRecordResponse struct {
@@ -1,11 +1,19 @@
 package dto
 
+type MessageResponse struct {
+	Message string `json:"message" example:"Logged Out"`
+}
+
 type MessageDeleteResponse struct {
 	Message string `json:"message" example:"User Deleted Successfully"`
+	// Reassigned adalah jumlah data per relasi yang dipindahkan ke reassignTo
+	Reassigned map[string]int64 `json:"reassigned,omitempty"`
 }
 
 type MessageDeletePatientResponse struct {
 	Message string `json:"message" example:"Patient deleted successfully"`
+	// Deleted adalah jumlah data per relasi yang ikut dihapus bersama pasien
+	Deleted map[string]int64 `json:"deleted,omitempty"`
 }
 
 type MessageDeleteAppointmentResponse struct {
@@ -0,0 +1,13 @@
+package dto
+
+// Pagination adalah metadata paging yang di-embed ke semua response list.
+// Mode offset mengisi total, page dan totalPages. Mode cursor (?cursor=)
+// mengisi nextCursor/prevCursor, dan total hanya jika withTotal=true.
+type Pagination struct {
+	Total      *int64 `json:"total,omitempty" example:"100"`
+	Page       *int   `json:"page,omitempty" example:"1"`
+	Limit      int    `json:"limit" example:"10"`
+	TotalPages *int   `json:"totalPages,omitempty" example:"10"`
+	NextCursor string `json:"nextCursor,omitempty" example:"eyJzIjoiLWNyZWF0ZWRfYXQsLWlkIiwiayI6W119"`
+	PrevCursor string `json:"prevCursor,omitempty"`
+}
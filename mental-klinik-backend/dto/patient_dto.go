@@ -1,21 +1,33 @@
 package dto
 
+// CreatePatientRequest mendaftarkan pasien. Contacts diisi ke kontak darurat
+// pasien; emergencyContact (satu nomor telepon) masih diterima untuk klien
+// lama dan disimpan sebagai kontak prioritas 1 dengan hubungan "other".
 type CreatePatientRequest struct {
-	FullName         string `json:"fullName" binding:"required" example:"Andi Saputra"`
-	NIK              string `json:"nik" binding:"required" example:"3201012345678900"`
-	BirthDate        string `json:"birthDate" binding:"required" example:"2000-01-01"`
-	Gender           string `json:"gender" binding:"required,oneof=male female other" example:"male"`
-	Phone            string `json:"phone" binding:"required" example:"08123456789"`
-	Address          string `json:"address" binding:"required" example:"Jl. Merdeka No. 10"`
-	EmergencyContact string `json:"emergencyContact" binding:"required" example:"08198765432"`
+	FullName         string                 `json:"fullName" binding:"required" example:"Andi Saputra"`
+	NIK              string                 `json:"nik" binding:"required" example:"3201010101000001"`
+	BirthDate        string                 `json:"birthDate" binding:"required" example:"2000-01-01"`
+	Gender           string                 `json:"gender" binding:"required,oneof=male female other" example:"male"`
+	Phone            string                 `json:"phone" binding:"required" example:"08123456789"`
+	Address          string                 `json:"address" binding:"required" example:"Jl. Merdeka No. 10"`
+	EmergencyContact string                 `json:"emergencyContact" example:"08198765432"` // deprecated, pakai contacts
+	Contacts         []CreateContactRequest `json:"contacts" binding:"omitempty,dive"`
 }
 
+// UpdatePatientInput mengubah field pasien yang diisi. emergencyContact
+// mengganti telepon kontak prioritas tertinggi (atau membuatnya jika belum
+// ada); kontak lain dikelola lewat endpoint kontak pasien.
 type UpdatePatientInput struct {
 	FullName         string `json:"fullName" example:"Budi Santoso"`
-	NIK              string `json:"nik" example:"3201012345678901"`
+	NIK              string `json:"nik" example:"3201013112800001"`
 	BirthDate        string `json:"birthDate" example:"1980-12-31"`
 	Gender           string `json:"gender" example:"male"`
 	Phone            string `json:"phone" example:"081234567891"`
 	Address          string `json:"address" example:"Jl. Merpati No. 123"`
-	EmergencyContact string `json:"emergencyContact" example:"081987654321"`
-}
\ No newline at end of file
+	EmergencyContact string `json:"emergencyContact" example:"081987654321"` // deprecated, pakai endpoint kontak
+}
+
+// DecodeNIKRequest adalah NIK yang dibaca untuk membantu pengisian form
+type DecodeNIKRequest struct {
+	NIK string `json:"nik" binding:"required" example:"3201014101900001"`
+}
@@ -3,12 +3,19 @@ package dto
 type PatientResponse struct {
 	ID               string `json:"id" example:"patient-001-ABC12345"`
 	FullName         string `json:"fullName" example:"Andi Saputra"`
-	NIK              string `json:"nik" example:"3201012345678900"`
+	NIK              string `json:"nik" example:"3201010101000001"`
 	BirthDate        string `json:"birthDate" example:"2000-01-01"`
 	Gender           string `json:"gender" example:"male"`
 	Phone            string `json:"phone" example:"08123456789"`
-	Address          string `json:"address" example:"Jl. Merdeka No. 10"`
+	Address          string `json:"address,omitempty" example:"Jl. Merdeka No. 10"`
 	EmergencyContact string `json:"emergencyContact" example:"08198765432"`
+	// Age adalah usia dalam tahun penuh, null jika tanggal lahir belum diketahui
+	Age *int `json:"age" example:"25"`
+	// IsMinor bernilai true untuk pasien di bawah 18 tahun, persetujuannya
+	// diberikan oleh wali
+	IsMinor bool `json:"isMinor" example:"false"`
+	// RedactedFields adalah field yang disamarkan atau dikosongkan untuk role ini
+	RedactedFields []string `json:"redactedFields,omitempty" example:"nik"`
 }
 
 type CreatePatientResponse struct {
@@ -17,11 +24,8 @@ type CreatePatientResponse struct {
 }
 
 type PaginatedPatientsResponse struct {
-	Data       []PatientResponse `json:"data"`
-	Total      int               `json:"total" example:"100"`
-	Page       int               `json:"page" example:"1"`
-	Limit      int               `json:"limit" example:"10"`
-	TotalPages int               `json:"totalPages" example:"10"`
+	Data []PatientResponse `json:"data"`
+	Pagination
 }
 
 type PatientMiniResponse struct {
@@ -29,9 +33,29 @@ type PatientMiniResponse struct {
 	FullName  string `json:"fullName"`
 	Gender    string `json:"gender"`
 	BirthDate string `json:"birthDate"`
+	Age       *int   `json:"age"`
+	IsMinor   bool   `json:"isMinor"`
 }
 
 type MedicalRecordMiniPatient struct {
 	ID       string `json:"id"`
 	FullName string `json:"fullName"`
-}
\ No newline at end of file
+}
+
+// RegionResponse adalah kode wilayah dari NIK; nama kosong jika wilayah tidak
+// dirinci di tabel wilayah
+type RegionResponse struct {
+	Code string `json:"code" example:"3201"`
+	Name string `json:"name,omitempty" example:"Kabupaten Bogor"`
+}
+
+// DecodeNIKResponse adalah isi NIK untuk mengisi otomatis form pendaftaran.
+// NIK hanya menyimpan 2 digit tahun, abadnya ditebak supaya tanggal lahir
+// tidak di masa depan.
+type DecodeNIKResponse struct {
+	Province  RegionResponse `json:"province"`
+	Regency   RegionResponse `json:"regency"`
+	District  RegionResponse `json:"district"`
+	BirthDate string         `json:"birthDate" example:"1990-01-01"`
+	Gender    string         `json:"gender" example:"female"`
+}
@@ -15,9 +15,6 @@ type PredictionResponse struct {
 }
 
 type PaginatedPredictionsResponse struct {
-	Data       []PredictionResponse `json:"data"`
-	Total      int                  `json:"total"`
-	Page       int                  `json:"page"`
-	Limit      int                  `json:"limit"`
-	TotalPages int                  `json:"totalPages"`
+	Data []PredictionResponse `json:"data"`
+	Pagination
 }
@@ -0,0 +1,7 @@
+package dto
+
+// StartRetentionRunRequest memulai run retensi manual. DryRun true hanya
+// menulis laporan; false menulis laporan lalu langsung menjalankannya.
+type StartRetentionRunRequest struct {
+	DryRun *bool `json:"dryRun" example:"true" binding:"required"`
+}
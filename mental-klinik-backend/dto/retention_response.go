@@ -0,0 +1,55 @@
+package dto
+
+import "time"
+
+type RetentionPolicyResponse struct {
+	Entity    string `json:"entity" example:"appointment"`
+	Action    string `json:"action" example:"purge"`
+	Basis     string `json:"basis" example:"deleted"`
+	RetainFor string `json:"retainFor" example:"2y"`
+}
+
+// RetentionSettingsResponse adalah pengaturan scheduler dan kebijakan yang
+// aktif (dari file konfigurasi)
+type RetentionSettingsResponse struct {
+	Enabled   bool                      `json:"enabled"`
+	Interval  string                    `json:"interval" example:"24h0m0s"`
+	DryRun    bool                      `json:"dryRun"`
+	BatchSize int                       `json:"batchSize" example:"500"`
+	Policies  []RetentionPolicyResponse `json:"policies"`
+}
+
+type RetentionResultResponse struct {
+	Entity    string    `json:"entity" example:"appointment"`
+	Action    string    `json:"action" example:"purge"`
+	Basis     string    `json:"basis" example:"deleted"`
+	RetainFor string    `json:"retainFor" example:"2y"`
+	Cutoff    time.Time `json:"cutoff"`
+	Eligible  int64     `json:"eligible" example:"42"`
+	OnHold    int64     `json:"onHold" example:"1"`
+	Processed int64     `json:"processed" example:"42"`
+	SampleIDs []string  `json:"sampleIds"`
+}
+
+type RetentionRunResponse struct {
+	ID            string                    `json:"id" example:"4b5c6d7e-8f90-4a1b-8c2d-3e4f5a6b7c8d"`
+	Trigger       string                    `json:"trigger" example:"scheduled"`
+	TriggeredByID *string                   `json:"triggeredById,omitempty"`
+	DryRun        bool                      `json:"dryRun"`
+	ReportRunID   *string                   `json:"reportRunId,omitempty"`
+	Status        string                    `json:"status" example:"completed"`
+	Error         string                    `json:"error,omitempty"`
+	StartedAt     time.Time                 `json:"startedAt"`
+	FinishedAt    *time.Time                `json:"finishedAt,omitempty"`
+	Results       []RetentionResultResponse `json:"results"`
+}
+
+type RetentionRunMessageResponse struct {
+	Message string               `json:"message"`
+	Run     RetentionRunResponse `json:"run"`
+}
+
+type PaginatedRetentionRunsResponse struct {
+	Data []RetentionRunResponse `json:"data"`
+	Pagination
+}
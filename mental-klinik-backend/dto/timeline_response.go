@@ -0,0 +1,55 @@
+package dto
+
+import "time"
+
+// TimelineEventResponse adalah satu event timeline klinis pasien. Hanya satu
+// kelompok detail yang terisi sesuai type (appointment juga terisi untuk
+// perubahan status); detail lengkap diambil dari endpoint resource-nya.
+type TimelineEventResponse struct {
+	ID           string    `json:"id" example:"assessment_taken:ASM-20250101-0001"`
+	Type         string    `json:"type" example:"assessment_taken"`
+	OccurredAt   time.Time `json:"occurredAt"`
+	ResourceType string    `json:"resourceType" example:"assessment"`
+	ResourceID   string    `json:"resourceId" example:"ASM-20250101-0001"`
+
+	Appointment   *TimelineAppointmentResponse   `json:"appointment,omitempty"`
+	StatusChange  *TimelineStatusChangeResponse  `json:"statusChange,omitempty"`
+	Assessment    *TimelineAssessmentResponse    `json:"assessment,omitempty"`
+	Prediction    *TimelinePredictionResponse    `json:"prediction,omitempty"`
+	MedicalRecord *TimelineMedicalRecordResponse `json:"medicalRecord,omitempty"`
+}
+
+type TimelineAppointmentResponse struct {
+	ScheduleAt time.Time        `json:"scheduleAt"`
+	Status     string           `json:"status" example:"pending"`
+	Notes      string           `json:"notes"`
+	User       UserMiniResponse `json:"user"`
+}
+
+type TimelineStatusChangeResponse struct {
+	FromStatus string                 `json:"fromStatus" example:"pending"`
+	ToStatus   string                 `json:"toStatus" example:"done"`
+	ChangedBy  *MedicalRecordMiniUser `json:"changedBy,omitempty"` // kosong untuk riwayat lama
+}
+
+type TimelineAssessmentResponse struct {
+	Date time.Time `json:"date"`
+}
+
+type TimelinePredictionResponse struct {
+	AssessmentID     string  `json:"assessmentId" example:"ASM-20250101-0001"`
+	ResultLabel      string  `json:"resultLabel" example:"moderate"`
+	ProbabilityScore float64 `json:"probabilityScore" example:"0.82"`
+}
+
+type TimelineMedicalRecordResponse struct {
+	Diagnosis      string                `json:"diagnosis"`
+	Treatment      string                `json:"treatment"`
+	User           MedicalRecordMiniUser `json:"user"`
+	RedactedFields []string              `json:"redactedFields,omitempty"`
+}
+
+type PaginatedTimelineResponse struct {
+	Data []TimelineEventResponse `json:"data"`
+	Pagination
+}
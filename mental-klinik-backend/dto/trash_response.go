@@ -0,0 +1,74 @@
+package dto
+
+import "time"
+
+// Response trash: data yang sama dengan response biasa ditambah waktu
+// penghapusannya
+
+type PatientTrashResponse struct {
+	PatientResponse
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+type PaginatedPatientTrashResponse struct {
+	Data []PatientTrashResponse `json:"data"`
+	Pagination
+}
+
+type UserTrashResponse struct {
+	UserResponse
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+type PaginatedUserTrashResponse struct {
+	Data []UserTrashResponse `json:"data"`
+	Pagination
+}
+
+type AppointmentTrashResponse struct {
+	AppointmentResponse
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+type PaginatedAppointmentTrashResponse struct {
+	Data []AppointmentTrashResponse `json:"data"`
+	Pagination
+}
+
+type AssessmentTrashResponse struct {
+	AssessmentResponse
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+type PaginatedAssessmentTrashResponse struct {
+	Data []AssessmentTrashResponse `json:"data"`
+	Pagination
+}
+
+type PredictionTrashResponse struct {
+	PredictionResponse
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+type PaginatedPredictionTrashResponse struct {
+	Data []PredictionTrashResponse `json:"data"`
+	Pagination
+}
+
+type MedicalRecordTrashResponse struct {
+	MedicalRecordResponse
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+type PaginatedMedicalRecordTrashResponse struct {
+	Data []MedicalRecordTrashResponse `json:"data"`
+	Pagination
+}
+
+// RestoreResponse dikembalikan setelah restore berhasil
+type RestoreResponse struct {
+	Message string `json:"message" example:"Patient restored successfully"`
+	// Restored adalah jumlah baris yang dipulihkan per tabel, termasuk data
+	// anak jika cascade
+	Restored map[string]int64 `json:"restored"`
+}
@@ -7,15 +7,26 @@ type UpdateUserInput struct {
 	Role     string `json:"role" binding:"omitempty,oneof=admin doctor staff"`
 }
 
-// RegisterUserRequest defines input for register endpoint (untuk Swagger)
+// RegisterUserRequest defines input for register endpoint. Role tidak lagi
+// diterima dari client: role diambil dari undangan (atau staff jika
+// pendaftaran mandiri dibuka lewat config).
 type RegisterUserRequest struct {
-	FullName string `json:"fullName" example:"John Doe"`
-	Email    string `json:"email" example:"john@example.com"`
-	Password string `json:"password" example:"password123"`
-	Role     string `json:"role" example:"admin"`
+	FullName    string `json:"fullName" example:"John Doe" binding:"required"`
+	Email       string `json:"email" example:"john@example.com" binding:"required,email"`
+	Password    string `json:"password" example:"password123" binding:"required,min=6"`
+	InviteToken string `json:"inviteToken" example:"opaque-invite-token"`
 }
 
 type LoginUserRequest struct {
 	Email    string `json:"email" example:"user@example.com" binding:"required,email"`
 	Password string `json:"password" example:"password123" binding:"required"`
-}
\ No newline at end of file
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" example:"opaque-refresh-token" binding:"required"`
+}
+
+type CreateInvitationRequest struct {
+	Email string `json:"email" example:"dokter@example.com" binding:"required,email"`
+	Role  string `json:"role" example:"doctor" binding:"required,oneof=admin doctor staff"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // import "mental-klinik-backend/models"
 
 type UserResponse struct {
@@ -16,17 +18,32 @@ type RegisterUserResponse struct {
 }
 
 type LoginResponse struct {
-	Token string `json:"token" example:"jwt-token"`
-	Email string `json:"email" example:"john@example.com"`
-	Role  string `json:"role" example:"admin"`
+	Token                 string    `json:"token" example:"jwt-token"`
+	ExpiresAt             time.Time `json:"expiresAt"`
+	RefreshToken          string    `json:"refreshToken" example:"opaque-refresh-token"`
+	RefreshTokenExpiresAt time.Time `json:"refreshTokenExpiresAt"`
+	Email                 string    `json:"email" example:"john@example.com"`
+	Role                  string    `json:"role" example:"admin"`
+}
+
+// TokenResponse dikembalikan endpoint refresh. Refresh token lama tidak
+// berlaku lagi setelah dipakai.
+type TokenResponse struct {
+	Token                 string    `json:"token" example:"jwt-token"`
+	ExpiresAt             time.Time `json:"expiresAt"`
+	RefreshToken          string    `json:"refreshToken" example:"opaque-refresh-token"`
+	RefreshTokenExpiresAt time.Time `json:"refreshTokenExpiresAt"`
+	Role                  string    `json:"role" example:"admin"`
+}
+
+type RevokeSessionsResponse struct {
+	Message string `json:"message" example:"Sessions Revoked"`
+	Revoked int64  `json:"revoked" example:"2"`
 }
 
 type PaginatedUsersResponse struct {
-	Data       []UserResponse `json:"data"`
-	Total      int            `json:"total" example:"100"`
-	Page       int            `json:"page" example:"1"`
-	Limit      int            `json:"limit" example:"10"`
-	TotalPages int            `json:"totalPages" example:"10"`
+	Data []UserResponse `json:"data"`
+	Pagination
 }
 
 type UserMiniResponse struct {
@@ -40,4 +57,29 @@ type MedicalRecordMiniUser struct {
 	ID       string `json:"id"`
 	FullName string `json:"fullName"`
 	Role     string `json:"role"`
-}
\ No newline at end of file
+}
+type InvitationResponse struct {
+	ID          string     `json:"id" example:"01j9z3x8k2m4n6p8q0r2s4t6v8"`
+	Email       string     `json:"email" example:"dokter@example.com"`
+	Role        string     `json:"role" example:"doctor"`
+	Status      string     `json:"status" example:"pending"` // pending, used, revoked, expired
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	CreatedByID string     `json:"createdById" example:"admin-001-a1b2c3d4"`
+	UsedAt      *time.Time `json:"usedAt,omitempty"`
+	UsedByID    *string    `json:"usedById,omitempty"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// CreateInvitationResponse memuat token undangan. Token hanya ditampilkan
+// sekali, kirimkan ke calon user lewat kanal yang aman.
+type CreateInvitationResponse struct {
+	Message    string             `json:"message" example:"Invitation Created"`
+	Token      string             `json:"token" example:"opaque-invite-token"`
+	Invitation InvitationResponse `json:"invitation"`
+}
+
+type PaginatedInvitationsResponse struct {
+	Data []InvitationResponse `json:"data"`
+	Pagination
+}
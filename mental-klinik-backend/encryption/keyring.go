@@ -0,0 +1,363 @@
+// Package encryption menyediakan enkripsi field at-rest dengan envelope
+// encryption: nilai field dienkripsi AES-256-GCM memakai data key, dan data
+// key disimpan di database dalam keadaan terbungkus (AES-256-GCM) oleh master
+// key dari konfigurasi. Master key tidak pernah disimpan di database.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/oklog/ulid/v2"
+
+	"mental-klinik-backend/models"
+)
+
+// KeySize adalah panjang master key, data key dan index key (AES-256)
+const KeySize = 32
+
+// prefix menandai nilai yang sudah dienkripsi: enc:v1:<data key id>:<base64>.
+// Nilai tanpa prefix dianggap data lama yang belum dienkripsi.
+const prefix = "enc:v1:"
+
+var (
+	ErrMalformedCiphertext = errors.New("malformed ciphertext")
+	ErrUnknownDataKey      = errors.New("unknown data key")
+	ErrNoActiveDataKey     = errors.New("no active data key")
+)
+
+// MasterKey adalah master key dari konfigurasi, ditulis sebagai
+// "<id>:<base64 32 byte>"
+type MasterKey struct {
+	ID  string
+	Key []byte
+}
+
+// ParseMasterKeys membaca daftar master key. Key pertama adalah key aktif
+// yang dipakai untuk membungkus data key, sisanya hanya untuk membuka data
+// key lama selama rotasi.
+func ParseMasterKeys(values []string) ([]MasterKey, error) {
+	if len(values) == 0 {
+		return nil, errors.New("at least one master key is required")
+	}
+	keys := make([]MasterKey, 0, len(values))
+	seen := make(map[string]bool)
+	for _, value := range values {
+		id, encoded, ok := strings.Cut(value, ":")
+		id = strings.TrimSpace(id)
+		if !ok || id == "" {
+			return nil, errors.New(`master key must be written as "<id>:<base64 key>"`)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate master key id %q", id)
+		}
+		seen[id] = true
+
+		key, err := ParseKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("master key %q: %w", id, err)
+		}
+		keys = append(keys, MasterKey{ID: id, Key: key})
+	}
+	return keys, nil
+}
+
+// ParseKey mendekode key base64 dan memastikan panjangnya 32 byte
+func ParseKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("key is not valid base64: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
+
+// KeyStore menyimpan data key yang sudah dibungkus
+type KeyStore interface {
+	FindAll() ([]models.DataKey, error)
+	// CreateActive menyimpan key baru sebagai satu-satunya key aktif
+	CreateActive(key *models.DataKey) error
+	// Rewrap mengganti versi terbungkus data key (misalnya saat master key dirotasi)
+	Rewrap(keys []models.DataKey) error
+}
+
+// Keyring memegang master key, data key yang sudah dibuka, dan key blind
+// index. Aman dipakai bersamaan dari banyak goroutine.
+type Keyring struct {
+	masters      map[string]cipher.AEAD
+	activeMaster string
+	indexKey     []byte
+	store        KeyStore
+
+	mu            sync.RWMutex
+	dataKeys      map[string]cipher.AEAD
+	activeDataKey string
+}
+
+// NewKeyring membuat keyring dan memuat semua data key dari store. Jika
+// belum ada data key sama sekali (database baru), data key pertama dibuat.
+func NewKeyring(masters []MasterKey, indexKey []byte, store KeyStore) (*Keyring, error) {
+	if len(masters) == 0 {
+		return nil, errors.New("at least one master key is required")
+	}
+	k := &Keyring{
+		masters:      make(map[string]cipher.AEAD, len(masters)),
+		activeMaster: masters[0].ID,
+		indexKey:     indexKey,
+		store:        store,
+	}
+	for _, m := range masters {
+		aead, err := newAEAD(m.Key)
+		if err != nil {
+			return nil, fmt.Errorf("master key %q: %w", m.ID, err)
+		}
+		k.masters[m.ID] = aead
+	}
+
+	if err := k.Reload(); err != nil {
+		return nil, err
+	}
+	if k.ActiveDataKey() == "" {
+		if _, err := k.Rotate(); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// Reload membuka ulang semua data key dari store, misalnya setelah data key
+// baru dibuat oleh proses lain (subcommand keys rotate)
+func (k *Keyring) Reload() error {
+	stored, err := k.store.FindAll()
+	if err != nil {
+		return fmt.Errorf("load data keys: %w", err)
+	}
+
+	dataKeys := make(map[string]cipher.AEAD, len(stored))
+	active := ""
+	for _, dk := range stored {
+		raw, err := k.unwrap(&dk)
+		if err != nil {
+			return err
+		}
+		aead, err := newAEAD(raw)
+		if err != nil {
+			return fmt.Errorf("data key %s: %w", dk.ID, err)
+		}
+		dataKeys[dk.ID] = aead
+		if dk.Active {
+			active = dk.ID
+		}
+	}
+
+	k.mu.Lock()
+	k.dataKeys, k.activeDataKey = dataKeys, active
+	k.mu.Unlock()
+	return nil
+}
+
+// ActiveDataKey mengembalikan ID data key yang dipakai untuk enkripsi baru
+func (k *Keyring) ActiveDataKey() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.activeDataKey
+}
+
+// ActiveMasterKey mengembalikan ID master key yang dipakai membungkus data key
+func (k *Keyring) ActiveMasterKey() string {
+	return k.activeMaster
+}
+
+// Rotate membuat data key baru yang langsung menjadi key aktif. Data lama
+// tetap terbaca dengan data key sebelumnya sampai dienkripsi ulang.
+func (k *Keyring) Rotate() (string, error) {
+	raw := make([]byte, KeySize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(raw)
+	if err != nil {
+		return "", err
+	}
+
+	dk := &models.DataKey{ID: ulid.Make().String(), Active: true}
+	if err := k.wrap(dk, raw); err != nil {
+		return "", err
+	}
+	if err := k.store.CreateActive(dk); err != nil {
+		return "", fmt.Errorf("store data key: %w", err)
+	}
+
+	k.mu.Lock()
+	k.dataKeys[dk.ID], k.activeDataKey = aead, dk.ID
+	k.mu.Unlock()
+	return dk.ID, nil
+}
+
+// Rewrap membungkus ulang setiap data key yang masih memakai master key lama
+// dengan master key aktif, lalu mengembalikan jumlah key yang diubah. Setelah
+// itu master key lama boleh dihapus dari konfigurasi.
+func (k *Keyring) Rewrap() (int, error) {
+	stored, err := k.store.FindAll()
+	if err != nil {
+		return 0, err
+	}
+
+	var changed []models.DataKey
+	for _, dk := range stored {
+		if dk.MasterKeyID == k.activeMaster {
+			continue
+		}
+		raw, err := k.unwrap(&dk)
+		if err != nil {
+			return 0, err
+		}
+		if err := k.wrap(&dk, raw); err != nil {
+			return 0, err
+		}
+		changed = append(changed, dk)
+	}
+	if len(changed) == 0 {
+		return 0, nil
+	}
+	if err := k.store.Rewrap(changed); err != nil {
+		return 0, err
+	}
+	return len(changed), nil
+}
+
+// Encrypt mengenkripsi plaintext dengan data key aktif. aad mengikat
+// ciphertext ke kolomnya, sehingga ciphertext tidak bisa dipindah ke kolom lain.
+func (k *Keyring) Encrypt(plaintext []byte, aad string) (string, error) {
+	k.mu.RLock()
+	id := k.activeDataKey
+	aead := k.dataKeys[id]
+	k.mu.RUnlock()
+	if aead == nil {
+		return "", ErrNoActiveDataKey
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(aad))
+	return prefix + id + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt membuka nilai hasil Encrypt. Nilai tanpa prefix enc:v1: adalah data
+// lama yang belum dienkripsi dan dikembalikan apa adanya.
+func (k *Keyring) Decrypt(value string, aad string) ([]byte, error) {
+	if !IsEncrypted(value) {
+		return []byte(value), nil
+	}
+
+	id, encoded, ok := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !ok {
+		return nil, ErrMalformedCiphertext
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrMalformedCiphertext
+	}
+
+	aead, err := k.dataKey(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformedCiphertext
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(aad))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt with data key %s: %w", id, err)
+	}
+	return plaintext, nil
+}
+
+// BlindIndex menghitung HMAC-SHA256 dari nilai yang sudah dinormalisasi.
+// Hasilnya deterministik sehingga bisa dipakai untuk pencarian exact match dan
+// unique constraint, tanpa menyimpan nilai aslinya.
+func (k *Keyring) BlindIndex(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, k.indexKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsEncrypted bernilai true jika value adalah ciphertext dari Encrypt
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// dataKey mengambil data key yang sudah dibuka. Key yang belum dikenal
+// (dibuat proses lain setelah keyring dimuat) dicoba dimuat ulang sekali.
+func (k *Keyring) dataKey(id string) (cipher.AEAD, error) {
+	k.mu.RLock()
+	aead := k.dataKeys[id]
+	k.mu.RUnlock()
+	if aead != nil {
+		return aead, nil
+	}
+
+	if err := k.Reload(); err != nil {
+		return nil, err
+	}
+	k.mu.RLock()
+	aead = k.dataKeys[id]
+	k.mu.RUnlock()
+	if aead == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDataKey, id)
+	}
+	return aead, nil
+}
+
+func (k *Keyring) wrap(dk *models.DataKey, raw []byte) error {
+	master := k.masters[k.activeMaster]
+	nonce := make([]byte, master.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	dk.MasterKeyID = k.activeMaster
+	dk.WrappedKey = master.Seal(nonce, nonce, raw, []byte(dk.ID))
+	return nil
+}
+
+func (k *Keyring) unwrap(dk *models.DataKey) ([]byte, error) {
+	master, ok := k.masters[dk.MasterKeyID]
+	if !ok {
+		return nil, fmt.Errorf("data key %s is wrapped by master key %q which is not configured", dk.ID, dk.MasterKeyID)
+	}
+	if len(dk.WrappedKey) < master.NonceSize() {
+		return nil, fmt.Errorf("data key %s: %w", dk.ID, ErrMalformedCiphertext)
+	}
+	nonce, sealed := dk.WrappedKey[:master.NonceSize()], dk.WrappedKey[master.NonceSize():]
+	raw, err := master.Open(nil, nonce, sealed, []byte(dk.ID))
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key %s with master key %q: %w", dk.ID, dk.MasterKeyID, err)
+	}
+	return raw, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
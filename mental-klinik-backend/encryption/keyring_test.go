@@ -0,0 +1,246 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"mental-klinik-backend/models"
+)
+
+// memoryStore adalah KeyStore di memori
+type memoryStore struct {
+	keys  []models.DataKey
+	loads int
+}
+
+func (s *memoryStore) FindAll() ([]models.DataKey, error) {
+	s.loads++
+	return append([]models.DataKey(nil), s.keys...), nil
+}
+
+func (s *memoryStore) CreateActive(key *models.DataKey) error {
+	for i := range s.keys {
+		s.keys[i].Active = false
+	}
+	s.keys = append(s.keys, *key)
+	return nil
+}
+
+func (s *memoryStore) Rewrap(keys []models.DataKey) error {
+	for _, key := range keys {
+		for i := range s.keys {
+			if s.keys[i].ID == key.ID {
+				s.keys[i].MasterKeyID, s.keys[i].WrappedKey = key.MasterKeyID, key.WrappedKey
+			}
+		}
+	}
+	return nil
+}
+
+func testKey(fill byte) []byte {
+	return bytes.Repeat([]byte{fill}, KeySize)
+}
+
+func newTestKeyring(t *testing.T, store *memoryStore, masters ...MasterKey) *Keyring {
+	t.Helper()
+	if len(masters) == 0 {
+		masters = []MasterKey{{ID: "m1", Key: testKey(1)}}
+	}
+	k, err := NewKeyring(masters, testKey(9), store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	store := &memoryStore{}
+	k := newTestKeyring(t, store)
+	if len(store.keys) != 1 || !store.keys[0].Active || k.ActiveDataKey() != store.keys[0].ID {
+		t.Fatalf("first data key was not created: %+v", store.keys)
+	}
+
+	ciphertext, err := k.Encrypt([]byte("3201011508900001"), "patients.nik")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(ciphertext) || !strings.HasPrefix(ciphertext, prefix+k.ActiveDataKey()+":") || strings.Contains(ciphertext, "3201011508900001") {
+		t.Errorf("ciphertext = %q", ciphertext)
+	}
+	again, _ := k.Encrypt([]byte("3201011508900001"), "patients.nik")
+	if again == ciphertext {
+		t.Error("encrypting twice returned the same ciphertext")
+	}
+
+	plaintext, err := k.Decrypt(ciphertext, "patients.nik")
+	if err != nil || string(plaintext) != "3201011508900001" {
+		t.Errorf("Decrypt = %q, %v", plaintext, err)
+	}
+}
+
+func TestDecryptAADMismatch(t *testing.T) {
+	k := newTestKeyring(t, &memoryStore{})
+	ciphertext, err := k.Encrypt([]byte("3201011508900001"), "patients.nik")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Ciphertext yang dipindah ke kolom lain tidak bisa dibuka
+	if plaintext, err := k.Decrypt(ciphertext, "patients.phone"); err == nil {
+		t.Fatalf("Decrypt with another column = %q, want error", plaintext)
+	}
+}
+
+func TestDecryptUnknownKey(t *testing.T) {
+	store := &memoryStore{}
+	k := newTestKeyring(t, store)
+	loads := store.loads
+
+	_, err := k.Decrypt(prefix+"01UNKNOWN:"+base64.RawStdEncoding.EncodeToString(make([]byte, 32)), "patients.nik")
+	if !errors.Is(err, ErrUnknownDataKey) {
+		t.Fatalf("Decrypt = %v, want ErrUnknownDataKey", err)
+	}
+	if store.loads != loads+1 {
+		t.Errorf("store loaded %d times, want one reload", store.loads-loads)
+	}
+}
+
+func TestDecryptMalformed(t *testing.T) {
+	k := newTestKeyring(t, &memoryStore{})
+	for _, value := range []string{prefix + "no-separator", prefix + k.ActiveDataKey() + ":not base64!", prefix + k.ActiveDataKey() + ":AAAA"} {
+		if _, err := k.Decrypt(value, "patients.nik"); !errors.Is(err, ErrMalformedCiphertext) {
+			t.Errorf("Decrypt(%q) = %v, want ErrMalformedCiphertext", value, err)
+		}
+	}
+}
+
+func TestDecryptLegacyPlaintext(t *testing.T) {
+	k := newTestKeyring(t, &memoryStore{})
+	plaintext, err := k.Decrypt("3201011508900001", "patients.nik")
+	if err != nil || string(plaintext) != "3201011508900001" {
+		t.Errorf("Decrypt = %q, %v", plaintext, err)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	store := &memoryStore{}
+	k := newTestKeyring(t, store)
+	oldKey := k.ActiveDataKey()
+	old, err := k.Encrypt([]byte("F32.1"), "medical_records.diagnosis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Proses lain yang memuat store sebelum rotasi
+	other := newTestKeyring(t, store)
+
+	newKey, err := k.Rotate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newKey == oldKey || k.ActiveDataKey() != newKey {
+		t.Fatalf("active data key = %s, want new key %s", k.ActiveDataKey(), newKey)
+	}
+	if len(store.keys) != 2 || store.keys[0].Active || !store.keys[1].Active {
+		t.Errorf("stored keys = %+v, want only the new key active", store.keys)
+	}
+
+	fresh, _ := k.Encrypt([]byte("F32.1"), "medical_records.diagnosis")
+	if !strings.HasPrefix(fresh, prefix+newKey+":") {
+		t.Errorf("new ciphertext %q does not use the new data key", fresh)
+	}
+	if plaintext, err := k.Decrypt(old, "medical_records.diagnosis"); err != nil || string(plaintext) != "F32.1" {
+		t.Errorf("Decrypt old ciphertext = %q, %v", plaintext, err)
+	}
+
+	// Proses lain tetap bisa membaca ciphertext baru lewat reload
+	if plaintext, err := other.Decrypt(fresh, "medical_records.diagnosis"); err != nil || string(plaintext) != "F32.1" {
+		t.Errorf("Decrypt in another keyring = %q, %v", plaintext, err)
+	}
+}
+
+func TestRewrap(t *testing.T) {
+	store := &memoryStore{}
+	oldMaster := MasterKey{ID: "m1", Key: testKey(1)}
+	newMaster := MasterKey{ID: "m2", Key: testKey(2)}
+
+	k := newTestKeyring(t, store, oldMaster)
+	first, _ := k.Encrypt([]byte("3201011508900001"), "patients.nik")
+	if _, err := k.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	second, _ := k.Encrypt([]byte("08123456789"), "patients.phone")
+
+	// Master key baru di depan, master key lama tetap ada selama rotasi
+	rotating := newTestKeyring(t, store, newMaster, oldMaster)
+	n, err := rotating.Rewrap()
+	if err != nil || n != 2 {
+		t.Fatalf("Rewrap = %d, %v, want 2 keys", n, err)
+	}
+	for _, dk := range store.keys {
+		if dk.MasterKeyID != "m2" {
+			t.Errorf("data key %s is still wrapped by %s", dk.ID, dk.MasterKeyID)
+		}
+	}
+	if n, err := rotating.Rewrap(); err != nil || n != 0 {
+		t.Errorf("second Rewrap = %d, %v, want nothing to do", n, err)
+	}
+
+	// Setelah rewrap master key lama boleh dihapus dari konfigurasi
+	k = newTestKeyring(t, store, newMaster)
+	if plaintext, err := k.Decrypt(first, "patients.nik"); err != nil || string(plaintext) != "3201011508900001" {
+		t.Errorf("Decrypt first = %q, %v", plaintext, err)
+	}
+	if plaintext, err := k.Decrypt(second, "patients.phone"); err != nil || string(plaintext) != "08123456789" {
+		t.Errorf("Decrypt second = %q, %v", plaintext, err)
+	}
+
+	// Tanpa master key yang membungkusnya, data key tidak bisa dibuka
+	if _, err := NewKeyring([]MasterKey{oldMaster}, testKey(9), store); err == nil {
+		t.Error("NewKeyring with only the retired master key succeeded")
+	}
+}
+
+func TestBlindIndex(t *testing.T) {
+	k := newTestKeyring(t, &memoryStore{})
+	index := k.BlindIndex("3201011508900001")
+	if index == "" || strings.Contains(index, "3201011508900001") {
+		t.Fatalf("BlindIndex = %q", index)
+	}
+	if again := k.BlindIndex(" 3201011508900001 "); again != index {
+		t.Errorf("BlindIndex is not deterministic: %q != %q", again, index)
+	}
+	if other := k.BlindIndex("3201011508900002"); other == index {
+		t.Error("different NIKs have the same blind index")
+	}
+	if empty := k.BlindIndex("  "); empty != "" {
+		t.Errorf("BlindIndex of an empty value = %q", empty)
+	}
+
+	otherKey, err := NewKeyring([]MasterKey{{ID: "m1", Key: testKey(1)}}, testKey(8), &memoryStore{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if otherKey.BlindIndex("3201011508900001") == index {
+		t.Error("blind index does not depend on the index key")
+	}
+}
+
+func TestParseMasterKeys(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString(testKey(1))
+	keys, err := ParseMasterKeys([]string{"m2:" + encoded, " m1 :" + encoded})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0].ID != "m2" || keys[1].ID != "m1" || !bytes.Equal(keys[1].Key, testKey(1)) {
+		t.Errorf("ParseMasterKeys = %+v", keys)
+	}
+
+	short := base64.StdEncoding.EncodeToString(testKey(1)[:16])
+	for _, values := range [][]string{nil, {encoded}, {":" + encoded}, {"m1:" + short}, {"m1:not base64"}, {"m1:" + encoded, "m1:" + encoded}} {
+		if _, err := ParseMasterKeys(values); err == nil {
+			t.Errorf("ParseMasterKeys(%q) succeeded", values)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package encryption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+
+	"gorm.io/gorm/schema"
+)
+
+// SerializerName adalah nama serializer GORM untuk field terenkripsi:
+//
+//	NIK string `gorm:"serializer:encrypted"`
+//
+// Field tetap bertipe string (atau []byte seperti datatypes.JSON) di model;
+// enkripsi saat menulis dan dekripsi saat membaca terjadi di serializer.
+const SerializerName = "encrypted"
+
+var errKeyringNotConfigured = errors.New("encryption keyring is not configured")
+
+// activeKeyring adalah keyring yang dipakai serializer. Serializer GORM
+// didaftarkan secara global, jadi keyring-nya juga global.
+var activeKeyring atomic.Pointer[Keyring]
+
+func init() {
+	schema.RegisterSerializer(SerializerName, fieldSerializer{})
+}
+
+// Use memasang keyring untuk serializer field terenkripsi. Harus dipanggil
+// saat startup sebelum model terenkripsi dibaca atau ditulis.
+func Use(k *Keyring) {
+	activeKeyring.Store(k)
+}
+
+type fieldSerializer struct{}
+
+// Scan mendekripsi nilai dari database ke field string atau []byte
+func (fieldSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	target := reflect.New(field.FieldType).Elem()
+
+	var stored string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		stored = v
+	case []byte:
+		stored = string(v)
+	default:
+		return fmt.Errorf("encrypted field %s: unsupported database type %T", field.Name, dbValue)
+	}
+
+	if stored != "" {
+		k := activeKeyring.Load()
+		if k == nil {
+			return errKeyringNotConfigured
+		}
+		plaintext, err := k.Decrypt(stored, associatedData(field))
+		if err != nil {
+			return fmt.Errorf("encrypted field %s: %w", field.Name, err)
+		}
+		if err := setPlaintext(target, plaintext); err != nil {
+			return fmt.Errorf("encrypted field %s: %w", field.Name, err)
+		}
+	}
+
+	field.ReflectValueOf(ctx, dst).Set(target)
+	return nil
+}
+
+// Value mengenkripsi nilai field sebelum ditulis. Nilai kosong disimpan
+// apa adanya (string kosong atau NULL).
+func (fieldSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(fieldValue)
+	var plaintext []byte
+	switch {
+	case !rv.IsValid():
+		return nil, nil
+	case rv.Kind() == reflect.String:
+		if rv.Len() == 0 {
+			return "", nil
+		}
+		plaintext = []byte(rv.String())
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+		if rv.Len() == 0 {
+			return nil, nil
+		}
+		plaintext = rv.Bytes()
+	default:
+		return nil, fmt.Errorf("encrypted field %s: unsupported type %s", field.Name, rv.Type())
+	}
+
+	k := activeKeyring.Load()
+	if k == nil {
+		return nil, errKeyringNotConfigured
+	}
+	return k.Encrypt(plaintext, associatedData(field))
+}
+
+// associatedData mengikat ciphertext ke tabel dan kolomnya
+func associatedData(field *schema.Field) string {
+	return field.Schema.Table + "." + field.DBName
+}
+
+func setPlaintext(target reflect.Value, plaintext []byte) error {
+	switch {
+	case target.Kind() == reflect.String:
+		target.SetString(string(plaintext))
+	case target.Kind() == reflect.Slice && target.Type().Elem().Kind() == reflect.Uint8:
+		target.SetBytes(plaintext)
+	default:
+		return fmt.Errorf("unsupported type %s", target.Type())
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package encryption
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm/schema"
+)
+
+var serializer fieldSerializer
+
+type secretNote struct {
+	ID      string
+	Note    string `gorm:"serializer:encrypted"`
+	Answers []byte `gorm:"serializer:encrypted"`
+}
+
+func parseField(t *testing.T, name string) *schema.Field {
+	t.Helper()
+	s, err := schema.Parse(&secretNote{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s.LookUpField(name)
+}
+
+func useTestKeyring(t *testing.T) *Keyring {
+	t.Helper()
+	k := newTestKeyring(t, &memoryStore{})
+	previous := activeKeyring.Load()
+	Use(k)
+	t.Cleanup(func() { activeKeyring.Store(previous) })
+	return k
+}
+
+func TestSerializerRoundTrip(t *testing.T) {
+	k := useTestKeyring(t)
+	ctx := context.Background()
+	for _, tt := range []struct {
+		field string
+		value any
+	}{
+		{"Note", "pasien mengeluh sulit tidur"},
+		{"Answers", []byte(`{"q1":3}`)},
+	} {
+		field := parseField(t, tt.field)
+		stored, err := serializer.Value(ctx, field, reflect.Value{}, tt.value)
+		if err != nil {
+			t.Fatalf("%s: Value: %v", tt.field, err)
+		}
+		ciphertext, ok := stored.(string)
+		if !ok || !IsEncrypted(ciphertext) {
+			t.Fatalf("%s: stored %#v, want ciphertext", tt.field, stored)
+		}
+		// AAD adalah tabel dan kolom
+		aad := "secret_notes." + field.DBName
+		if _, err := k.Decrypt(ciphertext, aad); err != nil {
+			t.Errorf("%s: Decrypt with %s: %v", tt.field, aad, err)
+		}
+
+		var row secretNote
+		if err := serializer.Scan(ctx, field, reflect.ValueOf(&row).Elem(), ciphertext); err != nil {
+			t.Fatalf("%s: Scan: %v", tt.field, err)
+		}
+		if got := field.ReflectValueOf(ctx, reflect.ValueOf(&row).Elem()).Interface(); !reflect.DeepEqual(got, tt.value) {
+			t.Errorf("%s: scanned %#v, want %#v", tt.field, got, tt.value)
+		}
+	}
+}
+
+func TestSerializerLegacyPlaintext(t *testing.T) {
+	useTestKeyring(t)
+	ctx := context.Background()
+	field := parseField(t, "Note")
+
+	// Nilai lama yang belum dienkripsi ulang dibaca apa adanya
+	for _, dbValue := range []any{"catatan lama", []byte("catatan lama")} {
+		var row secretNote
+		if err := serializer.Scan(ctx, field, reflect.ValueOf(&row).Elem(), dbValue); err != nil {
+			t.Fatalf("Scan(%#v): %v", dbValue, err)
+		}
+		if row.Note != "catatan lama" {
+			t.Errorf("Scan(%#v) = %q", dbValue, row.Note)
+		}
+	}
+
+	row := secretNote{Note: "sisa nilai lama"}
+	if err := serializer.Scan(ctx, field, reflect.ValueOf(&row).Elem(), nil); err != nil || row.Note != "" {
+		t.Errorf("Scan(NULL) = %q, %v", row.Note, err)
+	}
+}
+
+func TestSerializerEmptyValues(t *testing.T) {
+	useTestKeyring(t)
+	ctx := context.Background()
+	if stored, err := serializer.Value(ctx, parseField(t, "Note"), reflect.Value{}, ""); err != nil || stored != "" {
+		t.Errorf("Value(\"\") = %#v, %v", stored, err)
+	}
+	if stored, err := serializer.Value(ctx, parseField(t, "Answers"), reflect.Value{}, []byte(nil)); err != nil || stored != nil {
+		t.Errorf("Value(nil bytes) = %#v, %v", stored, err)
+	}
+	if _, err := serializer.Value(ctx, parseField(t, "Note"), reflect.Value{}, 42); err == nil {
+		t.Error("Value(int) succeeded")
+	}
+}
+
+func TestSerializerWithoutKeyring(t *testing.T) {
+	previous := activeKeyring.Swap(nil)
+	t.Cleanup(func() { activeKeyring.Store(previous) })
+
+	_, err := serializer.Value(context.Background(), parseField(t, "Note"), reflect.Value{}, "rahasia")
+	if err == nil || !strings.Contains(err.Error(), "not configured") {
+		t.Errorf("Value = %v, want keyring error", err)
+	}
+}
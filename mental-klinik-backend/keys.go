@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"mental-klinik-backend/config"
+	"mental-klinik-backend/databases"
+	"mental-klinik-backend/encryption"
+	"mental-klinik-backend/repositories"
+)
+
+const keysUsage = `usage:
+  go run main.go keys status [flags]      tampilkan data key dan jumlah nilai terenkripsi per key
+  go run main.go keys rotate [flags]      buat data key baru yang aktif untuk enkripsi berikutnya
+  go run main.go keys rewrap [flags]      bungkus ulang data key dengan master key aktif (pertama
+                                          di ENCRYPTION_MASTER_KEYS) setelah master key dirotasi
+  go run main.go keys reencrypt [flags]   enkripsi ulang semua field dengan data key aktif dan
+                                          hitung ulang blind index NIK (juga untuk data lama)`
+
+// runKeys menjalankan subcommand keys untuk rotasi kunci enkripsi field
+func runKeys(args []string) error {
+	if len(args) == 0 {
+		return errors.New(keysUsage)
+	}
+	action, args := args[0], args[1:]
+
+	cfg, err := config.Load(args)
+	if err != nil {
+		return err
+	}
+	db := database.ConnectDB(cfg.Database)
+	if err := database.EnsureSchemaUpToDate(db); err != nil {
+		return err
+	}
+	keyring, store, err := openKeyring(cfg, db)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "status":
+		keys, err := store.FindAll()
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			state := ""
+			if key.Active {
+				state = "active"
+			}
+			fmt.Printf("%-26s master=%-10s created=%s %s\n",
+				key.ID, key.MasterKeyID, key.CreatedAt.Format("2006-01-02 15:04:05"), state)
+		}
+		usage, err := store.Usage()
+		if err != nil {
+			return err
+		}
+		for _, u := range usage {
+			keyID := u.DataKeyID
+			if keyID == "" {
+				keyID = "(plaintext)"
+			}
+			fmt.Printf("%-30s %-26s %d\n", u.Table+"."+u.Column, keyID, u.Count)
+		}
+	case "rotate":
+		id, err := keyring.Rotate()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created data key %s (active)\n", id)
+		fmt.Println("restart the server to encrypt new data with this key, then run: keys reencrypt")
+	case "rewrap":
+		n, err := keyring.Rewrap()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("rewrapped %d data key(s) with master key %s\n", n, keyring.ActiveMasterKey())
+	case "reencrypt":
+		n, err := store.Reencrypt(keyring)
+		if err != nil {
+			return fmt.Errorf("reencrypt (after %d rows): %w", n, err)
+		}
+		fmt.Printf("re-encrypted %d row(s) with data key %s\n", n, keyring.ActiveDataKey())
+	default:
+		return fmt.Errorf("unknown keys command %q\n%s", action, keysUsage)
+	}
+	return nil
+}
+
+// openKeyring memuat master key dari konfigurasi dan data key dari database,
+// lalu memasang keyring untuk serializer field terenkripsi
+func openKeyring(cfg *config.Config, db *gorm.DB) (*encryption.Keyring, repositories.EncryptionRepository, error) {
+	masters, err := encryption.ParseMasterKeys(cfg.Encryption.MasterKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+	indexKey, err := encryption.ParseKey(cfg.Encryption.IndexKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	store := repositories.NewEncryptionRepository(db)
+	keyring, err := encryption.NewKeyring(masters, indexKey, store)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load encryption keys: %w", err)
+	}
+	encryption.Use(keyring)
+	return keyring, store, nil
+}
@@ -0,0 +1,255 @@
+package listquery
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Paging keyset: data diurutkan berdasarkan kolom sort ditambah id sebagai
+// pemutus seri, lalu halaman berikutnya diambil dengan kondisi
+// "(sort key, id) setelah baris terakhir" alih-alih OFFSET. Cursor berisi nilai
+// sort key dan id baris acuan, di-encode base64 supaya opaque bagi client.
+
+// cursorToken adalah isi cursor sebelum di-encode
+type cursorToken struct {
+	Sort     string   `json:"s"` // signature sort, cursor hanya valid untuk sort yang sama
+	Keys     []string `json:"k"` // nilai kolom sort baris acuan, urut sesuai Sorts
+	Backward bool     `json:"b"` // true untuk prevCursor
+}
+
+// Page adalah metadata hasil Find
+type Page struct {
+	Total      *int64 // nil jika total tidak dihitung (mode cursor tanpa withTotal)
+	NextCursor string
+	PrevCursor string
+}
+
+func (q *Query) parseCursor(values url.Values, spec Spec) error {
+	if !spec.Cursor {
+		return &Error{Param: "cursor", Message: "cursor pagination is not supported by this endpoint"}
+	}
+	if values.Get("page") != "" {
+		return &Error{Param: "page", Message: "page cannot be combined with cursor"}
+	}
+	q.Cursor, q.Page, q.Offset = true, 0, 0
+
+	if raw := strings.TrimSpace(values.Get("withTotal")); raw != "" {
+		withTotal, err := strconv.ParseBool(raw)
+		if err != nil {
+			return &Error{Param: "withTotal", Message: "must be a boolean"}
+		}
+		q.WithTotal = withTotal
+	}
+
+	// id wajib ada di akhir sort supaya urutan unik
+	id, ok := spec.field("id")
+	if !ok || !id.Sortable {
+		return &Error{Param: "cursor", Message: "cursor pagination is not supported by this endpoint"}
+	}
+	hasID := false
+	for _, s := range q.Sorts {
+		hasID = hasID || s.Column == id.Column
+	}
+	if !hasID {
+		desc := len(q.Sorts) > 0 && q.Sorts[len(q.Sorts)-1].Desc
+		q.Sorts = append(q.Sorts, Sort{Name: id.Name, Column: id.Column, Type: id.Type, Desc: desc})
+	}
+
+	// Cursor menyimpan nilai sort key baris acuan, NULL tidak bisa dibandingkan
+	// dengan > / < sehingga kolom nullable ditolak
+	for _, s := range q.Sorts {
+		if field, _ := spec.field(s.Name); field.Nullable {
+			return &Error{Param: "sort", Message: fmt.Sprintf("%q can be empty and cannot be used as a sort field with cursor", s.Name)}
+		}
+	}
+
+	raw := strings.TrimSpace(values.Get("cursor"))
+	if raw == "" {
+		return nil // halaman pertama
+	}
+
+	token, err := decodeCursor(raw)
+	if err != nil || len(token.Keys) != len(q.Sorts) {
+		return &Error{Param: "cursor", Message: "invalid cursor"}
+	}
+	if token.Sort != q.sortSignature() {
+		return &Error{Param: "cursor", Message: "cursor was issued for a different sort"}
+	}
+	for i, s := range q.Sorts {
+		if _, err := parseKey(s.Type, token.Keys[i]); err != nil {
+			return &Error{Param: "cursor", Message: "invalid cursor"}
+		}
+	}
+	q.position = token
+	return nil
+}
+
+func (q *Query) sortSignature() string {
+	parts := make([]string, len(q.Sorts))
+	for i, s := range q.Sorts {
+		parts[i] = s.Column
+		if s.Desc {
+			parts[i] = "-" + s.Column
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func decodeCursor(raw string) (*cursorToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, err
+	}
+	var token cursorToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func encodeCursor(token cursorToken) string {
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func parseKey(t Type, raw string) (any, error) {
+	switch t {
+	case Number:
+		return strconv.ParseFloat(raw, 64)
+	case Time:
+		return time.Parse(time.RFC3339Nano, raw)
+	default:
+		return raw, nil
+	}
+}
+
+// applyCursor menambahkan kondisi keyset, ORDER BY dan LIMIT (+1 baris untuk
+// mengetahui apakah masih ada halaman berikutnya)
+func (q *Query) applyCursor(db *gorm.DB) *gorm.DB {
+	backward := q.position != nil && q.position.Backward
+
+	if q.position != nil {
+		// (a, b, id) > (va, vb, vid) dengan arah per kolom:
+		// a > va OR (a = va AND b > vb) OR (a = va AND b = vb AND id > vid)
+		var conds []string
+		var args []any
+		for i, s := range q.Sorts {
+			var parts []string
+			for j := 0; j < i; j++ {
+				key, _ := parseKey(q.Sorts[j].Type, q.position.Keys[j])
+				parts = append(parts, q.Sorts[j].Column+" = ?")
+				args = append(args, key)
+			}
+			op := ">"
+			if s.Desc != backward {
+				op = "<"
+			}
+			key, _ := parseKey(s.Type, q.position.Keys[i])
+			parts = append(parts, s.Column+" "+op+" ?")
+			args = append(args, key)
+			conds = append(conds, "("+strings.Join(parts, " AND ")+")")
+		}
+		db = db.Where("("+strings.Join(conds, " OR ")+")", args...)
+	}
+
+	for _, s := range q.Sorts {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: s.Column, Raw: true}, Desc: s.Desc != backward})
+	}
+	return db.Limit(q.Limit + 1)
+}
+
+// cursorFor membuat cursor dari nilai kolom sort pada row. Nilai diambil dari
+// JSON row berdasarkan Sort.Name, sehingga nama field di Spec harus sama
+// dengan tag json model.
+func (q *Query) cursorFor(row any, backward bool) (string, error) {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return "", err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", err
+	}
+
+	keys := make([]string, len(q.Sorts))
+	for i, s := range q.Sorts {
+		switch v := fields[s.Name].(type) {
+		case string:
+			keys[i] = v
+		case float64:
+			keys[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return "", fmt.Errorf("listquery: field %q is missing from row JSON", s.Name)
+		}
+	}
+	return encodeCursor(cursorToken{Sort: q.sortSignature(), Keys: keys, Backward: backward}), nil
+}
+
+// Find menjalankan query list pada db yang sudah berisi kondisi tambahan
+// (search, preload, join). Filter, sort dan paging (offset atau cursor)
+// diterapkan di sini. Total dihitung pada mode offset, dan pada mode cursor
+// hanya jika WithTotal.
+func Find[T any](db *gorm.DB, q *Query) ([]T, Page, error) {
+	var page Page
+	db = q.ApplyFilters(db)
+
+	if !q.Cursor || q.WithTotal {
+		var total int64
+		if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return nil, page, err
+		}
+		page.Total = &total
+	}
+
+	var rows []T
+	if !q.Cursor {
+		err := q.ApplyPage(q.ApplySort(db)).Find(&rows).Error
+		return rows, page, err
+	}
+
+	if err := q.applyCursor(db).Find(&rows).Error; err != nil {
+		return nil, page, err
+	}
+
+	hasMore := len(rows) > q.Limit
+	if hasMore {
+		rows = rows[:q.Limit]
+	}
+	backward := q.position != nil && q.position.Backward
+	if backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+	if len(rows) == 0 {
+		return rows, page, nil
+	}
+
+	// Arah maju: ada next jika masih ada sisa, ada prev jika bukan halaman
+	// pertama. Arah mundur kebalikannya.
+	hasNext, hasPrev := hasMore, q.position != nil
+	if backward {
+		hasNext, hasPrev = true, hasMore
+	}
+
+	var err error
+	if hasNext {
+		if page.NextCursor, err = q.cursorFor(rows[len(rows)-1], false); err != nil {
+			return nil, page, err
+		}
+	}
+	if hasPrev {
+		if page.PrevCursor, err = q.cursorFor(rows[0], true); err != nil {
+			return nil, page, err
+		}
+	}
+	return rows, page, nil
+}
@@ -0,0 +1,147 @@
+package listquery
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testRow struct {
+	ID         string     `json:"id"`
+	Status     string     `json:"status"`
+	Score      float64    `json:"score"`
+	ScheduleAt time.Time  `json:"scheduleAt"`
+	EndDate    *time.Time `json:"endDate"`
+}
+
+func TestParseCursorAppendsID(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"cursor=", "-appointments.schedule_at,-appointments.id"},
+		{"cursor=&sort=status", "appointments.status,appointments.id"},
+		{"cursor=&sort=-id", "-appointments.id"},
+		{"cursor=&sort=id,-score", "appointments.id,-score"},
+	}
+	for _, tt := range tests {
+		q, err := parse(t, tt.raw, testSpec)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.raw, err)
+		}
+		if !q.Cursor || q.Page != 0 || q.Offset != 0 || q.position != nil {
+			t.Errorf("%q: query %+v is not a first cursor page", tt.raw, q)
+		}
+		if got := q.sortSignature(); got != tt.want {
+			t.Errorf("%q: sort = %s, want %s", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	scheduleAt := time.Date(2024, 5, 1, 9, 30, 0, 123000000, time.UTC)
+	row := testRow{ID: "appointment-007-abcdefgh", Status: "pending", Score: 0.75, ScheduleAt: scheduleAt}
+
+	tests := []struct {
+		sort     string
+		backward bool
+		keys     []string
+	}{
+		{"-scheduleAt", false, []string{"2024-05-01T09:30:00.123Z", "appointment-007-abcdefgh"}},
+		{"status,score", true, []string{"pending", "0.75", "appointment-007-abcdefgh"}},
+	}
+	for _, tt := range tests {
+		first, err := parse(t, "cursor=&sort="+tt.sort, testSpec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cursor, err := first.cursorFor(row, tt.backward)
+		if err != nil {
+			t.Fatalf("%s: cursorFor: %v", tt.sort, err)
+		}
+		if strings.ContainsAny(cursor, "+/=") {
+			t.Errorf("%s: cursor %q is not URL safe", tt.sort, cursor)
+		}
+
+		next, err := parse(t, "cursor="+cursor+"&sort="+tt.sort+"&withTotal=true", testSpec)
+		if err != nil {
+			t.Fatalf("%s: parse cursor: %v", tt.sort, err)
+		}
+		if next.position == nil || next.position.Backward != tt.backward || strings.Join(next.position.Keys, "|") != strings.Join(tt.keys, "|") {
+			t.Errorf("%s: position = %+v, want keys %v backward %v", tt.sort, next.position, tt.keys, tt.backward)
+		}
+		if !next.WithTotal {
+			t.Errorf("%s: withTotal was not parsed", tt.sort)
+		}
+
+		// Cursor hanya berlaku untuk sort yang sama
+		_, err = parse(t, "cursor="+cursor+"&sort=fullName", testSpec)
+		assertParam(t, err, "cursor")
+	}
+}
+
+func TestParseCursorErrors(t *testing.T) {
+	valid := encodeCursor(cursorToken{Sort: "-appointments.schedule_at,-appointments.id", Keys: []string{"2024-05-01T09:30:00Z", "appointment-001"}})
+	badKey := encodeCursor(cursorToken{Sort: "-appointments.schedule_at,-appointments.id", Keys: []string{"yesterday", "appointment-001"}})
+	fewKeys := encodeCursor(cursorToken{Sort: "-appointments.schedule_at,-appointments.id", Keys: []string{"2024-05-01T09:30:00Z"}})
+
+	if _, err := parse(t, "cursor="+valid, testSpec); err != nil {
+		t.Fatalf("valid cursor: %v", err)
+	}
+	tests := []struct {
+		raw   string
+		spec  Spec
+		param string
+	}{
+		{"cursor=not-base64!", testSpec, "cursor"},
+		{"cursor=" + badKey, testSpec, "cursor"},
+		{"cursor=" + fewKeys, testSpec, "cursor"},
+		{"cursor=" + valid + "&page=2", testSpec, "page"},
+		{"cursor=&withTotal=maybe", testSpec, "withTotal"},
+		{"cursor=", Spec{Fields: testSpec.Fields, DefaultSort: "-scheduleAt"}, "cursor"},
+		{"cursor=", Spec{Fields: testSpec.Fields[1:], DefaultSort: "-scheduleAt", Cursor: true}, "cursor"},
+	}
+	for _, tt := range tests {
+		_, err := parse(t, tt.raw, tt.spec)
+		assertParam(t, err, tt.param)
+	}
+}
+
+func TestCursorNullableSort(t *testing.T) {
+	// Offset paging boleh sort kolom nullable
+	if _, err := parse(t, "sort=endDate", testSpec); err != nil {
+		t.Fatalf("offset paging: %v", err)
+	}
+
+	// Cursor paging menolaknya (400) alih-alih gagal saat membuat cursor
+	for _, raw := range []string{"cursor=&sort=endDate", "cursor=&sort=status,-endDate"} {
+		_, err := parse(t, raw, testSpec)
+		assertParam(t, err, "sort")
+	}
+	_, err := parse(t, "cursor=", Spec{Fields: testSpec.Fields, DefaultSort: "endDate", Cursor: true})
+	assertParam(t, err, "sort")
+}
+
+func TestCursorForMissingField(t *testing.T) {
+	q := &Query{Sorts: []Sort{{Name: "endDate", Column: "end_date", Type: Time}, {Name: "id", Column: "id"}}}
+	if _, err := q.cursorFor(testRow{ID: "x"}, false); err == nil {
+		t.Fatal("cursorFor with a NULL sort key succeeded")
+	}
+	endDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := q.cursorFor(testRow{ID: "x", EndDate: &endDate}, false); err != nil {
+		t.Fatalf("cursorFor: %v", err)
+	}
+}
+
+func assertParam(t *testing.T, err error, param string) {
+	t.Helper()
+	var queryErr *Error
+	if !errors.As(err, &queryErr) {
+		t.Errorf("error = %v, want *Error for %q", err, param)
+		return
+	}
+	if queryErr.Param != param {
+		t.Errorf("param = %q (%s), want %q", queryErr.Param, queryErr.Message, param)
+	}
+}
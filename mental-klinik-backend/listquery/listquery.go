@@ -0,0 +1,387 @@
+// Package listquery mem-parse parameter list endpoint (filter, sort, paging)
+// berdasarkan whitelist kolom per resource, lalu menerapkannya ke query GORM.
+// Nama kolom SQL hanya berasal dari Spec, tidak pernah dari input user.
+//
+// Format query string:
+//
+//	?status=pending                   filter eq
+//	?status[in]=pending,done          filter in (dipisah koma)
+//	?createdAt[gte]=2024-01-01        filter gte / lte
+//	?fullName[ilike]=budi             filter ilike (mengandung teks)
+//	?sort=-scheduleAt,fullName        sort multi kolom, "-" berarti descending
+//	?page=2&limit=20                  paging, limit dibatasi Spec.MaxLimit
+//	?cursor=&limit=20                 paging keyset (lihat cursor.go), halaman pertama
+//	?cursor=<nextCursor>&withTotal=1  halaman berikutnya, ikut hitung total
+package listquery
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	DefaultLimit = 10
+	MaxLimit     = 100
+
+	maxInValues = 100
+	maxSorts    = 5
+)
+
+type Op string
+
+const (
+	OpEq    Op = "eq"
+	OpIn    Op = "in"
+	OpGte   Op = "gte"
+	OpLte   Op = "lte"
+	OpIlike Op = "ilike"
+)
+
+// Kumpulan operator yang umum dipakai
+var (
+	EqualityOps = []Op{OpEq, OpIn}
+	RangeOps    = []Op{OpEq, OpGte, OpLte}
+	TextOps     = []Op{OpEq, OpIn, OpIlike}
+)
+
+// Type menentukan cara value filter di-parse
+type Type int
+
+const (
+	String Type = iota
+	Number
+	Time
+)
+
+// Field adalah satu kolom yang boleh di-sort dan/atau difilter
+type Field struct {
+	Name     string // nama di query string, camelCase seperti JSON response
+	Column   string // kolom SQL, boleh dengan prefix tabel
+	Type     Type
+	Sortable bool
+	Ops      []Op // operator filter yang diizinkan, kosong berarti tidak bisa difilter
+	// Nullable menandai kolom yang boleh NULL. Kolom ini bisa di-sort pada
+	// paging offset, tapi tidak pada paging cursor.
+	Nullable bool
+}
+
+// Spec mendeskripsikan parameter list yang diterima satu resource
+type Spec struct {
+	Fields       []Field
+	DefaultSort  string   // format sama dengan param sort, misal "-createdAt"
+	DefaultLimit int      // 0 berarti DefaultLimit
+	MaxLimit     int      // 0 berarti MaxLimit
+	Params       []string // param lain yang ditangani endpoint sendiri (misal "search")
+	Cursor       bool     // true jika endpoint mendukung paging ?cursor=
+}
+
+type Sort struct {
+	Name   string
+	Column string
+	Type   Type
+	Desc   bool
+}
+
+type Filter struct {
+	Column string
+	Op     Op
+	Values []any
+}
+
+// Query adalah hasil Parse yang sudah tervalidasi
+type Query struct {
+	Page    int
+	Limit   int
+	Offset  int
+	Sorts   []Sort
+	Filters []Filter
+
+	Cursor    bool // mode keyset, Page dan Offset tidak dipakai
+	WithTotal bool // mode keyset: hitung total juga (COUNT(*))
+	position  *cursorToken
+}
+
+// Error dikembalikan Parse untuk parameter yang tidak dikenal atau tidak valid
+type Error struct {
+	Param   string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid query parameter %q: %s", e.Param, e.Message)
+}
+
+// param yang selalu dikenali. sortBy/sortOrder adalah nama lama dari sort/order.
+var reservedParams = map[string]bool{
+	"page": true, "limit": true, "sort": true, "order": true, "sortBy": true, "sortOrder": true,
+	"cursor": true, "withTotal": true,
+}
+
+var paramPattern = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)(?:\[([a-z]+)\])?$`)
+
+// Parse memvalidasi values terhadap spec
+func Parse(values url.Values, spec Spec) (*Query, error) {
+	q := &Query{}
+
+	page, err := parsePositiveInt(values, "page", 1)
+	if err != nil {
+		return nil, err
+	}
+	defaultLimit := spec.DefaultLimit
+	if defaultLimit <= 0 {
+		defaultLimit = DefaultLimit
+	}
+	maxLimit := spec.MaxLimit
+	if maxLimit <= 0 {
+		maxLimit = MaxLimit
+	}
+	limit, err := parsePositiveInt(values, "limit", defaultLimit)
+	if err != nil {
+		return nil, err
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	q.Page, q.Limit, q.Offset = page, limit, (page-1)*limit
+
+	if q.Sorts, err = parseSorts(values, spec); err != nil {
+		return nil, err
+	}
+
+	if _, ok := values["cursor"]; ok {
+		if err := q.parseCursor(values, spec); err != nil {
+			return nil, err
+		}
+	}
+
+	extra := map[string]bool{}
+	for _, p := range spec.Params {
+		extra[p] = true
+	}
+
+	// urutkan key supaya SQL yang dihasilkan selalu sama untuk input yang sama
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if reservedParams[key] || extra[key] {
+			continue
+		}
+		raws := values[key]
+
+		match := paramPattern.FindStringSubmatch(key)
+		if match == nil {
+			return nil, &Error{Param: key, Message: "unknown parameter"}
+		}
+		field, ok := spec.field(match[1])
+		if !ok || len(field.Ops) == 0 {
+			return nil, &Error{Param: key, Message: "unknown filter field"}
+		}
+
+		op := OpEq
+		if match[2] != "" {
+			op = Op(match[2])
+		}
+		if !field.allows(op) {
+			return nil, &Error{Param: key, Message: fmt.Sprintf("operator %q is not allowed for this field", op)}
+		}
+
+		for _, raw := range raws {
+			filter, err := parseFilter(key, field, op, raw)
+			if err != nil {
+				return nil, err
+			}
+			q.Filters = append(q.Filters, filter)
+		}
+	}
+	return q, nil
+}
+
+func parsePositiveInt(values url.Values, key string, fallback int) (int, error) {
+	raw := strings.TrimSpace(values.Get(key))
+	if raw == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		return 0, &Error{Param: key, Message: "must be a positive integer"}
+	}
+	return n, nil
+}
+
+func parseSorts(values url.Values, spec Spec) ([]Sort, error) {
+	sortKey, orderKey := "sort", "order"
+	if values.Get(sortKey) == "" && values.Get("sortBy") != "" {
+		sortKey = "sortBy"
+	}
+	if values.Get(orderKey) == "" && values.Get("sortOrder") != "" {
+		orderKey = "sortOrder"
+	}
+
+	// order hanya berlaku untuk kolom sort tanpa prefix "-"/"+"
+	defaultDesc := false
+	switch strings.ToLower(strings.TrimSpace(values.Get(orderKey))) {
+	case "", "asc":
+	case "desc":
+		defaultDesc = true
+	default:
+		return nil, &Error{Param: orderKey, Message: `must be "asc" or "desc"`}
+	}
+
+	raw := strings.TrimSpace(values.Get(sortKey))
+	if raw == "" {
+		raw = spec.DefaultSort
+		sortKey = "sort"
+	}
+	if raw == "" {
+		return nil, nil
+	}
+
+	var sorts []Sort
+	seen := map[string]bool{}
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		desc := defaultDesc
+		switch {
+		case strings.HasPrefix(item, "-"):
+			desc, item = true, item[1:]
+		case strings.HasPrefix(item, "+"):
+			desc, item = false, item[1:]
+		}
+
+		field, ok := spec.field(item)
+		if !ok || !field.Sortable {
+			return nil, &Error{Param: sortKey, Message: fmt.Sprintf("unknown sort field %q", item)}
+		}
+		if seen[field.Column] {
+			return nil, &Error{Param: sortKey, Message: fmt.Sprintf("duplicate sort field %q", item)}
+		}
+		seen[field.Column] = true
+		sorts = append(sorts, Sort{Name: field.Name, Column: field.Column, Type: field.Type, Desc: desc})
+	}
+	if len(sorts) > maxSorts {
+		return nil, &Error{Param: sortKey, Message: fmt.Sprintf("at most %d sort fields are allowed", maxSorts)}
+	}
+	return sorts, nil
+}
+
+func parseFilter(key string, field Field, op Op, raw string) (Filter, error) {
+	parts := []string{raw}
+	if op == OpIn {
+		parts = strings.Split(raw, ",")
+		if len(parts) > maxInValues {
+			return Filter{}, &Error{Param: key, Message: fmt.Sprintf("at most %d values are allowed", maxInValues)}
+		}
+	}
+
+	filter := Filter{Column: field.Column, Op: op}
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return Filter{}, &Error{Param: key, Message: "value must not be empty"}
+		}
+		value, err := parseValue(field.Type, op, part)
+		if err != nil {
+			return Filter{}, &Error{Param: key, Message: err.Error()}
+		}
+		filter.Values = append(filter.Values, value)
+	}
+	return filter, nil
+}
+
+func parseValue(t Type, op Op, raw string) (any, error) {
+	switch t {
+	case Number:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", raw)
+		}
+		return n, nil
+	case Time:
+		if ts, err := time.Parse(time.RFC3339, raw); err == nil {
+			return ts, nil
+		}
+		day, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a date (YYYY-MM-DD) or RFC3339 timestamp", raw)
+		}
+		// lte dengan tanggal saja mencakup seluruh hari tersebut
+		if op == OpLte {
+			return day.Add(24*time.Hour - time.Nanosecond), nil
+		}
+		return day, nil
+	default:
+		return raw, nil
+	}
+}
+
+func (s Spec) field(name string) (Field, bool) {
+	for _, f := range s.Fields {
+		// nama kolom snake_case tetap diterima untuk kompatibilitas param sort lama
+		if f.Name == name || f.Column == name || strings.HasSuffix(f.Column, "."+name) {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+func (f Field) allows(op Op) bool {
+	for _, allowed := range f.Ops {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyFilters menambahkan kondisi WHERE dari filter
+func (q *Query) ApplyFilters(db *gorm.DB) *gorm.DB {
+	for _, f := range q.Filters {
+		switch f.Op {
+		case OpEq:
+			db = db.Where(f.Column+" = ?", f.Values[0])
+		case OpIn:
+			db = db.Where(f.Column+" IN ?", f.Values)
+		case OpGte:
+			db = db.Where(f.Column+" >= ?", f.Values[0])
+		case OpLte:
+			db = db.Where(f.Column+" <= ?", f.Values[0])
+		case OpIlike:
+			db = db.Where(f.Column+" ILIKE ?", "%"+escapeLike(fmt.Sprint(f.Values[0]))+"%")
+		}
+	}
+	return db
+}
+
+// ApplySort menambahkan ORDER BY sesuai urutan sort
+func (q *Query) ApplySort(db *gorm.DB) *gorm.DB {
+	for _, s := range q.Sorts {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: s.Column, Raw: true}, Desc: s.Desc})
+	}
+	return db
+}
+
+// ApplyPage menambahkan OFFSET dan LIMIT
+func (q *Query) ApplyPage(db *gorm.DB) *gorm.DB {
+	return db.Offset(q.Offset).Limit(q.Limit)
+}
+
+// TotalPages menghitung jumlah halaman dari total data
+func (q *Query) TotalPages(total int64) int {
+	return int((total + int64(q.Limit) - 1) / int64(q.Limit))
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
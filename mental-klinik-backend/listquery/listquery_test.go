@@ -0,0 +1,151 @@
+package listquery
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testSpec = Spec{
+	Fields: []Field{
+		{Name: "id", Column: "appointments.id", Sortable: true, Ops: EqualityOps},
+		{Name: "status", Column: "appointments.status", Sortable: true, Ops: EqualityOps},
+		{Name: "fullName", Column: "patients.full_name", Sortable: true, Ops: TextOps},
+		{Name: "score", Column: "score", Type: Number, Sortable: true, Ops: RangeOps},
+		{Name: "scheduleAt", Column: "appointments.schedule_at", Type: Time, Sortable: true, Ops: RangeOps},
+		{Name: "endDate", Column: "end_date", Type: Time, Sortable: true, Ops: RangeOps, Nullable: true},
+		{Name: "patientId", Column: "appointments.patient_id", Ops: EqualityOps},
+	},
+	DefaultSort: "-scheduleAt",
+	MaxLimit:    50,
+	Params:      []string{"search"},
+	Cursor:      true,
+}
+
+func parse(t *testing.T, raw string, spec Spec) (*Query, error) {
+	t.Helper()
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Parse(values, spec)
+}
+
+func TestParsePaging(t *testing.T) {
+	tests := []struct {
+		raw                 string
+		page, limit, offset int
+	}{
+		{"", 1, DefaultLimit, 0},
+		{"page=3&limit=20", 3, 20, 40},
+		{"limit=500", 1, 50, 0},
+	}
+	for _, tt := range tests {
+		q, err := parse(t, tt.raw, testSpec)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.raw, err)
+		}
+		if q.Page != tt.page || q.Limit != tt.limit || q.Offset != tt.offset {
+			t.Errorf("%q: page %d limit %d offset %d, want %d %d %d", tt.raw, q.Page, q.Limit, q.Offset, tt.page, tt.limit, tt.offset)
+		}
+	}
+}
+
+func TestParseSorts(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []Sort
+	}{
+		{"", []Sort{{Name: "scheduleAt", Column: "appointments.schedule_at", Type: Time, Desc: true}}},
+		{"sort=fullName,-score", []Sort{
+			{Name: "fullName", Column: "patients.full_name"},
+			{Name: "score", Column: "score", Type: Number, Desc: true},
+		}},
+		{"sort=status&order=desc", []Sort{{Name: "status", Column: "appointments.status", Desc: true}}},
+		{"sort=%2Bstatus&order=desc", []Sort{{Name: "status", Column: "appointments.status"}}},
+		// nama kolom dan param lama tetap diterima
+		{"sortBy=schedule_at&sortOrder=desc", []Sort{{Name: "scheduleAt", Column: "appointments.schedule_at", Type: Time, Desc: true}}},
+		{"sort=endDate", []Sort{{Name: "endDate", Column: "end_date", Type: Time}}},
+	}
+	for _, tt := range tests {
+		q, err := parse(t, tt.raw, testSpec)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.raw, err)
+		}
+		if !reflect.DeepEqual(q.Sorts, tt.want) {
+			t.Errorf("%q: sorts = %+v, want %+v", tt.raw, q.Sorts, tt.want)
+		}
+	}
+}
+
+func TestParseFilters(t *testing.T) {
+	day := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		raw  string
+		want []Filter
+	}{
+		{"status=pending", []Filter{{Column: "appointments.status", Op: OpEq, Values: []any{"pending"}}}},
+		{"status[in]=pending, done", []Filter{{Column: "appointments.status", Op: OpIn, Values: []any{"pending", "done"}}}},
+		{"score[gte]=0.5", []Filter{{Column: "score", Op: OpGte, Values: []any{0.5}}}},
+		{"scheduleAt[gte]=2024-01-31", []Filter{{Column: "appointments.schedule_at", Op: OpGte, Values: []any{day}}}},
+		{"scheduleAt[lte]=2024-01-31", []Filter{{Column: "appointments.schedule_at", Op: OpLte, Values: []any{day.Add(24*time.Hour - time.Nanosecond)}}}},
+		{"fullName[ilike]=budi&search=x", []Filter{{Column: "patients.full_name", Op: OpIlike, Values: []any{"budi"}}}},
+		{"patientId=patient-001&status=done", []Filter{
+			{Column: "appointments.patient_id", Op: OpEq, Values: []any{"patient-001"}},
+			{Column: "appointments.status", Op: OpEq, Values: []any{"done"}},
+		}},
+	}
+	for _, tt := range tests {
+		q, err := parse(t, tt.raw, testSpec)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.raw, err)
+		}
+		if !reflect.DeepEqual(q.Filters, tt.want) {
+			t.Errorf("%q: filters = %+v, want %+v", tt.raw, q.Filters, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		raw   string
+		param string
+	}{
+		{"page=0", "page"},
+		{"limit=abc", "limit"},
+		{"order=up", "order"},
+		{"sort=password", "sort"},
+		{"sort=patientId", "sort"},
+		{"sort=status,-status", "sort"},
+		{"sort=id,status,fullName,score,scheduleAt,endDate", "sort"},
+		{"password=secret", "password"},
+		{"status[gte]=pending", "status[gte]"},
+		{"score=high", "score"},
+		{"scheduleAt[gte]=yesterday", "scheduleAt[gte]"},
+		{"status[in]=pending,,done", "status[in]"},
+		{"status[like]=x", "status[like]"},
+		{"id)or(1=1", "id)or(1"},
+	}
+	for _, tt := range tests {
+		_, err := parse(t, tt.raw, testSpec)
+		var queryErr *Error
+		if !errors.As(err, &queryErr) {
+			t.Errorf("%q: error = %v, want *Error", tt.raw, err)
+			continue
+		}
+		if queryErr.Param != tt.param {
+			t.Errorf("%q: param = %q, want %q", tt.raw, queryErr.Param, tt.param)
+		}
+	}
+}
+
+func TestTotalPages(t *testing.T) {
+	q := &Query{Limit: 10}
+	for total, want := range map[int64]int{0: 0, 1: 1, 10: 1, 11: 2, 95: 10} {
+		if got := q.TotalPages(total); got != want {
+			t.Errorf("TotalPages(%d) = %d, want %d", total, got, want)
+		}
+	}
+}
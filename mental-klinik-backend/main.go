@@ -14,36 +14,171 @@ import (
 	"time"
 
 
+	"mental-klinik-backend/config"
+	"mental-klinik-backend/controllers"
 	"mental-klinik-backend/databases" 
+	"mental-klinik-backend/middlewares"
+	"mental-klinik-backend/nik"
+	"mental-klinik-backend/policy"
+	"mental-klinik-backend/repositories"
 	"mental-klinik-backend/routes"
+	"mental-klinik-backend/services"
+	"mental-klinik-backend/utils"
 
 	_ "mental-klinik-backend/docs" // Swagger UI
 
 	"github.com/gin-contrib/cors"  
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
 
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 func main() {
-	// Load .env file
-	err := godotenv.Load()
+	// Subcommand: go run main.go migrate up|down|status|create
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Subcommand: go run main.go create-admin (admin pertama dari config/env)
+	if len(os.Args) > 1 && os.Args[1] == "create-admin" {
+		if err := runCreateAdmin(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Subcommand: go run main.go keys status|rotate|rewrap|reencrypt
+	if len(os.Args) > 1 && os.Args[1] == "keys" {
+		if err := runKeys(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Load konfigurasi (.env opsional, file, env, flag)
+	cfg, err := config.Load(os.Args[1:])
 	if err != nil {
-		log.Fatal("Error Loading .env File")
+		log.Fatal("Failed to load config: ", err)
 	}
 
 	// Koneksi DB
-	database.ConnectDB()
+	db := database.ConnectDB(cfg.Database)
 	log.Println("DATABASE CONNECTED!!")
 
+	if err := database.EnsureSchemaUpToDate(db); err != nil {
+		log.Fatal(err)
+	}
+
+	// Kunci enkripsi field (master key dari config, data key dari database)
+	keyring, _, err := openKeyring(cfg, db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Repository
+	userRepo := repositories.NewUserRepository(db)
+	patientRepo := repositories.NewPatientRepository(db, keyring)
+	assessmentRepo := repositories.NewAssessmentRepository(db)
+	appointmentRepo := repositories.NewAppointmentRepository(db)
+	predictionRepo := repositories.NewPredictionRepository(db)
+	medicalRecordRepo := repositories.NewMedicalRecordRepository(db)
+	sequenceRepo := repositories.NewSequenceRepository(db)
+	sessionRepo := repositories.NewSessionRepository(db)
+	invitationRepo := repositories.NewInvitationRepository(db)
+	careTeamRepo := repositories.NewCareTeamRepository(db)
+	auditRepo := repositories.NewAuditRepository(db)
+	emergencyAccessRepo := repositories.NewEmergencyAccessRepository(db)
+	consentRepo := repositories.NewConsentRepository(db)
+	dataExportRepo := repositories.NewDataExportRepository(db)
+	erasureRepo := repositories.NewErasureRepository(db)
+	legalHoldRepo := repositories.NewLegalHoldRepository(db)
+	retentionRepo := repositories.NewRetentionRepository(db)
+	duplicateRepo := repositories.NewDuplicateRepository(db, keyring)
+	timelineRepo := repositories.NewTimelineRepository(db)
+	contactRepo := repositories.NewContactRepository(db)
+
+	// ID generator (readable dengan sequence DB, atau ULID)
+	idGenerator, err := utils.NewIDGenerator(cfg.IDs.Format, sequenceRepo)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Auth
+	jwtManager := middlewares.NewJWTManager(cfg.JWT)
+	sessionService := services.NewSessionService(sessionRepo, userRepo, jwtManager, cfg.JWT.RefreshTTL.Duration)
+	emergencyAccessService := services.NewEmergencyAccessService(emergencyAccessRepo, patientRepo, careTeamRepo, jwtManager, cfg.Auth.BreakGlassTTL.Duration)
+	authMiddleware := jwtManager.AuthMiddleware(sessionService, emergencyAccessService)
+
+	// Policy hak akses (role + kepemilikan + tim perawatan)
+	accessPolicy := policy.New(careTeamRepo)
+
+	// Validasi NIK pasien dengan tabel wilayah bawaan atau dari file
+	nikRegions := nik.DefaultRegions()
+	if cfg.NIK.RegionsFile != "" {
+		if nikRegions, err = nik.LoadRegionsFile(cfg.NIK.RegionsFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Service
+	userService := services.NewUserService(userRepo, invitationRepo, sessionService, idGenerator, cfg.Auth.AllowRegistration)
+	invitationService := services.NewInvitationService(invitationRepo, cfg.Auth.InviteTTL.Duration)
+	patientService := services.NewPatientService(patientRepo, contactRepo, idGenerator, nik.NewValidator(nikRegions))
+	assessmentService := services.NewAssessmentService(assessmentRepo, idGenerator)
+	appointmentService := services.NewAppointmentService(appointmentRepo, patientRepo, userRepo, idGenerator)
+	predictionClient := services.NewHTTPPredictionClient(cfg.Prediction.URL, cfg.Prediction.Timeout.Duration)
+	consentService := services.NewConsentService(consentRepo, patientRepo, contactRepo, idGenerator)
+	predictionService := services.NewPredictionService(predictionRepo, assessmentRepo, predictionClient, consentService, idGenerator)
+	medicalRecordService := services.NewMedicalRecordService(medicalRecordRepo, patientRepo, userRepo, idGenerator)
+	careTeamService := services.NewCareTeamService(careTeamRepo, patientRepo, userRepo, idGenerator)
+	auditService := services.NewAuditService(auditRepo)
+	dataExportService := services.NewDataExportService(dataExportRepo, patientRepo)
+	erasureService := services.NewErasureService(erasureRepo, patientRepo)
+	legalHoldService := services.NewLegalHoldService(legalHoldRepo, patientRepo)
+	retentionService := services.NewRetentionService(retentionRepo, retentionSettings(cfg.Retention))
+	duplicateService := services.NewDuplicateService(duplicateRepo, services.DuplicateSettings{
+		Enabled:   cfg.Duplicates.Enabled,
+		Interval:  cfg.Duplicates.Interval.Duration,
+		Threshold: cfg.Duplicates.Threshold,
+	})
+	timelineService := services.NewTimelineService(timelineRepo, patientRepo)
+	contactService := services.NewContactService(contactRepo, patientRepo, idGenerator)
+
+	if err := bootstrapAdmin(cfg, userService); err != nil {
+		log.Fatal(err)
+	}
+
+	// Lanjutkan job export data pasien yang terputus saat server berhenti
+	if err := dataExportService.Resume(); err != nil {
+		log.Printf("data export: resume unfinished jobs: %v", err)
+	}
+
+	// Scheduler kebijakan retensi (hapus / anonimkan data lama)
+	if err := retentionService.RecoverInterrupted(); err != nil {
+		log.Printf("retention: recover interrupted runs: %v", err)
+	}
+	if cfg.Retention.Enabled {
+		retentionService.Schedule()
+	}
+
+	// Scheduler deteksi pasien duplikat
+	if err := duplicateService.RecoverInterrupted(); err != nil {
+		log.Printf("duplicates: recover interrupted scans: %v", err)
+	}
+	if cfg.Duplicates.Enabled {
+		duplicateService.Schedule()
+	}
+
 	// Inisialisasi Gin Router
 	r := gin.Default()
 
 	// Middleware CORS
 	r.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"http://localhost:3000", "http://172.26.0.1:3000"}, // alamat frontend
+		AllowOrigins:     cfg.CORS.AllowOrigins, // alamat frontend
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
 		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
 		ExposeHeaders:    []string{"Content-Length"},
@@ -59,18 +194,44 @@ func main() {
 		c.JSON(200, gin.H{"message": "testing berhasil"})
 	})
 
-	routes.UserRoutes(r)
-	routes.PatientRoutes(r)
-	routes.AssessmentRoutes(r)
-	routes.AppointmentRoutes(r)
-	routes.PredictionRoutes(r)
-	routes.MedicalRecordRoutes(r)
+	routes.UserRoutes(r, controllers.NewUserController(userService, sessionService, accessPolicy), authMiddleware)
+	routes.InvitationRoutes(r, controllers.NewInvitationController(invitationService), authMiddleware)
+	routes.PatientRoutes(r, controllers.NewPatientController(patientService, accessPolicy, auditService), authMiddleware)
+	routes.AssessmentRoutes(r, controllers.NewAssessmentController(assessmentService, accessPolicy, auditService), authMiddleware)
+	routes.AppointmentRoutes(r, controllers.NewAppointmentController(appointmentService, accessPolicy), authMiddleware)
+	routes.PredictionRoutes(r, controllers.NewPredictionController(predictionService, assessmentService, accessPolicy, auditService), authMiddleware)
+	routes.MedicalRecordRoutes(r, controllers.NewMedicalRecordController(medicalRecordService, accessPolicy, auditService), authMiddleware)
+	routes.CareTeamRoutes(r, controllers.NewCareTeamController(careTeamService, accessPolicy), authMiddleware)
+	routes.AuditRoutes(r, controllers.NewAuditController(auditService), authMiddleware)
+	routes.EmergencyAccessRoutes(r, controllers.NewEmergencyAccessController(emergencyAccessService, auditService), authMiddleware)
+	routes.ConsentRoutes(r, controllers.NewConsentController(consentService, accessPolicy, auditService), authMiddleware)
+	routes.DataSubjectRoutes(r, controllers.NewDataSubjectController(dataExportService, erasureService, legalHoldService, auditService), authMiddleware)
+	routes.RetentionRoutes(r, controllers.NewRetentionController(retentionService), authMiddleware)
+	routes.DuplicateRoutes(r, controllers.NewDuplicateController(duplicateService, accessPolicy, auditService), authMiddleware)
+	routes.TimelineRoutes(r, controllers.NewTimelineController(timelineService, accessPolicy, auditService), authMiddleware)
+	routes.ContactRoutes(r, controllers.NewContactController(contactService, accessPolicy, auditService), authMiddleware)
 
 	// Listen & Serve
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080" // Default Port
+	log.Println("Server Running on port", cfg.Server.Port)
+	r.Run(":" + cfg.Server.Port)
+}
+
+// retentionSettings mengubah konfigurasi retensi menjadi pengaturan service
+func retentionSettings(cfg config.RetentionConfig) services.RetentionSettings {
+	policies := make([]services.RetentionPolicy, 0, len(cfg.Policies))
+	for _, p := range cfg.Policies {
+		policies = append(policies, services.RetentionPolicy{
+			Entity:    p.Entity,
+			Action:    p.Action,
+			Basis:     p.Basis,
+			RetainFor: p.RetainFor,
+		})
+	}
+	return services.RetentionSettings{
+		Enabled:   cfg.Enabled,
+		Interval:  cfg.Interval.Duration,
+		DryRun:    cfg.DryRun,
+		BatchSize: cfg.BatchSize,
+		Policies:  policies,
 	}
-	log.Println("Server Running on port", port)
-	r.Run(":" + port)
-}
\ No newline at end of file
+}
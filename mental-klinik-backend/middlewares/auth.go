@@ -3,22 +3,62 @@ package middlewares
 import (
 	// "fmt"
 	"net/http"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"mental-klinik-backend/config"
 )
 
 type JWTClaims struct {
 	UserID string   `json:"userId"`
 	Role   string `json:"role"`
+	// SessionID menghubungkan access token ke sesi login di database sehingga
+	// token bisa dicabut sebelum expired
+	SessionID string `json:"sid"`
+	// Emergency terisi untuk token akses darurat (break-the-glass)
+	Emergency *EmergencyClaim `json:"emg,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// EmergencyClaim adalah scope akses darurat: satu pasien, tercatat sebagai
+// satu record akses darurat
+type EmergencyClaim struct {
+	AccessID  string `json:"id"`
+	PatientID string `json:"pid"`
+}
+
+// SessionValidator dipakai AuthMiddleware untuk mengecek apakah sesi token
+// masih aktif (belum logout / dicabut admin)
+type SessionValidator interface {
+	IsActive(sessionID string) (bool, error)
+}
+
+// EmergencyAccessValidator dipakai AuthMiddleware untuk mengecek apakah akses
+// darurat pada token belum dicabut atau habis
+type EmergencyAccessValidator interface {
+	IsActive(accessID string) (bool, error)
+}
+
+// JWTManager menerbitkan dan memverifikasi JWT memakai secret dari config
+// (dibaca sekali saat startup, bukan di setiap request).
+type JWTManager struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewJWTManager(cfg config.JWTConfig) *JWTManager {
+	return &JWTManager{
+		secret: []byte(cfg.Secret),
+		ttl:    cfg.TTL.Duration,
+	}
+}
+
 // Middleware: Verifikasi JWT Token
-func AuthMiddleware() gin.HandlerFunc {
+func (m *JWTManager) AuthMiddleware(sessions SessionValidator, emergency EmergencyAccessValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
@@ -29,8 +69,8 @@ func AuthMiddleware() gin.HandlerFunc {
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
 		token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-			return []byte(os.Getenv("JWT_SECRET")), nil
-		})
+			return m.secret, nil
+		}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 		if err != nil || !token.Valid {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or Expired Token"})
@@ -43,9 +83,43 @@ func AuthMiddleware() gin.HandlerFunc {
 			return 
 		}
 
+		// Token tanpa sesi (format lama) atau dari sesi yang sudah dicabut ditolak
+		if claims.SessionID == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid Token Claims"})
+			return
+		}
+		active, err := sessions.IsActive(claims.SessionID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify session"})
+			return
+		}
+		if !active {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+			return
+		}
+
+		// Token akses darurat ditolak begitu aksesnya dicabut admin
+		if claims.Emergency != nil {
+			active, err := emergency.IsActive(claims.Emergency.AccessID)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify emergency access"})
+				return
+			}
+			if !active {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Emergency access has ended"})
+				return
+			}
+		}
+
 		// Simpan ke Context
 		c.Set("userId", claims.UserID)
 		c.Set("role", claims.Role)
+		c.Set("sessionId", claims.SessionID)
+		c.Set("tokenId", claims.ID)
+		if claims.Emergency != nil {
+			c.Set("emergencyAccessId", claims.Emergency.AccessID)
+			c.Set("emergencyPatientId", claims.Emergency.PatientID)
+		}
 
 		c.Next()
 	}
@@ -71,16 +145,43 @@ func AuthorizeRole(allowedRoles ...string) gin.HandlerFunc {
 	}
 }
 
-// Generate JWT Token
-func GenerateToken(userID string, role string) (string, error) {
+// Generate JWT Token. Mengembalikan token beserta waktu expired-nya.
+func (m *JWTManager) GenerateToken(userID string, role string, sessionID string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.ttl)
 	claims := JWTClaims{
-		UserID: userID,
-		Role: role,
+		UserID:    userID,
+		Role:      role,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(os.Getenv("JWT_SECRET")))
-}
\ No newline at end of file
+	signed, err := token.SignedString(m.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// GenerateEmergencyToken menerbitkan token akses darurat untuk sesi yang sama
+// dengan scope satu pasien. Token berlaku sampai expiresAt (akhir akses
+// darurat), bukan TTL token biasa.
+func (m *JWTManager) GenerateEmergencyToken(userID string, role string, sessionID string, accessID string, patientID string, expiresAt time.Time) (string, error) {
+	claims := JWTClaims{
+		UserID:    userID,
+		Role:      role,
+		SessionID: sessionID,
+		Emergency: &EmergencyClaim{AccessID: accessID, PatientID: patientID},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+}
@@ -0,0 +1,124 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"mental-klinik-backend/config"
+)
+
+// activeSet adalah SessionValidator / EmergencyAccessValidator di memori
+type activeSet struct {
+	active map[string]bool
+	err    error
+}
+
+func (s activeSet) IsActive(id string) (bool, error) {
+	return s.active[id], s.err
+}
+
+func newTestServer(m *JWTManager, sessions SessionValidator, emergency EmergencyAccessValidator) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/me", m.AuthMiddleware(sessions, emergency), func(c *gin.Context) {
+		c.String(http.StatusOK, "%s %s %s %s", c.GetString("userId"), c.GetString("role"), c.GetString("sessionId"), c.GetString("emergencyPatientId"))
+	})
+	return router
+}
+
+func get(router *gin.Engine, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func newTestJWTManager(secret string, ttl time.Duration) *JWTManager {
+	return NewJWTManager(config.JWTConfig{Secret: secret, TTL: config.Duration{Duration: ttl}})
+}
+
+func TestAuthMiddlewareSession(t *testing.T) {
+	m := newTestJWTManager("secret", time.Minute)
+	sessions := activeSet{active: map[string]bool{"session-1": true}}
+	router := newTestServer(m, sessions, activeSet{})
+
+	active, _, _ := m.GenerateToken("doctor-001-aaaaaaaa", "doctor", "session-1")
+	revoked, _, _ := m.GenerateToken("doctor-001-aaaaaaaa", "doctor", "session-2")
+	noSession, _, _ := m.GenerateToken("doctor-001-aaaaaaaa", "doctor", "")
+	expired, _, _ := newTestJWTManager("secret", -time.Minute).GenerateToken("doctor-001-aaaaaaaa", "doctor", "session-1")
+	otherSecret, _, _ := newTestJWTManager("other", time.Minute).GenerateToken("doctor-001-aaaaaaaa", "doctor", "session-1")
+
+	tests := []struct {
+		name   string
+		token  string
+		status int
+		body   string
+	}{
+		{"active session", active, http.StatusOK, "doctor-001-aaaaaaaa doctor session-1 "},
+		{"revoked session", revoked, http.StatusUnauthorized, "Token has been revoked"},
+		{"token without session", noSession, http.StatusUnauthorized, "Invalid Token Claims"},
+		{"expired token", expired, http.StatusUnauthorized, "Invalid or Expired Token"},
+		{"other secret", otherSecret, http.StatusUnauthorized, "Invalid or Expired Token"},
+		{"missing header", "", http.StatusUnauthorized, "Missing or Invalid Authorization Header"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := get(router, tt.token)
+			if rec.Code != tt.status || !strings.Contains(rec.Body.String(), tt.body) {
+				t.Errorf("GET /me = %d %s, want %d %s", rec.Code, rec.Body, tt.status, tt.body)
+			}
+		})
+	}
+
+	failing := newTestServer(m, activeSet{err: errors.New("connection refused")}, activeSet{})
+	if rec := get(failing, active); rec.Code != http.StatusInternalServerError {
+		t.Errorf("session lookup error = %d, want 500", rec.Code)
+	}
+}
+
+func TestAuthMiddlewareEmergency(t *testing.T) {
+	m := newTestJWTManager("secret", time.Minute)
+	sessions := activeSet{active: map[string]bool{"session-1": true}}
+	emergency := activeSet{active: map[string]bool{"access-1": true}}
+	router := newTestServer(m, sessions, emergency)
+
+	expiresAt := time.Now().Add(time.Hour)
+	active, _ := m.GenerateEmergencyToken("doctor-001-aaaaaaaa", "doctor", "session-1", "access-1", "patient-001-bbbbbbbb", expiresAt)
+	ended, _ := m.GenerateEmergencyToken("doctor-001-aaaaaaaa", "doctor", "session-1", "access-2", "patient-001-bbbbbbbb", expiresAt)
+	expired, _ := m.GenerateEmergencyToken("doctor-001-aaaaaaaa", "doctor", "session-1", "access-1", "patient-001-bbbbbbbb", time.Now().Add(-time.Minute))
+	revokedSession, _ := m.GenerateEmergencyToken("doctor-001-aaaaaaaa", "doctor", "session-2", "access-1", "patient-001-bbbbbbbb", expiresAt)
+
+	tests := []struct {
+		name   string
+		token  string
+		status int
+		body   string
+	}{
+		{"active access", active, http.StatusOK, "doctor-001-aaaaaaaa doctor session-1 patient-001-bbbbbbbb"},
+		{"revoked access", ended, http.StatusUnauthorized, "Emergency access has ended"},
+		{"expired access", expired, http.StatusUnauthorized, "Invalid or Expired Token"},
+		{"revoked session", revokedSession, http.StatusUnauthorized, "Token has been revoked"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := get(router, tt.token)
+			if rec.Code != tt.status || !strings.Contains(rec.Body.String(), tt.body) {
+				t.Errorf("GET /me = %d %s, want %d %s", rec.Code, rec.Body, tt.status, tt.body)
+			}
+		})
+	}
+
+	failing := newTestServer(m, sessions, activeSet{err: errors.New("connection refused")})
+	if rec := get(failing, active); rec.Code != http.StatusInternalServerError {
+		t.Errorf("emergency lookup error = %d, want 500", rec.Code)
+	}
+}
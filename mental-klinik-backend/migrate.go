@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"mental-klinik-backend/config"
+	"mental-klinik-backend/databases"
+	"mental-klinik-backend/migrations"
+)
+
+const migrateUsage = `usage:
+  go run main.go migrate up [flags]          jalankan semua migration yang belum dijalankan
+  go run main.go migrate down [N] [flags]    rollback N migration terakhir (default 1)
+  go run main.go migrate status [flags]      tampilkan status setiap migration
+  go run main.go migrate create <name>       buat file migration baru di folder migrations/`
+
+// runMigrate menjalankan subcommand migrate. Flag konfigurasi (-config, -db-host,
+// dst.) diletakkan setelah argumen subcommand.
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	action, args := args[0], args[1:]
+
+	if action == "create" {
+		if len(args) == 0 {
+			return errors.New(migrateUsage)
+		}
+		upPath, downPath, err := migrations.Create("migrations", args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println("created", upPath)
+		fmt.Println("created", downPath)
+		return nil
+	}
+
+	steps := 1
+	if action == "down" && len(args) > 0 {
+		if n, err := strconv.Atoi(args[0]); err == nil {
+			if n < 1 {
+				return errors.New("N must be at least 1")
+			}
+			steps = n
+			args = args[1:]
+		}
+	}
+
+	cfg, err := config.Load(args)
+	if err != nil {
+		return err
+	}
+
+	migrator, err := migrations.New(database.ConnectDB(cfg.Database))
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "up":
+		done, err := migrator.Up()
+		for _, m := range done {
+			fmt.Printf("applied  %06d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(done) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		done, err := migrator.Down(steps)
+		for _, m := range done {
+			fmt.Printf("reverted %06d_%s\n", m.Version, m.Name)
+		}
+		return err
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%06d_%-40s %s\n", s.Version, s.Name, state)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", action, migrateUsage)
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS medical_records;
+DROP TABLE IF EXISTS appointments;
+DROP TABLE IF EXISTS predictions;
+DROP TABLE IF EXISTS assessments;
+DROP TABLE IF EXISTS patients;
+DROP TABLE IF EXISTS users;
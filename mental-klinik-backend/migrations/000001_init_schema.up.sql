@@ -0,0 +1,85 @@
+-- Baseline schema: sama dengan hasil AutoMigrate dari package models sebelum
+-- migration dipakai. Semua statement idempotent supaya aman dijalankan di
+-- database lama yang tabelnya sudah dibuat AutoMigrate.
+
+CREATE TABLE IF NOT EXISTS users (
+    id         text PRIMARY KEY,
+    full_name  text,
+    email      text,
+    password   text,
+    role       text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    CONSTRAINT uni_users_email UNIQUE (email)
+);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS patients (
+    id                text PRIMARY KEY,
+    full_name         text,
+    nik               text,
+    birth_date        text,
+    gender            text,
+    phone             text,
+    address           text,
+    emergency_contact text,
+    created_at        timestamptz,
+    updated_at        timestamptz,
+    deleted_at        timestamptz,
+    CONSTRAINT uni_patients_nik UNIQUE (nik)
+);
+CREATE INDEX IF NOT EXISTS idx_patients_deleted_at ON patients (deleted_at);
+
+CREATE TABLE IF NOT EXISTS assessments (
+    id         text PRIMARY KEY,
+    patient_id text,
+    date       timestamptz,
+    answers    jsonb,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    CONSTRAINT fk_patients_assessments FOREIGN KEY (patient_id) REFERENCES patients (id)
+);
+CREATE INDEX IF NOT EXISTS idx_assessments_deleted_at ON assessments (deleted_at);
+
+CREATE TABLE IF NOT EXISTS predictions (
+    id                text PRIMARY KEY,
+    assessment_id     text,
+    result_label      text,
+    probability_score decimal,
+    created_at        timestamptz,
+    updated_at        timestamptz,
+    deleted_at        timestamptz,
+    CONSTRAINT fk_assessments_prediction FOREIGN KEY (assessment_id) REFERENCES assessments (id)
+);
+CREATE INDEX IF NOT EXISTS idx_predictions_deleted_at ON predictions (deleted_at);
+
+CREATE TABLE IF NOT EXISTS appointments (
+    id          text PRIMARY KEY,
+    patient_id  text,
+    user_id     text,
+    schedule_at timestamptz,
+    status      text,
+    notes       text,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    deleted_at  timestamptz,
+    CONSTRAINT fk_patients_appointments FOREIGN KEY (patient_id) REFERENCES patients (id),
+    CONSTRAINT fk_users_appointments FOREIGN KEY (user_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_appointments_deleted_at ON appointments (deleted_at);
+
+CREATE TABLE IF NOT EXISTS medical_records (
+    id         text PRIMARY KEY,
+    patient_id text,
+    user_id    text,
+    diagnosis  text,
+    treatment  text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    CONSTRAINT fk_patients_medical_records FOREIGN KEY (patient_id) REFERENCES patients (id),
+    CONSTRAINT fk_users_medical_records FOREIGN KEY (user_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_medical_records_deleted_at ON medical_records (deleted_at);
@@ -0,0 +1,8 @@
+DROP SEQUENCE IF EXISTS id_seq_staff;
+DROP SEQUENCE IF EXISTS id_seq_doctor;
+DROP SEQUENCE IF EXISTS id_seq_admin;
+DROP SEQUENCE IF EXISTS id_seq_prediction;
+DROP SEQUENCE IF EXISTS id_seq_record;
+DROP SEQUENCE IF EXISTS id_seq_assessment;
+DROP SEQUENCE IF EXISTS id_seq_appointment;
+DROP SEQUENCE IF EXISTS id_seq_patient;
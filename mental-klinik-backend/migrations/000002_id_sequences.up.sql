@@ -0,0 +1,35 @@
+-- Sequence nomor urut untuk ID readable (patient-001-xxxx). Nilai awal diambil
+-- dari nomor terbesar yang sudah dipakai, termasuk data yang di-soft delete,
+-- supaya ID lama tidak pernah terbit ulang.
+CREATE SEQUENCE IF NOT EXISTS id_seq_patient;
+CREATE SEQUENCE IF NOT EXISTS id_seq_appointment;
+CREATE SEQUENCE IF NOT EXISTS id_seq_assessment;
+CREATE SEQUENCE IF NOT EXISTS id_seq_record;
+CREATE SEQUENCE IF NOT EXISTS id_seq_prediction;
+CREATE SEQUENCE IF NOT EXISTS id_seq_admin;
+CREATE SEQUENCE IF NOT EXISTS id_seq_doctor;
+CREATE SEQUENCE IF NOT EXISTS id_seq_staff;
+
+SELECT setval('id_seq_patient', GREATEST(n, 1), n > 0)
+FROM (SELECT COALESCE(MAX(substring(id FROM '^patient-(\d+)-')::bigint), 0) AS n FROM patients) s;
+
+SELECT setval('id_seq_appointment', GREATEST(n, 1), n > 0)
+FROM (SELECT COALESCE(MAX(substring(id FROM '^appointment-(\d+)-')::bigint), 0) AS n FROM appointments) s;
+
+SELECT setval('id_seq_assessment', GREATEST(n, 1), n > 0)
+FROM (SELECT COALESCE(MAX(substring(id FROM '^assessment-(\d+)-')::bigint), 0) AS n FROM assessments) s;
+
+SELECT setval('id_seq_record', GREATEST(n, 1), n > 0)
+FROM (SELECT COALESCE(MAX(substring(id FROM '^record-(\d+)-')::bigint), 0) AS n FROM medical_records) s;
+
+SELECT setval('id_seq_prediction', GREATEST(n, 1), n > 0)
+FROM (SELECT COALESCE(MAX(substring(id FROM '^prediction-(\d+)-')::bigint), 0) AS n FROM predictions) s;
+
+SELECT setval('id_seq_admin', GREATEST(n, 1), n > 0)
+FROM (SELECT COALESCE(MAX(substring(id FROM '^admin-(\d+)-')::bigint), 0) AS n FROM users) s;
+
+SELECT setval('id_seq_doctor', GREATEST(n, 1), n > 0)
+FROM (SELECT COALESCE(MAX(substring(id FROM '^doctor-(\d+)-')::bigint), 0) AS n FROM users) s;
+
+SELECT setval('id_seq_staff', GREATEST(n, 1), n > 0)
+FROM (SELECT COALESCE(MAX(substring(id FROM '^staff-(\d+)-')::bigint), 0) AS n FROM users) s;
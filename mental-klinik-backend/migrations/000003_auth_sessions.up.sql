@@ -0,0 +1,26 @@
+-- Sesi login dan refresh token (disimpan sebagai hash). Satu sesi adalah satu
+-- family refresh token; mencabut sesi mencabut semua token di dalamnya.
+CREATE TABLE IF NOT EXISTS auth_sessions (
+    id             text PRIMARY KEY,
+    user_id        text NOT NULL,
+    user_agent     text,
+    ip_address     text,
+    created_at     timestamptz NOT NULL,
+    last_used_at   timestamptz,
+    revoked_at     timestamptz,
+    revoked_reason text,
+    CONSTRAINT fk_auth_sessions_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_auth_sessions_user_id ON auth_sessions (user_id);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id         text PRIMARY KEY,
+    session_id text NOT NULL,
+    token_hash text NOT NULL,
+    expires_at timestamptz NOT NULL,
+    used_at    timestamptz,
+    created_at timestamptz NOT NULL,
+    CONSTRAINT uni_refresh_tokens_token_hash UNIQUE (token_hash),
+    CONSTRAINT fk_refresh_tokens_session FOREIGN KEY (session_id) REFERENCES auth_sessions (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session_id ON refresh_tokens (session_id);
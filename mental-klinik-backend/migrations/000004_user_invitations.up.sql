@@ -0,0 +1,18 @@
+-- Undangan pendaftaran user. Role ditentukan admin saat membuat undangan,
+-- token disimpan sebagai hash dan hanya bisa dipakai sekali.
+CREATE TABLE IF NOT EXISTS invitations (
+    id            text PRIMARY KEY,
+    email         text NOT NULL,
+    role          text NOT NULL,
+    token_hash    text NOT NULL,
+    expires_at    timestamptz NOT NULL,
+    created_by_id text NOT NULL,
+    used_at       timestamptz,
+    used_by_id    text,
+    revoked_at    timestamptz,
+    created_at    timestamptz NOT NULL,
+    CONSTRAINT uni_invitations_token_hash UNIQUE (token_hash),
+    CONSTRAINT fk_invitations_created_by FOREIGN KEY (created_by_id) REFERENCES users (id),
+    CONSTRAINT fk_invitations_used_by FOREIGN KEY (used_by_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_invitations_email ON invitations (email);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS care_assignments;
+DROP SEQUENCE IF EXISTS id_seq_care;
@@ -0,0 +1,52 @@
+-- Tim perawatan pasien: penugasan eksplisit klinisi utama, klinisi pendamping
+-- dan staff dengan tanggal mulai dan selesai (end_date eksklusif).
+CREATE SEQUENCE IF NOT EXISTS id_seq_care;
+
+CREATE TABLE IF NOT EXISTS care_assignments (
+    id             text PRIMARY KEY,
+    patient_id     text NOT NULL,
+    user_id        text NOT NULL,
+    role           text NOT NULL,
+    start_date     date NOT NULL,
+    end_date       date,
+    end_reason     text NOT NULL DEFAULT '',
+    assigned_by_id text NOT NULL,
+    ended_by_id    text,
+    created_at     timestamptz NOT NULL,
+    updated_at     timestamptz NOT NULL,
+    CONSTRAINT chk_care_assignments_role CHECK (role IN ('primary', 'secondary', 'staff')),
+    CONSTRAINT chk_care_assignments_dates CHECK (end_date IS NULL OR end_date >= start_date),
+    CONSTRAINT fk_care_assignments_patient FOREIGN KEY (patient_id) REFERENCES patients (id),
+    CONSTRAINT fk_care_assignments_user FOREIGN KEY (user_id) REFERENCES users (id),
+    CONSTRAINT fk_care_assignments_assigned_by FOREIGN KEY (assigned_by_id) REFERENCES users (id),
+    CONSTRAINT fk_care_assignments_ended_by FOREIGN KEY (ended_by_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_care_assignments_patient_id ON care_assignments (patient_id);
+CREATE INDEX IF NOT EXISTS idx_care_assignments_user_id ON care_assignments (user_id);
+
+-- Satu klinisi utama tanpa tanggal selesai per pasien, dan satu penugasan
+-- tanpa tanggal selesai per pasien-user. Tumpang tindih dengan penugasan yang
+-- punya tanggal selesai dicek di service.
+CREATE UNIQUE INDEX IF NOT EXISTS uni_care_assignments_open_primary
+    ON care_assignments (patient_id) WHERE role = 'primary' AND end_date IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uni_care_assignments_open_member
+    ON care_assignments (patient_id, user_id) WHERE end_date IS NULL;
+
+-- Backfill dari relasi implisit sebelumnya: dokter yang punya appointment atau
+-- menulis rekam medis pasien menjadi klinisi pendamping sejak interaksi
+-- pertamanya, supaya akses yang sudah ada tidak hilang.
+INSERT INTO care_assignments (id, patient_id, user_id, role, start_date, assigned_by_id, created_at, updated_at)
+SELECT 'care-' || lpad(nextval('id_seq_care')::text, 3, '0') || '-' || substr(md5(random()::text), 1, 8),
+       s.patient_id, s.user_id, 'secondary', s.start_date, s.user_id, now(), now()
+FROM (
+    SELECT src.patient_id, src.user_id, MIN(src.created_at)::date AS start_date
+    FROM (
+        SELECT patient_id, user_id, created_at FROM appointments WHERE deleted_at IS NULL
+        UNION ALL
+        SELECT patient_id, user_id, created_at FROM medical_records WHERE deleted_at IS NULL
+    ) src
+    JOIN users u ON u.id = src.user_id AND u.role = 'doctor' AND u.deleted_at IS NULL
+    JOIN patients p ON p.id = src.patient_id AND p.deleted_at IS NULL
+    GROUP BY src.patient_id, src.user_id
+) s
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,43 @@
+-- Audit log akses PHI. Setiap entry menyimpan hash entry sebelumnya (rantai
+-- hash), dan tabel hanya bisa ditambah: UPDATE/DELETE ditolak trigger.
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id             bigint PRIMARY KEY,
+    occurred_at    timestamptz NOT NULL,
+    actor_id       text NOT NULL,
+    actor_role     text NOT NULL,
+    session_id     text NOT NULL DEFAULT '',
+    action         text NOT NULL,
+    resource_type  text NOT NULL,
+    resource_id    text NOT NULL DEFAULT '',
+    patient_id     text NOT NULL DEFAULT '',
+    changed_fields jsonb,
+    ip_address     text NOT NULL DEFAULT '',
+    user_agent     text NOT NULL DEFAULT '',
+    prev_hash      text NOT NULL,
+    hash           text NOT NULL,
+    CONSTRAINT uni_audit_logs_hash UNIQUE (hash)
+);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_occurred_at ON audit_logs (occurred_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id ON audit_logs (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_patient_id ON audit_logs (patient_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_resource ON audit_logs (resource_type, resource_id);
+
+-- Posisi terakhir rantai hash (satu baris), dikunci saat menambah entry
+CREATE TABLE IF NOT EXISTS audit_chain_head (
+    id        integer PRIMARY KEY,
+    last_id   bigint NOT NULL,
+    last_hash text NOT NULL,
+    CONSTRAINT chk_audit_chain_head_single CHECK (id = 1)
+);
+INSERT INTO audit_chain_head (id, last_id, last_hash) VALUES (1, 0, '') ON CONFLICT DO NOTHING;
+
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_audit_logs_append_only ON audit_logs;
+CREATE TRIGGER trg_audit_logs_append_only
+    BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
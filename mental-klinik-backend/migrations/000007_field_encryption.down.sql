@@ -0,0 +1,11 @@
+-- Nilai yang sudah dienkripsi tidak didekripsi oleh migration ini (master key
+-- tidak tersedia di SQL); jawaban asesmen terenkripsi disimpan sebagai string
+-- JSON supaya kolom tetap bisa dikembalikan ke jsonb.
+ALTER TABLE assessments ALTER COLUMN answers TYPE jsonb
+    USING CASE WHEN answers LIKE 'enc:v1:%' THEN to_jsonb(answers) ELSE answers::jsonb END;
+
+ALTER TABLE patients DROP CONSTRAINT IF EXISTS uni_patients_nik_index;
+ALTER TABLE patients DROP COLUMN IF EXISTS nik_index;
+ALTER TABLE patients ADD CONSTRAINT uni_patients_nik UNIQUE (nik);
+
+DROP TABLE IF EXISTS data_keys;
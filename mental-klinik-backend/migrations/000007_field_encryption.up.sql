@@ -0,0 +1,22 @@
+-- Enkripsi field at-rest (envelope encryption). data_keys menyimpan data key
+-- AES-256 yang dibungkus master key dari konfigurasi; kolom terenkripsi
+-- berisi "enc:v1:<data key id>:<base64>". Data lama tetap plaintext sampai
+-- dijalankan: go run main.go keys reencrypt
+CREATE TABLE IF NOT EXISTS data_keys (
+    id            text PRIMARY KEY,
+    master_key_id text NOT NULL,
+    wrapped_key   bytea NOT NULL,
+    active        boolean NOT NULL DEFAULT false,
+    created_at    timestamptz,
+    updated_at    timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uni_data_keys_active ON data_keys (active) WHERE active;
+
+-- Ciphertext NIK berbeda setiap kali ditulis, jadi keunikan dan pencarian NIK
+-- pindah ke blind index (HMAC-SHA256 dengan index key dari konfigurasi)
+ALTER TABLE patients ADD COLUMN IF NOT EXISTS nik_index text;
+ALTER TABLE patients DROP CONSTRAINT IF EXISTS uni_patients_nik;
+ALTER TABLE patients ADD CONSTRAINT uni_patients_nik_index UNIQUE (nik_index);
+
+-- Jawaban asesmen terenkripsi bukan lagi JSON yang valid
+ALTER TABLE assessments ALTER COLUMN answers TYPE text USING answers::text;
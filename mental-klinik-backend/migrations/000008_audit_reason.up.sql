@@ -0,0 +1,4 @@
+-- Alasan akses (misalnya justifikasi saat membuka field yang disamarkan).
+-- Kolom baru tidak mengubah hash entry lama karena reason kosong tidak ikut
+-- di-hash.
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS reason text NOT NULL DEFAULT '';
@@ -0,0 +1,33 @@
+-- Akses darurat (break-the-glass) ke pasien di luar tim perawatan. Baris
+-- tidak pernah dihapus; admin menandai hasil review-nya.
+CREATE TABLE IF NOT EXISTS emergency_accesses (
+    id             text PRIMARY KEY,
+    user_id        text NOT NULL,
+    patient_id     text NOT NULL,
+    session_id     text NOT NULL,
+    reason         text NOT NULL,
+    ip_address     text NOT NULL DEFAULT '',
+    user_agent     text NOT NULL DEFAULT '',
+    granted_at     timestamptz NOT NULL,
+    expires_at     timestamptz NOT NULL,
+    revoked_at     timestamptz,
+    revoked_by_id  text,
+    reviewed_at    timestamptz,
+    reviewed_by_id text,
+    review_outcome text NOT NULL DEFAULT '',
+    review_notes   text NOT NULL DEFAULT '',
+    created_at     timestamptz,
+    updated_at     timestamptz,
+    CONSTRAINT chk_emergency_accesses_window CHECK (expires_at > granted_at),
+    CONSTRAINT chk_emergency_accesses_review_outcome
+        CHECK (review_outcome IN ('', 'justified', 'unjustified')),
+    CONSTRAINT fk_emergency_accesses_user FOREIGN KEY (user_id) REFERENCES users (id),
+    CONSTRAINT fk_emergency_accesses_patient FOREIGN KEY (patient_id) REFERENCES patients (id),
+    CONSTRAINT fk_emergency_accesses_revoked_by FOREIGN KEY (revoked_by_id) REFERENCES users (id),
+    CONSTRAINT fk_emergency_accesses_reviewed_by FOREIGN KEY (reviewed_by_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_emergency_accesses_user_id ON emergency_accesses (user_id);
+CREATE INDEX IF NOT EXISTS idx_emergency_accesses_patient_id ON emergency_accesses (patient_id);
+-- Antrean review admin
+CREATE INDEX IF NOT EXISTS idx_emergency_accesses_pending_review
+    ON emergency_accesses (granted_at) WHERE reviewed_at IS NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS patient_consents;
+DROP TABLE IF EXISTS consent_documents;
+DROP SEQUENCE IF EXISTS id_seq_consent;
+DROP SEQUENCE IF EXISTS id_seq_consentdoc;
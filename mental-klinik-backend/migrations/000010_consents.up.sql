@@ -0,0 +1,47 @@
+-- Persetujuan pasien: dokumen persetujuan berversi per jenis dan catatan
+-- persetujuan pasien (diberikan, dicatat oleh, ditarik).
+CREATE SEQUENCE IF NOT EXISTS id_seq_consentdoc;
+CREATE SEQUENCE IF NOT EXISTS id_seq_consent;
+
+CREATE TABLE IF NOT EXISTS consent_documents (
+    id            text PRIMARY KEY,
+    type          text NOT NULL,
+    version       integer NOT NULL,
+    title         text NOT NULL,
+    body          text NOT NULL,
+    created_by_id text NOT NULL,
+    created_at    timestamptz NOT NULL,
+    updated_at    timestamptz NOT NULL,
+    CONSTRAINT chk_consent_documents_type
+        CHECK (type IN ('treatment', 'ai_prediction', 'research', 'data_sharing')),
+    CONSTRAINT chk_consent_documents_version CHECK (version > 0),
+    CONSTRAINT uni_consent_documents_type_version UNIQUE (type, version),
+    CONSTRAINT fk_consent_documents_created_by FOREIGN KEY (created_by_id) REFERENCES users (id)
+);
+
+CREATE TABLE IF NOT EXISTS patient_consents (
+    id                text PRIMARY KEY,
+    patient_id        text NOT NULL,
+    document_id       text NOT NULL,
+    type              text NOT NULL,
+    method            text NOT NULL,
+    notes             text NOT NULL DEFAULT '',
+    granted_at        timestamptz NOT NULL,
+    captured_by_id    text NOT NULL,
+    withdrawn_at      timestamptz,
+    withdrawn_by_id   text,
+    withdrawal_reason text NOT NULL DEFAULT '',
+    created_at        timestamptz NOT NULL,
+    updated_at        timestamptz NOT NULL,
+    CONSTRAINT chk_patient_consents_method CHECK (method IN ('written', 'verbal', 'electronic')),
+    CONSTRAINT chk_patient_consents_withdrawal CHECK (withdrawn_at IS NULL OR withdrawn_at >= granted_at),
+    CONSTRAINT fk_patient_consents_patient FOREIGN KEY (patient_id) REFERENCES patients (id),
+    CONSTRAINT fk_patient_consents_document FOREIGN KEY (document_id) REFERENCES consent_documents (id),
+    CONSTRAINT fk_patient_consents_captured_by FOREIGN KEY (captured_by_id) REFERENCES users (id),
+    CONSTRAINT fk_patient_consents_withdrawn_by FOREIGN KEY (withdrawn_by_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_patient_consents_patient_id ON patient_consents (patient_id);
+
+-- Satu persetujuan aktif per pasien per jenis
+CREATE UNIQUE INDEX IF NOT EXISTS uni_patient_consents_active
+    ON patient_consents (patient_id, type) WHERE withdrawn_at IS NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS erasure_requests;
+DROP TABLE IF EXISTS legal_holds;
+DROP TABLE IF EXISTS data_exports;
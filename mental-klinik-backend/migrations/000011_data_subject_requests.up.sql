@@ -0,0 +1,69 @@
+-- Hak subjek data (UU PDP): export data pasien, legal hold dan permintaan
+-- penghapusan / anonimisasi dengan persetujuan admin.
+CREATE TABLE IF NOT EXISTS data_exports (
+    id               text PRIMARY KEY,
+    patient_id       text NOT NULL,
+    requested_by_id  text NOT NULL,
+    status           text NOT NULL,
+    error            text NOT NULL DEFAULT '',
+    bundle           bytea,
+    checksum         text NOT NULL DEFAULT '',
+    size             bigint NOT NULL DEFAULT 0,
+    requested_at     timestamptz NOT NULL,
+    completed_at     timestamptz,
+    expires_at       timestamptz,
+    created_at       timestamptz NOT NULL,
+    updated_at       timestamptz NOT NULL,
+    CONSTRAINT chk_data_exports_status
+        CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    CONSTRAINT fk_data_exports_patient FOREIGN KEY (patient_id) REFERENCES patients (id),
+    CONSTRAINT fk_data_exports_requested_by FOREIGN KEY (requested_by_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_data_exports_patient_id ON data_exports (patient_id);
+
+CREATE TABLE IF NOT EXISTS legal_holds (
+    id              text PRIMARY KEY,
+    patient_id      text NOT NULL,
+    reason          text NOT NULL,
+    reference       text NOT NULL DEFAULT '',
+    placed_by_id    text NOT NULL,
+    placed_at       timestamptz NOT NULL,
+    released_at     timestamptz,
+    released_by_id  text,
+    release_reason  text NOT NULL DEFAULT '',
+    created_at      timestamptz NOT NULL,
+    updated_at      timestamptz NOT NULL,
+    CONSTRAINT fk_legal_holds_patient FOREIGN KEY (patient_id) REFERENCES patients (id),
+    CONSTRAINT fk_legal_holds_placed_by FOREIGN KEY (placed_by_id) REFERENCES users (id),
+    CONSTRAINT fk_legal_holds_released_by FOREIGN KEY (released_by_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_legal_holds_patient_id ON legal_holds (patient_id);
+
+CREATE TABLE IF NOT EXISTS erasure_requests (
+    id               text PRIMARY KEY,
+    patient_id       text NOT NULL,
+    mode             text NOT NULL,
+    reason           text NOT NULL,
+    status           text NOT NULL,
+    requested_by_id  text NOT NULL,
+    requested_at     timestamptz NOT NULL,
+    reviewed_by_id   text,
+    reviewed_at      timestamptz,
+    review_notes     text NOT NULL DEFAULT '',
+    completed_at     timestamptz,
+    -- text (bukan jsonb) supaya isi persis sama dengan byte yang di-hash
+    certificate      text,
+    certificate_hash text NOT NULL DEFAULT '',
+    created_at       timestamptz NOT NULL,
+    updated_at       timestamptz NOT NULL,
+    CONSTRAINT chk_erasure_requests_mode CHECK (mode IN ('erase', 'anonymize')),
+    CONSTRAINT chk_erasure_requests_status CHECK (status IN ('pending', 'rejected', 'completed')),
+    CONSTRAINT fk_erasure_requests_patient FOREIGN KEY (patient_id) REFERENCES patients (id),
+    CONSTRAINT fk_erasure_requests_requested_by FOREIGN KEY (requested_by_id) REFERENCES users (id),
+    CONSTRAINT fk_erasure_requests_reviewed_by FOREIGN KEY (reviewed_by_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_erasure_requests_patient_id ON erasure_requests (patient_id);
+
+-- Satu permintaan yang menunggu persetujuan per pasien
+CREATE UNIQUE INDEX IF NOT EXISTS uni_erasure_requests_pending
+    ON erasure_requests (patient_id) WHERE status = 'pending';
@@ -0,0 +1,2 @@
+ALTER TABLE patients DROP COLUMN IF EXISTS anonymized_at;
+DROP TABLE IF EXISTS retention_runs;
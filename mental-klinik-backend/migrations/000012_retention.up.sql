@@ -0,0 +1,32 @@
+-- Kebijakan retensi: riwayat run penghapusan / anonimisasi otomatis dan
+-- penanda pasien yang sudah dianonimkan.
+CREATE TABLE IF NOT EXISTS retention_runs (
+    id               text PRIMARY KEY,
+    trigger          text NOT NULL,
+    triggered_by_id  text,
+    dry_run          boolean NOT NULL,
+    report_run_id    text,
+    status           text NOT NULL,
+    error            text NOT NULL DEFAULT '',
+    started_at       timestamptz NOT NULL,
+    finished_at      timestamptz,
+    results          jsonb,
+    created_at       timestamptz NOT NULL,
+    updated_at       timestamptz NOT NULL,
+    CONSTRAINT chk_retention_runs_trigger CHECK (trigger IN ('scheduled', 'manual')),
+    CONSTRAINT chk_retention_runs_status CHECK (status IN ('running', 'completed', 'failed')),
+    CONSTRAINT chk_retention_runs_report CHECK (dry_run OR report_run_id IS NOT NULL),
+    CONSTRAINT fk_retention_runs_triggered_by FOREIGN KEY (triggered_by_id) REFERENCES users (id),
+    CONSTRAINT fk_retention_runs_report FOREIGN KEY (report_run_id) REFERENCES retention_runs (id)
+);
+CREATE INDEX IF NOT EXISTS idx_retention_runs_started_at ON retention_runs (started_at);
+
+-- Hanya satu run yang boleh berjalan sekaligus (termasuk antar instance)
+CREATE UNIQUE INDEX IF NOT EXISTS uni_retention_runs_running
+    ON retention_runs (status) WHERE status = 'running';
+
+ALTER TABLE patients ADD COLUMN IF NOT EXISTS anonymized_at timestamptz;
+
+UPDATE patients p SET anonymized_at = e.completed_at
+FROM erasure_requests e
+WHERE e.patient_id = p.id AND e.status = 'completed';
@@ -0,0 +1,7 @@
+-- Gagal jika email / NIK yang sama sudah dipakai lagi oleh data baru; hapus
+-- permanen salah satunya dulu.
+DROP INDEX IF EXISTS uni_patients_nik_index;
+ALTER TABLE patients ADD CONSTRAINT uni_patients_nik_index UNIQUE (nik_index);
+
+DROP INDEX IF EXISTS uni_users_email;
+ALTER TABLE users ADD CONSTRAINT uni_users_email UNIQUE (email);
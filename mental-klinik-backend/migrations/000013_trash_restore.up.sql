@@ -0,0 +1,8 @@
+-- Trash & restore: email user dan NIK pasien hanya wajib unik di antara data
+-- yang aktif, sehingga data di trash tidak menghalangi pendaftaran ulang.
+-- Bentrokan dengan data baru dicek saat restore.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS uni_users_email;
+CREATE UNIQUE INDEX IF NOT EXISTS uni_users_email ON users (email) WHERE deleted_at IS NULL;
+
+ALTER TABLE patients DROP CONSTRAINT IF EXISTS uni_patients_nik_index;
+CREATE UNIQUE INDEX IF NOT EXISTS uni_patients_nik_index ON patients (nik_index) WHERE deleted_at IS NULL;
@@ -0,0 +1,30 @@
+-- Data yang ikut dihapus oleh migration ini tidak dipulihkan otomatis,
+-- gunakan restore pasien dengan cascade.
+DROP INDEX IF EXISTS idx_medical_records_user_id;
+DROP INDEX IF EXISTS idx_medical_records_patient_id;
+DROP INDEX IF EXISTS idx_appointments_user_id;
+DROP INDEX IF EXISTS idx_appointments_patient_id;
+DROP INDEX IF EXISTS idx_predictions_assessment_id;
+DROP INDEX IF EXISTS idx_assessments_patient_id;
+
+ALTER TABLE medical_records DROP CONSTRAINT IF EXISTS fk_users_medical_records;
+ALTER TABLE medical_records ADD CONSTRAINT fk_users_medical_records
+    FOREIGN KEY (user_id) REFERENCES users (id);
+ALTER TABLE medical_records DROP CONSTRAINT IF EXISTS fk_patients_medical_records;
+ALTER TABLE medical_records ADD CONSTRAINT fk_patients_medical_records
+    FOREIGN KEY (patient_id) REFERENCES patients (id);
+
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS fk_users_appointments;
+ALTER TABLE appointments ADD CONSTRAINT fk_users_appointments
+    FOREIGN KEY (user_id) REFERENCES users (id);
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS fk_patients_appointments;
+ALTER TABLE appointments ADD CONSTRAINT fk_patients_appointments
+    FOREIGN KEY (patient_id) REFERENCES patients (id);
+
+ALTER TABLE predictions DROP CONSTRAINT IF EXISTS fk_assessments_prediction;
+ALTER TABLE predictions ADD CONSTRAINT fk_assessments_prediction
+    FOREIGN KEY (assessment_id) REFERENCES assessments (id);
+
+ALTER TABLE assessments DROP CONSTRAINT IF EXISTS fk_patients_assessments;
+ALTER TABLE assessments ADD CONSTRAINT fk_patients_assessments
+    FOREIGN KEY (patient_id) REFERENCES patients (id);
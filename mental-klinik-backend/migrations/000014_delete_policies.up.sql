@@ -0,0 +1,46 @@
+-- Kebijakan penghapusan: foreign key data klinis dideklarasikan ulang secara
+-- eksplisit dengan ON DELETE RESTRICT (hard delete hanya lewat retensi dan
+-- penghapusan data yang menghapus anak lebih dulu), ditambah index untuk
+-- kolom foreign key yang dipakai saat cascade.
+ALTER TABLE assessments DROP CONSTRAINT IF EXISTS fk_patients_assessments;
+ALTER TABLE assessments ADD CONSTRAINT fk_patients_assessments
+    FOREIGN KEY (patient_id) REFERENCES patients (id) ON DELETE RESTRICT;
+
+ALTER TABLE predictions DROP CONSTRAINT IF EXISTS fk_assessments_prediction;
+ALTER TABLE predictions ADD CONSTRAINT fk_assessments_prediction
+    FOREIGN KEY (assessment_id) REFERENCES assessments (id) ON DELETE RESTRICT;
+
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS fk_patients_appointments;
+ALTER TABLE appointments ADD CONSTRAINT fk_patients_appointments
+    FOREIGN KEY (patient_id) REFERENCES patients (id) ON DELETE RESTRICT;
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS fk_users_appointments;
+ALTER TABLE appointments ADD CONSTRAINT fk_users_appointments
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE RESTRICT;
+
+ALTER TABLE medical_records DROP CONSTRAINT IF EXISTS fk_patients_medical_records;
+ALTER TABLE medical_records ADD CONSTRAINT fk_patients_medical_records
+    FOREIGN KEY (patient_id) REFERENCES patients (id) ON DELETE RESTRICT;
+ALTER TABLE medical_records DROP CONSTRAINT IF EXISTS fk_users_medical_records;
+ALTER TABLE medical_records ADD CONSTRAINT fk_users_medical_records
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_assessments_patient_id ON assessments (patient_id);
+CREATE INDEX IF NOT EXISTS idx_predictions_assessment_id ON predictions (assessment_id);
+CREATE INDEX IF NOT EXISTS idx_appointments_patient_id ON appointments (patient_id);
+CREATE INDEX IF NOT EXISTS idx_appointments_user_id ON appointments (user_id);
+CREATE INDEX IF NOT EXISTS idx_medical_records_patient_id ON medical_records (patient_id);
+CREATE INDEX IF NOT EXISTS idx_medical_records_user_id ON medical_records (user_id);
+
+-- Data klinis pasien yang sudah dihapus sebelum cascade berlaku ikut
+-- dihapus dengan waktu penghapusan pasiennya, supaya konsisten dan bisa
+-- dipulihkan dengan restore cascade.
+UPDATE predictions pr SET deleted_at = p.deleted_at
+FROM assessments a JOIN patients p ON p.id = a.patient_id
+WHERE pr.assessment_id = a.id AND a.deleted_at IS NULL
+  AND p.deleted_at IS NOT NULL AND pr.deleted_at IS NULL;
+UPDATE appointments x SET deleted_at = p.deleted_at
+FROM patients p WHERE x.patient_id = p.id AND p.deleted_at IS NOT NULL AND x.deleted_at IS NULL;
+UPDATE assessments x SET deleted_at = p.deleted_at
+FROM patients p WHERE x.patient_id = p.id AND p.deleted_at IS NOT NULL AND x.deleted_at IS NULL;
+UPDATE medical_records x SET deleted_at = p.deleted_at
+FROM patients p WHERE x.patient_id = p.id AND p.deleted_at IS NOT NULL AND x.deleted_at IS NULL;
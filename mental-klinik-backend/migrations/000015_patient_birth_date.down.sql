@@ -0,0 +1,6 @@
+ALTER TABLE patient_consents DROP COLUMN IF EXISTS guardian_name;
+
+DROP INDEX IF EXISTS idx_patients_birth_date;
+ALTER TABLE patients ALTER COLUMN birth_date TYPE text
+    USING coalesce(to_char(birth_date, 'YYYY-MM-DD'), birth_date_legacy);
+ALTER TABLE patients DROP COLUMN IF EXISTS birth_date_legacy;
@@ -0,0 +1,37 @@
+-- Tanggal lahir pasien menjadi kolom date. Hanya nilai YYYY-MM-DD yang valid
+-- yang dikonversi; nilai lain (misalnya "01/02/2000", yang ambigu antara
+-- DD/MM dan MM/DD) disimpan apa adanya di birth_date_legacy dengan
+-- birth_date NULL, dan dilaporkan lewat GET /api/patients/legacy-birth-dates
+-- supaya diperbaiki manual.
+CREATE FUNCTION pg_temp.iso_date(value text) RETURNS date AS $$
+BEGIN
+    IF value !~ '^\d{4}-\d{2}-\d{2}$' THEN
+        RETURN NULL;
+    END IF;
+    RETURN value::date;
+EXCEPTION WHEN others THEN
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE patients ADD COLUMN IF NOT EXISTS birth_date_legacy text;
+
+UPDATE patients SET birth_date_legacy = birth_date
+WHERE btrim(coalesce(birth_date, '')) <> '' AND pg_temp.iso_date(btrim(birth_date)) IS NULL;
+
+ALTER TABLE patients ALTER COLUMN birth_date TYPE date USING pg_temp.iso_date(btrim(birth_date));
+CREATE INDEX IF NOT EXISTS idx_patients_birth_date ON patients (birth_date);
+
+DO $$
+DECLARE
+    unconverted integer;
+BEGIN
+    SELECT count(*) INTO unconverted FROM patients WHERE birth_date_legacy IS NOT NULL;
+    IF unconverted > 0 THEN
+        RAISE WARNING '% patient birth date(s) could not be converted, see patients.birth_date_legacy', unconverted;
+    END IF;
+END;
+$$;
+
+-- Persetujuan untuk pasien di bawah umur diberikan oleh wali
+ALTER TABLE patient_consents ADD COLUMN IF NOT EXISTS guardian_name text NOT NULL DEFAULT '';
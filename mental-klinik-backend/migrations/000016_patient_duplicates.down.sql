@@ -0,0 +1,5 @@
+ALTER TABLE patients DROP COLUMN IF EXISTS merged_into_id;
+ALTER TABLE patient_merges DROP CONSTRAINT IF EXISTS fk_patient_merges_candidate;
+DROP TABLE IF EXISTS duplicate_candidates;
+DROP TABLE IF EXISTS patient_merges;
+DROP TABLE IF EXISTS duplicate_scans;
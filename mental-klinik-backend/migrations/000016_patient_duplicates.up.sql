@@ -0,0 +1,76 @@
+-- Deteksi dan penggabungan pasien duplikat: riwayat scan, antrean review
+-- kandidat, catatan penggabungan (untuk undo) dan penanda pasien yang sudah
+-- digabung ke pasien lain.
+CREATE TABLE IF NOT EXISTS duplicate_scans (
+    id               text PRIMARY KEY,
+    trigger          text NOT NULL,
+    triggered_by_id  text,
+    status           text NOT NULL,
+    error            text NOT NULL DEFAULT '',
+    threshold        double precision NOT NULL,
+    patients         bigint NOT NULL DEFAULT 0,
+    candidates       bigint NOT NULL DEFAULT 0,
+    created          bigint NOT NULL DEFAULT 0,
+    started_at       timestamptz NOT NULL,
+    finished_at      timestamptz,
+    created_at       timestamptz NOT NULL,
+    updated_at       timestamptz NOT NULL,
+    CONSTRAINT chk_duplicate_scans_trigger CHECK (trigger IN ('scheduled', 'manual')),
+    CONSTRAINT chk_duplicate_scans_status CHECK (status IN ('running', 'completed', 'failed')),
+    CONSTRAINT fk_duplicate_scans_triggered_by FOREIGN KEY (triggered_by_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_duplicate_scans_started_at ON duplicate_scans (started_at);
+
+-- Hanya satu scan yang boleh berjalan sekaligus (termasuk antar instance)
+CREATE UNIQUE INDEX IF NOT EXISTS uni_duplicate_scans_running
+    ON duplicate_scans (status) WHERE status = 'running';
+
+CREATE TABLE IF NOT EXISTS patient_merges (
+    id             text PRIMARY KEY,
+    survivor_id    text NOT NULL,
+    merged_id      text NOT NULL,
+    candidate_id   text,
+    moved          jsonb NOT NULL,
+    merged_by_id   text NOT NULL,
+    merged_at      timestamptz NOT NULL,
+    undone_by_id   text,
+    undone_at      timestamptz,
+    created_at     timestamptz NOT NULL,
+    updated_at     timestamptz NOT NULL,
+    CONSTRAINT chk_patient_merges_distinct CHECK (survivor_id <> merged_id),
+    CONSTRAINT fk_patient_merges_survivor FOREIGN KEY (survivor_id) REFERENCES patients (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_patient_merges_merged FOREIGN KEY (merged_id) REFERENCES patients (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_patient_merges_merged_by FOREIGN KEY (merged_by_id) REFERENCES users (id),
+    CONSTRAINT fk_patient_merges_undone_by FOREIGN KEY (undone_by_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_patient_merges_survivor_id ON patient_merges (survivor_id);
+CREATE INDEX IF NOT EXISTS idx_patient_merges_merged_id ON patient_merges (merged_id);
+
+CREATE TABLE IF NOT EXISTS duplicate_candidates (
+    id              text PRIMARY KEY,
+    patient_a_id    text NOT NULL,
+    patient_b_id    text NOT NULL,
+    score           double precision NOT NULL,
+    reasons         jsonb,
+    status          text NOT NULL,
+    detected_at     timestamptz NOT NULL,
+    reviewed_by_id  text,
+    reviewed_at     timestamptz,
+    merge_id        text,
+    created_at      timestamptz NOT NULL,
+    updated_at      timestamptz NOT NULL,
+    CONSTRAINT chk_duplicate_candidates_order CHECK (patient_a_id < patient_b_id COLLATE "C"),
+    CONSTRAINT chk_duplicate_candidates_status CHECK (status IN ('pending', 'dismissed', 'merged')),
+    CONSTRAINT uni_duplicate_candidates_pair UNIQUE (patient_a_id, patient_b_id),
+    CONSTRAINT fk_duplicate_candidates_patient_a FOREIGN KEY (patient_a_id) REFERENCES patients (id) ON DELETE CASCADE,
+    CONSTRAINT fk_duplicate_candidates_patient_b FOREIGN KEY (patient_b_id) REFERENCES patients (id) ON DELETE CASCADE,
+    CONSTRAINT fk_duplicate_candidates_reviewed_by FOREIGN KEY (reviewed_by_id) REFERENCES users (id),
+    CONSTRAINT fk_duplicate_candidates_merge FOREIGN KEY (merge_id) REFERENCES patient_merges (id)
+);
+CREATE INDEX IF NOT EXISTS idx_duplicate_candidates_status ON duplicate_candidates (status, score);
+CREATE INDEX IF NOT EXISTS idx_duplicate_candidates_patient_b_id ON duplicate_candidates (patient_b_id);
+
+ALTER TABLE patient_merges
+    ADD CONSTRAINT fk_patient_merges_candidate FOREIGN KEY (candidate_id) REFERENCES duplicate_candidates (id);
+
+ALTER TABLE patients ADD COLUMN IF NOT EXISTS merged_into_id text;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS appointment_status_changes;
+DROP SEQUENCE IF EXISTS id_seq_statuschange;
@@ -0,0 +1,27 @@
+-- Riwayat perubahan status appointment, dipakai timeline klinis pasien.
+-- Riwayat ikut terhapus bersama appointment-nya.
+CREATE SEQUENCE IF NOT EXISTS id_seq_statuschange;
+
+CREATE TABLE IF NOT EXISTS appointment_status_changes (
+    id              text PRIMARY KEY,
+    appointment_id  text NOT NULL,
+    from_status     text NOT NULL,
+    to_status       text NOT NULL,
+    changed_by_id   text,
+    changed_at      timestamptz NOT NULL,
+    created_at      timestamptz NOT NULL,
+    CONSTRAINT fk_appointment_status_changes_appointment FOREIGN KEY (appointment_id)
+        REFERENCES appointments (id) ON DELETE CASCADE,
+    CONSTRAINT fk_appointment_status_changes_changed_by FOREIGN KEY (changed_by_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_appointment_status_changes_appointment_id
+    ON appointment_status_changes (appointment_id, changed_at);
+
+-- Appointment lama yang sudah tidak pending diberi satu perubahan dari
+-- pending pada updated_at terakhir. Waktu dan pengubahnya hanya perkiraan,
+-- karena itu changed_by_id dibiarkan kosong.
+INSERT INTO appointment_status_changes (id, appointment_id, from_status, to_status, changed_at, created_at)
+SELECT 'backfill-' || id, id, 'pending', status, COALESCE(updated_at, created_at, now()), now()
+FROM appointments
+WHERE status IS NOT NULL AND status <> 'pending'
+ON CONFLICT (id) DO NOTHING;
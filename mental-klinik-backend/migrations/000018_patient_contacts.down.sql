@@ -0,0 +1,17 @@
+ALTER TABLE patient_consents DROP CONSTRAINT IF EXISTS fk_patient_consents_guardian_contact;
+ALTER TABLE patient_consents DROP COLUMN IF EXISTS guardian_contact_id;
+
+-- Kontak prioritas tertinggi yang teleponnya belum terenkripsi dikembalikan
+-- ke kolom teks; telepon terenkripsi tidak bisa didekripsi di SQL
+ALTER TABLE patients ADD COLUMN IF NOT EXISTS emergency_contact text;
+UPDATE patients p SET emergency_contact = c.phone
+FROM (
+    SELECT DISTINCT ON (patient_id) patient_id, phone
+    FROM patient_contacts
+    WHERE phone NOT LIKE 'enc:v1:%'
+    ORDER BY patient_id, priority, created_at
+) c
+WHERE c.patient_id = p.id;
+
+DROP TABLE IF EXISTS patient_contacts;
+DROP SEQUENCE IF EXISTS id_seq_contact;
@@ -0,0 +1,38 @@
+-- Kontak darurat dan wali pasien, menggantikan kolom teks
+-- patients.emergency_contact. Telepon kontak disimpan terenkripsi seperti
+-- telepon pasien (nilai lama tetap plaintext sampai enkripsi ulang).
+CREATE SEQUENCE IF NOT EXISTS id_seq_contact;
+
+CREATE TABLE IF NOT EXISTS patient_contacts (
+    id                        text PRIMARY KEY,
+    patient_id                text NOT NULL,
+    full_name                 text NOT NULL DEFAULT '',
+    relationship              text NOT NULL,
+    phone                     text NOT NULL,
+    priority                  integer NOT NULL,
+    may_receive_clinical_info boolean NOT NULL DEFAULT false,
+    legal_guardian            boolean NOT NULL DEFAULT false,
+    created_at                timestamptz NOT NULL,
+    updated_at                timestamptz NOT NULL,
+    CONSTRAINT chk_patient_contacts_relationship CHECK (relationship IN
+        ('parent', 'spouse', 'child', 'sibling', 'relative', 'friend', 'caregiver', 'other')),
+    CONSTRAINT chk_patient_contacts_priority CHECK (priority > 0),
+    CONSTRAINT fk_patient_contacts_patient FOREIGN KEY (patient_id)
+        REFERENCES patients (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_patient_contacts_patient_id ON patient_contacts (patient_id, priority);
+
+-- Kontak darurat lama menjadi kontak prioritas 1 tanpa nama dan hubungan
+-- "other"; staff melengkapinya saat data pasien diperbarui
+INSERT INTO patient_contacts (id, patient_id, relationship, phone, priority, created_at, updated_at)
+SELECT 'contact-' || lpad(nextval('id_seq_contact')::text, 3, '0') || '-' || substr(md5(random()::text || id), 1, 8),
+       id, 'other', btrim(emergency_contact), 1, now(), now()
+FROM patients
+WHERE btrim(COALESCE(emergency_contact, '')) <> '';
+
+ALTER TABLE patients DROP COLUMN IF EXISTS emergency_contact;
+
+-- Persetujuan pasien di bawah umur boleh menunjuk kontak wali yang menyetujui
+ALTER TABLE patient_consents ADD COLUMN IF NOT EXISTS guardian_contact_id text;
+ALTER TABLE patient_consents ADD CONSTRAINT fk_patient_consents_guardian_contact
+    FOREIGN KEY (guardian_contact_id) REFERENCES patient_contacts (id) ON DELETE SET NULL;
@@ -18,6 +18,22 @@ type Appointment struct {
 	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relations
-	Patient Patient `gorm:"foreignKey:PatientID"`
-	User    User    `gorm:"foreignKey:UserID"`
+	Patient       Patient                   `gorm:"foreignKey:PatientID"`
+	User          User                      `gorm:"foreignKey:UserID"`
+	StatusChanges []AppointmentStatusChange `gorm:"foreignKey:AppointmentID" json:"-"`
+}
+
+// AppointmentStatusChange mencatat satu perubahan status appointment.
+// ChangedByID kosong untuk riwayat lama yang diisi ulang saat migration.
+type AppointmentStatusChange struct {
+	ID            string    `gorm:"primaryKey" json:"id"`
+	AppointmentID string    `gorm:"not null;index" json:"appointmentId"`
+	FromStatus    string    `gorm:"not null" json:"fromStatus"`
+	ToStatus      string    `gorm:"not null" json:"toStatus"`
+	ChangedByID   *string   `json:"changedById"`
+	ChangedAt     time.Time `gorm:"not null" json:"changedAt"`
+	CreatedAt     time.Time `json:"createdAt"`
+
+	// Relations
+	ChangedBy *User `gorm:"foreignKey:ChangedByID" json:"-"`
 }
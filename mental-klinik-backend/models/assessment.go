@@ -10,7 +10,7 @@ type Assessment struct {
 	ID        string         `gorm:"primaryKey" json:"id"`
 	PatientID string         `json:"patientId"`
 	Date      time.Time      `json:"date"`
-	Answers   datatypes.JSON `json:"answers"`
+	Answers   datatypes.JSON `gorm:"serializer:encrypted" json:"answers"`
 	CreatedAt time.Time      `json:"createdAt"`
 	UpdatedAt time.Time      `json:"updatedAt"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Action yang dicatat di audit log
+const (
+	AuditActionCreate = "create"
+	AuditActionRead   = "read"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+	// AuditActionReveal adalah membuka nilai lengkap field yang disamarkan
+	AuditActionReveal = "reveal"
+	// AuditActionBreakGlass adalah membuka akses darurat ke pasien di luar
+	// tim perawatan
+	AuditActionBreakGlass = "break_glass"
+	// AuditActionExport adalah pengunduhan bundle export data pasien
+	AuditActionExport = "export"
+	// AuditActionErase adalah penghapusan / anonimisasi data pasien
+	AuditActionErase = "erase"
+	// AuditActionRestore adalah pemulihan data dari trash
+	AuditActionRestore = "restore"
+	// AuditActionMerge adalah penggabungan pasien duplikat, AuditActionUnmerge
+	// pembatalannya
+	AuditActionMerge   = "merge"
+	AuditActionUnmerge = "unmerge"
+)
+
+// AuditLog mencatat satu akses ke data pasien (PHI). Entry tidak pernah diubah
+// atau dihapus: setiap entry menyimpan hash entry sebelumnya sehingga
+// perubahan di tengah rantai bisa dideteksi (lihat ComputeHash).
+type AuditLog struct {
+	ID            int64          `gorm:"primaryKey;autoIncrement:false" json:"id"`
+	OccurredAt    time.Time      `gorm:"not null" json:"occurredAt"`
+	ActorID       string         `gorm:"not null" json:"actorId"`
+	ActorRole     string         `gorm:"not null" json:"actorRole"`
+	SessionID     string         `json:"sessionId"`
+	Action        string         `gorm:"not null" json:"action"`
+	ResourceType  string         `gorm:"not null" json:"resourceType"`
+	ResourceID    string         `json:"resourceId"`
+	PatientID     string         `json:"patientId"`
+	ChangedFields datatypes.JSON `json:"changedFields"` // nama field yang berubah (atau dibuka), tanpa nilainya
+	Reason        string         `gorm:"not null;default:''" json:"reason"`
+	IPAddress     string         `json:"ipAddress"`
+	UserAgent     string         `json:"userAgent"`
+	PrevHash      string         `gorm:"not null" json:"prevHash"`
+	Hash          string         `gorm:"not null;unique" json:"hash"`
+}
+
+// AuditChainHead menyimpan posisi terakhir rantai hash. Baris tunggal ini
+// dikunci saat menambah entry supaya penulisan bersamaan tetap berurutan.
+type AuditChainHead struct {
+	ID       int    `gorm:"primaryKey"`
+	LastID   int64  `gorm:"not null"`
+	LastHash string `gorm:"not null"`
+}
+
+func (AuditChainHead) TableName() string {
+	return "audit_chain_head"
+}
+
+// Fields mengembalikan daftar field yang berubah
+func (l *AuditLog) Fields() []string {
+	var fields []string
+	if len(l.ChangedFields) > 0 {
+		_ = json.Unmarshal(l.ChangedFields, &fields)
+	}
+	return fields
+}
+
+// ComputeHash menghitung hash entry dari isi entry dan hash entry sebelumnya.
+// Isi di-serialize dengan urutan field tetap dan waktu dalam UTC supaya hasil
+// sama saat dihitung ulang dari data di database.
+func (l *AuditLog) ComputeHash(prevHash string) string {
+	fields := l.Fields()
+	if fields == nil {
+		fields = []string{}
+	}
+	payload, _ := json.Marshal(struct {
+		ID            int64    `json:"id"`
+		OccurredAt    string   `json:"occurredAt"`
+		ActorID       string   `json:"actorId"`
+		ActorRole     string   `json:"actorRole"`
+		SessionID     string   `json:"sessionId"`
+		Action        string   `json:"action"`
+		ResourceType  string   `json:"resourceType"`
+		ResourceID    string   `json:"resourceId"`
+		PatientID     string   `json:"patientId"`
+		ChangedFields []string `json:"changedFields"`
+		IPAddress     string   `json:"ipAddress"`
+		UserAgent     string   `json:"userAgent"`
+		Reason        string   `json:"reason,omitempty"`
+	}{
+		ID:            l.ID,
+		OccurredAt:    l.OccurredAt.UTC().Format(time.RFC3339Nano),
+		ActorID:       l.ActorID,
+		ActorRole:     l.ActorRole,
+		SessionID:     l.SessionID,
+		Action:        l.Action,
+		ResourceType:  l.ResourceType,
+		ResourceID:    l.ResourceID,
+		PatientID:     l.PatientID,
+		ChangedFields: fields,
+		IPAddress:     l.IPAddress,
+		UserAgent:     l.UserAgent,
+		Reason:        l.Reason,
+	})
+
+	sum := sha256.Sum256(append([]byte(prevHash+"\n"), payload...))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+func testAuditLog() AuditLog {
+	return AuditLog{
+		ID:            7,
+		OccurredAt:    time.Date(2024, 5, 1, 9, 30, 0, 123456000, time.UTC),
+		ActorID:       "doctor-001-aaaaaaaa",
+		ActorRole:     "doctor",
+		SessionID:     "session-1",
+		Action:        AuditActionUpdate,
+		ResourceType:  "patient",
+		ResourceID:    "patient-001-bbbbbbbb",
+		PatientID:     "patient-001-bbbbbbbb",
+		ChangedFields: datatypes.JSON(`["phone","address"]`),
+		IPAddress:     "10.0.0.1",
+		UserAgent:     "test",
+	}
+}
+
+func TestAuditLogComputeHash(t *testing.T) {
+	entry := testAuditLog()
+	hash := entry.ComputeHash("prev")
+	if len(hash) != 64 {
+		t.Fatalf("hash = %q, want hex SHA-256", hash)
+	}
+
+	// Hash dihitung ulang dari data di database: zona waktu dan kolom
+	// hasil sebelumnya tidak berpengaruh
+	stored := testAuditLog()
+	stored.OccurredAt = entry.OccurredAt.In(time.FixedZone("WIB", 7*3600))
+	stored.PrevHash, stored.Hash = "prev", hash
+	if got := stored.ComputeHash("prev"); got != hash {
+		t.Errorf("recomputed hash = %s, want %s", got, hash)
+	}
+
+	if entry.ComputeHash("other") == hash {
+		t.Error("hash does not depend on the previous hash")
+	}
+	changes := map[string]func(l *AuditLog){
+		"id":             func(l *AuditLog) { l.ID++ },
+		"occurredAt":     func(l *AuditLog) { l.OccurredAt = l.OccurredAt.Add(time.Microsecond) },
+		"actorId":        func(l *AuditLog) { l.ActorID = "admin-001-cccccccc" },
+		"actorRole":      func(l *AuditLog) { l.ActorRole = "admin" },
+		"sessionId":      func(l *AuditLog) { l.SessionID = "" },
+		"action":         func(l *AuditLog) { l.Action = AuditActionRead },
+		"resourceType":   func(l *AuditLog) { l.ResourceType = "medical_record" },
+		"resourceId":     func(l *AuditLog) { l.ResourceID = "patient-002-dddddddd" },
+		"patientId":      func(l *AuditLog) { l.PatientID = "" },
+		"changedFields":  func(l *AuditLog) { l.ChangedFields = datatypes.JSON(`["phone"]`) },
+		"field order":    func(l *AuditLog) { l.ChangedFields = datatypes.JSON(`["address","phone"]`) },
+		"ipAddress":      func(l *AuditLog) { l.IPAddress = "10.0.0.2" },
+		"userAgent":      func(l *AuditLog) { l.UserAgent = "curl" },
+		"reason":         func(l *AuditLog) { l.Reason = "verifikasi" },
+		"no field names": func(l *AuditLog) { l.ChangedFields = nil },
+	}
+	for name, change := range changes {
+		changed := testAuditLog()
+		change(&changed)
+		if changed.ComputeHash("prev") == hash {
+			t.Errorf("changing %s does not change the hash", name)
+		}
+	}
+}
+
+func TestAuditLogFields(t *testing.T) {
+	entry := testAuditLog()
+	if fields := entry.Fields(); len(fields) != 2 || fields[0] != "phone" || fields[1] != "address" {
+		t.Errorf("Fields = %v", fields)
+	}
+	entry.ChangedFields = nil
+	if fields := entry.Fields(); fields != nil {
+		t.Errorf("Fields without changes = %v", fields)
+	}
+	// Entry tanpa field dan dengan daftar kosong menghasilkan hash yang sama
+	empty := testAuditLog()
+	empty.ChangedFields = datatypes.JSON(`[]`)
+	if entry.ComputeHash("") != empty.ComputeHash("") {
+		t.Error("nil and empty changed fields hash differently")
+	}
+}
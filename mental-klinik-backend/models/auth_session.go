@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// AuthSession adalah satu sesi login (satu "family" refresh token). Access
+// token membawa ID sesi di claim sid, sehingga mencabut sesi langsung
+// membuat access token-nya ditolak.
+type AuthSession struct {
+	ID            string     `gorm:"primaryKey" json:"id"`
+	UserID        string     `gorm:"not null;index" json:"userId"`
+	UserAgent     string     `json:"userAgent"`
+	IPAddress     string     `json:"ipAddress"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	LastUsedAt    *time.Time `json:"lastUsedAt"`
+	RevokedAt     *time.Time `json:"revokedAt"`
+	RevokedReason string     `json:"revokedReason"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// RefreshToken disimpan sebagai hash SHA-256, token aslinya hanya dikirim
+// sekali ke client. UsedAt terisi saat token dirotasi; token yang sudah
+// dipakai lalu dipakai lagi dianggap dicuri.
+type RefreshToken struct {
+	ID        string     `gorm:"primaryKey" json:"id"`
+	SessionID string     `gorm:"not null;index" json:"sessionId"`
+	TokenHash string     `gorm:"not null;unique" json:"-"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	UsedAt    *time.Time `json:"usedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+
+	// Relations
+	Session AuthSession `gorm:"foreignKey:SessionID" json:"-"`
+}
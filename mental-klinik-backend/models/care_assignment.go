@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// Peran anggota tim perawatan pasien
+const (
+	CareRolePrimary   = "primary"   // klinisi utama, hanya satu per pasien
+	CareRoleSecondary = "secondary" // klinisi pendamping
+	CareRoleStaff     = "staff"     // staff pendamping (administrasi, koordinasi)
+)
+
+// CareAssignment menugaskan satu user ke tim perawatan satu pasien. EndDate
+// eksklusif: penugasan aktif mulai StartDate sampai sehari sebelum EndDate,
+// nil berarti belum berakhir.
+type CareAssignment struct {
+	ID           string     `gorm:"primaryKey" json:"id"`
+	PatientID    string     `gorm:"not null" json:"patientId"`
+	UserID       string     `gorm:"not null" json:"userId"`
+	Role         string     `gorm:"not null" json:"role"`
+	StartDate    time.Time  `gorm:"type:date;not null" json:"startDate"`
+	EndDate      *time.Time `gorm:"type:date" json:"endDate"`
+	EndReason    string     `json:"endReason"`
+	AssignedByID string     `gorm:"not null" json:"assignedById"`
+	EndedByID    *string    `json:"endedById"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+
+	// Relations
+	Patient Patient `gorm:"foreignKey:PatientID" json:"-"`
+	User    User    `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// ActiveOn menentukan apakah penugasan berlaku pada tanggal day
+func (a *CareAssignment) ActiveOn(day time.Time) bool {
+	return !a.StartDate.After(day) && (a.EndDate == nil || a.EndDate.After(day))
+}
+
+// OpenOn menentukan apakah penugasan masih berlaku atau akan berlaku pada
+// atau setelah tanggal day (belum berakhir)
+func (a *CareAssignment) OpenOn(day time.Time) bool {
+	return a.EndDate == nil || a.EndDate.After(day)
+}
+
+// Status menurunkan status penugasan pada tanggal day
+func (a *CareAssignment) Status(day time.Time) string {
+	switch {
+	case a.StartDate.After(day):
+		return "upcoming"
+	case a.ActiveOn(day):
+		return "active"
+	default:
+		return "ended"
+	}
+}
@@ -0,0 +1,78 @@
+package models
+
+import "time"
+
+// Jenis persetujuan pasien
+const (
+	ConsentTypeTreatment    = "treatment"     // persetujuan tindakan/terapi
+	ConsentTypeAIPrediction = "ai_prediction" // asesmen dikirim ke service prediksi AI
+	ConsentTypeResearch     = "research"      // data dipakai untuk penelitian
+	ConsentTypeDataSharing  = "data_sharing"  // data dibagikan ke pihak ketiga
+)
+
+// ConsentTypes adalah semua jenis persetujuan, urutan tetap untuk ringkasan
+var ConsentTypes = []string{
+	ConsentTypeTreatment,
+	ConsentTypeAIPrediction,
+	ConsentTypeResearch,
+	ConsentTypeDataSharing,
+}
+
+// Cara persetujuan diambil
+const (
+	ConsentMethodWritten    = "written"
+	ConsentMethodVerbal     = "verbal"
+	ConsentMethodElectronic = "electronic"
+)
+
+// ConsentDocument adalah satu versi teks persetujuan. Dokumen tidak pernah
+// diubah; perubahan teks diterbitkan sebagai versi baru dengan Version + 1.
+type ConsentDocument struct {
+	ID          string    `gorm:"primaryKey" json:"id"`
+	Type        string    `gorm:"not null" json:"type"`
+	Version     int       `gorm:"not null" json:"version"`
+	Title       string    `gorm:"not null" json:"title"`
+	Body        string    `gorm:"not null" json:"body"`
+	CreatedByID string    `gorm:"not null" json:"createdById"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// PatientConsent mencatat persetujuan pasien atas satu versi dokumen: kapan
+// diberikan, siapa yang mencatat, dan penarikannya. Persetujuan yang ditarik
+// tetap disimpan sebagai riwayat; per pasien hanya ada satu persetujuan aktif
+// untuk setiap jenis.
+type PatientConsent struct {
+	ID                string     `gorm:"primaryKey" json:"id"`
+	PatientID         string     `gorm:"not null;index" json:"patientId"`
+	DocumentID        string     `gorm:"not null" json:"documentId"`
+	Type              string     `gorm:"not null" json:"type"`
+	Method            string     `gorm:"not null" json:"method"`
+	Notes             string     `json:"notes"`
+	GuardianName      string     `gorm:"not null;default:''" json:"guardianName"` // wali yang menyetujui untuk pasien di bawah umur
+	GuardianContactID *string    `json:"guardianContactId"`                       // kontak wali sah yang menyetujui, jika tercatat sebagai kontak pasien
+	GrantedAt         time.Time  `gorm:"not null" json:"grantedAt"`
+	CapturedByID      string     `gorm:"not null" json:"capturedById"`
+	WithdrawnAt       *time.Time `json:"withdrawnAt"`
+	WithdrawnByID     *string    `json:"withdrawnById"`
+	WithdrawalReason  string     `json:"withdrawalReason"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+
+	// Relations
+	Document   ConsentDocument `gorm:"foreignKey:DocumentID" json:"-"`
+	CapturedBy User            `gorm:"foreignKey:CapturedByID" json:"-"`
+}
+
+// Active bernilai true jika persetujuan belum ditarik
+func (c *PatientConsent) Active() bool {
+	return c.WithdrawnAt == nil
+}
+
+// Status adalah "active" atau "withdrawn"
+func (c *PatientConsent) Status() string {
+	if c.Active() {
+		return "active"
+	}
+	return "withdrawn"
+}
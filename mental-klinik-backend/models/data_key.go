@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// DataKey adalah kunci AES-256 untuk enkripsi field (envelope encryption).
+// Yang disimpan hanya versi terbungkus (wrapped) oleh master key dari
+// konfigurasi; MasterKeyID menunjuk master key yang membungkusnya. Hanya satu
+// data key yang aktif dan dipakai untuk enkripsi baru, data key lama tetap
+// disimpan supaya data yang belum dienkripsi ulang masih bisa dibaca.
+type DataKey struct {
+	ID          string    `gorm:"primaryKey" json:"id"`
+	MasterKeyID string    `gorm:"not null" json:"masterKeyId"`
+	WrappedKey  []byte    `gorm:"not null" json:"-"`
+	Active      bool      `gorm:"not null" json:"active"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
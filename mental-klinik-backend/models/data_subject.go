@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Status job export data pasien
+const (
+	DataExportPending    = "pending"
+	DataExportProcessing = "processing"
+	DataExportCompleted  = "completed"
+	DataExportFailed     = "failed"
+)
+
+// DataExport adalah satu permintaan export data pribadi pasien (hak akses
+// subjek data UU PDP). Bundle berisi file zip (data.json dan summary.pdf)
+// setelah job selesai.
+type DataExport struct {
+	ID            string     `gorm:"primaryKey" json:"id"`
+	PatientID     string     `gorm:"not null;index" json:"patientId"`
+	RequestedByID string     `gorm:"not null" json:"requestedById"`
+	Status        string     `gorm:"not null" json:"status"`
+	Error         string     `json:"error"`
+	Bundle        []byte     `json:"-"`
+	Checksum      string     `json:"checksum"` // SHA-256 hex dari Bundle
+	Size          int64      `json:"size"`
+	RequestedAt   time.Time  `gorm:"not null" json:"requestedAt"`
+	CompletedAt   *time.Time `json:"completedAt"`
+	ExpiresAt     *time.Time `json:"expiresAt"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+// Mode dan status permintaan penghapusan data pasien
+const (
+	ErasureModeErase     = "erase"     // hapus permanen kecuali data dengan kewajiban simpan
+	ErasureModeAnonymize = "anonymize" // hapus identitas, data klinis tetap untuk statistik
+
+	ErasurePending   = "pending"
+	ErasureRejected  = "rejected"
+	ErasureCompleted = "completed"
+)
+
+// ErasureRequest adalah permintaan penghapusan atau anonimisasi data pasien.
+// Dijalankan setelah disetujui admin lain (bukan peminta) dan hanya jika
+// pasien tidak sedang dalam legal hold. Certificate adalah bukti penyelesaian
+// dengan hash SHA-256 di CertificateHash.
+type ErasureRequest struct {
+	ID              string         `gorm:"primaryKey" json:"id"`
+	PatientID       string         `gorm:"not null;index" json:"patientId"`
+	Mode            string         `gorm:"not null" json:"mode"`
+	Reason          string         `gorm:"not null" json:"reason"`
+	Status          string         `gorm:"not null" json:"status"`
+	RequestedByID   string         `gorm:"not null" json:"requestedById"`
+	RequestedAt     time.Time      `gorm:"not null" json:"requestedAt"`
+	ReviewedByID    *string        `json:"reviewedById"`
+	ReviewedAt      *time.Time     `json:"reviewedAt"`
+	ReviewNotes     string         `json:"reviewNotes"`
+	CompletedAt     *time.Time     `json:"completedAt"`
+	Certificate     datatypes.JSON `json:"certificate"`
+	CertificateHash string         `json:"certificateHash"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+}
+
+// LegalHold menahan data pasien dari penghapusan, misalnya selama sengketa
+// hukum atau permintaan aparat. Hold aktif sampai ReleasedAt terisi.
+type LegalHold struct {
+	ID            string     `gorm:"primaryKey" json:"id"`
+	PatientID     string     `gorm:"not null;index" json:"patientId"`
+	Reason        string     `gorm:"not null" json:"reason"`
+	Reference     string     `json:"reference"` // nomor perkara / surat
+	PlacedByID    string     `gorm:"not null" json:"placedById"`
+	PlacedAt      time.Time  `gorm:"not null" json:"placedAt"`
+	ReleasedAt    *time.Time `json:"releasedAt"`
+	ReleasedByID  *string    `json:"releasedById"`
+	ReleaseReason string     `json:"releaseReason"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+// Active bernilai true jika hold belum dilepas
+func (h *LegalHold) Active() bool {
+	return h.ReleasedAt == nil
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// Hasil review admin atas akses darurat
+const (
+	EmergencyReviewJustified   = "justified"
+	EmergencyReviewUnjustified = "unjustified"
+)
+
+// EmergencyAccess adalah satu pemakaian akses darurat (break-the-glass):
+// klinisi di luar tim perawatan membuka akses ke satu pasien dengan alasan
+// tertulis, berlaku sampai ExpiresAt. Setiap akses darurat masuk antrean
+// review admin sampai ReviewedAt terisi.
+type EmergencyAccess struct {
+	ID            string     `gorm:"primaryKey" json:"id"`
+	UserID        string     `gorm:"not null;index" json:"userId"`
+	PatientID     string     `gorm:"not null;index" json:"patientId"`
+	SessionID     string     `gorm:"not null" json:"sessionId"`
+	Reason        string     `gorm:"not null" json:"reason"`
+	IPAddress     string     `json:"ipAddress"`
+	UserAgent     string     `json:"userAgent"`
+	GrantedAt     time.Time  `gorm:"not null" json:"grantedAt"`
+	ExpiresAt     time.Time  `gorm:"not null" json:"expiresAt"`
+	RevokedAt     *time.Time `json:"revokedAt"`
+	RevokedByID   *string    `json:"revokedById"`
+	ReviewedAt    *time.Time `json:"reviewedAt"`
+	ReviewedByID  *string    `json:"reviewedById"`
+	ReviewOutcome string     `json:"reviewOutcome"`
+	ReviewNotes   string     `json:"reviewNotes"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+
+	// Relations
+	Patient Patient `gorm:"foreignKey:PatientID" json:"-"`
+	User    User    `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// ActiveAt bernilai true jika akses belum dicabut dan belum lewat waktunya
+func (a *EmergencyAccess) ActiveAt(now time.Time) bool {
+	return a.RevokedAt == nil && now.Before(a.ExpiresAt)
+}
+
+// Status menurunkan status akses dari timestamp-nya
+func (a *EmergencyAccess) Status(now time.Time) string {
+	switch {
+	case a.RevokedAt != nil:
+		return "revoked"
+	case !now.Before(a.ExpiresAt):
+		return "expired"
+	default:
+		return "active"
+	}
+}
+
+// ReviewStatus adalah "pending" sampai admin memberi hasil review
+func (a *EmergencyAccess) ReviewStatus() string {
+	if a.ReviewedAt == nil {
+		return "pending"
+	}
+	return a.ReviewOutcome
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Invitation adalah undangan dari admin untuk mendaftar dengan role tertentu.
+// Token aslinya hanya ditampilkan sekali saat dibuat, yang disimpan hash-nya.
+type Invitation struct {
+	ID          string     `gorm:"primaryKey" json:"id"`
+	Email       string     `gorm:"not null" json:"email"`
+	Role        string     `gorm:"not null" json:"role"`
+	TokenHash   string     `gorm:"not null;unique" json:"-"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	CreatedByID string     `gorm:"not null" json:"createdById"`
+	UsedAt      *time.Time `json:"usedAt"`
+	UsedByID    *string    `json:"usedById"`
+	RevokedAt   *time.Time `json:"revokedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+
+	// Relations
+	CreatedBy User `gorm:"foreignKey:CreatedByID" json:"-"`
+}
+
+// Status menurunkan status undangan dari timestamp-nya
+func (i *Invitation) Status(now time.Time) string {
+	switch {
+	case i.UsedAt != nil:
+		return "used"
+	case i.RevokedAt != nil:
+		return "revoked"
+	case now.After(i.ExpiresAt):
+		return "expired"
+	default:
+		return "pending"
+	}
+}
@@ -10,8 +10,8 @@ type MedicalRecord struct {
 	ID        string         `gorm:"primaryKey" json:"id"`
 	PatientID string         `json:"patientId"`
 	UserID    string         `json:"userId"`
-	Diagnosis string         `json:"diagnosis"`
-	Treatment string         `json:"treatment"`
+	Diagnosis string         `gorm:"serializer:encrypted" json:"diagnosis"`
+	Treatment string         `gorm:"serializer:encrypted" json:"treatment"`
 	CreatedAt time.Time      `json:"createdAt"`
 	UpdatedAt time.Time      `json:"updatedAt"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
@@ -6,21 +6,66 @@ import (
 	"gorm.io/gorm"
 )
 
+// AdultAge adalah usia dewasa; pasien yang lebih muda dianggap di bawah umur
+// sehingga persetujuannya diberikan oleh wali
+const AdultAge = 18
+
+// Patient menyimpan NIK, Phone dan Address terenkripsi (lihat package
+// encryption); pencarian NIK memakai NIKIndex.
 type Patient struct {
 	ID               string         `gorm:"primaryKey" json:"id"`
 	FullName         string         `json:"fullName"`
-	NIK              string         `gorm:"unique" json:"nik"`
-	BirthDate        string         `json:"birthDate"`
+	NIK              string         `gorm:"serializer:encrypted" json:"nik"`
+	NIKIndex         *string        `gorm:"column:nik_index;uniqueIndex:uni_patients_nik_index,where:deleted_at IS NULL" json:"-"` // blind index NIK untuk pencarian exact
+	BirthDate        *time.Time     `gorm:"type:date" json:"birthDate"` // nil jika nilai lama tidak bisa dikonversi
+	BirthDateLegacy  *string        `json:"-"`                          // nilai lama yang tidak bisa dikonversi, dilaporkan ke admin
 	Gender           string         `json:"gender"`
-	Phone            string         `json:"phone"`
-	Address          string         `json:"address"`
-	EmergencyContact string         `json:"emergencyContact"`
+	Phone            string         `gorm:"serializer:encrypted" json:"phone"`
+	Address          string         `gorm:"serializer:encrypted" json:"address"`
 	CreatedAt        time.Time      `json:"createdAt"`
 	UpdatedAt        time.Time      `json:"updatedAt"`
 	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
+	AnonymizedAt     *time.Time     `json:"-"` // terisi setelah identitas dihapus (erasure / retensi)
+	MergedIntoID     *string        `json:"-"` // pasien tujuan jika pasien ini digabung sebagai duplikat
 
 	// Relations
 	Appointments    []Appointment    `gorm:"foreignKey:PatientID"`
 	Assessments     []Assessment     `gorm:"foreignKey:PatientID"`
 	MedicalRecords  []MedicalRecord  `gorm:"foreignKey:PatientID"`
-}
\ No newline at end of file
+	Contacts        []PatientContact `gorm:"foreignKey:PatientID"`
+}
+
+// Age adalah usia pasien dalam tahun penuh pada tanggal day, ok bernilai
+// false jika tanggal lahir belum diketahui
+func (p *Patient) Age(day time.Time) (age int, ok bool) {
+	if p.BirthDate == nil {
+		return 0, false
+	}
+	birth := *p.BirthDate
+	age = day.Year() - birth.Year()
+	if day.Month() < birth.Month() || (day.Month() == birth.Month() && day.Day() < birth.Day()) {
+		age--
+	}
+	return age, true
+}
+
+// IsMinor bernilai true jika pasien belum mencapai AdultAge pada tanggal day.
+// Pasien tanpa tanggal lahir tidak dianggap di bawah umur.
+func (p *Patient) IsMinor(day time.Time) bool {
+	age, ok := p.Age(day)
+	return ok && age < AdultAge
+}
+
+// PrimaryContact adalah kontak yang dihubungi pertama (priority terkecil,
+// lalu yang dibuat lebih dulu), nil jika Contacts kosong atau tidak dimuat
+func (p *Patient) PrimaryContact() *PatientContact {
+	var primary *PatientContact
+	for i := range p.Contacts {
+		contact := &p.Contacts[i]
+		if primary == nil || contact.Priority < primary.Priority ||
+			(contact.Priority == primary.Priority && contact.CreatedAt.Before(primary.CreatedAt)) {
+			primary = contact
+		}
+	}
+	return primary
+}
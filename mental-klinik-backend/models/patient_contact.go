@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Hubungan kontak dengan pasien
+const (
+	ContactRelationshipParent    = "parent"
+	ContactRelationshipSpouse    = "spouse"
+	ContactRelationshipChild     = "child"
+	ContactRelationshipSibling   = "sibling"
+	ContactRelationshipRelative  = "relative"
+	ContactRelationshipFriend    = "friend"
+	ContactRelationshipCaregiver = "caregiver"
+	ContactRelationshipOther     = "other"
+)
+
+// PatientContact adalah kontak darurat atau wali pasien. Priority 1 dihubungi
+// pertama; Phone disimpan terenkripsi (lihat package encryption).
+type PatientContact struct {
+	ID                     string    `gorm:"primaryKey" json:"id"`
+	PatientID              string    `gorm:"not null;index" json:"patientId"`
+	FullName               string    `gorm:"not null;default:''" json:"fullName"` // kosong untuk kontak hasil migrasi kolom lama
+	Relationship           string    `gorm:"not null" json:"relationship"`
+	Phone                  string    `gorm:"serializer:encrypted;not null" json:"phone"`
+	Priority               int       `gorm:"not null" json:"priority"`
+	MayReceiveClinicalInfo bool      `gorm:"not null;default:false" json:"mayReceiveClinicalInfo"`
+	LegalGuardian          bool      `gorm:"not null;default:false" json:"legalGuardian"` // wali sah, menyetujui tindakan untuk pasien di bawah umur
+	CreatedAt              time.Time `json:"createdAt"`
+	UpdatedAt              time.Time `json:"updatedAt"`
+}
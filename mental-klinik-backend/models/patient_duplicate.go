@@ -0,0 +1,95 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Status kandidat duplikat pasien
+const (
+	DuplicatePending   = "pending"   // menunggu review admin
+	DuplicateDismissed = "dismissed" // bukan orang yang sama, tidak dimunculkan lagi
+	DuplicateMerged    = "merged"    // sudah digabung, lihat MergeID
+)
+
+// Alasan kecocokan kandidat duplikat
+const (
+	DuplicateReasonName             = "name"
+	DuplicateReasonSimilarName      = "similar_name"
+	DuplicateReasonBirthDate        = "birth_date"
+	DuplicateReasonSimilarBirthDate = "similar_birth_date"
+	DuplicateReasonPhone            = "phone"
+	DuplicateReasonSimilarNIK       = "similar_nik"
+)
+
+// Pemicu dan status satu scan duplikat
+const (
+	DuplicateScanScheduled = "scheduled"
+	DuplicateScanManual    = "manual"
+
+	DuplicateScanRunning   = "running"
+	DuplicateScanCompleted = "completed"
+	DuplicateScanFailed    = "failed"
+)
+
+// DuplicateCandidate adalah pasangan pasien yang kemungkinan orang yang sama.
+// PatientAID selalu lebih kecil dari PatientBID sehingga satu pasangan hanya
+// punya satu baris.
+type DuplicateCandidate struct {
+	ID           string         `gorm:"primaryKey" json:"id"`
+	PatientAID   string         `gorm:"column:patient_a_id;not null" json:"patientAId"`
+	PatientBID   string         `gorm:"column:patient_b_id;not null" json:"patientBId"`
+	Score        float64        `gorm:"not null" json:"score"`
+	Reasons      datatypes.JSON `json:"reasons"` // []string, lihat DuplicateReason*
+	Status       string         `gorm:"not null" json:"status"`
+	DetectedAt   time.Time      `gorm:"not null" json:"detectedAt"`
+	ReviewedByID *string        `json:"reviewedById"`
+	ReviewedAt   *time.Time     `json:"reviewedAt"`
+	MergeID      *string        `json:"mergeId"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	UpdatedAt    time.Time      `json:"updatedAt"`
+
+	// Relations, termasuk pasien yang sudah dihapus
+	PatientA Patient `gorm:"foreignKey:PatientAID" json:"-"`
+	PatientB Patient `gorm:"foreignKey:PatientBID" json:"-"`
+}
+
+// PatientMerge mencatat penggabungan pasien MergedID ke SurvivorID. Moved
+// menyimpan ID baris yang dipindahkan per tabel sehingga penggabungan bisa
+// dibatalkan tanpa ikut memindahkan data yang dibuat setelahnya.
+type PatientMerge struct {
+	ID          string         `gorm:"primaryKey" json:"id"`
+	SurvivorID  string         `gorm:"not null;index" json:"survivorId"`
+	MergedID    string         `gorm:"not null;index" json:"mergedId"`
+	CandidateID *string        `json:"candidateId"`
+	Moved       datatypes.JSON `json:"moved"` // map[string][]string
+	MergedByID  string         `gorm:"not null" json:"mergedById"`
+	MergedAt    time.Time      `gorm:"not null" json:"mergedAt"`
+	UndoneByID  *string        `json:"undoneById"`
+	UndoneAt    *time.Time     `json:"undoneAt"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+// Undone bernilai true jika penggabungan sudah dibatalkan
+func (m *PatientMerge) Undone() bool {
+	return m.UndoneAt != nil
+}
+
+// DuplicateScan adalah satu eksekusi job pencarian duplikat pasien
+type DuplicateScan struct {
+	ID            string     `gorm:"primaryKey" json:"id"`
+	Trigger       string     `gorm:"not null" json:"trigger"`
+	TriggeredByID *string    `json:"triggeredById"`
+	Status        string     `gorm:"not null" json:"status"`
+	Error         string     `json:"error"`
+	Threshold     float64    `gorm:"not null" json:"threshold"`
+	Patients      int64      `gorm:"not null" json:"patients"`   // pasien yang dibandingkan
+	Candidates    int64      `gorm:"not null" json:"candidates"` // pasangan di atas threshold
+	Created       int64      `gorm:"not null" json:"created"`    // kandidat baru di antrean review
+	StartedAt     time.Time  `gorm:"not null" json:"startedAt"`
+	FinishedAt    *time.Time `json:"finishedAt"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
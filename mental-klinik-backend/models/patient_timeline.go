@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Jenis event di timeline klinis pasien
+const (
+	TimelineAppointmentScheduled     = "appointment_scheduled"
+	TimelineAppointmentStatusChanged = "appointment_status_changed"
+	TimelineAssessmentTaken          = "assessment_taken"
+	TimelinePredictionGenerated      = "prediction_generated"
+	TimelineRecordWritten            = "medical_record_written"
+)
+
+// TimelineEventTypes adalah seluruh jenis event timeline
+var TimelineEventTypes = []string{
+	TimelineAppointmentScheduled,
+	TimelineAppointmentStatusChanged,
+	TimelineAssessmentTaken,
+	TimelinePredictionGenerated,
+	TimelineRecordWritten,
+}
+
+// TimelineEvent adalah satu baris timeline klinis pasien. Bukan tabel: baris
+// disusun dari appointment, riwayat status, assessment, prediksi dan rekam
+// medis pasien, lalu datanya diisi ke salah satu field detail sesuai Type.
+type TimelineEvent struct {
+	ID         string    `json:"id"` // <type>:<id baris sumber>
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurredAt"`
+
+	AppointmentID   *string `json:"-"`
+	StatusChangeID  *string `json:"-"`
+	AssessmentID    *string `json:"-"`
+	PredictionID    *string `json:"-"`
+	MedicalRecordID *string `json:"-"`
+
+	// Detail, diisi repository
+	Appointment   *Appointment             `gorm:"-" json:"-"`
+	StatusChange  *AppointmentStatusChange `gorm:"-" json:"-"`
+	Assessment    *Assessment              `gorm:"-" json:"-"`
+	Prediction    *Prediction              `gorm:"-" json:"-"`
+	MedicalRecord *MedicalRecord           `gorm:"-" json:"-"`
+}
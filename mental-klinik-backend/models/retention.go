@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Action dan dasar perhitungan kebijakan retensi
+const (
+	RetentionPurge     = "purge"     // hapus permanen
+	RetentionAnonymize = "anonymize" // hapus identitas / teks bebas, baris tetap ada
+
+	RetentionBasisDeleted   = "deleted"    // sejak baris di-soft delete
+	RetentionBasisLastVisit = "last_visit" // sejak kunjungan terakhir pasien
+	RetentionBasisExpired   = "expired"    // sejak bundle export kedaluwarsa
+)
+
+// RetentionSupport adalah dasar dan action yang didukung satu entity
+type RetentionSupport struct {
+	Bases   []string
+	Actions []string
+}
+
+// RetentionEntities adalah entity yang bisa diatur kebijakan retensinya.
+// Audit log tidak termasuk karena append-only.
+var RetentionEntities = map[string]RetentionSupport{
+	"appointment": {
+		Bases:   []string{RetentionBasisDeleted},
+		Actions: []string{RetentionPurge, RetentionAnonymize},
+	},
+	"assessment": {
+		Bases:   []string{RetentionBasisDeleted},
+		Actions: []string{RetentionPurge},
+	},
+	"prediction": {
+		Bases:   []string{RetentionBasisDeleted},
+		Actions: []string{RetentionPurge},
+	},
+	"medical_record": {
+		Bases:   []string{RetentionBasisDeleted, RetentionBasisLastVisit},
+		Actions: []string{RetentionPurge},
+	},
+	"patient": {
+		Bases:   []string{RetentionBasisDeleted, RetentionBasisLastVisit},
+		Actions: []string{RetentionAnonymize},
+	},
+	"data_export": {
+		Bases:   []string{RetentionBasisExpired},
+		Actions: []string{RetentionPurge},
+	},
+}
+
+// Pemicu dan status satu run retensi
+const (
+	RetentionTriggerScheduled = "scheduled"
+	RetentionTriggerManual    = "manual"
+
+	RetentionRunning   = "running"
+	RetentionCompleted = "completed"
+	RetentionFailed    = "failed"
+)
+
+// RetentionRun adalah satu eksekusi kebijakan retensi. Run dry-run hanya
+// menghitung baris yang memenuhi syarat; run yang menghapus selalu didahului
+// laporan dry-run yang ditunjuk ReportRunID.
+type RetentionRun struct {
+	ID            string         `gorm:"primaryKey" json:"id"`
+	Trigger       string         `gorm:"not null" json:"trigger"`
+	TriggeredByID *string        `json:"triggeredById"`
+	DryRun        bool           `gorm:"not null" json:"dryRun"`
+	ReportRunID   *string        `json:"reportRunId"`
+	Status        string         `gorm:"not null" json:"status"`
+	Error         string         `json:"error"`
+	StartedAt     time.Time      `gorm:"not null" json:"startedAt"`
+	FinishedAt    *time.Time     `json:"finishedAt"`
+	Results       datatypes.JSON `json:"results"` // []RetentionResult
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+}
+
+// RetentionResult adalah hasil satu kebijakan di dalam run. OnHold adalah
+// baris yang memenuhi syarat tetapi ditahan legal hold.
+type RetentionResult struct {
+	Entity    string    `json:"entity"`
+	Action    string    `json:"action"`
+	Basis     string    `json:"basis"`
+	RetainFor string    `json:"retainFor"`
+	Cutoff    time.Time `json:"cutoff"`
+	Eligible  int64     `json:"eligible"`
+	OnHold    int64     `json:"onHold"`
+	Processed int64     `json:"processed"`
+	SampleIDs []string  `json:"sampleIds"`
+}
@@ -9,7 +9,7 @@ import (
 type User struct {
 	ID        string         `gorm:"primaryKey" json:"id"`
 	FullName  string         `json:"fullName" binding:"required"`
-	Email     string         `gorm:"unique" json:"email" binding:"required,email"`
+	Email     string         `gorm:"uniqueIndex:uni_users_email,where:deleted_at IS NULL" json:"email" binding:"required,email"`
 	Password  string         `json:"password" binding:"required,min=6"`
 	Role      string         `json:"role" binding:"required,oneof=admin doctor staff"`
 	CreatedAt time.Time      `json:"createdAt"`
@@ -0,0 +1,165 @@
+// Package nik memvalidasi dan membaca Nomor Induk Kependudukan (NIK): 6 digit
+// kode wilayah (provinsi, kabupaten / kota, kecamatan), 6 digit tanggal lahir
+// DDMMYY (tanggal ditambah 40 untuk perempuan) dan 4 digit nomor urut.
+package nik
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Length adalah jumlah digit NIK
+const Length = 16
+
+// Jenis kelamin yang dikodekan di NIK, sama dengan nilai gender pasien
+const (
+	GenderMale   = "male"
+	GenderFemale = "female"
+)
+
+// dateLayout adalah format tanggal lahir di request / response
+const dateLayout = "2006-01-02"
+
+// Info adalah isi NIK yang sudah dibaca
+type Info struct {
+	Province  Region
+	Regency   Region
+	District  Region
+	BirthDate time.Time
+	Gender    string
+	Serial    string
+}
+
+// FieldError adalah kesalahan validasi pada satu field request
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError berisi semua kesalahan validasi per field
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Errors))
+	for _, fieldErr := range e.Errors {
+		parts = append(parts, fieldErr.Field+" "+fieldErr.Message)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Validator membaca NIK dengan tabel wilayah tertentu
+type Validator struct {
+	regions *Regions
+	now     func() time.Time
+}
+
+func NewValidator(regions *Regions) *Validator {
+	return &Validator{regions: regions, now: time.Now}
+}
+
+// Decode membaca NIK. Kesalahan dikembalikan sebagai *ValidationError pada
+// field "nik".
+func (v *Validator) Decode(raw string) (*Info, error) {
+	info, errs := v.decode(strings.TrimSpace(raw))
+	if len(errs) > 0 {
+		return nil, &ValidationError{Errors: errs}
+	}
+	return info, nil
+}
+
+// Validate memastikan NIK valid dan cocok dengan tanggal lahir (YYYY-MM-DD)
+// dan jenis kelamin yang diisi. Gender selain male / female tidak
+// dicocokkan karena NIK hanya mengodekan keduanya.
+func (v *Validator) Validate(raw string, birthDate string, gender string) error {
+	var errs []FieldError
+	birth, err := time.Parse(dateLayout, birthDate)
+	if err != nil {
+		errs = append(errs, FieldError{Field: "birthDate", Message: "must be a date in YYYY-MM-DD format"})
+	} else if birth.After(v.now()) {
+		errs = append(errs, FieldError{Field: "birthDate", Message: "must not be in the future"})
+	}
+
+	info, nikErrs := v.decode(strings.TrimSpace(raw))
+	errs = append(errs, nikErrs...)
+	if info != nil {
+		// Tahun di NIK hanya 2 digit, abadnya diambil dari tanggal lahir
+		encoded := info.BirthDate
+		if err == nil && (birth.Day() != encoded.Day() || birth.Month() != encoded.Month() || birth.Year()%100 != encoded.Year()%100) {
+			errs = append(errs, FieldError{
+				Field:   "birthDate",
+				Message: "does not match the birth date encoded in nik (DDMMYY " + encoded.Format("020106") + ")",
+			})
+		}
+		if (gender == GenderMale || gender == GenderFemale) && gender != info.Gender {
+			errs = append(errs, FieldError{Field: "gender", Message: "does not match the gender encoded in nik (" + info.Gender + ")"})
+		}
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+func (v *Validator) decode(raw string) (*Info, []FieldError) {
+	invalid := func(format string, args ...interface{}) []FieldError {
+		return []FieldError{{Field: "nik", Message: fmt.Sprintf(format, args...)}}
+	}
+	if len(raw) != Length || !isDigits(raw) {
+		return nil, invalid("must be exactly %d digits", Length)
+	}
+
+	var errs []FieldError
+	info := &Info{Serial: raw[12:]}
+	var ok bool
+	if info.Province, ok = v.regions.Lookup(raw[0:2]); !ok {
+		errs = append(errs, invalid("has unknown province code %s", raw[0:2])...)
+	} else if info.Regency, ok = v.regions.Lookup(raw[0:4]); !ok || raw[2:4] == "00" {
+		errs = append(errs, invalid("has unknown regency code %s", raw[0:4])...)
+	} else if info.District, ok = v.regions.Lookup(raw[0:6]); !ok || raw[4:6] == "00" {
+		errs = append(errs, invalid("has unknown district code %s", raw[0:6])...)
+	}
+
+	day, _ := strconv.Atoi(raw[6:8])
+	month, _ := strconv.Atoi(raw[8:10])
+	year, _ := strconv.Atoi(raw[10:12])
+	info.Gender = GenderMale
+	if day > 40 {
+		info.Gender = GenderFemale
+		day -= 40
+	}
+	birth, ok := v.birthDate(day, month, year)
+	if !ok {
+		errs = append(errs, invalid("encodes an invalid birth date %s", raw[6:12])...)
+	}
+	info.BirthDate = birth
+
+	if info.Serial == "0000" {
+		errs = append(errs, invalid("has an invalid serial number 0000")...)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return info, nil
+}
+
+// birthDate menyusun tanggal lahir dari DD, MM dan YY. Abad dipilih supaya
+// tanggalnya tidak di masa depan.
+func (v *Validator) birthDate(day int, month int, year int) (time.Time, bool) {
+	if month < 1 || month > 12 || day < 1 {
+		return time.Time{}, false
+	}
+	date := time.Date(2000+year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.After(v.now()) {
+		date = time.Date(1900+year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	}
+	// time.Date menormalkan tanggal seperti 31 Februari, tolak jika bergeser
+	if date.Day() != day || int(date.Month()) != month {
+		return time.Time{}, false
+	}
+	return date, true
+}
@@ -0,0 +1,274 @@
+package nik
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testRegions = `code,name
+32,Jawa Barat
+32.01,Kabupaten Bogor
+32.01.01,Cibinong
+32.73,Kota Bandung
+32.73.01,Sukasari
+51,Bali
+`
+
+func newTestValidator(t *testing.T) *Validator {
+	t.Helper()
+	regions, err := LoadRegions(strings.NewReader(testRegions))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := NewValidator(regions)
+	v.now = func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+	return v
+}
+
+func messages(err error) []string {
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		return nil
+	}
+	var out []string
+	for _, fieldErr := range invalid.Errors {
+		out = append(out, fieldErr.Field+" "+fieldErr.Message)
+	}
+	return out
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name      string
+		nik       string
+		district  string
+		birthDate string
+		gender    string
+	}{
+		{"male", "3201011508900001", "Cibinong", "1990-08-15", GenderMale},
+		{"female day plus 40", "3273015508900002", "Sukasari", "1990-08-15", GenderFemale},
+		{"female first day", "3201014101050003", "Cibinong", "2005-01-01", GenderFemale},
+		{"female last day", "3201017112990004", "Cibinong", "1999-12-31", GenderFemale},
+		{"century from today", "3201011508250005", "Cibinong", "2025-08-15", GenderMale},
+		{"future date means last century", "3201011508300006", "Cibinong", "1930-08-15", GenderMale},
+		{"leap day", "3201012902000007", "Cibinong", "2000-02-29", GenderMale},
+		{"surrounding spaces", " 3201011508900001 ", "Cibinong", "1990-08-15", GenderMale},
+	}
+	v := newTestValidator(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := v.Decode(tt.nik)
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if info.District.Name != tt.district || info.BirthDate.Format(dateLayout) != tt.birthDate || info.Gender != tt.gender {
+				t.Errorf("Decode = %s %s %s, want %s %s %s",
+					info.District.Name, info.BirthDate.Format(dateLayout), info.Gender, tt.district, tt.birthDate, tt.gender)
+			}
+			if info.Province.Code != info.District.Code[:2] || info.Regency.Code != info.District.Code[:4] || info.Province.Name == "" || info.Regency.Name == "" {
+				t.Errorf("regions = %+v %+v %+v", info.Province, info.Regency, info.District)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		nik  string
+		want []string
+	}{
+		{"too short", "320101150890001", []string{"nik must be exactly 16 digits"}},
+		{"letters", "32010115089O0001", []string{"nik must be exactly 16 digits"}},
+		{"unknown province", "9901011508900001", []string{"nik has unknown province code 99"}},
+		{"province 00", "0001011508900001", []string{"nik has unknown province code 00"}},
+		{"unknown regency", "3299011508900001", []string{"nik has unknown regency code 3299"}},
+		{"regency 00", "3200011508900001", []string{"nik has unknown regency code 3200"}},
+		{"regency of another province", "5101011508900001", []string{"nik has unknown regency code 5101"}},
+		{"unknown district", "3201991508900001", []string{"nik has unknown district code 320199"}},
+		{"district 00", "3201001508900001", []string{"nik has unknown district code 320100"}},
+		{"district of another regency", "3273021508900001", []string{"nik has unknown district code 327302"}},
+		{"day 00", "3201010008900001", []string{"nik encodes an invalid birth date 000890"}},
+		{"day 32", "3201013208900001", []string{"nik encodes an invalid birth date 320890"}},
+		{"female day 72", "3201017208900001", []string{"nik encodes an invalid birth date 720890"}},
+		{"female day 40", "3201014008900001", []string{"nik encodes an invalid birth date 400890"}},
+		{"month 00", "3201011500900001", []string{"nik encodes an invalid birth date 150090"}},
+		{"month 13", "3201011513900001", []string{"nik encodes an invalid birth date 151390"}},
+		{"31 April", "3201013104900001", []string{"nik encodes an invalid birth date 310490"}},
+		{"29 February in a common year", "3201012902990001", []string{"nik encodes an invalid birth date 290299"}},
+		{"female 30 February", "3201017002900001", []string{"nik encodes an invalid birth date 700290"}},
+		{"serial 0000", "3201011508900000", []string{"nik has an invalid serial number 0000"}},
+		{"several errors", "3299013208900000", []string{
+			"nik has unknown regency code 3299",
+			"nik encodes an invalid birth date 320890",
+			"nik has an invalid serial number 0000",
+		}},
+	}
+	v := newTestValidator(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := v.Decode(tt.nik)
+			if err == nil {
+				t.Fatalf("Decode = %+v, want error", info)
+			}
+			if got := messages(err); strings.Join(got, "; ") != strings.Join(tt.want, "; ") {
+				t.Errorf("errors = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		nik       string
+		birthDate string
+		gender    string
+		want      []string
+	}{
+		{"valid male", "3201011508900001", "1990-08-15", GenderMale, nil},
+		{"valid female", "3201015508900001", "1990-08-15", GenderFemale, nil},
+		{"other gender is not compared", "3201015508900001", "1990-08-15", "other", nil},
+		// Abad diambil dari tanggal lahir yang diisi
+		{"century from birth date", "3201011508250001", "1925-08-15", GenderMale, nil},
+		{"birth date mismatch", "3201011508900001", "1990-08-16", GenderMale, []string{
+			"birthDate does not match the birth date encoded in nik (DDMMYY 150890)",
+		}},
+		{"female birth date mismatch", "3201015508900001", "1990-08-25", GenderFemale, []string{
+			"birthDate does not match the birth date encoded in nik (DDMMYY 150890)",
+		}},
+		{"gender mismatch", "3201015508900001", "1990-08-15", GenderMale, []string{
+			"gender does not match the gender encoded in nik (female)",
+		}},
+		{"invalid birth date format", "3201011508900001", "15-08-1990", GenderMale, []string{
+			"birthDate must be a date in YYYY-MM-DD format",
+		}},
+		{"impossible birth date", "3201011508900001", "1990-02-30", GenderMale, []string{
+			"birthDate must be a date in YYYY-MM-DD format",
+		}},
+		{"birth date in the future", "3201011508900001", "2090-08-15", GenderMale, []string{
+			"birthDate must not be in the future",
+		}},
+		{"invalid nik and gender are reported together", "3201997208900001", "1990-08-15", GenderFemale, []string{
+			"nik has unknown district code 320199",
+			"nik encodes an invalid birth date 720890",
+		}},
+	}
+	v := newTestValidator(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.nik, tt.birthDate, tt.gender)
+			if got := messages(err); strings.Join(got, "; ") != strings.Join(tt.want, "; ") {
+				t.Errorf("errors = %q, want %q", got, tt.want)
+			}
+			if tt.want == nil && err != nil {
+				t.Errorf("Validate = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	regions, err := LoadRegions(strings.NewReader(testRegions))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		code string
+		name string
+		ok   bool
+	}{
+		{"32", "Jawa Barat", true},
+		{"3201", "Kabupaten Bogor", true},
+		{"320101", "Cibinong", true},
+		{"99", "", false},
+		{"3299", "", false},
+		{"320199", "", false},
+		// Bali tidak punya kabupaten di tabel, kodenya tetap ditolak
+		{"5101", "", false},
+		{"510101", "", false},
+	}
+	for _, tt := range tests {
+		region, ok := regions.Lookup(tt.code)
+		if ok != tt.ok || region.Name != tt.name || region.Code != tt.code {
+			t.Errorf("Lookup(%s) = %+v, %v, want %q, %v", tt.code, region, ok, tt.name, tt.ok)
+		}
+	}
+}
+
+func TestLoadRegionsErrors(t *testing.T) {
+	tests := map[string]string{
+		"no header":    "32,Jawa Barat\n",
+		"empty":        "",
+		"bad code":     "code,name\n3A,Jawa Barat\n",
+		"odd length":   "code,name\n320,Jawa Barat\n",
+		"extra column": "code,name\n32,Jawa Barat,x\n",
+	}
+	for name, csv := range tests {
+		if _, err := LoadRegions(strings.NewReader(csv)); err == nil {
+			t.Errorf("%s: LoadRegions succeeded", name)
+		}
+	}
+}
+
+func TestDefaultRegions(t *testing.T) {
+	regions := DefaultRegions()
+	for _, code := range []string{"11", "31", "32", "51", "91", "96"} {
+		if _, ok := regions.Lookup(code); !ok {
+			t.Errorf("bundled table is missing province %s", code)
+		}
+	}
+
+	tests := []struct {
+		code string
+		name string
+		ok   bool
+	}{
+		{"3273", "Kota Bandung", true},
+		{"5171", "Kota Denpasar", true},
+		// Kabupaten / kota di Jawa dan Bali dimuat lengkap
+		{"3299", "", false},
+		{"5199", "", false},
+		// Kabupaten / kota di luar Jawa dan Bali dan semua kecamatan tidak
+		// dimuat, kodenya diterima tanpa nama
+		{"1101", "", true},
+		{"9471", "", true},
+		{"327301", "", true},
+		{"110101", "", true},
+		// Provinsi tetap dicek
+		{"99", "", false},
+		{"9901", "", false},
+	}
+	for _, tt := range tests {
+		region, ok := regions.Lookup(tt.code)
+		if ok != tt.ok || region.Name != tt.name {
+			t.Errorf("Lookup(%s) = %+v, %v, want %q, %v", tt.code, region, ok, tt.name, tt.ok)
+		}
+	}
+}
+
+func TestDecodeDefaultRegions(t *testing.T) {
+	v := NewValidator(DefaultRegions())
+	info, err := v.Decode("3273011501900001")
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if info.Province.Name != "Jawa Barat" || info.Regency.Name != "Kota Bandung" || info.District.Code != "327301" {
+		t.Errorf("regions = %+v %+v %+v", info.Province, info.Regency, info.District)
+	}
+	if info.BirthDate.Format(dateLayout) != "1990-01-15" || info.Gender != GenderMale {
+		t.Errorf("Decode = %s %s, want 1990-01-15 male", info.BirthDate.Format(dateLayout), info.Gender)
+	}
+
+	for _, raw := range []string{"1101015508900001", "9471011508900001"} {
+		if _, err := v.Decode(raw); err != nil {
+			t.Errorf("Decode(%s) outside Java and Bali: %v", raw, err)
+		}
+	}
+	_, err = v.Decode("3299011501900001")
+	if got := messages(err); strings.Join(got, "; ") != "nik has unknown regency code 3299" {
+		t.Errorf("unknown Java regency: errors = %q", got)
+	}
+}
@@ -0,0 +1,117 @@
+package nik
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// regionsCSV adalah tabel wilayah bawaan: seluruh provinsi dan kabupaten /
+// kota di Jawa dan Bali, tanpa kecamatan. Tabel lengkap Kemendagri (sampai
+// kecamatan) bisa dimuat dengan LoadRegionsFile.
+//
+//go:embed regions.csv
+var regionsCSV string
+
+// bundledRegencies adalah provinsi yang kabupaten / kotanya dimuat lengkap di
+// tabel bawaan
+var bundledRegencies = []string{"31", "32", "33", "34", "35", "36", "51"}
+
+// Region adalah satu kode wilayah beserta namanya
+type Region struct {
+	Code string
+	Name string
+}
+
+// Regions adalah tabel kode wilayah administrasi: provinsi (2 digit),
+// kabupaten / kota (4 digit) dan kecamatan (6 digit). Kode yang tidak ada di
+// tabel ditolak, kecuali kode di bawah induk yang sengaja tidak dirinci.
+type Regions struct {
+	names map[string]string
+	// omitted berisi kode induk yang wilayah di bawahnya tidak dimuat tabel
+	omitted map[string]bool
+}
+
+// DefaultRegions memuat tabel wilayah bawaan. Tabel ini tidak memuat
+// kecamatan dan kabupaten / kota di luar Jawa dan Bali, sehingga kode di
+// tingkat itu hanya dicek sampai induknya.
+func DefaultRegions() *Regions {
+	regions, err := LoadRegions(strings.NewReader(regionsCSV))
+	if err != nil {
+		panic(fmt.Sprintf("nik: bundled region table: %v", err))
+	}
+	for code := range regions.names {
+		if len(code) == 4 || (len(code) == 2 && !slices.Contains(bundledRegencies, code)) {
+			regions.omitted[code] = true
+		}
+	}
+	return regions
+}
+
+// LoadRegionsFile memuat tabel wilayah dari file CSV
+func LoadRegionsFile(path string) (*Regions, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open region table: %w", err)
+	}
+	defer file.Close()
+	return LoadRegions(file)
+}
+
+// LoadRegions membaca CSV dengan header "code,name". Kode boleh ditulis
+// dengan titik seperti format Kemendagri (32.01.01).
+func LoadRegions(r io.Reader) (*Regions, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read region table: %w", err)
+	}
+	if len(rows) == 0 || !strings.EqualFold(rows[0][0], "code") {
+		return nil, errors.New(`region table must start with a "code,name" header`)
+	}
+
+	regions := &Regions{names: map[string]string{}, omitted: map[string]bool{}}
+	for i, row := range rows[1:] {
+		code := strings.ReplaceAll(strings.TrimSpace(row[0]), ".", "")
+		if !isDigits(code) || (len(code) != 2 && len(code) != 4 && len(code) != 6) {
+			return nil, fmt.Errorf("region table line %d: invalid code %q", i+2, row[0])
+		}
+		regions.names[code] = strings.TrimSpace(row[1])
+	}
+	return regions, nil
+}
+
+// Lookup mengembalikan wilayah dengan kode code. ok bernilai false jika kode
+// tidak ada di tabel; kode di bawah induk yang tidak dirinci tabel diterima
+// tanpa nama.
+func (r *Regions) Lookup(code string) (Region, bool) {
+	if name, found := r.names[code]; found {
+		return Region{Code: code, Name: name}, true
+	}
+	return Region{Code: code}, r.skipped(code)
+}
+
+// skipped melaporkan apakah code berada di bawah induk yang tidak dirinci
+// tabel, langsung maupun lewat induk di atasnya
+func (r *Regions) skipped(code string) bool {
+	if len(code) <= 2 {
+		return false
+	}
+	parent := code[:len(code)-2]
+	return r.omitted[parent] || r.skipped(parent)
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,205 @@
+// Package pdf membuat dokumen PDF teks sederhana (A4, font Helvetica bawaan
+// PDF) untuk ringkasan export data pasien dan sertifikat penghapusan. Tidak
+// mendukung gambar atau tabel; baris panjang dipotong per kata.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	pageWidth    = 595 // A4 dalam point
+	pageHeight   = 842
+	marginLeft   = 50
+	marginTop    = 60
+	marginBottom = 60
+
+	bodySize    = 10
+	headingSize = 13
+	titleSize   = 16
+
+	// Perkiraan jumlah karakter per baris untuk lebar area teks (Helvetica
+	// rata-rata setengah ukuran font)
+	bodyChars    = 95
+	headingChars = 70
+	titleChars   = 55
+)
+
+type line struct {
+	text string
+	font string // F1 regular, F2 bold
+	size int
+}
+
+// Document adalah dokumen yang sedang disusun. Baris ditambahkan berurutan
+// lalu dipecah ke halaman saat Bytes dipanggil.
+type Document struct {
+	title   string
+	created time.Time
+	lines   []line
+}
+
+// New membuat dokumen dengan judul di awal halaman pertama
+func New(title string) *Document {
+	d := &Document{title: title, created: time.Now()}
+	d.add(title, "F2", titleSize, titleChars)
+	d.Space()
+	return d
+}
+
+// Heading menambahkan judul bagian
+func (d *Document) Heading(text string) {
+	d.Space()
+	d.add(text, "F2", headingSize, headingChars)
+}
+
+// Text menambahkan paragraf
+func (d *Document) Text(text string) {
+	d.add(text, "F1", bodySize, bodyChars)
+}
+
+// Field menambahkan baris "label: value"
+func (d *Document) Field(label string, value string) {
+	if value == "" {
+		value = "-"
+	}
+	d.Text(label + ": " + value)
+}
+
+// Space menambahkan baris kosong
+func (d *Document) Space() {
+	d.lines = append(d.lines, line{font: "F1", size: bodySize})
+}
+
+func (d *Document) add(text string, font string, size int, width int) {
+	for _, paragraph := range strings.Split(text, "\n") {
+		for _, wrapped := range wrap(paragraph, width) {
+			d.lines = append(d.lines, line{text: wrapped, font: font, size: size})
+		}
+	}
+}
+
+// wrap memotong teks per kata supaya tiap baris maksimal width karakter
+func wrap(text string, width int) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{""}
+	}
+	var lines []string
+	current := ""
+	for _, word := range words {
+		for len([]rune(word)) > width {
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			runes := []rune(word)
+			lines = append(lines, string(runes[:width]))
+			word = string(runes[width:])
+		}
+		switch {
+		case current == "":
+			current = word
+		case len([]rune(current))+1+len([]rune(word)) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = word
+		}
+	}
+	return append(lines, current)
+}
+
+// Bytes menghasilkan file PDF
+func (d *Document) Bytes() []byte {
+	pages := d.paginate()
+
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1 catalog, 2 pages, 3-4 font, 5 info, lalu per halaman: page + content
+	firstPage := 6
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /CreationDate (D:%s) >>", escape(d.title), d.created.UTC().Format("20060102150405Z")))
+
+	for i, page := range pages {
+		var content bytes.Buffer
+		y := pageHeight - marginTop
+		for _, l := range page {
+			if l.text != "" {
+				fmt.Fprintf(&content, "BT /%s %d Tf %d %d Td (%s) Tj ET\n", l.font, l.size, marginLeft, y, escape(l.text))
+			}
+			y -= leading(l.size)
+		}
+		footer := fmt.Sprintf("%s - halaman %d dari %d", d.title, i+1, len(pages))
+		fmt.Fprintf(&content, "BT /F1 8 Tf %d %d Td (%s) Tj ET\n", marginLeft, marginBottom/2, escape(footer))
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
+
+func (d *Document) paginate() [][]line {
+	var pages [][]line
+	var page []line
+	y := pageHeight - marginTop
+	for _, l := range d.lines {
+		if y-leading(l.size) < marginBottom && len(page) > 0 {
+			pages = append(pages, page)
+			page = nil
+			y = pageHeight - marginTop
+		}
+		page = append(page, l)
+		y -= leading(l.size)
+	}
+	return append(pages, page)
+}
+
+func leading(size int) int {
+	return size * 3 / 2
+}
+
+// escape mengubah teks ke WinAnsi dan meng-escape karakter khusus string PDF.
+// Karakter di luar Latin-1 diganti "?".
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\t':
+			b.WriteByte(' ')
+		case r < 0x20 || (r >= 0x7f && r < 0xa0) || r > 0xff:
+			b.WriteByte('?')
+		default:
+			b.WriteByte(byte(r))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,144 @@
+package policy
+
+import (
+	"sort"
+	"strings"
+)
+
+// Visibility menentukan bagaimana field sensitif ditampilkan di response
+type Visibility int
+
+const (
+	Hidden  Visibility = iota // field dikosongkan
+	Masked                    // hanya awal dan akhir nilai yang terlihat
+	Visible                   // nilai lengkap
+)
+
+// Field sensitif yang dibentuk per role (nama sama dengan key JSON response)
+const (
+	FieldNIK              = "nik"
+	FieldPhone            = "phone"
+	FieldAddress          = "address"
+	FieldEmergencyContact = "emergencyContact"
+	FieldDiagnosis        = "diagnosis"
+	FieldTreatment        = "treatment"
+)
+
+// fieldRule adalah tampilan satu field per role. Treating berlaku untuk dokter
+// yang merawat pasien (tim perawatan, penulis rekam medis, atau akses
+// darurat untuk pasien tersebut); dokter lain
+// tidak melihat field sensitif sama sekali. Revealable berarti nilai lengkap
+// boleh dibuka dengan alasan (reveal) oleh user yang boleh membaca resource.
+type fieldRule struct {
+	Admin      Visibility
+	Staff      Visibility
+	Treating   Visibility
+	Revealable bool
+}
+
+var fieldRules = map[ResourceType]map[string]fieldRule{
+	Patient: {
+		FieldNIK:              {Admin: Masked, Staff: Masked, Treating: Masked, Revealable: true},
+		FieldPhone:            {Admin: Masked, Staff: Visible, Treating: Visible, Revealable: true},
+		FieldAddress:          {Admin: Hidden, Staff: Visible, Treating: Visible, Revealable: true},
+		FieldEmergencyContact: {Admin: Masked, Staff: Visible, Treating: Visible, Revealable: true},
+	},
+	PatientContact: {
+		FieldPhone: {Admin: Masked, Staff: Visible, Treating: Visible, Revealable: true},
+	},
+	MedicalRecord: {
+		FieldDiagnosis: {Admin: Hidden, Staff: Hidden, Treating: Visible},
+		FieldTreatment: {Admin: Hidden, Staff: Hidden, Treating: Visible},
+	},
+}
+
+// Revealable bernilai true jika field boleh dibuka lewat reveal
+func Revealable(resourceType ResourceType, field string) bool {
+	return fieldRules[resourceType][field].Revealable
+}
+
+// RevealableFields mengembalikan field yang boleh dibuka untuk jenis resource
+func RevealableFields(resourceType ResourceType) []string {
+	var fields []string
+	for name, rule := range fieldRules[resourceType] {
+		if rule.Revealable {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// FieldView memutuskan tampilan field sensitif untuk satu subject. Dibuat per
+// request; hasil cek tim perawatan di-cache per pasien supaya list tidak
+// mengulang query yang sama.
+type FieldView struct {
+	subject  Subject
+	careTeam CareTeam
+	treating map[string]bool
+}
+
+// FieldView membuat FieldView untuk subject
+func (p *Policy) FieldView(subject Subject) *FieldView {
+	return &FieldView{subject: subject, careTeam: p.careTeam, treating: make(map[string]bool)}
+}
+
+// Visibility mengembalikan tampilan field pada resource. Field tanpa aturan
+// selalu Visible.
+func (v *FieldView) Visibility(resource Resource, field string) (Visibility, error) {
+	rule, ok := fieldRules[resource.Type][field]
+	if !ok {
+		return Visible, nil
+	}
+
+	switch v.subject.Role {
+	case RoleAdmin:
+		return rule.Admin, nil
+	case RoleStaff:
+		return rule.Staff, nil
+	case RoleDoctor:
+		treating, err := v.isTreating(resource)
+		if err != nil {
+			return Hidden, err
+		}
+		if treating {
+			return rule.Treating, nil
+		}
+	}
+	return Hidden, nil
+}
+
+func (v *FieldView) isTreating(resource Resource) (bool, error) {
+	if resource.OwnerID != "" && resource.OwnerID == v.subject.UserID {
+		return true, nil
+	}
+	if resource.PatientID == "" {
+		return false, nil
+	}
+	if resource.PatientID == v.subject.EmergencyPatientID {
+		return true, nil
+	}
+	if treating, ok := v.treating[resource.PatientID]; ok {
+		return treating, nil
+	}
+	treating, err := v.careTeam.IsOnCareTeam(v.subject.UserID, resource.PatientID)
+	if err != nil {
+		return false, err
+	}
+	v.treating[resource.PatientID] = treating
+	return treating, nil
+}
+
+// Mask menyamarkan nilai dengan menyisakan beberapa karakter di awal dan
+// akhir, misalnya NIK 3201012345678900 menjadi 3201********8900
+func Mask(value string) string {
+	runes := []rune(value)
+	keep := len(runes) / 4
+	if keep > 4 {
+		keep = 4
+	}
+	if keep == 0 {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:keep]) + strings.Repeat("*", len(runes)-2*keep) + string(runes[len(runes)-keep:])
+}
@@ -0,0 +1,166 @@
+// Package policy memutuskan apakah subject (user yang login) boleh melakukan
+// action terhadap resource. Aturan ditulis per jenis resource di rules.go dan
+// tidak bergantung pada HTTP, sehingga bisa diuji langsung.
+package policy
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Role yang dikenal sistem
+const (
+	RoleAdmin  = "admin"
+	RoleDoctor = "doctor"
+	RoleStaff  = "staff"
+)
+
+type Action string
+
+const (
+	Create Action = "create"
+	Read   Action = "read"
+	List   Action = "list"
+	Update Action = "update"
+	Delete Action = "delete"
+	// AssignRole adalah mengubah role user (terpisah dari Update biasa)
+	AssignRole Action = "assign_role"
+)
+
+type ResourceType string
+
+const (
+	Patient        ResourceType = "patient"
+	User           ResourceType = "user"
+	Appointment    ResourceType = "appointment"
+	Assessment     ResourceType = "assessment"
+	MedicalRecord  ResourceType = "medical_record"
+	Prediction     ResourceType = "prediction"
+	CareAssignment ResourceType = "care_assignment"
+	Consent        ResourceType = "consent"
+	PatientContact ResourceType = "patient_contact"
+	// Hak subjek data: akses diatur per route (tanpa rule), dipakai di audit log
+	DataExport     ResourceType = "data_export"
+	ErasureRequest ResourceType = "erasure_request"
+	LegalHold      ResourceType = "legal_hold"
+)
+
+// Subject adalah user yang melakukan request
+type Subject struct {
+	UserID string
+	Role   string
+	// EmergencyPatientID terisi jika request memakai token akses darurat
+	// (break-the-glass) untuk satu pasien
+	EmergencyPatientID string
+}
+
+// Resource adalah atribut resource yang relevan untuk keputusan akses.
+// Field yang tidak diketahui (misalnya saat list atau create) dibiarkan kosong.
+type Resource struct {
+	Type ResourceType
+	ID   string
+	// PatientID adalah pasien yang terkait dengan resource (untuk cek care team)
+	PatientID string
+	// OwnerID adalah user pemilik resource: dokter appointment, penulis rekam
+	// medis, atau user itu sendiri untuk resource User
+	OwnerID string
+}
+
+// CareTeam menjawab apakah user termasuk tim perawatan seorang pasien
+type CareTeam interface {
+	IsOnCareTeam(userID string, patientID string) (bool, error)
+}
+
+// ErrDenied dibungkus oleh DeniedError sehingga bisa dicek dengan errors.Is
+var ErrDenied = errors.New("access denied")
+
+// DeniedError menjelaskan alasan akses ditolak
+type DeniedError struct {
+	Reason string
+}
+
+func (e *DeniedError) Error() string {
+	return "access denied: " + e.Reason
+}
+
+func (e *DeniedError) Unwrap() error {
+	return ErrDenied
+}
+
+func deny(format string, args ...interface{}) error {
+	return &DeniedError{Reason: fmt.Sprintf(format, args...)}
+}
+
+// Request adalah satu pertanyaan akses yang diteruskan ke rule
+type Request struct {
+	Subject  Subject
+	Action   Action
+	Resource Resource
+
+	careTeam CareTeam
+}
+
+// OnCareTeam mengecek apakah subject ada di tim perawatan pasien resource
+func (r Request) OnCareTeam() (bool, error) {
+	if r.Resource.PatientID == "" {
+		return false, nil
+	}
+	return r.careTeam.IsOnCareTeam(r.Subject.UserID, r.Resource.PatientID)
+}
+
+// EmergencyAccess bernilai true jika subject memakai akses darurat untuk
+// pasien resource. Akses darurat hanya untuk membaca.
+func (r Request) EmergencyAccess() bool {
+	return r.Subject.EmergencyPatientID != "" && r.Subject.EmergencyPatientID == r.Resource.PatientID &&
+		(r.Action == Read || r.Action == List)
+}
+
+// IsOwner bernilai true jika subject adalah pemilik resource
+func (r Request) IsOwner() bool {
+	return r.Resource.OwnerID != "" && r.Resource.OwnerID == r.Subject.UserID
+}
+
+// Rule mengembalikan nil jika akses diizinkan, DeniedError jika ditolak, atau
+// error lain jika keputusan tidak bisa diambil (misalnya query gagal)
+type Rule func(req Request) error
+
+// Policy menyimpan rule untuk setiap jenis resource
+type Policy struct {
+	rules    map[ResourceType]Rule
+	careTeam CareTeam
+}
+
+// New membuat Policy dengan rule bawaan aplikasi
+func New(careTeam CareTeam) *Policy {
+	return &Policy{rules: defaultRules(), careTeam: careTeam}
+}
+
+// Authorize memutuskan akses. Admin selalu diizinkan; resource tanpa rule
+// selalu ditolak.
+func (p *Policy) Authorize(subject Subject, action Action, resource Resource) error {
+	if subject.Role == RoleAdmin {
+		return nil
+	}
+	rule, ok := p.rules[resource.Type]
+	if !ok {
+		return deny("no rule for resource %s", resource.Type)
+	}
+	return rule(Request{Subject: subject, Action: action, Resource: resource, careTeam: p.careTeam})
+}
+
+// Scope membatasi hasil list. CareTeamOf kosong berarti tanpa batasan,
+// terisi berarti hanya data pasien yang ada di tim perawatan user tersebut
+// ditambah EmergencyPatientID (akses darurat) jika ada.
+type Scope struct {
+	CareTeamOf         string
+	EmergencyPatientID string
+}
+
+// ListScope mengembalikan batasan list untuk subject. Dipanggil setelah
+// Authorize(List) diizinkan.
+func (p *Policy) ListScope(subject Subject, resourceType ResourceType) Scope {
+	if subject.Role == RoleDoctor && resourceType != User {
+		return Scope{CareTeamOf: subject.UserID, EmergencyPatientID: subject.EmergencyPatientID}
+	}
+	return Scope{}
+}
@@ -0,0 +1,305 @@
+package policy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeCareTeam adalah CareTeam di memori: members[patientID] berisi user di
+// tim perawatan pasien tersebut
+type fakeCareTeam struct {
+	members map[string][]string
+	err     error
+	calls   int
+}
+
+func (c *fakeCareTeam) IsOnCareTeam(userID string, patientID string) (bool, error) {
+	c.calls++
+	if c.err != nil {
+		return false, c.err
+	}
+	for _, member := range c.members[patientID] {
+		if member == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+const (
+	doctorOnTeam  = "doctor-001-aaaaaaaa"
+	doctorOffTeam = "doctor-002-bbbbbbbb"
+	staffUser     = "staff-001-cccccccc"
+	adminUser     = "admin-001-dddddddd"
+	patientID     = "patient-001-eeeeeeee"
+)
+
+func newTestPolicy() (*Policy, *fakeCareTeam) {
+	careTeam := &fakeCareTeam{members: map[string][]string{patientID: {doctorOnTeam}}}
+	return New(careTeam), careTeam
+}
+
+func doctor(userID string) Subject {
+	return Subject{UserID: userID, Role: RoleDoctor}
+}
+
+func TestAuthorizeAdminAlwaysAllowed(t *testing.T) {
+	p, careTeam := newTestPolicy()
+	admin := Subject{UserID: adminUser, Role: RoleAdmin}
+	types := []ResourceType{Patient, User, Appointment, Assessment, MedicalRecord, Prediction, CareAssignment, Consent, PatientContact, DataExport}
+	actions := []Action{Create, Read, List, Update, Delete, AssignRole}
+	for _, resourceType := range types {
+		for _, action := range actions {
+			resource := Resource{Type: resourceType, ID: "x", PatientID: patientID, OwnerID: doctorOffTeam}
+			if err := p.Authorize(admin, action, resource); err != nil {
+				t.Errorf("admin %s %s: %v", action, resourceType, err)
+			}
+		}
+	}
+	if careTeam.calls != 0 {
+		t.Errorf("admin access queried the care team %d times", careTeam.calls)
+	}
+}
+
+func TestAuthorizeMedicalRecord(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject Subject
+		action  Action
+		ownerID string
+		allowed bool
+	}{
+		{"doctor on care team reads", doctor(doctorOnTeam), Read, doctorOffTeam, true},
+		{"doctor off care team reads", doctor(doctorOffTeam), Read, doctorOnTeam, false},
+		{"author off care team reads own record", doctor(doctorOffTeam), Read, doctorOffTeam, true},
+		{"author updates own record", doctor(doctorOnTeam), Update, doctorOnTeam, true},
+		{"doctor on care team updates another doctor's record", doctor(doctorOnTeam), Update, doctorOffTeam, false},
+		{"doctor off care team updates another doctor's record", doctor(doctorOffTeam), Update, doctorOnTeam, false},
+		{"doctor on care team writes as themselves", doctor(doctorOnTeam), Create, doctorOnTeam, true},
+		{"doctor off care team writes as themselves", doctor(doctorOffTeam), Create, doctorOffTeam, false},
+		{"doctor writes as another doctor", doctor(doctorOnTeam), Create, doctorOffTeam, false},
+		{"doctor deletes own record", doctor(doctorOnTeam), Delete, doctorOnTeam, false},
+		{"staff reads", Subject{UserID: staffUser, Role: RoleStaff}, Read, doctorOnTeam, true},
+		{"staff updates", Subject{UserID: staffUser, Role: RoleStaff}, Update, doctorOnTeam, false},
+		{"emergency read", Subject{UserID: doctorOffTeam, Role: RoleDoctor, EmergencyPatientID: patientID}, Read, doctorOnTeam, true},
+		{"emergency update", Subject{UserID: doctorOffTeam, Role: RoleDoctor, EmergencyPatientID: patientID}, Update, doctorOnTeam, false},
+		{"emergency for another patient", Subject{UserID: doctorOffTeam, Role: RoleDoctor, EmergencyPatientID: "patient-002-ffffffff"}, Read, doctorOnTeam, false},
+		{"unknown role", Subject{UserID: "x", Role: "patient"}, Read, doctorOnTeam, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := newTestPolicy()
+			resource := Resource{Type: MedicalRecord, ID: "record-001-gggggggg", PatientID: patientID, OwnerID: tt.ownerID}
+			err := p.Authorize(tt.subject, tt.action, resource)
+			if tt.allowed && err != nil {
+				t.Fatalf("Authorize = %v, want allowed", err)
+			}
+			if !tt.allowed && !errors.Is(err, ErrDenied) {
+				t.Fatalf("Authorize = %v, want ErrDenied", err)
+			}
+		})
+	}
+}
+
+func TestAuthorizeNonOwnerUpdate(t *testing.T) {
+	p, _ := newTestPolicy()
+	tests := []struct {
+		name     string
+		subject  Subject
+		resource Resource
+	}{
+		{"user updates another account", Subject{UserID: staffUser, Role: RoleStaff}, Resource{Type: User, ID: doctorOnTeam, OwnerID: doctorOnTeam}},
+		{"doctor updates another doctor's appointment", doctor(doctorOnTeam), Resource{Type: Appointment, PatientID: patientID, OwnerID: doctorOffTeam}},
+		{"doctor updates another doctor's medical record", doctor(doctorOnTeam), Resource{Type: MedicalRecord, PatientID: patientID, OwnerID: doctorOffTeam}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.Authorize(tt.subject, Update, tt.resource)
+			var denied *DeniedError
+			if !errors.As(err, &denied) || denied.Reason == "" {
+				t.Fatalf("Authorize = %v, want DeniedError with a reason", err)
+			}
+		})
+	}
+
+	self := Subject{UserID: staffUser, Role: RoleStaff}
+	if err := p.Authorize(self, Update, Resource{Type: User, ID: staffUser, OwnerID: staffUser}); err != nil {
+		t.Errorf("user updates own account: %v", err)
+	}
+	if err := p.Authorize(self, AssignRole, Resource{Type: User, ID: staffUser, OwnerID: staffUser}); !errors.Is(err, ErrDenied) {
+		t.Errorf("user assigns own role: %v, want ErrDenied", err)
+	}
+}
+
+func TestAuthorizeCareTeamError(t *testing.T) {
+	p, careTeam := newTestPolicy()
+	careTeam.err = errors.New("connection refused")
+	err := p.Authorize(doctor(doctorOnTeam), Read, Resource{Type: Patient, ID: patientID, PatientID: patientID})
+	if !errors.Is(err, careTeam.err) || errors.Is(err, ErrDenied) {
+		t.Fatalf("Authorize = %v, want the care team error", err)
+	}
+}
+
+func TestAuthorizeUnknownResource(t *testing.T) {
+	p, _ := newTestPolicy()
+	err := p.Authorize(Subject{UserID: staffUser, Role: RoleStaff}, Read, Resource{Type: "invoice"})
+	if !errors.Is(err, ErrDenied) {
+		t.Fatalf("Authorize = %v, want ErrDenied", err)
+	}
+}
+
+func TestListScope(t *testing.T) {
+	p, _ := newTestPolicy()
+	tests := []struct {
+		name         string
+		subject      Subject
+		resourceType ResourceType
+		want         Scope
+	}{
+		{"admin", Subject{UserID: adminUser, Role: RoleAdmin}, Patient, Scope{}},
+		{"staff", Subject{UserID: staffUser, Role: RoleStaff}, MedicalRecord, Scope{}},
+		{"doctor", doctor(doctorOnTeam), Patient, Scope{CareTeamOf: doctorOnTeam}},
+		{"doctor medical records", doctor(doctorOnTeam), MedicalRecord, Scope{CareTeamOf: doctorOnTeam}},
+		{"doctor with emergency access", Subject{UserID: doctorOffTeam, Role: RoleDoctor, EmergencyPatientID: patientID}, Appointment,
+			Scope{CareTeamOf: doctorOffTeam, EmergencyPatientID: patientID}},
+		{"doctor users", doctor(doctorOnTeam), User, Scope{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.ListScope(tt.subject, tt.resourceType); got != tt.want {
+				t.Errorf("ListScope = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldView(t *testing.T) {
+	patient := Resource{Type: Patient, ID: patientID, PatientID: patientID}
+	record := Resource{Type: MedicalRecord, ID: "record-001-gggggggg", PatientID: patientID, OwnerID: doctorOnTeam}
+	tests := []struct {
+		name      string
+		subject   Subject
+		resource  Resource
+		field     string
+		want      Visibility
+		careCalls int
+	}{
+		{"admin NIK", Subject{UserID: adminUser, Role: RoleAdmin}, patient, FieldNIK, Masked, 0},
+		{"staff NIK", Subject{UserID: staffUser, Role: RoleStaff}, patient, FieldNIK, Masked, 0},
+		{"treating doctor NIK", doctor(doctorOnTeam), patient, FieldNIK, Masked, 1},
+		{"other doctor NIK", doctor(doctorOffTeam), patient, FieldNIK, Hidden, 1},
+		{"admin diagnosis", Subject{UserID: adminUser, Role: RoleAdmin}, record, FieldDiagnosis, Hidden, 0},
+		{"staff diagnosis", Subject{UserID: staffUser, Role: RoleStaff}, record, FieldDiagnosis, Hidden, 0},
+		{"staff treatment", Subject{UserID: staffUser, Role: RoleStaff}, record, FieldTreatment, Hidden, 0},
+		{"author diagnosis", doctor(doctorOnTeam), record, FieldDiagnosis, Visible, 0},
+		{"care team diagnosis", doctor(doctorOnTeam), Resource{Type: MedicalRecord, PatientID: patientID, OwnerID: doctorOffTeam}, FieldDiagnosis, Visible, 1},
+		{"other doctor diagnosis", doctor(doctorOffTeam), record, FieldDiagnosis, Hidden, 1},
+		{"other doctor treatment", doctor(doctorOffTeam), record, FieldTreatment, Hidden, 1},
+		{"emergency diagnosis", Subject{UserID: doctorOffTeam, Role: RoleDoctor, EmergencyPatientID: patientID}, record, FieldDiagnosis, Visible, 0},
+		{"field without rule", doctor(doctorOffTeam), patient, "fullName", Visible, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, careTeam := newTestPolicy()
+			got, err := p.FieldView(tt.subject).Visibility(tt.resource, tt.field)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Visibility = %v, want %v", got, tt.want)
+			}
+			if careTeam.calls != tt.careCalls {
+				t.Errorf("care team queried %d times, want %d", careTeam.calls, tt.careCalls)
+			}
+		})
+	}
+}
+
+// Setiap field sensitif pasien per role: nilai lengkap hanya untuk staff dan
+// dokter yang merawat, NIK selalu disamarkan
+func TestFieldViewPatientFields(t *testing.T) {
+	subjects := []struct {
+		name    string
+		subject Subject
+	}{
+		{"admin", Subject{UserID: adminUser, Role: RoleAdmin}},
+		{"staff", Subject{UserID: staffUser, Role: RoleStaff}},
+		{"treating doctor", doctor(doctorOnTeam)},
+		{"other doctor", doctor(doctorOffTeam)},
+	}
+	tests := []struct {
+		resourceType ResourceType
+		field        string
+		want         []Visibility // urutan sama dengan subjects
+	}{
+		{Patient, FieldNIK, []Visibility{Masked, Masked, Masked, Hidden}},
+		{Patient, FieldPhone, []Visibility{Masked, Visible, Visible, Hidden}},
+		{Patient, FieldAddress, []Visibility{Hidden, Visible, Visible, Hidden}},
+		{Patient, FieldEmergencyContact, []Visibility{Masked, Visible, Visible, Hidden}},
+		{PatientContact, FieldPhone, []Visibility{Masked, Visible, Visible, Hidden}},
+		{MedicalRecord, FieldDiagnosis, []Visibility{Hidden, Hidden, Visible, Hidden}},
+		{MedicalRecord, FieldTreatment, []Visibility{Hidden, Hidden, Visible, Hidden}},
+	}
+	p, _ := newTestPolicy()
+	for _, tt := range tests {
+		for i, s := range subjects {
+			resource := Resource{Type: tt.resourceType, ID: "x", PatientID: patientID}
+			got, err := p.FieldView(s.subject).Visibility(resource, tt.field)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want[i] {
+				t.Errorf("%s %s.%s = %v, want %v", s.name, tt.resourceType, tt.field, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestRevealable(t *testing.T) {
+	if got := RevealableFields(Patient); strings.Join(got, ",") != "address,emergencyContact,nik,phone" {
+		t.Errorf("RevealableFields(Patient) = %v", got)
+	}
+	if got := RevealableFields(PatientContact); strings.Join(got, ",") != "phone" {
+		t.Errorf("RevealableFields(PatientContact) = %v", got)
+	}
+	// Catatan klinis tidak bisa dibuka lewat reveal
+	for _, field := range []string{FieldDiagnosis, FieldTreatment} {
+		if Revealable(MedicalRecord, field) {
+			t.Errorf("%s is revealable", field)
+		}
+	}
+	if Revealable(Patient, "fullName") || Revealable(Patient, FieldDiagnosis) {
+		t.Error("field without a rule is revealable")
+	}
+}
+
+func TestFieldViewCachesCareTeam(t *testing.T) {
+	p, careTeam := newTestPolicy()
+	view := p.FieldView(doctor(doctorOnTeam))
+	for _, field := range []string{FieldNIK, FieldPhone, FieldAddress} {
+		if _, err := view.Visibility(Resource{Type: Patient, PatientID: patientID}, field); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if careTeam.calls != 1 {
+		t.Errorf("care team queried %d times, want 1", careTeam.calls)
+	}
+}
+
+func TestMask(t *testing.T) {
+	tests := map[string]string{
+		"3201012345678900": "3201********8900",
+		"08123456789":      "08*******89",
+		"Jl. Merdeka 10":   "Jl.******** 10",
+		"1234":             "1**4",
+		"Ñandú":            "Ñ***ú",
+		"abc":              "***",
+		"":                 "",
+	}
+	for in, want := range tests {
+		if got := Mask(in); got != want {
+			t.Errorf("Mask(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
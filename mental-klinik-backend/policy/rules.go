@@ -0,0 +1,238 @@
+package policy
+
+func defaultRules() map[ResourceType]Rule {
+	return map[ResourceType]Rule{
+		Patient:        patientRule,
+		User:           userRule,
+		Appointment:    appointmentRule,
+		Assessment:     assessmentRule,
+		MedicalRecord:  medicalRecordRule,
+		Prediction:     predictionRule,
+		CareAssignment: careAssignmentRule,
+		Consent:        consentRule,
+		PatientContact: patientContactRule,
+	}
+}
+
+// allowActions adalah helper untuk role yang boleh melakukan action tertentu
+// tanpa syarat tambahan
+func allowActions(req Request, actions ...Action) error {
+	for _, a := range actions {
+		if req.Action == a {
+			return nil
+		}
+	}
+	return deny("%s cannot %s %s", req.Subject.Role, req.Action, req.Resource.Type)
+}
+
+// requireCareTeam mengizinkan akses hanya jika subject ada di tim perawatan
+// pasien resource, atau membaca dengan akses darurat untuk pasien tersebut
+func requireCareTeam(req Request) error {
+	if req.EmergencyAccess() {
+		return nil
+	}
+	ok, err := req.OnCareTeam()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return deny("patient is not on your care team")
+	}
+	return nil
+}
+
+// Pasien: staff mengelola data pasien (kecuali hapus), dokter hanya melihat
+// pasien di tim perawatannya.
+func patientRule(req Request) error {
+	switch req.Subject.Role {
+	case RoleStaff:
+		return allowActions(req, Create, Read, List, Update)
+	case RoleDoctor:
+		switch req.Action {
+		case List:
+			return nil
+		case Read:
+			return requireCareTeam(req)
+		}
+	}
+	return deny("%s cannot %s patients", req.Subject.Role, req.Action)
+}
+
+// User: selain admin, user hanya boleh melihat dan mengubah akunnya sendiri
+// dan tidak boleh mengubah role.
+func userRule(req Request) error {
+	switch req.Action {
+	case Read, Update:
+		if req.IsOwner() {
+			return nil
+		}
+		return deny("you can only access your own account")
+	case AssignRole:
+		return deny("only admin can change roles")
+	}
+	return deny("only admin can %s users", req.Action)
+}
+
+// Appointment: staff menjadwalkan dan melihat, dokter mengelola appointment
+// miliknya dan melihat appointment pasien di tim perawatannya.
+func appointmentRule(req Request) error {
+	switch req.Subject.Role {
+	case RoleStaff:
+		return allowActions(req, Create, Read, List)
+	case RoleDoctor:
+		switch req.Action {
+		case Create, Update:
+			if req.IsOwner() {
+				return nil
+			}
+			return deny("doctors can only manage their own appointments")
+		case Read:
+			if req.IsOwner() {
+				return nil
+			}
+			return requireCareTeam(req)
+		case List:
+			// List per user hanya untuk diri sendiri, list per pasien butuh
+			// care team, list umum dibatasi lewat ListScope
+			if req.Resource.OwnerID != "" {
+				if req.IsOwner() {
+					return nil
+				}
+				return deny("doctors can only list their own appointments")
+			}
+			if req.Resource.PatientID != "" {
+				return requireCareTeam(req)
+			}
+			return nil
+		}
+	}
+	return deny("%s cannot %s appointments", req.Subject.Role, req.Action)
+}
+
+// Assessment: staff mencatat dan melihat, dokter mengelola assessment pasien
+// di tim perawatannya.
+func assessmentRule(req Request) error {
+	switch req.Subject.Role {
+	case RoleStaff:
+		return allowActions(req, Create, Read, List)
+	case RoleDoctor:
+		switch req.Action {
+		case Create, Read, Update:
+			return requireCareTeam(req)
+		case List:
+			if req.Resource.PatientID != "" {
+				return requireCareTeam(req)
+			}
+			return nil
+		}
+	}
+	return deny("%s cannot %s assessments", req.Subject.Role, req.Action)
+}
+
+// Rekam medis: dokter hanya boleh mengubah rekam medis yang ia tulis sendiri
+// dan hanya menulis untuk pasien di tim perawatannya.
+func medicalRecordRule(req Request) error {
+	switch req.Subject.Role {
+	case RoleStaff:
+		return allowActions(req, Create, Read, List)
+	case RoleDoctor:
+		switch req.Action {
+		case Create:
+			if !req.IsOwner() {
+				return deny("doctors can only author medical records as themselves")
+			}
+			return requireCareTeam(req)
+		case Update:
+			if req.IsOwner() {
+				return nil
+			}
+			return deny("doctors can only edit medical records they authored")
+		case Read:
+			if req.IsOwner() {
+				return nil
+			}
+			return requireCareTeam(req)
+		case List:
+			if req.Resource.PatientID != "" {
+				return requireCareTeam(req)
+			}
+			return nil
+		}
+	}
+	return deny("%s cannot %s medical records", req.Subject.Role, req.Action)
+}
+
+// Prediksi: dokter menjalankan dan melihat prediksi pasien di tim
+// perawatannya, staff hanya melihat. Ubah dan hapus khusus admin.
+func predictionRule(req Request) error {
+	switch req.Subject.Role {
+	case RoleStaff:
+		return allowActions(req, Read, List)
+	case RoleDoctor:
+		switch req.Action {
+		case Create, Read:
+			return requireCareTeam(req)
+		case List:
+			return nil
+		}
+	}
+	return deny("%s cannot %s predictions", req.Subject.Role, req.Action)
+}
+
+// Tim perawatan: staff mengatur penugasan (assign, transfer, akhiri,
+// handover), dokter hanya melihat penugasannya sendiri dan tim perawatan
+// pasiennya. OwnerID adalah user yang ditugaskan.
+func careAssignmentRule(req Request) error {
+	switch req.Subject.Role {
+	case RoleStaff:
+		return allowActions(req, Create, Read, List, Update)
+	case RoleDoctor:
+		switch req.Action {
+		case Read:
+			if req.IsOwner() {
+				return nil
+			}
+			return requireCareTeam(req)
+		case List:
+			if req.Resource.OwnerID != "" {
+				if req.IsOwner() {
+					return nil
+				}
+				return deny("doctors can only list their own caseload")
+			}
+			return nil
+		}
+	}
+	return deny("%s cannot %s care team assignments", req.Subject.Role, req.Action)
+}
+
+// Persetujuan pasien: staff mencatat dan menarik persetujuan, dokter hanya
+// untuk pasien di tim perawatannya. Riwayat selalu per pasien.
+func consentRule(req Request) error {
+	switch req.Subject.Role {
+	case RoleStaff:
+		return allowActions(req, Create, Read, List, Update)
+	case RoleDoctor:
+		switch req.Action {
+		case Create, Read, List, Update:
+			return requireCareTeam(req)
+		}
+	}
+	return deny("%s cannot %s consents", req.Subject.Role, req.Action)
+}
+
+// Kontak darurat pasien: staff mengelola kontak, dokter menambah dan mengubah
+// kontak pasien di tim perawatannya. Dengan akses darurat dokter bisa
+// membaca kontak pasien tersebut.
+func patientContactRule(req Request) error {
+	switch req.Subject.Role {
+	case RoleStaff:
+		return allowActions(req, Create, Read, List, Update, Delete)
+	case RoleDoctor:
+		switch req.Action {
+		case Create, Read, List, Update:
+			return requireCareTeam(req)
+		}
+	}
+	return deny("%s cannot %s patient contacts", req.Subject.Role, req.Action)
+}
@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// AppointmentListSpec adalah kolom appointment yang boleh difilter dan di-sort.
+// Kolom memakai prefix tabel karena search melakukan join ke patients.
+var AppointmentListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "appointments.id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "patientId", Column: "appointments.patient_id", Ops: listquery.EqualityOps},
+		{Name: "userId", Column: "appointments.user_id", Ops: listquery.EqualityOps},
+		{Name: "status", Column: "appointments.status", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "scheduleAt", Column: "appointments.schedule_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "createdAt", Column: "appointments.created_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "updatedAt", Column: "appointments.updated_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "scheduleAt",
+	Params:      []string{"search"},
+	Cursor:      true,
+}
+
+// AppointmentTrashSpec adalah kolom trash appointment yang boleh difilter dan di-sort
+var AppointmentTrashSpec = TrashSpec(AppointmentListSpec)
+
+// AppointmentHistorySpec dipakai endpoint appointment per pasien / per user.
+// Sama dengan AppointmentListSpec tanpa search.
+var AppointmentHistorySpec = listquery.Spec{
+	Fields:      AppointmentListSpec.Fields,
+	DefaultSort: "-scheduleAt",
+	Cursor:      true,
+}
+
+// AppointmentFilter menampung parameter list appointment (search, filter, sort, paging)
+type AppointmentFilter struct {
+	Search             string // nama lengkap pasien
+	CareTeamOf         string // jika terisi, hanya pasien di tim perawatan user ini
+	EmergencyPatientID string // pasien akses darurat (break-the-glass) yang ikut terlihat
+	listquery.Query
+}
+
+type AppointmentRepository interface {
+	Create(appointment *models.Appointment) error
+	FindByID(id string) (*models.Appointment, error)
+	FindAll(filter AppointmentFilter) ([]models.Appointment, listquery.Page, error)
+	FindByPatientID(patientID string, query listquery.Query) ([]models.Appointment, listquery.Page, error)
+	FindByUserID(userID string, query listquery.Query) ([]models.Appointment, listquery.Page, error)
+	Update(appointment *models.Appointment) error
+	ChangeStatus(change *models.AppointmentStatusChange) error
+	Delete(id string) error
+	FindDeleted(query listquery.Query) ([]models.Appointment, listquery.Page, error)
+	Restore(id string) (*models.Appointment, RestoreResult, error)
+}
+
+type appointmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAppointmentRepository(db *gorm.DB) AppointmentRepository {
+	return &appointmentRepository{db: db}
+}
+
+func (r *appointmentRepository) Create(appointment *models.Appointment) error {
+	return r.db.Create(appointment).Error
+}
+
+func (r *appointmentRepository) FindByID(id string) (*models.Appointment, error) {
+	var appointment models.Appointment
+	if err := r.db.Preload("Patient").Preload("User", unscoped).
+		First(&appointment, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &appointment, nil
+}
+
+func (r *appointmentRepository) FindAll(filter AppointmentFilter) ([]models.Appointment, listquery.Page, error) {
+	query := r.db.Model(&models.Appointment{}).Preload("Patient").Preload("User", unscoped)
+
+	if filter.Search != "" {
+		query = query.Joins("JOIN patients ON patients.id = appointments.patient_id").
+			Where("patients.full_name ILIKE ?", "%"+filter.Search+"%")
+	}
+	if filter.CareTeamOf != "" {
+		query = query.Where("appointments.patient_id IN (?)", scopedPatientIDs(r.db, filter.CareTeamOf, filter.EmergencyPatientID))
+	}
+
+	return listquery.Find[models.Appointment](query, &filter.Query)
+}
+
+func (r *appointmentRepository) FindByPatientID(patientID string, query listquery.Query) ([]models.Appointment, listquery.Page, error) {
+	tx := r.db.Model(&models.Appointment{}).Preload("Patient").
+		Where("appointments.patient_id = ?", patientID)
+	return listquery.Find[models.Appointment](tx, &query)
+}
+
+func (r *appointmentRepository) FindByUserID(userID string, query listquery.Query) ([]models.Appointment, listquery.Page, error) {
+	tx := r.db.Model(&models.Appointment{}).Preload("User", unscoped).
+		Where("appointments.user_id = ?", userID)
+	return listquery.Find[models.Appointment](tx, &query)
+}
+
+func (r *appointmentRepository) Update(appointment *models.Appointment) error {
+	return r.db.Omit(clause.Associations).Save(appointment).Error
+}
+
+// ChangeStatus mengubah status appointment ke change.ToStatus dan mencatat
+// perubahannya. FromStatus diisi dari status saat ini; jika status sudah sama
+// tidak ada yang dicatat.
+func (r *appointmentRepository) ChangeStatus(change *models.AppointmentStatusChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var appointment models.Appointment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&appointment, "id = ?", change.AppointmentID).Error; err != nil {
+			return translateError(err)
+		}
+		if appointment.Status == change.ToStatus {
+			return nil
+		}
+		change.FromStatus = appointment.Status
+		err := tx.Model(&appointment).
+			Updates(map[string]interface{}{"status": change.ToStatus, "updated_at": change.ChangedAt}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(change).Error
+	})
+}
+
+func (r *appointmentRepository) Delete(id string) error {
+	return r.db.Delete(&models.Appointment{}, "id = ?", id).Error
+}
+
+func (r *appointmentRepository) FindDeleted(query listquery.Query) ([]models.Appointment, listquery.Page, error) {
+	tx := r.db.Model(&models.Appointment{}).Preload("Patient").Preload("User")
+	return findDeleted[models.Appointment](tx, "appointments", &query)
+}
+
+// Restore memulihkan appointment dari trash. Pasien dan dokter / staff-nya
+// harus masih aktif.
+func (r *appointmentRepository) Restore(id string) (*models.Appointment, RestoreResult, error) {
+	var appointment models.Appointment
+	result := RestoreResult{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockDeleted(tx, &appointment, id); err != nil {
+			return err
+		}
+		if err := requireActivePatient(tx, appointment.PatientID); err != nil {
+			return err
+		}
+		active, err := isActive(tx, &models.User{}, "id = ?", appointment.UserID)
+		if err != nil {
+			return err
+		}
+		if !active {
+			return restoreConflict("user %s is deleted, restore the user first", appointment.UserID)
+		}
+		return restoreRows(tx.Where("id = ?", id), &models.Appointment{}, "appointments", result)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	appointment.DeletedAt = gorm.DeletedAt{}
+	return &appointment, result, nil
+}
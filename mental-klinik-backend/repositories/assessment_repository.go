@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// AssessmentListSpec adalah kolom assessment yang boleh difilter dan di-sort
+var AssessmentListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "patientId", Column: "patient_id", Ops: listquery.EqualityOps},
+		{Name: "date", Column: "date", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "createdAt", Column: "created_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "updatedAt", Column: "updated_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "-createdAt",
+	Cursor:      true,
+}
+
+// AssessmentTrashSpec adalah kolom trash assessment yang boleh difilter dan di-sort
+var AssessmentTrashSpec = TrashSpec(AssessmentListSpec)
+
+// AssessmentFilter menampung parameter list assessment (filter, sort, paging)
+type AssessmentFilter struct {
+	CareTeamOf         string // jika terisi, hanya pasien di tim perawatan user ini
+	EmergencyPatientID string // pasien akses darurat (break-the-glass) yang ikut terlihat
+	listquery.Query
+}
+
+type AssessmentRepository interface {
+	Create(assessment *models.Assessment) error
+	FindByID(id string) (*models.Assessment, error)
+	FindAll(filter AssessmentFilter) ([]models.Assessment, listquery.Page, error)
+	FindByPatientID(patientID string, query listquery.Query) ([]models.Assessment, listquery.Page, error)
+	Update(assessment *models.Assessment) error
+	Delete(id string) error
+	FindDeleted(query listquery.Query) ([]models.Assessment, listquery.Page, error)
+	Restore(id string, cascade bool) (*models.Assessment, RestoreResult, error)
+}
+
+type assessmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAssessmentRepository(db *gorm.DB) AssessmentRepository {
+	return &assessmentRepository{db: db}
+}
+
+func (r *assessmentRepository) Create(assessment *models.Assessment) error {
+	return r.db.Create(assessment).Error
+}
+
+func (r *assessmentRepository) FindByID(id string) (*models.Assessment, error) {
+	var assessment models.Assessment
+	if err := r.db.
+		Preload("Patient").
+		Preload("Prediction").
+		First(&assessment, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &assessment, nil
+}
+
+func (r *assessmentRepository) FindAll(filter AssessmentFilter) ([]models.Assessment, listquery.Page, error) {
+	tx := r.db.Model(&models.Assessment{}).Preload("Patient").Preload("Prediction")
+	if filter.CareTeamOf != "" {
+		tx = tx.Where("patient_id IN (?)", scopedPatientIDs(r.db, filter.CareTeamOf, filter.EmergencyPatientID))
+	}
+	return listquery.Find[models.Assessment](tx, &filter.Query)
+}
+
+func (r *assessmentRepository) FindByPatientID(patientID string, query listquery.Query) ([]models.Assessment, listquery.Page, error) {
+	tx := r.db.Model(&models.Assessment{}).Preload("Prediction").Preload("Patient").
+		Where("patient_id = ?", patientID)
+	return listquery.Find[models.Assessment](tx, &query)
+}
+
+func (r *assessmentRepository) Update(assessment *models.Assessment) error {
+	return r.db.Omit(clause.Associations).Save(assessment).Error
+}
+
+func (r *assessmentRepository) Delete(id string) error {
+	return r.db.Delete(&models.Assessment{}, "id = ?", id).Error
+}
+
+func (r *assessmentRepository) FindDeleted(query listquery.Query) ([]models.Assessment, listquery.Page, error) {
+	tx := r.db.Model(&models.Assessment{}).Preload("Patient").Preload("Prediction")
+	return findDeleted[models.Assessment](tx, "assessments", &query)
+}
+
+// Restore memulihkan assessment dari trash jika pasiennya masih aktif. Dengan
+// cascade, prediksi yang terhapus bersama assessment ikut dipulihkan.
+func (r *assessmentRepository) Restore(id string, cascade bool) (*models.Assessment, RestoreResult, error) {
+	var assessment models.Assessment
+	result := RestoreResult{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockDeleted(tx, &assessment, id); err != nil {
+			return err
+		}
+		if err := requireActivePatient(tx, assessment.PatientID); err != nil {
+			return err
+		}
+		if err := restoreRows(tx.Where("id = ?", id), &models.Assessment{}, "assessments", result); err != nil {
+			return err
+		}
+		if !cascade {
+			return nil
+		}
+		predictions := tx.Where("assessment_id = ?", id).Scopes(deletedWith(assessment.DeletedAt.Time))
+		return restoreRows(predictions, &models.Prediction{}, "predictions", result)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	assessment.DeletedAt = gorm.DeletedAt{}
+	return &assessment, result, nil
+}
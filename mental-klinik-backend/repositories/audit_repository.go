@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// auditBatchSize adalah jumlah entry yang dibaca per batch saat export dan
+// verifikasi rantai hash
+const auditBatchSize = 500
+
+// AuditLogListSpec adalah kolom audit log yang boleh difilter dan di-sort
+var AuditLogListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "id", Type: listquery.Number, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "occurredAt", Column: "occurred_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "actorId", Column: "actor_id", Ops: listquery.EqualityOps},
+		{Name: "actorRole", Column: "actor_role", Ops: listquery.EqualityOps},
+		{Name: "action", Column: "action", Ops: listquery.EqualityOps},
+		{Name: "resourceType", Column: "resource_type", Ops: listquery.EqualityOps},
+		{Name: "resourceId", Column: "resource_id", Ops: listquery.EqualityOps},
+		{Name: "patientId", Column: "patient_id", Ops: listquery.EqualityOps},
+		{Name: "ipAddress", Column: "ip_address", Ops: listquery.EqualityOps},
+		{Name: "reason", Column: "reason", Ops: listquery.EqualityOps},
+	},
+	DefaultSort: "-id",
+	MaxLimit:    500,
+}
+
+type AuditRepository interface {
+	Append(entries []models.AuditLog) error
+	FindAll(query listquery.Query) ([]models.AuditLog, listquery.Page, error)
+	Each(query listquery.Query, fn func(entry *models.AuditLog) error) error
+	Head() (*models.AuditChainHead, error)
+}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+// Append menambahkan entry ke ujung rantai hash dalam satu transaksi. Baris
+// audit_chain_head dikunci (FOR UPDATE) sehingga penulisan bersamaan
+// menunggu giliran dan tidak pernah memakai hash sebelumnya yang sama.
+func (r *auditRepository) Append(entries []models.AuditLog) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var head models.AuditChainHead
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&head, "id = ?", 1).Error; err != nil {
+			return err
+		}
+
+		for i := range entries {
+			entries[i].ID = head.LastID + 1
+			entries[i].PrevHash = head.LastHash
+			entries[i].Hash = entries[i].ComputeHash(head.LastHash)
+			head.LastID, head.LastHash = entries[i].ID, entries[i].Hash
+		}
+
+		if err := tx.Create(&entries).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.AuditChainHead{}).Where("id = ?", 1).
+			Updates(map[string]interface{}{"last_id": head.LastID, "last_hash": head.LastHash}).Error
+	})
+}
+
+func (r *auditRepository) FindAll(query listquery.Query) ([]models.AuditLog, listquery.Page, error) {
+	return listquery.Find[models.AuditLog](r.db.Model(&models.AuditLog{}), &query)
+}
+
+// Each membaca semua entry yang cocok dengan filter query, urut dari yang
+// paling lama (FindInBatches mengurutkan per primary key), per batch supaya
+// export besar tidak dimuat sekaligus
+func (r *auditRepository) Each(query listquery.Query, fn func(entry *models.AuditLog) error) error {
+	var batch []models.AuditLog
+	tx := query.ApplyFilters(r.db.Model(&models.AuditLog{}))
+	result := tx.FindInBatches(&batch, auditBatchSize, func(_ *gorm.DB, _ int) error {
+		for i := range batch {
+			if err := fn(&batch[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return result.Error
+}
+
+func (r *auditRepository) Head() (*models.AuditChainHead, error) {
+	var head models.AuditChainHead
+	if err := r.db.First(&head, "id = ?", 1).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &head, nil
+}
@@ -0,0 +1,211 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/utils"
+)
+
+// ErrCareAssignmentChanged dikembalikan saat penugasan yang akan diakhiri
+// ternyata sudah berakhir (termasuk oleh request lain yang bersamaan)
+var ErrCareAssignmentChanged = errors.New("care assignment has already ended")
+
+// CareAssignmentListSpec adalah kolom penugasan tim perawatan yang boleh
+// difilter dan di-sort
+var CareAssignmentListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "care_assignments.id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "patientId", Column: "care_assignments.patient_id", Ops: listquery.EqualityOps},
+		{Name: "userId", Column: "care_assignments.user_id", Ops: listquery.EqualityOps},
+		{Name: "role", Column: "care_assignments.role", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "startDate", Column: "care_assignments.start_date", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "endDate", Column: "care_assignments.end_date", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps, Nullable: true},
+		{Name: "createdAt", Column: "care_assignments.created_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "-startDate",
+	Params:      []string{"active"},
+}
+
+// CaseloadSpec dipakai endpoint caseload user yang login (selalu penugasan
+// aktif miliknya sendiri)
+var CaseloadSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "role", Column: "care_assignments.role", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "startDate", Column: "care_assignments.start_date", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "-startDate",
+}
+
+// CareAssignmentFilter menampung parameter list penugasan tim perawatan
+type CareAssignmentFilter struct {
+	UserID             string // jika terisi, hanya penugasan milik user ini
+	Active             *bool  // true: hanya yang aktif hari ini, false: yang tidak aktif
+	CareTeamOf         string // jika terisi, hanya pasien di tim perawatan user ini
+	EmergencyPatientID string // pasien akses darurat (break-the-glass) yang ikut terlihat
+	listquery.Query
+}
+
+// CareTransfer adalah satu langkah pemindahan penugasan: From diakhiri pada
+// EffectiveDate, Replaced (penugasan lama milik user tujuan pada pasien yang
+// sama, misalnya saat klinisi pendamping naik menjadi klinisi utama) ikut
+// diakhiri, lalu Next dibuat jika tidak nil.
+type CareTransfer struct {
+	From     *models.CareAssignment
+	Replaced *models.CareAssignment
+	Next     *models.CareAssignment
+
+	EffectiveDate time.Time
+}
+
+// CareTeamRepository menyimpan penugasan tim perawatan pasien. Seorang user
+// termasuk tim perawatan pasien selama punya penugasan yang aktif hari ini.
+type CareTeamRepository interface {
+	IsOnCareTeam(userID string, patientID string) (bool, error)
+	Create(assignment *models.CareAssignment) error
+	FindByID(id string) (*models.CareAssignment, error)
+	FindAll(filter CareAssignmentFilter) ([]models.CareAssignment, listquery.Page, error)
+	FindOpenByPatient(patientID string, day time.Time) ([]models.CareAssignment, error)
+	FindOpenByUser(userID string, day time.Time) ([]models.CareAssignment, error)
+	End(id string, endDate time.Time, reason string, endedBy string) error
+	Transfer(transfers []CareTransfer, reason string, endedBy string) error
+}
+
+type careTeamRepository struct {
+	db *gorm.DB
+}
+
+func NewCareTeamRepository(db *gorm.DB) CareTeamRepository {
+	return &careTeamRepository{db: db}
+}
+
+func (r *careTeamRepository) IsOnCareTeam(userID string, patientID string) (bool, error) {
+	var count int64
+	err := activeCareAssignments(r.db.Model(&models.CareAssignment{}), utils.Today()).
+		Where("user_id = ? AND patient_id = ?", userID, patientID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *careTeamRepository) Create(assignment *models.CareAssignment) error {
+	return r.db.Omit("Patient", "User").Create(assignment).Error
+}
+
+func (r *careTeamRepository) FindByID(id string) (*models.CareAssignment, error) {
+	var assignment models.CareAssignment
+	if err := r.db.Preload("Patient").Preload("User").
+		First(&assignment, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &assignment, nil
+}
+
+func (r *careTeamRepository) FindAll(filter CareAssignmentFilter) ([]models.CareAssignment, listquery.Page, error) {
+	tx := r.db.Model(&models.CareAssignment{}).Preload("Patient").Preload("User")
+
+	if filter.UserID != "" {
+		tx = tx.Where("care_assignments.user_id = ?", filter.UserID)
+	}
+	if filter.Active != nil {
+		today := utils.Today()
+		if *filter.Active {
+			tx = activeCareAssignments(tx, today)
+		} else {
+			tx = tx.Where("NOT (care_assignments.start_date <= ? AND (care_assignments.end_date IS NULL OR care_assignments.end_date > ?))", today, today)
+		}
+	}
+	if filter.CareTeamOf != "" {
+		tx = tx.Where("care_assignments.patient_id IN (?)", scopedPatientIDs(r.db, filter.CareTeamOf, filter.EmergencyPatientID))
+	}
+
+	return listquery.Find[models.CareAssignment](tx, &filter.Query)
+}
+
+// FindOpenByPatient mengambil penugasan pasien yang belum berakhir pada
+// tanggal day (aktif maupun yang baru akan dimulai)
+func (r *careTeamRepository) FindOpenByPatient(patientID string, day time.Time) ([]models.CareAssignment, error) {
+	var assignments []models.CareAssignment
+	err := r.db.Where("patient_id = ? AND (end_date IS NULL OR end_date > ?)", patientID, day).
+		Order("start_date").Find(&assignments).Error
+	return assignments, err
+}
+
+// FindOpenByUser mengambil penugasan user yang belum berakhir pada tanggal day
+func (r *careTeamRepository) FindOpenByUser(userID string, day time.Time) ([]models.CareAssignment, error) {
+	var assignments []models.CareAssignment
+	err := r.db.Where("user_id = ? AND (end_date IS NULL OR end_date > ?)", userID, day).
+		Order("patient_id").Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *careTeamRepository) End(id string, endDate time.Time, reason string, endedBy string) error {
+	return endCareAssignment(r.db, id, endDate, reason, endedBy)
+}
+
+// Transfer menjalankan semua langkah pemindahan dalam satu transaksi, jadi
+// pasien tidak pernah tertinggal tanpa klinisi di tengah handover.
+func (r *careTeamRepository) Transfer(transfers []CareTransfer, reason string, endedBy string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, t := range transfers {
+			if err := endCareAssignment(tx, t.From.ID, t.EffectiveDate, reason, endedBy); err != nil {
+				return err
+			}
+			if t.Replaced != nil {
+				if err := endCareAssignment(tx, t.Replaced.ID, t.EffectiveDate, reason, endedBy); err != nil {
+					return err
+				}
+			}
+			if t.Next != nil {
+				if err := tx.Omit("Patient", "User").Create(t.Next).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// endCareAssignment mengisi tanggal selesai penugasan. Update bersyarat
+// memastikan penugasan yang sudah berakhir tidak diubah lagi.
+func endCareAssignment(db *gorm.DB, id string, endDate time.Time, reason string, endedBy string) error {
+	result := db.Model(&models.CareAssignment{}).
+		Where("id = ? AND (end_date IS NULL OR end_date > ?)", id, endDate).
+		Updates(map[string]interface{}{
+			"end_date":    endDate,
+			"end_reason":  reason,
+			"ended_by_id": endedBy,
+			"updated_at":  time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCareAssignmentChanged
+	}
+	return nil
+}
+
+// activeCareAssignments membatasi query ke penugasan yang berlaku pada day
+func activeCareAssignments(db *gorm.DB, day time.Time) *gorm.DB {
+	return db.Where("care_assignments.start_date <= ? AND (care_assignments.end_date IS NULL OR care_assignments.end_date > ?)", day, day)
+}
+
+// careTeamPatientIDs adalah subquery ID pasien di tim perawatan user hari ini,
+// dipakai juga untuk membatasi hasil list (lihat policy.Scope)
+func careTeamPatientIDs(db *gorm.DB, userID string) *gorm.DB {
+	return activeCareAssignments(db.Table("care_assignments").Select("care_assignments.patient_id"), utils.Today()).
+		Where("care_assignments.user_id = ?", userID)
+}
+
+// scopedPatientIDs adalah careTeamPatientIDs ditambah pasien akses darurat
+// milik token yang sedang dipakai (lihat policy.Scope)
+func scopedPatientIDs(db *gorm.DB, userID string, emergencyPatientID string) *gorm.DB {
+	if emergencyPatientID == "" {
+		return careTeamPatientIDs(db, userID)
+	}
+	return db.Raw("? UNION SELECT ?", careTeamPatientIDs(db, userID), emergencyPatientID)
+}
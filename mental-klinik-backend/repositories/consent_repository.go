@@ -0,0 +1,213 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// ErrConsentChanged dikembalikan saat persetujuan yang akan ditarik ternyata
+// sudah ditarik (termasuk oleh request lain yang bersamaan)
+var ErrConsentChanged = errors.New("consent has already been withdrawn")
+
+// ConsentDocumentListSpec adalah kolom dokumen persetujuan yang boleh
+// difilter dan di-sort
+var ConsentDocumentListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "type", Column: "type", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "version", Column: "version", Type: listquery.Number, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "createdAt", Column: "created_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "type,-version",
+	Params:      []string{"current"},
+}
+
+// PatientConsentListSpec adalah kolom riwayat persetujuan pasien yang boleh
+// difilter dan di-sort
+var PatientConsentListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "patient_consents.id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "type", Column: "patient_consents.type", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "documentId", Column: "patient_consents.document_id", Ops: listquery.EqualityOps},
+		{Name: "method", Column: "patient_consents.method", Ops: listquery.EqualityOps},
+		{Name: "grantedAt", Column: "patient_consents.granted_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "withdrawnAt", Column: "patient_consents.withdrawn_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps, Nullable: true},
+	},
+	DefaultSort: "-grantedAt",
+	Params:      []string{"active"},
+}
+
+// ConsentDocumentFilter menampung parameter list dokumen persetujuan
+type ConsentDocumentFilter struct {
+	Current bool // hanya versi terbaru per jenis
+	listquery.Query
+}
+
+// PatientConsentFilter menampung parameter riwayat persetujuan satu pasien
+type PatientConsentFilter struct {
+	PatientID string
+	Active    *bool // true: hanya yang belum ditarik, false: hanya yang ditarik
+	listquery.Query
+}
+
+type ConsentRepository interface {
+	CreateDocument(document *models.ConsentDocument) error
+	FindDocumentByID(id string) (*models.ConsentDocument, error)
+	FindCurrentDocument(consentType string) (*models.ConsentDocument, error)
+	FindDocuments(filter ConsentDocumentFilter) ([]models.ConsentDocument, listquery.Page, error)
+	LatestVersions() (map[string]int, error)
+
+	Grant(consent *models.PatientConsent, supersede *models.PatientConsent, reason string) error
+	FindByID(id string) (*models.PatientConsent, error)
+	FindAll(filter PatientConsentFilter) ([]models.PatientConsent, listquery.Page, error)
+	FindActive(patientID string, consentType string) (*models.PatientConsent, error)
+	FindAllActive(patientID string) ([]models.PatientConsent, error)
+	Withdraw(id string, withdrawnBy string, reason string, at time.Time) error
+}
+
+type consentRepository struct {
+	db *gorm.DB
+}
+
+func NewConsentRepository(db *gorm.DB) ConsentRepository {
+	return &consentRepository{db: db}
+}
+
+// CreateDocument menyimpan dokumen sebagai versi berikutnya untuk jenisnya.
+// Version diisi di sini; dua penerbitan bersamaan ditolak unique index.
+func (r *consentRepository) CreateDocument(document *models.ConsentDocument) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var latest int
+		if err := tx.Model(&models.ConsentDocument{}).Where("type = ?", document.Type).
+			Select("COALESCE(MAX(version), 0)").Scan(&latest).Error; err != nil {
+			return err
+		}
+		document.Version = latest + 1
+		return tx.Create(document).Error
+	})
+}
+
+func (r *consentRepository) FindDocumentByID(id string) (*models.ConsentDocument, error) {
+	var document models.ConsentDocument
+	if err := r.db.First(&document, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &document, nil
+}
+
+func (r *consentRepository) FindCurrentDocument(consentType string) (*models.ConsentDocument, error) {
+	var document models.ConsentDocument
+	if err := r.db.Where("type = ?", consentType).Order("version DESC").
+		First(&document).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &document, nil
+}
+
+func (r *consentRepository) FindDocuments(filter ConsentDocumentFilter) ([]models.ConsentDocument, listquery.Page, error) {
+	tx := r.db.Model(&models.ConsentDocument{})
+	if filter.Current {
+		tx = tx.Where("version = (SELECT MAX(d.version) FROM consent_documents d WHERE d.type = consent_documents.type)")
+	}
+	return listquery.Find[models.ConsentDocument](tx, &filter.Query)
+}
+
+// LatestVersions mengembalikan versi terbaru per jenis persetujuan
+func (r *consentRepository) LatestVersions() (map[string]int, error) {
+	var rows []struct {
+		Type    string
+		Version int
+	}
+	if err := r.db.Model(&models.ConsentDocument{}).Select("type, MAX(version) AS version").
+		Group("type").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	versions := make(map[string]int, len(rows))
+	for _, row := range rows {
+		versions[row.Type] = row.Version
+	}
+	return versions, nil
+}
+
+// Grant menyimpan persetujuan baru. Jika supersede terisi (persetujuan aktif
+// jenis yang sama atas versi lama), persetujuan lama ditarik dalam transaksi
+// yang sama.
+func (r *consentRepository) Grant(consent *models.PatientConsent, supersede *models.PatientConsent, reason string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if supersede != nil {
+			if err := withdrawConsent(tx, supersede.ID, consent.CapturedByID, reason, consent.GrantedAt); err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Document", "CapturedBy").Create(consent).Error
+	})
+}
+
+func (r *consentRepository) FindByID(id string) (*models.PatientConsent, error) {
+	var consent models.PatientConsent
+	if err := r.db.Preload("Document").Preload("CapturedBy").
+		First(&consent, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &consent, nil
+}
+
+func (r *consentRepository) FindAll(filter PatientConsentFilter) ([]models.PatientConsent, listquery.Page, error) {
+	tx := r.db.Model(&models.PatientConsent{}).Preload("Document").Preload("CapturedBy").
+		Where("patient_consents.patient_id = ?", filter.PatientID)
+	if filter.Active != nil {
+		if *filter.Active {
+			tx = tx.Where("patient_consents.withdrawn_at IS NULL")
+		} else {
+			tx = tx.Where("patient_consents.withdrawn_at IS NOT NULL")
+		}
+	}
+	return listquery.Find[models.PatientConsent](tx, &filter.Query)
+}
+
+func (r *consentRepository) FindActive(patientID string, consentType string) (*models.PatientConsent, error) {
+	var consent models.PatientConsent
+	if err := r.db.Preload("Document").
+		Where("patient_id = ? AND type = ? AND withdrawn_at IS NULL", patientID, consentType).
+		First(&consent).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &consent, nil
+}
+
+func (r *consentRepository) FindAllActive(patientID string) ([]models.PatientConsent, error) {
+	var consents []models.PatientConsent
+	err := r.db.Preload("Document").
+		Where("patient_id = ? AND withdrawn_at IS NULL", patientID).
+		Order("type").Find(&consents).Error
+	return consents, err
+}
+
+func (r *consentRepository) Withdraw(id string, withdrawnBy string, reason string, at time.Time) error {
+	return withdrawConsent(r.db, id, withdrawnBy, reason, at)
+}
+
+// withdrawConsent menandai persetujuan ditarik. Update bersyarat memastikan
+// persetujuan yang sudah ditarik tidak diubah lagi.
+func withdrawConsent(db *gorm.DB, id string, withdrawnBy string, reason string, at time.Time) error {
+	result := db.Model(&models.PatientConsent{}).
+		Where("id = ? AND withdrawn_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"withdrawn_at":      at,
+			"withdrawn_by_id":   withdrawnBy,
+			"withdrawal_reason": reason,
+			"updated_at":        at,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrConsentChanged
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// PatientContactListSpec adalah kolom kontak pasien yang boleh difilter dan
+// di-sort. Urutan default sama dengan urutan kontak dihubungi.
+var PatientContactListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "patient_contacts.id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "fullName", Column: "patient_contacts.full_name", Sortable: true, Ops: listquery.TextOps},
+		{Name: "relationship", Column: "patient_contacts.relationship", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "priority", Column: "patient_contacts.priority", Type: listquery.Number, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "createdAt", Column: "patient_contacts.created_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "priority,createdAt",
+	Params:      []string{"mayReceiveClinicalInfo", "legalGuardian", "reveal", "reason"},
+}
+
+// PatientContactFilter menampung parameter list kontak satu pasien
+type PatientContactFilter struct {
+	PatientID              string
+	MayReceiveClinicalInfo *bool
+	LegalGuardian          *bool
+	listquery.Query
+}
+
+type ContactRepository interface {
+	Create(contact *models.PatientContact) error
+	FindByID(id string) (*models.PatientContact, error)
+	FindAll(filter PatientContactFilter) ([]models.PatientContact, listquery.Page, error)
+	FindByPatientID(patientID string) ([]models.PatientContact, error)
+	Update(contact *models.PatientContact) error
+	Delete(id string) error
+}
+
+type contactRepository struct {
+	db *gorm.DB
+}
+
+func NewContactRepository(db *gorm.DB) ContactRepository {
+	return &contactRepository{db: db}
+}
+
+func (r *contactRepository) Create(contact *models.PatientContact) error {
+	return r.db.Create(contact).Error
+}
+
+func (r *contactRepository) FindByID(id string) (*models.PatientContact, error) {
+	var contact models.PatientContact
+	if err := r.db.First(&contact, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &contact, nil
+}
+
+func (r *contactRepository) FindAll(filter PatientContactFilter) ([]models.PatientContact, listquery.Page, error) {
+	tx := r.db.Model(&models.PatientContact{}).Where("patient_contacts.patient_id = ?", filter.PatientID)
+	if filter.MayReceiveClinicalInfo != nil {
+		tx = tx.Where("patient_contacts.may_receive_clinical_info = ?", *filter.MayReceiveClinicalInfo)
+	}
+	if filter.LegalGuardian != nil {
+		tx = tx.Where("patient_contacts.legal_guardian = ?", *filter.LegalGuardian)
+	}
+	return listquery.Find[models.PatientContact](tx, &filter.Query)
+}
+
+// FindByPatientID mengambil semua kontak pasien sesuai urutan dihubungi
+func (r *contactRepository) FindByPatientID(patientID string) ([]models.PatientContact, error) {
+	var contacts []models.PatientContact
+	err := r.db.Scopes(orderContacts).Where("patient_id = ?", patientID).Find(&contacts).Error
+	return contacts, err
+}
+
+func (r *contactRepository) Update(contact *models.PatientContact) error {
+	return r.db.Save(contact).Error
+}
+
+func (r *contactRepository) Delete(id string) error {
+	result := r.db.Delete(&models.PatientContact{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// orderContacts mengurutkan kontak sesuai urutan dihubungi: priority, lalu
+// yang dibuat lebih dulu
+func orderContacts(db *gorm.DB) *gorm.DB {
+	return db.Order("priority, created_at")
+}
+
+// preloadContacts memuat kontak pasien sesuai urutan dihubungi
+func preloadContacts(db *gorm.DB) *gorm.DB {
+	return db.Preload("Contacts", orderContacts)
+}
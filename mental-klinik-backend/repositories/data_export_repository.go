@@ -0,0 +1,188 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// ErrDataExportChanged dikembalikan saat status job export sudah diubah
+// proses lain (misalnya job yang sama diambil dua kali)
+var ErrDataExportChanged = errors.New("data export has already changed")
+
+// DataExportListSpec adalah kolom export data yang boleh difilter dan di-sort
+var DataExportListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "patientId", Column: "patient_id", Ops: listquery.EqualityOps},
+		{Name: "requestedById", Column: "requested_by_id", Ops: listquery.EqualityOps},
+		{Name: "status", Column: "status", Ops: listquery.EqualityOps},
+		{Name: "requestedAt", Column: "requested_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "-requestedAt",
+}
+
+// PatientData adalah semua data yang terkait dengan satu pasien, termasuk
+// baris yang sudah di-soft delete
+type PatientData struct {
+	Patient           models.Patient
+	Appointments      []models.Appointment
+	Assessments       []models.Assessment // Prediction ikut dimuat
+	MedicalRecords    []models.MedicalRecord
+	CareAssignments   []models.CareAssignment
+	Consents          []models.PatientConsent // Document ikut dimuat
+	EmergencyAccesses []models.EmergencyAccess
+	AccessLog         []models.AuditLog
+}
+
+type DataExportRepository interface {
+	Create(export *models.DataExport) error
+	FindByID(id string) (*models.DataExport, error)
+	FindBundle(id string) (*models.DataExport, error)
+	FindAll(query listquery.Query) ([]models.DataExport, listquery.Page, error)
+	FindUnfinished() ([]models.DataExport, error)
+	Start(id string, at time.Time) error
+	Complete(id string, bundle []byte, checksum string, at time.Time, expiresAt time.Time) error
+	Fail(id string, message string, at time.Time) error
+	CollectPatientData(patientID string) (*PatientData, error)
+}
+
+type dataExportRepository struct {
+	db *gorm.DB
+}
+
+func NewDataExportRepository(db *gorm.DB) DataExportRepository {
+	return &dataExportRepository{db: db}
+}
+
+func (r *dataExportRepository) Create(export *models.DataExport) error {
+	return r.db.Create(export).Error
+}
+
+// FindByID tidak memuat isi bundle
+func (r *dataExportRepository) FindByID(id string) (*models.DataExport, error) {
+	var export models.DataExport
+	if err := r.db.Omit("bundle").First(&export, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &export, nil
+}
+
+// FindBundle memuat export beserta isi bundle untuk di-download
+func (r *dataExportRepository) FindBundle(id string) (*models.DataExport, error) {
+	var export models.DataExport
+	if err := r.db.First(&export, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &export, nil
+}
+
+func (r *dataExportRepository) FindAll(query listquery.Query) ([]models.DataExport, listquery.Page, error) {
+	return listquery.Find[models.DataExport](r.db.Model(&models.DataExport{}).Omit("bundle"), &query)
+}
+
+// FindUnfinished mengambil job yang belum selesai, dipakai saat startup untuk
+// melanjutkan job yang terputus
+func (r *dataExportRepository) FindUnfinished() ([]models.DataExport, error) {
+	var exports []models.DataExport
+	err := r.db.Omit("bundle").
+		Where("status IN ?", []string{models.DataExportPending, models.DataExportProcessing}).
+		Order("requested_at").Find(&exports).Error
+	return exports, err
+}
+
+func (r *dataExportRepository) Start(id string, at time.Time) error {
+	return r.updateExport(
+		r.db.Where("id = ? AND status IN ?", id, []string{models.DataExportPending, models.DataExportProcessing}),
+		map[string]interface{}{"status": models.DataExportProcessing, "updated_at": at},
+	)
+}
+
+func (r *dataExportRepository) Complete(id string, bundle []byte, checksum string, at time.Time, expiresAt time.Time) error {
+	return r.updateExport(
+		r.db.Where("id = ? AND status = ?", id, models.DataExportProcessing),
+		map[string]interface{}{
+			"status":       models.DataExportCompleted,
+			"bundle":       bundle,
+			"checksum":     checksum,
+			"size":         len(bundle),
+			"completed_at": at,
+			"expires_at":   expiresAt,
+			"updated_at":   at,
+		},
+	)
+}
+
+func (r *dataExportRepository) Fail(id string, message string, at time.Time) error {
+	return r.updateExport(
+		r.db.Where("id = ? AND status = ?", id, models.DataExportProcessing),
+		map[string]interface{}{"status": models.DataExportFailed, "error": message, "updated_at": at},
+	)
+}
+
+func (r *dataExportRepository) updateExport(where *gorm.DB, values map[string]interface{}) error {
+	result := where.Model(&models.DataExport{}).Updates(values)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrDataExportChanged
+	}
+	return nil
+}
+
+// CollectPatientData membaca semua data pasien dalam satu transaksi read-only
+// supaya bundle konsisten
+func (r *dataExportRepository) CollectPatientData(patientID string) (*PatientData, error) {
+	data := &PatientData{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Session supaya kondisi tiap query tidak menumpuk di statement yang sama
+		tx = tx.Unscoped().Session(&gorm.Session{})
+		if err := tx.Scopes(preloadContacts).First(&data.Patient, "id = ?", patientID).Error; err != nil {
+			return translateError(err)
+		}
+		steps := []func() error{
+			func() error {
+				return tx.Preload("User", unscoped).
+					Where("patient_id = ?", patientID).Order("schedule_at").Find(&data.Appointments).Error
+			},
+			func() error {
+				return tx.Preload("Prediction", unscoped).
+					Where("patient_id = ?", patientID).Order("date").Find(&data.Assessments).Error
+			},
+			func() error {
+				return tx.Preload("User", unscoped).
+					Where("patient_id = ?", patientID).Order("created_at").Find(&data.MedicalRecords).Error
+			},
+			func() error {
+				return tx.Preload("User", unscoped).
+					Where("patient_id = ?", patientID).Order("start_date").Find(&data.CareAssignments).Error
+			},
+			func() error {
+				return tx.Preload("Document").
+					Where("patient_id = ?", patientID).Order("granted_at").Find(&data.Consents).Error
+			},
+			func() error {
+				return tx.Where("patient_id = ?", patientID).Order("granted_at").Find(&data.EmergencyAccesses).Error
+			},
+			func() error {
+				return tx.Where("patient_id = ?", patientID).Order("id").Find(&data.AccessLog).Error
+			},
+		}
+		for _, step := range steps {
+			if err := step(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, &sql.TxOptions{ReadOnly: true, Isolation: sql.LevelRepeatableRead})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
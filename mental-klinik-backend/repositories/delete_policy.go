@@ -0,0 +1,206 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"mental-klinik-backend/models"
+)
+
+// Kebijakan penghapusan per relasi saat induknya dihapus
+const (
+	// OnDeleteCascade ikut men-soft delete anak dengan deleted_at yang sama
+	// dengan induk, sehingga bisa dipulihkan bersama lewat restore cascade
+	OnDeleteCascade = "cascade"
+	// OnDeleteRestrict menolak penghapusan selama anak masih ada
+	OnDeleteRestrict = "restrict"
+	// OnDeleteReassign memindahkan anak ke induk pengganti; tanpa pengganti
+	// berlaku seperti restrict
+	OnDeleteReassign = "reassign"
+)
+
+// maxBlockerIDs adalah jumlah ID contoh per relasi di daftar blocker
+const maxBlockerIDs = 10
+
+// ErrInvalidReassignTarget dikembalikan jika user pengganti tidak ada, sama
+// dengan user yang dihapus, atau role-nya berbeda
+var ErrInvalidReassignTarget = errors.New("invalid reassign target")
+
+// Relation mendeklarasikan satu relasi anak dan kebijakan penghapusannya
+type Relation struct {
+	Name     string      // nama relasi di daftar blocker dan hasil penghapusan
+	Model    interface{} // model anak
+	Column   string      // kolom foreign key ke induk
+	OnDelete string
+	// Scope membatasi baris anak yang terkena kebijakan (opsional). at adalah
+	// waktu penghapusan.
+	Scope    func(db *gorm.DB, at time.Time) *gorm.DB
+	Children []Relation // relasi turunan anak, diproses sebelum anaknya
+}
+
+// PatientRelations: seluruh data klinis pasien ikut dihapus bersama pasien.
+// Penugasan tim perawatan tidak punya soft delete dan dibiarkan, pasien yang
+// dihapus sudah tidak muncul di daftar pasien tim.
+var PatientRelations = []Relation{
+	{Name: "appointments", Model: &models.Appointment{}, Column: "patient_id", OnDelete: OnDeleteCascade},
+	{Name: "assessments", Model: &models.Assessment{}, Column: "patient_id", OnDelete: OnDeleteCascade, Children: []Relation{
+		{Name: "predictions", Model: &models.Prediction{}, Column: "assessment_id", OnDelete: OnDeleteCascade},
+	}},
+	{Name: "medical_records", Model: &models.MedicalRecord{}, Column: "patient_id", OnDelete: OnDeleteCascade},
+}
+
+// UserRelations: appointment yang belum berjalan harus dipindahkan ke user
+// lain, dan tim perawatan yang masih berlaku harus ditransfer dulu. Riwayat
+// (appointment selesai / batal, rekam medis, undangan) tetap menunjuk user
+// yang dihapus.
+var UserRelations = []Relation{
+	{
+		Name: "appointments", Model: &models.Appointment{}, Column: "user_id", OnDelete: OnDeleteReassign,
+		Scope: func(db *gorm.DB, at time.Time) *gorm.DB {
+			return db.Where("status = ?", "pending")
+		},
+	},
+	{
+		Name: "care_assignments", Model: &models.CareAssignment{}, Column: "user_id", OnDelete: OnDeleteRestrict,
+		Scope: func(db *gorm.DB, at time.Time) *gorm.DB {
+			day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+			return db.Where("end_date IS NULL OR end_date > ?", day)
+		},
+	},
+}
+
+// DeleteBlocker adalah relasi yang menahan penghapusan beserta contoh ID-nya
+type DeleteBlocker struct {
+	Relation string
+	Policy   string
+	Count    int64
+	IDs      []string
+}
+
+// DeleteBlockedError dikembalikan jika ada relasi restrict (atau reassign
+// tanpa pengganti) yang masih punya data
+type DeleteBlockedError struct {
+	Blockers []DeleteBlocker
+}
+
+func (e *DeleteBlockedError) Error() string {
+	parts := make([]string, 0, len(e.Blockers))
+	for _, blocker := range e.Blockers {
+		parts = append(parts, fmt.Sprintf("%d %s", blocker.Count, blocker.Relation))
+	}
+	return "still referenced by " + strings.Join(parts, ", ")
+}
+
+// DeleteResult adalah jumlah baris per relasi yang ikut dihapus (cascade)
+// dan yang dipindahkan (reassign) oleh satu penghapusan
+type DeleteResult struct {
+	Deleted    map[string]int64
+	Reassigned map[string]int64
+}
+
+// deleteWithRelations men-soft delete induk model dengan ID id beserta
+// relasinya sesuai kebijakan, di dalam transaksi tx. Semua blocker dikumpulkan
+// dulu sebelum ada data yang diubah. reassignTo adalah ID induk pengganti
+// untuk relasi reassign.
+func deleteWithRelations(tx *gorm.DB, model interface{}, id string, relations []Relation, reassignTo string) (*DeleteResult, error) {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(model, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	at := time.Now()
+
+	var blockers []DeleteBlocker
+	if err := collectBlockers(tx, relations, id, at, reassignTo, &blockers); err != nil {
+		return nil, err
+	}
+	if len(blockers) > 0 {
+		return nil, &DeleteBlockedError{Blockers: blockers}
+	}
+
+	result := &DeleteResult{Deleted: map[string]int64{}, Reassigned: map[string]int64{}}
+	if err := applyRelations(tx, relations, id, at, reassignTo, result); err != nil {
+		return nil, err
+	}
+	if err := tx.Model(model).Where("id = ?", id).UpdateColumn("deleted_at", at).Error; err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// relationRows membatasi query ke baris aktif relasi yang menunjuk induk
+// parentIDs (satu ID atau subquery ID)
+func relationRows(tx *gorm.DB, relation Relation, parentIDs interface{}, at time.Time) *gorm.DB {
+	rows := tx.Model(relation.Model).Where(relation.Column+" IN (?)", parentIDs)
+	if relation.Scope != nil {
+		rows = relation.Scope(rows, at)
+	}
+	return rows
+}
+
+func collectBlockers(tx *gorm.DB, relations []Relation, parentIDs interface{}, at time.Time, reassignTo string, blockers *[]DeleteBlocker) error {
+	for _, relation := range relations {
+		blocking := relation.OnDelete == OnDeleteRestrict || (relation.OnDelete == OnDeleteReassign && reassignTo == "")
+		if blocking {
+			var count int64
+			if err := relationRows(tx, relation, parentIDs, at).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				var ids []string
+				if err := relationRows(tx, relation, parentIDs, at).Order("id").Limit(maxBlockerIDs).Pluck("id", &ids).Error; err != nil {
+					return err
+				}
+				*blockers = append(*blockers, DeleteBlocker{Relation: relation.Name, Policy: relation.OnDelete, Count: count, IDs: ids})
+			}
+		}
+		if len(relation.Children) > 0 {
+			childParents := relationRows(tx, relation, parentIDs, at).Select("id")
+			if err := collectBlockers(tx, relation.Children, childParents, at, reassignTo, blockers); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func applyRelations(tx *gorm.DB, relations []Relation, parentIDs interface{}, at time.Time, reassignTo string, result *DeleteResult) error {
+	for _, relation := range relations {
+		// Turunan diproses dulu, selagi anaknya masih aktif
+		if len(relation.Children) > 0 {
+			childParents := relationRows(tx, relation, parentIDs, at).Select("id")
+			if err := applyRelations(tx, relation.Children, childParents, at, reassignTo, result); err != nil {
+				return err
+			}
+		}
+
+		var applied *gorm.DB
+		switch relation.OnDelete {
+		case OnDeleteCascade:
+			applied = relationRows(tx, relation, parentIDs, at).UpdateColumn("deleted_at", at)
+			if applied.Error == nil && applied.RowsAffected > 0 {
+				result.Deleted[relation.Name] += applied.RowsAffected
+			}
+		case OnDeleteReassign:
+			applied = relationRows(tx, relation, parentIDs, at).Update(relation.Column, reassignTo)
+			if applied.Error == nil && applied.RowsAffected > 0 {
+				result.Reassigned[relation.Name] += applied.RowsAffected
+			}
+		default:
+			continue
+		}
+		if applied.Error != nil {
+			return applied.Error
+		}
+	}
+	return nil
+}
+
+// unscoped dipakai untuk preload relasi yang boleh menunjuk data yang sudah
+// dihapus, misalnya dokter di riwayat appointment
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
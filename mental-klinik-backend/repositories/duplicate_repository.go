@@ -0,0 +1,453 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+var (
+	// ErrDuplicateScanRunning dikembalikan saat scan duplikat lain masih
+	// berjalan
+	ErrDuplicateScanRunning = errors.New("another duplicate scan is still running")
+	// ErrDuplicateCandidateChanged dikembalikan jika kandidat sudah direview
+	// oleh request lain
+	ErrDuplicateCandidateChanged = errors.New("duplicate candidate has already been reviewed")
+	// ErrPatientMergeChanged dikembalikan jika penggabungan sudah dibatalkan
+	ErrPatientMergeChanged = errors.New("patient merge has already been undone")
+)
+
+// DuplicateCandidateListSpec adalah kolom antrean kandidat duplikat yang
+// boleh difilter dan di-sort
+var DuplicateCandidateListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "status", Column: "status", Ops: listquery.EqualityOps},
+		{Name: "score", Column: "score", Type: listquery.Number, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "detectedAt", Column: "detected_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "-score",
+	Params:      []string{"patientId"},
+	Cursor:      true,
+}
+
+// PatientMergeListSpec adalah kolom riwayat penggabungan pasien yang boleh
+// difilter dan di-sort
+var PatientMergeListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "survivorId", Column: "survivor_id", Ops: listquery.EqualityOps},
+		{Name: "mergedId", Column: "merged_id", Ops: listquery.EqualityOps},
+		{Name: "mergedAt", Column: "merged_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "-mergedAt",
+	Cursor:      true,
+}
+
+// DuplicateScanListSpec adalah kolom riwayat scan duplikat yang boleh
+// difilter dan di-sort
+var DuplicateScanListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "trigger", Column: "trigger", Ops: listquery.EqualityOps},
+		{Name: "status", Column: "status", Ops: listquery.EqualityOps},
+		{Name: "startedAt", Column: "started_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "-startedAt",
+}
+
+// DuplicateCandidateFilter menampung parameter antrean kandidat duplikat
+type DuplicateCandidateFilter struct {
+	PatientID string // jika terisi, hanya kandidat yang melibatkan pasien ini
+	listquery.Query
+}
+
+// mergeRelations adalah tabel data klinis dan kontak yang dipindahkan ke
+// pasien yang dipertahankan. Prediksi ikut assessment-nya. Persetujuan, tim
+// perawatan dan akses darurat tetap di pasien yang digabung karena berlaku
+// per pasien.
+var mergeRelations = []struct {
+	table string
+	model interface{}
+}{
+	{"appointments", &models.Appointment{}},
+	{"assessments", &models.Assessment{}},
+	{"medical_records", &models.MedicalRecord{}},
+	{"patient_contacts", &models.PatientContact{}},
+}
+
+type DuplicateRepository interface {
+	CreateScan(scan *models.DuplicateScan) error
+	FinishScan(scan *models.DuplicateScan) error
+	FailInterrupted(at time.Time) (int64, error)
+	FindScans(query listquery.Query) ([]models.DuplicateScan, listquery.Page, error)
+	FindMatchablePatients() ([]models.Patient, error)
+	SaveCandidates(candidates []models.DuplicateCandidate) (int64, error)
+	FindCandidates(filter DuplicateCandidateFilter) ([]models.DuplicateCandidate, listquery.Page, error)
+	FindCandidateByID(id string) (*models.DuplicateCandidate, error)
+	DismissCandidate(id string, reviewedBy string, at time.Time) error
+	Merge(merge *models.PatientMerge) error
+	UndoMerge(id string, undoneBy string, at time.Time) (*models.PatientMerge, RestoreResult, error)
+	FindMerges(query listquery.Query) ([]models.PatientMerge, listquery.Page, error)
+	FindMergeByID(id string) (*models.PatientMerge, error)
+}
+
+type duplicateRepository struct {
+	db    *gorm.DB
+	index BlindIndexer
+}
+
+func NewDuplicateRepository(db *gorm.DB, index BlindIndexer) DuplicateRepository {
+	return &duplicateRepository{db: db, index: index}
+}
+
+// CreateScan menyimpan scan baru dengan status running jika tidak ada scan
+// lain yang sedang berjalan. Index unik uni_duplicate_scans_running menjaga
+// hal yang sama antar instance.
+func (r *duplicateRepository) CreateScan(scan *models.DuplicateScan) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var running int64
+		if err := tx.Model(&models.DuplicateScan{}).Where("status = ?", models.DuplicateScanRunning).Count(&running).Error; err != nil {
+			return err
+		}
+		if running > 0 {
+			return ErrDuplicateScanRunning
+		}
+		return tx.Create(scan).Error
+	})
+}
+
+func (r *duplicateRepository) FinishScan(scan *models.DuplicateScan) error {
+	return r.db.Model(&models.DuplicateScan{}).Where("id = ?", scan.ID).
+		Updates(map[string]interface{}{
+			"status":      scan.Status,
+			"error":       scan.Error,
+			"patients":    scan.Patients,
+			"candidates":  scan.Candidates,
+			"created":     scan.Created,
+			"finished_at": scan.FinishedAt,
+			"updated_at":  time.Now(),
+		}).Error
+}
+
+// FailInterrupted menandai scan yang masih running sebagai gagal. Dipanggil
+// saat startup: scan tersebut terputus karena server berhenti.
+func (r *duplicateRepository) FailInterrupted(at time.Time) (int64, error) {
+	result := r.db.Model(&models.DuplicateScan{}).
+		Where("status = ?", models.DuplicateScanRunning).
+		Updates(map[string]interface{}{
+			"status":      models.DuplicateScanFailed,
+			"error":       "interrupted by server shutdown",
+			"finished_at": at,
+			"updated_at":  at,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *duplicateRepository) FindScans(query listquery.Query) ([]models.DuplicateScan, listquery.Page, error) {
+	return listquery.Find[models.DuplicateScan](r.db.Model(&models.DuplicateScan{}), &query)
+}
+
+// FindMatchablePatients mengembalikan field pembanding semua pasien aktif
+// yang belum dianonimkan. Telepon dan NIK terenkripsi sehingga dibandingkan
+// di aplikasi, bukan di SQL.
+func (r *duplicateRepository) FindMatchablePatients() ([]models.Patient, error) {
+	var patients []models.Patient
+	err := r.db.Select("id", "full_name", "nik", "birth_date", "phone").
+		Where("anonymized_at IS NULL").Order("id").Find(&patients).Error
+	return patients, err
+}
+
+// SaveCandidates menambahkan kandidat baru ke antrean dan memperbarui skor
+// kandidat yang masih pending. Pasangan yang sudah ditolak atau digabung
+// tidak dimunculkan lagi. Mengembalikan jumlah kandidat baru.
+func (r *duplicateRepository) SaveCandidates(candidates []models.DuplicateCandidate) (int64, error) {
+	var created int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range candidates {
+			candidate := &candidates[i]
+			var existing models.DuplicateCandidate
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("patient_a_id = ? AND patient_b_id = ?", candidate.PatientAID, candidate.PatientBID).
+				First(&existing).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				if err := tx.Create(candidate).Error; err != nil {
+					return err
+				}
+				created++
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if existing.Status != models.DuplicatePending {
+				continue
+			}
+			err = tx.Model(&existing).Updates(map[string]interface{}{
+				"score":       candidate.Score,
+				"reasons":     candidate.Reasons,
+				"detected_at": candidate.DetectedAt,
+				"updated_at":  candidate.UpdatedAt,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return created, err
+}
+
+// FindCandidates mengembalikan antrean kandidat. Kandidat pending yang salah
+// satu pasiennya sudah dihapus tidak ditampilkan.
+func (r *duplicateRepository) FindCandidates(filter DuplicateCandidateFilter) ([]models.DuplicateCandidate, listquery.Page, error) {
+	query := r.db.Model(&models.DuplicateCandidate{}).
+		Preload("PatientA", unscoped).Preload("PatientA.Contacts", orderContacts).
+		Preload("PatientB", unscoped).Preload("PatientB.Contacts", orderContacts).
+		Where("status <> ? OR NOT EXISTS (SELECT 1 FROM patients p WHERE p.id IN (patient_a_id, patient_b_id) AND p.deleted_at IS NOT NULL)",
+			models.DuplicatePending)
+	if filter.PatientID != "" {
+		query = query.Where("patient_a_id = ? OR patient_b_id = ?", filter.PatientID, filter.PatientID)
+	}
+	return listquery.Find[models.DuplicateCandidate](query, &filter.Query)
+}
+
+func (r *duplicateRepository) FindCandidateByID(id string) (*models.DuplicateCandidate, error) {
+	var candidate models.DuplicateCandidate
+	err := r.db.Preload("PatientA", unscoped).Preload("PatientA.Contacts", orderContacts).
+		Preload("PatientB", unscoped).Preload("PatientB.Contacts", orderContacts).
+		First(&candidate, "id = ?", id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &candidate, nil
+}
+
+// DismissCandidate menandai kandidat pending sebagai bukan duplikat
+func (r *duplicateRepository) DismissCandidate(id string, reviewedBy string, at time.Time) error {
+	result := r.db.Model(&models.DuplicateCandidate{}).
+		Where("id = ? AND status = ?", id, models.DuplicatePending).
+		Updates(map[string]interface{}{
+			"status":         models.DuplicateDismissed,
+			"reviewed_by_id": reviewedBy,
+			"reviewed_at":    at,
+			"updated_at":     at,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrDuplicateCandidateChanged
+	}
+	return nil
+}
+
+// Merge menggabungkan pasien merge.MergedID ke merge.SurvivorID dalam satu
+// transaksi: appointment, assessment dan rekam medis (termasuk yang di trash)
+// dipindahkan, pasien yang digabung di-soft delete dengan penanda
+// merged_into_id, dan kandidat pending untuk pasangan ini ditandai merged.
+// ID baris yang dipindahkan dicatat di merge.Moved untuk undo.
+func (r *duplicateRepository) Merge(merge *models.PatientMerge) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		ids := []string{merge.SurvivorID, merge.MergedID}
+		var patients []models.Patient
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "anonymized_at").
+			Where("id IN ?", ids).Order("id").Find(&patients).Error
+		if err != nil {
+			return err
+		}
+		if len(patients) != len(ids) {
+			return ErrNotFound
+		}
+		for _, patient := range patients {
+			if patient.AnonymizedAt != nil {
+				return restoreConflict("patient %s data has been anonymized", patient.ID)
+			}
+			holds, err := countActiveHolds(tx, patient.ID)
+			if err != nil {
+				return err
+			}
+			if holds > 0 {
+				return ErrPatientOnLegalHold
+			}
+		}
+
+		moved := map[string][]string{}
+		for _, relation := range mergeRelations {
+			var rowIDs []string
+			rows := tx.Unscoped().Model(relation.model).Where("patient_id = ?", merge.MergedID)
+			if err := rows.Order("id").Pluck("id", &rowIDs).Error; err != nil {
+				return err
+			}
+			if len(rowIDs) == 0 {
+				continue
+			}
+			err := tx.Unscoped().Model(relation.model).Where("id IN ?", rowIDs).
+				Updates(map[string]interface{}{"patient_id": merge.SurvivorID, "updated_at": merge.MergedAt}).Error
+			if err != nil {
+				return err
+			}
+			moved[relation.table] = rowIDs
+		}
+		if merge.Moved, err = json.Marshal(moved); err != nil {
+			return err
+		}
+
+		a, b := OrderedPair(merge.SurvivorID, merge.MergedID)
+		var candidate models.DuplicateCandidate
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("patient_a_id = ? AND patient_b_id = ? AND status = ?", a, b, models.DuplicatePending).
+			First(&candidate).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil {
+			merge.CandidateID = &candidate.ID
+		}
+		if err := tx.Create(merge).Error; err != nil {
+			return err
+		}
+		if merge.CandidateID != nil {
+			err := tx.Model(&models.DuplicateCandidate{}).Where("id = ?", candidate.ID).
+				Updates(map[string]interface{}{
+					"status":         models.DuplicateMerged,
+					"reviewed_by_id": merge.MergedByID,
+					"reviewed_at":    merge.MergedAt,
+					"merge_id":       merge.ID,
+					"updated_at":     merge.MergedAt,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.Patient{}).Where("id = ?", merge.MergedID).
+			UpdateColumns(map[string]interface{}{"deleted_at": merge.MergedAt, "merged_into_id": merge.SurvivorID}).Error
+	})
+}
+
+// UndoMerge membatalkan penggabungan: baris yang dulu dipindahkan dan masih
+// milik pasien yang dipertahankan dikembalikan, pasien yang digabung
+// dipulihkan, dan kandidatnya kembali pending. Data yang dibuat setelah
+// penggabungan tetap di pasien yang dipertahankan.
+func (r *duplicateRepository) UndoMerge(id string, undoneBy string, at time.Time) (*models.PatientMerge, RestoreResult, error) {
+	var merge models.PatientMerge
+	result := RestoreResult{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&merge, "id = ?", id).Error
+		if err != nil {
+			return translateError(err)
+		}
+		if merge.Undone() {
+			return ErrPatientMergeChanged
+		}
+
+		var merged models.Patient
+		err = tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).First(&merged, "id = ?", merge.MergedID).Error
+		if err != nil {
+			return translateError(err)
+		}
+		if merged.AnonymizedAt != nil {
+			return restoreConflict("patient %s data has been anonymized", merged.ID)
+		}
+		if !merged.DeletedAt.Valid || merged.MergedIntoID == nil || *merged.MergedIntoID != merge.SurvivorID {
+			return restoreConflict("patient %s has changed since the merge", merged.ID)
+		}
+		if merged.NIK != "" {
+			var other models.Patient
+			err := tx.Where(matchNIK(tx, r.index, merged.NIK)).Where("id <> ?", merged.ID).Select("id").First(&other).Error
+			if err == nil {
+				return restoreConflict("NIK is registered to active patient %s", other.ID)
+			}
+			if err = translateError(err); !errors.Is(err, ErrNotFound) {
+				return err
+			}
+		}
+		for _, patientID := range []string{merge.SurvivorID, merge.MergedID} {
+			holds, err := countActiveHolds(tx, patientID)
+			if err != nil {
+				return err
+			}
+			if holds > 0 {
+				return ErrPatientOnLegalHold
+			}
+		}
+
+		var moved map[string][]string
+		if err := json.Unmarshal(merge.Moved, &moved); err != nil {
+			return err
+		}
+		for _, relation := range mergeRelations {
+			rowIDs := moved[relation.table]
+			if len(rowIDs) == 0 {
+				continue
+			}
+			back := tx.Unscoped().Model(relation.model).
+				Where("id IN ? AND patient_id = ?", rowIDs, merge.SurvivorID).
+				Updates(map[string]interface{}{"patient_id": merge.MergedID, "updated_at": at})
+			if back.Error != nil {
+				return back.Error
+			}
+			if back.RowsAffected > 0 {
+				result[relation.table] = back.RowsAffected
+			}
+		}
+
+		err = tx.Unscoped().Model(&models.Patient{}).Where("id = ?", merged.ID).
+			UpdateColumns(map[string]interface{}{"deleted_at": nil, "merged_into_id": nil}).Error
+		if err != nil {
+			return err
+		}
+		result["patients"] = 1
+
+		merge.UndoneByID = &undoneBy
+		merge.UndoneAt = &at
+		err = tx.Model(&models.PatientMerge{}).Where("id = ?", merge.ID).
+			Updates(map[string]interface{}{"undone_by_id": undoneBy, "undone_at": at, "updated_at": at}).Error
+		if err != nil {
+			return err
+		}
+		if merge.CandidateID == nil {
+			return nil
+		}
+		return tx.Model(&models.DuplicateCandidate{}).Where("id = ?", *merge.CandidateID).
+			Updates(map[string]interface{}{
+				"status":         models.DuplicatePending,
+				"reviewed_by_id": nil,
+				"reviewed_at":    nil,
+				"merge_id":       nil,
+				"updated_at":     at,
+			}).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &merge, result, nil
+}
+
+func (r *duplicateRepository) FindMerges(query listquery.Query) ([]models.PatientMerge, listquery.Page, error) {
+	return listquery.Find[models.PatientMerge](r.db.Model(&models.PatientMerge{}), &query)
+}
+
+func (r *duplicateRepository) FindMergeByID(id string) (*models.PatientMerge, error) {
+	var merge models.PatientMerge
+	if err := r.db.First(&merge, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &merge, nil
+}
+
+// OrderedPair mengurutkan dua ID pasien seperti kolom patient_a_id /
+// patient_b_id (urutan byte, sama dengan collation "C" di database)
+func OrderedPair(x string, y string) (string, string) {
+	if x < y {
+		return x, y
+	}
+	return y, x
+}
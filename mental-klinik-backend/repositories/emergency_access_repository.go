@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// ErrEmergencyAccessChanged dikembalikan saat akses darurat yang akan dicabut
+// atau di-review ternyata sudah dicabut / di-review (termasuk oleh request
+// lain yang bersamaan)
+var ErrEmergencyAccessChanged = errors.New("emergency access has already changed")
+
+// EmergencyAccessListSpec adalah kolom akses darurat yang boleh difilter dan
+// di-sort di laporan review admin
+var EmergencyAccessListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "emergency_accesses.id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "userId", Column: "emergency_accesses.user_id", Ops: listquery.EqualityOps},
+		{Name: "patientId", Column: "emergency_accesses.patient_id", Ops: listquery.EqualityOps},
+		{Name: "reviewOutcome", Column: "emergency_accesses.review_outcome", Ops: listquery.EqualityOps},
+		{Name: "grantedAt", Column: "emergency_accesses.granted_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "expiresAt", Column: "emergency_accesses.expires_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "reviewedAt", Column: "emergency_accesses.reviewed_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps, Nullable: true},
+	},
+	DefaultSort: "-grantedAt",
+	Params:      []string{"reviewed"},
+}
+
+// EmergencyAccessFilter menampung parameter laporan akses darurat
+type EmergencyAccessFilter struct {
+	Reviewed *bool // false: hanya antrean yang belum di-review
+	listquery.Query
+}
+
+type EmergencyAccessRepository interface {
+	Create(access *models.EmergencyAccess) error
+	FindByID(id string) (*models.EmergencyAccess, error)
+	FindAll(filter EmergencyAccessFilter) ([]models.EmergencyAccess, listquery.Page, error)
+	CountPendingReview() (int64, error)
+	IsActive(id string, now time.Time) (bool, error)
+	Revoke(id string, revokedBy string, at time.Time) error
+	Review(id string, reviewedBy string, outcome string, notes string, at time.Time) error
+}
+
+type emergencyAccessRepository struct {
+	db *gorm.DB
+}
+
+func NewEmergencyAccessRepository(db *gorm.DB) EmergencyAccessRepository {
+	return &emergencyAccessRepository{db: db}
+}
+
+func (r *emergencyAccessRepository) Create(access *models.EmergencyAccess) error {
+	return r.db.Omit("Patient", "User").Create(access).Error
+}
+
+func (r *emergencyAccessRepository) FindByID(id string) (*models.EmergencyAccess, error) {
+	var access models.EmergencyAccess
+	if err := r.db.Preload("Patient").Preload("User").
+		First(&access, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &access, nil
+}
+
+func (r *emergencyAccessRepository) FindAll(filter EmergencyAccessFilter) ([]models.EmergencyAccess, listquery.Page, error) {
+	tx := r.db.Model(&models.EmergencyAccess{}).Preload("Patient").Preload("User")
+	if filter.Reviewed != nil {
+		if *filter.Reviewed {
+			tx = tx.Where("emergency_accesses.reviewed_at IS NOT NULL")
+		} else {
+			tx = tx.Where("emergency_accesses.reviewed_at IS NULL")
+		}
+	}
+	return listquery.Find[models.EmergencyAccess](tx, &filter.Query)
+}
+
+func (r *emergencyAccessRepository) CountPendingReview() (int64, error) {
+	var count int64
+	err := r.db.Model(&models.EmergencyAccess{}).Where("reviewed_at IS NULL").Count(&count).Error
+	return count, err
+}
+
+// IsActive dipanggil di setiap request yang membawa token akses darurat
+func (r *emergencyAccessRepository) IsActive(id string, now time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.EmergencyAccess{}).
+		Where("id = ? AND revoked_at IS NULL AND expires_at > ?", id, now).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *emergencyAccessRepository) Revoke(id string, revokedBy string, at time.Time) error {
+	return r.updateOnce(
+		r.db.Where("id = ? AND revoked_at IS NULL AND expires_at > ?", id, at),
+		map[string]interface{}{"revoked_at": at, "revoked_by_id": revokedBy, "updated_at": at},
+	)
+}
+
+func (r *emergencyAccessRepository) Review(id string, reviewedBy string, outcome string, notes string, at time.Time) error {
+	return r.updateOnce(
+		r.db.Where("id = ? AND reviewed_at IS NULL", id),
+		map[string]interface{}{
+			"reviewed_at":    at,
+			"reviewed_by_id": reviewedBy,
+			"review_outcome": outcome,
+			"review_notes":   notes,
+			"updated_at":     at,
+		},
+	)
+}
+
+// updateOnce menjalankan update bersyarat; jika tidak ada baris yang cocok
+// berarti akses sudah berubah
+func (r *emergencyAccessRepository) updateOnce(where *gorm.DB, values map[string]interface{}) error {
+	result := where.Model(&models.EmergencyAccess{}).Updates(values)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrEmergencyAccessChanged
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"mental-klinik-backend/models"
+)
+
+// reencryptBatchSize adalah jumlah baris yang dienkripsi ulang per batch
+const reencryptBatchSize = 200
+
+// encryptedColumns adalah kolom terenkripsi per tabel (lihat tag
+// serializer:encrypted di package models)
+var encryptedColumns = []struct {
+	Table   string
+	Columns []string
+}{
+	{Table: "patients", Columns: []string{"nik", "phone", "address"}},
+	{Table: "medical_records", Columns: []string{"diagnosis", "treatment"}},
+	{Table: "assessments", Columns: []string{"answers"}},
+	{Table: "patient_contacts", Columns: []string{"phone"}},
+}
+
+// DataKeyUsage adalah jumlah nilai terenkripsi per data key di satu kolom.
+// DataKeyID kosong berarti nilai lama yang belum dienkripsi.
+type DataKeyUsage struct {
+	Table     string
+	Column    string
+	DataKeyID string
+	Count     int64
+}
+
+// EncryptionRepository menyimpan data key (implementasi encryption.KeyStore)
+// dan menjalankan enkripsi ulang kolom terenkripsi.
+type EncryptionRepository interface {
+	FindAll() ([]models.DataKey, error)
+	CreateActive(key *models.DataKey) error
+	Rewrap(keys []models.DataKey) error
+	Usage() ([]DataKeyUsage, error)
+	Reencrypt(index BlindIndexer) (int64, error)
+}
+
+type encryptionRepository struct {
+	db *gorm.DB
+}
+
+func NewEncryptionRepository(db *gorm.DB) EncryptionRepository {
+	return &encryptionRepository{db: db}
+}
+
+func (r *encryptionRepository) FindAll() ([]models.DataKey, error) {
+	var keys []models.DataKey
+	err := r.db.Order("created_at").Find(&keys).Error
+	return keys, err
+}
+
+// CreateActive menonaktifkan data key lama dan menyimpan key baru sebagai
+// key aktif dalam satu transaksi
+func (r *encryptionRepository) CreateActive(key *models.DataKey) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.DataKey{}).Where("active").
+			Updates(map[string]interface{}{"active": false, "updated_at": time.Now()}).Error; err != nil {
+			return err
+		}
+		key.Active = true
+		return tx.Create(key).Error
+	})
+}
+
+func (r *encryptionRepository) Rewrap(keys []models.DataKey) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, key := range keys {
+			err := tx.Model(&models.DataKey{}).Where("id = ?", key.ID).
+				Updates(map[string]interface{}{
+					"master_key_id": key.MasterKeyID,
+					"wrapped_key":   key.WrappedKey,
+					"updated_at":    time.Now(),
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Usage menghitung nilai terenkripsi per data key di setiap kolom, termasuk
+// baris yang sudah di-soft delete. Data key tanpa pemakaian boleh dihapus
+// setelah enkripsi ulang.
+func (r *encryptionRepository) Usage() ([]DataKeyUsage, error) {
+	var usage []DataKeyUsage
+	for _, t := range encryptedColumns {
+		for _, column := range t.Columns {
+			var rows []DataKeyUsage
+			keyID := "CASE WHEN " + column + " LIKE 'enc:v1:%' THEN split_part(" + column + ", ':', 3) ELSE '' END"
+			err := r.db.Table(t.Table).
+				Select(keyID + " AS data_key_id, count(*) AS count").
+				Where(column + " IS NOT NULL AND " + column + " <> ''").
+				Group("data_key_id").Order("data_key_id").
+				Scan(&rows).Error
+			if err != nil {
+				return nil, err
+			}
+			for _, row := range rows {
+				row.Table, row.Column = t.Table, column
+				usage = append(usage, row)
+			}
+		}
+	}
+	return usage, nil
+}
+
+// Reencrypt menulis ulang semua kolom terenkripsi dengan data key aktif
+// (termasuk data lama yang masih plaintext) dan menghitung ulang blind index
+// NIK. Baris yang berubah di tengah proses dilewati karena sudah ditulis
+// ulang oleh update tersebut. Mengembalikan jumlah baris yang ditulis ulang.
+func (r *encryptionRepository) Reencrypt(index BlindIndexer) (int64, error) {
+	var total int64
+
+	n, err := reencryptRows(r.db, encryptedColumns[0].Columns, func(p *models.Patient) (string, time.Time) {
+		if nikIndex := index.BlindIndex(p.NIK); nikIndex != "" {
+			p.NIKIndex = &nikIndex
+		} else {
+			p.NIKIndex = nil
+		}
+		return p.ID, p.UpdatedAt
+	}, "nik_index")
+	total += n
+	if err != nil {
+		return total, err
+	}
+
+	n, err = reencryptRows(r.db, encryptedColumns[1].Columns, func(m *models.MedicalRecord) (string, time.Time) {
+		return m.ID, m.UpdatedAt
+	})
+	total += n
+	if err != nil {
+		return total, err
+	}
+
+	n, err = reencryptRows(r.db, encryptedColumns[2].Columns, func(a *models.Assessment) (string, time.Time) {
+		return a.ID, a.UpdatedAt
+	})
+	total += n
+	if err != nil {
+		return total, err
+	}
+
+	n, err = reencryptRows(r.db, encryptedColumns[3].Columns, func(c *models.PatientContact) (string, time.Time) {
+		return c.ID, c.UpdatedAt
+	})
+	total += n
+	return total, err
+}
+
+// reencryptRows membaca baris per batch (serializer mendekripsi), lalu
+// menulis ulang kolom terenkripsi (serializer mengenkripsi dengan data key
+// aktif). prepare mengembalikan ID dan updated_at baris untuk update bersyarat.
+func reencryptRows[T any](db *gorm.DB, columns []string, prepare func(row *T) (string, time.Time), extra ...string) (int64, error) {
+	var total int64
+	var batch []T
+	selected := append(append([]string{}, columns...), extra...)
+
+	result := db.Unscoped().Model(new(T)).FindInBatches(&batch, reencryptBatchSize, func(_ *gorm.DB, _ int) error {
+		for i := range batch {
+			id, updatedAt := prepare(&batch[i])
+			result := db.Unscoped().Model(new(T)).
+				Where("id = ? AND updated_at IS NOT DISTINCT FROM ?", id, updatedAt).
+				Select(selected).UpdateColumns(&batch[i])
+			if result.Error != nil {
+				return result.Error
+			}
+			total += result.RowsAffected
+		}
+		return nil
+	})
+	return total, result.Error
+}
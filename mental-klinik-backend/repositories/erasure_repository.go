@@ -0,0 +1,314 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+var (
+	// ErrErasureRequestChanged dikembalikan saat permintaan yang akan
+	// disetujui atau ditolak sudah tidak pending lagi
+	ErrErasureRequestChanged = errors.New("erasure request has already been reviewed")
+	// ErrPatientOnLegalHold dikembalikan Execute jika pasien masih memiliki
+	// legal hold aktif saat penghapusan akan dijalankan
+	ErrPatientOnLegalHold = errors.New("patient is under an active legal hold")
+)
+
+// ErasureRequestListSpec adalah kolom permintaan penghapusan yang boleh
+// difilter dan di-sort
+var ErasureRequestListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "patientId", Column: "patient_id", Ops: listquery.EqualityOps},
+		{Name: "mode", Column: "mode", Ops: listquery.EqualityOps},
+		{Name: "status", Column: "status", Ops: listquery.EqualityOps},
+		{Name: "requestedById", Column: "requested_by_id", Ops: listquery.EqualityOps},
+		{Name: "requestedAt", Column: "requested_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "completedAt", Column: "completed_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps, Nullable: true},
+	},
+	DefaultSort: "-requestedAt",
+}
+
+// ErasureResult adalah jumlah baris per tabel yang dihapus, dianonimkan dan
+// disimpan (karena kewajiban hukum) oleh satu penghapusan
+type ErasureResult struct {
+	Erased     map[string]int64
+	Anonymized map[string]int64
+	Retained   map[string]int64
+}
+
+// ErasureCertifier menyusun sertifikat penyelesaian dari hasil penghapusan.
+// Dipanggil di dalam transaksi sehingga sertifikat tersimpan bersama
+// perubahan datanya.
+type ErasureCertifier func(result ErasureResult) (certificate []byte, hash string, err error)
+
+type ErasureRepository interface {
+	Create(request *models.ErasureRequest) error
+	FindByID(id string) (*models.ErasureRequest, error)
+	FindAll(query listquery.Query) ([]models.ErasureRequest, listquery.Page, error)
+	FindByPatient(patientID string, status string) (*models.ErasureRequest, error)
+	Reject(id string, reviewedBy string, notes string, at time.Time) error
+	Execute(request *models.ErasureRequest, reviewedBy string, notes string, at time.Time, certify ErasureCertifier) error
+}
+
+type erasureRepository struct {
+	db *gorm.DB
+}
+
+func NewErasureRepository(db *gorm.DB) ErasureRepository {
+	return &erasureRepository{db: db}
+}
+
+func (r *erasureRepository) Create(request *models.ErasureRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *erasureRepository) FindByID(id string) (*models.ErasureRequest, error) {
+	var request models.ErasureRequest
+	if err := r.db.First(&request, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &request, nil
+}
+
+func (r *erasureRepository) FindAll(query listquery.Query) ([]models.ErasureRequest, listquery.Page, error) {
+	return listquery.Find[models.ErasureRequest](r.db.Model(&models.ErasureRequest{}), &query)
+}
+
+// FindByPatient mengambil permintaan terbaru pasien dengan status tertentu
+func (r *erasureRepository) FindByPatient(patientID string, status string) (*models.ErasureRequest, error) {
+	var request models.ErasureRequest
+	err := r.db.Where("patient_id = ? AND status = ?", patientID, status).
+		Order("requested_at DESC").First(&request).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &request, nil
+}
+
+func (r *erasureRepository) Reject(id string, reviewedBy string, notes string, at time.Time) error {
+	return r.review(r.db, id, map[string]interface{}{
+		"status":         models.ErasureRejected,
+		"reviewed_by_id": reviewedBy,
+		"reviewed_at":    at,
+		"review_notes":   notes,
+		"updated_at":     at,
+	})
+}
+
+// Execute menjalankan penghapusan atau anonimisasi dalam satu transaksi.
+// Baris pasien dikunci dan legal hold dicek ulang di dalam transaksi supaya
+// hold yang dipasang bersamaan tidak terlewat.
+//
+// Kedua mode: identitas pasien dikosongkan (nama diganti, NIK, telepon,
+// alamat dan kontak darurat dihapus, tanggal lahir dibulatkan ke tahun),
+// pasien di-soft delete, bundle export dihapus, persetujuan aktif ditarik dan
+// catatan bebas dikosongkan, penugasan tim perawatan diakhiri.
+// Mode erase juga menghapus permanen appointment, asesmen, prediksi dan
+// penugasan tim perawatan. Rekam medis (wajib disimpan, Permenkes 24/2022),
+// audit log, akses darurat dan riwayat persetujuan selalu disimpan.
+func (r *erasureRepository) Execute(request *models.ErasureRequest, reviewedBy string, notes string, at time.Time, certify ErasureCertifier) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockPatient(tx, request.PatientID); err != nil {
+			return err
+		}
+		holds, err := countActiveHolds(tx, request.PatientID)
+		if err != nil {
+			return err
+		}
+		if holds > 0 {
+			return ErrPatientOnLegalHold
+		}
+
+		result := ErasureResult{
+			Erased:     map[string]int64{},
+			Anonymized: map[string]int64{},
+			Retained:   map[string]int64{},
+		}
+		if err := erasePatientData(tx, request, reviewedBy, at, &result); err != nil {
+			return err
+		}
+		if err := countRetained(tx, request.PatientID, &result); err != nil {
+			return err
+		}
+
+		certificate, hash, err := certify(result)
+		if err != nil {
+			return err
+		}
+		return r.review(tx, request.ID, map[string]interface{}{
+			"status":           models.ErasureCompleted,
+			"reviewed_by_id":   reviewedBy,
+			"reviewed_at":      at,
+			"review_notes":     notes,
+			"completed_at":     at,
+			"certificate":      string(certificate),
+			"certificate_hash": hash,
+			"updated_at":       at,
+		})
+	})
+}
+
+func erasePatientData(tx *gorm.DB, request *models.ErasureRequest, reviewedBy string, at time.Time, result *ErasureResult) error {
+	patientID := request.PatientID
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+
+	exports := tx.Where("patient_id = ?", patientID).Delete(&models.DataExport{})
+	if exports.Error != nil {
+		return exports.Error
+	}
+	result.Erased["data_exports"] = exports.RowsAffected
+
+	consents := tx.Model(&models.PatientConsent{}).
+		Where("patient_id = ?", patientID).
+		Updates(map[string]interface{}{"notes": "", "updated_at": at})
+	if consents.Error != nil {
+		return consents.Error
+	}
+	withdrawn := tx.Model(&models.PatientConsent{}).
+		Where("patient_id = ? AND withdrawn_at IS NULL", patientID).
+		Updates(map[string]interface{}{
+			"withdrawn_at":      at,
+			"withdrawn_by_id":   reviewedBy,
+			"withdrawal_reason": "erasure:" + request.ID,
+			"updated_at":        at,
+		})
+	if withdrawn.Error != nil {
+		return withdrawn.Error
+	}
+	result.Anonymized["patient_consents"] = consents.RowsAffected
+
+	if request.Mode == models.ErasureModeErase {
+		// Prediksi dihapus sebelum asesmen karena foreign key
+		assessmentIDs := tx.Unscoped().Model(&models.Assessment{}).Select("id").Where("patient_id = ?", patientID)
+		steps := []struct {
+			table  string
+			delete func() *gorm.DB
+		}{
+			{"predictions", func() *gorm.DB {
+				return tx.Unscoped().Where("assessment_id IN (?)", assessmentIDs).Delete(&models.Prediction{})
+			}},
+			{"assessments", func() *gorm.DB {
+				return tx.Unscoped().Where("patient_id = ?", patientID).Delete(&models.Assessment{})
+			}},
+			{"appointments", func() *gorm.DB {
+				return tx.Unscoped().Where("patient_id = ?", patientID).Delete(&models.Appointment{})
+			}},
+			{"care_assignments", func() *gorm.DB {
+				return tx.Where("patient_id = ?", patientID).Delete(&models.CareAssignment{})
+			}},
+		}
+		for _, step := range steps {
+			deleted := step.delete()
+			if deleted.Error != nil {
+				return deleted.Error
+			}
+			result.Erased[step.table] = deleted.RowsAffected
+		}
+	} else {
+		appointments := tx.Unscoped().Model(&models.Appointment{}).
+			Where("patient_id = ?", patientID).
+			Updates(map[string]interface{}{"notes": "", "updated_at": at})
+		if appointments.Error != nil {
+			return appointments.Error
+		}
+		result.Anonymized["appointments"] = appointments.RowsAffected
+
+		ended := tx.Model(&models.CareAssignment{}).
+			Where("patient_id = ? AND (end_date IS NULL OR end_date > ?)", patientID, day).
+			Updates(map[string]interface{}{
+				"end_date":   day,
+				"end_reason": "erasure:" + request.ID,
+				"updated_at": at,
+			})
+		if ended.Error != nil {
+			return ended.Error
+		}
+		result.Anonymized["care_assignments"] = ended.RowsAffected
+	}
+
+	if err := anonymizePatient(tx, patientID, at); err != nil {
+		return err
+	}
+	result.Anonymized["patients"] = 1
+	return nil
+}
+
+// anonymizePatient menghapus identitas pasien: nama diganti, NIK, telepon dan
+// alamat dikosongkan, kontak darurat dihapus, tanggal lahir dibulatkan ke
+// tahun. Pasien yang belum dihapus ikut di-soft delete.
+func anonymizePatient(tx *gorm.DB, patientID string, at time.Time) error {
+	var patient models.Patient
+	if err := tx.Unscoped().Select("birth_date", "deleted_at").First(&patient, "id = ?", patientID).Error; err != nil {
+		return translateError(err)
+	}
+	values := map[string]interface{}{
+		"full_name":         "Pasien Anonim",
+		"nik":               "",
+		"nik_index":         nil,
+		"phone":             "",
+		"address":           "",
+		"birth_date":        birthYear(patient.BirthDate),
+		"birth_date_legacy": nil,
+		"anonymized_at":     at,
+		"updated_at":        at,
+	}
+	if !patient.DeletedAt.Valid {
+		values["deleted_at"] = at
+	}
+	if err := tx.Where("patient_id = ?", patientID).Delete(&models.PatientContact{}).Error; err != nil {
+		return err
+	}
+	// Lewat Table supaya nilai kosong tidak melewati serializer enkripsi
+	return tx.Table("patients").Where("id = ?", patientID).Updates(values).Error
+}
+
+// countRetained menghitung data yang tetap disimpan setelah penghapusan
+func countRetained(tx *gorm.DB, patientID string, result *ErasureResult) error {
+	retained := []struct {
+		table string
+		query *gorm.DB
+	}{
+		{"medical_records", tx.Unscoped().Model(&models.MedicalRecord{})},
+		{"audit_logs", tx.Model(&models.AuditLog{})},
+		{"emergency_accesses", tx.Model(&models.EmergencyAccess{})},
+		{"patient_consents", tx.Model(&models.PatientConsent{})},
+		{"legal_holds", tx.Model(&models.LegalHold{})},
+	}
+	for _, item := range retained {
+		var count int64
+		if err := item.query.Where("patient_id = ?", patientID).Count(&count).Error; err != nil {
+			return err
+		}
+		result.Retained[item.table] = count
+	}
+	return nil
+}
+
+// birthYear menyisakan tahun lahir (YYYY-01-01) untuk statistik usia
+func birthYear(birthDate *time.Time) *time.Time {
+	if birthDate == nil {
+		return nil
+	}
+	year := time.Date(birthDate.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	return &year
+}
+
+// review menyelesaikan permintaan yang masih pending
+func (r *erasureRepository) review(db *gorm.DB, id string, values map[string]interface{}) error {
+	result := db.Model(&models.ErasureRequest{}).
+		Where("id = ? AND status = ?", id, models.ErasurePending).
+		Updates(values)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrErasureRequestChanged
+	}
+	return nil
+}
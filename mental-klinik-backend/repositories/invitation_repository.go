@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// ErrInvitationUnavailable dikembalikan AcceptInvitation jika undangan sudah
+// dipakai, dicabut atau expired (termasuk oleh request lain yang bersamaan)
+var ErrInvitationUnavailable = errors.New("invitation is no longer available")
+
+// InvitationListSpec adalah kolom undangan yang boleh difilter dan di-sort
+var InvitationListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "email", Column: "email", Sortable: true, Ops: listquery.TextOps},
+		{Name: "role", Column: "role", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "expiresAt", Column: "expires_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "createdAt", Column: "created_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "-createdAt",
+}
+
+type InvitationRepository interface {
+	Create(invitation *models.Invitation) error
+	FindByID(id string) (*models.Invitation, error)
+	FindByTokenHash(hash string) (*models.Invitation, error)
+	FindAll(query listquery.Query) ([]models.Invitation, listquery.Page, error)
+	AcceptInvitation(invitation *models.Invitation, user *models.User) error
+	Revoke(id string) error
+}
+
+type invitationRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationRepository(db *gorm.DB) InvitationRepository {
+	return &invitationRepository{db: db}
+}
+
+func (r *invitationRepository) Create(invitation *models.Invitation) error {
+	return r.db.Omit("CreatedBy").Create(invitation).Error
+}
+
+func (r *invitationRepository) FindByID(id string) (*models.Invitation, error) {
+	var invitation models.Invitation
+	if err := r.db.First(&invitation, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &invitation, nil
+}
+
+func (r *invitationRepository) FindByTokenHash(hash string) (*models.Invitation, error) {
+	var invitation models.Invitation
+	if err := r.db.First(&invitation, "token_hash = ?", hash).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &invitation, nil
+}
+
+func (r *invitationRepository) FindAll(query listquery.Query) ([]models.Invitation, listquery.Page, error) {
+	return listquery.Find[models.Invitation](r.db.Model(&models.Invitation{}), &query)
+}
+
+// AcceptInvitation menandai undangan terpakai dan membuat user-nya dalam satu
+// transaksi. Update bersyarat memastikan satu undangan hanya menghasilkan
+// satu akun.
+func (r *invitationRepository) AcceptInvitation(invitation *models.Invitation, user *models.User) error {
+	now := time.Now()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.Invitation{}).
+			Where("id = ? AND used_at IS NULL AND revoked_at IS NULL AND expires_at > ?", invitation.ID, now).
+			Updates(map[string]interface{}{"used_at": now, "used_by_id": user.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvitationUnavailable
+		}
+		return nil
+	})
+}
+
+func (r *invitationRepository) Revoke(id string) error {
+	return r.db.Model(&models.Invitation{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
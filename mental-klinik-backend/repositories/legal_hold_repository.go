@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// ErrLegalHoldChanged dikembalikan saat hold yang akan dilepas ternyata sudah
+// dilepas
+var ErrLegalHoldChanged = errors.New("legal hold has already been released")
+
+// LegalHoldListSpec adalah kolom legal hold yang boleh difilter dan di-sort
+var LegalHoldListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "patientId", Column: "patient_id", Ops: listquery.EqualityOps},
+		{Name: "placedById", Column: "placed_by_id", Ops: listquery.EqualityOps},
+		{Name: "placedAt", Column: "placed_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "releasedAt", Column: "released_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps, Nullable: true},
+	},
+	DefaultSort: "-placedAt",
+	Params:      []string{"active"},
+}
+
+// LegalHoldFilter menampung parameter list legal hold
+type LegalHoldFilter struct {
+	Active *bool
+	listquery.Query
+}
+
+type LegalHoldRepository interface {
+	Create(hold *models.LegalHold) error
+	FindByID(id string) (*models.LegalHold, error)
+	FindAll(filter LegalHoldFilter) ([]models.LegalHold, listquery.Page, error)
+	CountActive(patientID string) (int64, error)
+	Release(id string, releasedBy string, reason string, at time.Time) error
+}
+
+type legalHoldRepository struct {
+	db *gorm.DB
+}
+
+func NewLegalHoldRepository(db *gorm.DB) LegalHoldRepository {
+	return &legalHoldRepository{db: db}
+}
+
+// Create mengunci baris pasien dulu supaya tidak bisa bersamaan dengan
+// penghapusan yang sedang berjalan (lihat ErasureRepository.Execute)
+func (r *legalHoldRepository) Create(hold *models.LegalHold) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockPatient(tx, hold.PatientID); err != nil {
+			return err
+		}
+		return tx.Create(hold).Error
+	})
+}
+
+func (r *legalHoldRepository) FindByID(id string) (*models.LegalHold, error) {
+	var hold models.LegalHold
+	if err := r.db.First(&hold, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &hold, nil
+}
+
+func (r *legalHoldRepository) FindAll(filter LegalHoldFilter) ([]models.LegalHold, listquery.Page, error) {
+	tx := r.db.Model(&models.LegalHold{})
+	if filter.Active != nil {
+		if *filter.Active {
+			tx = tx.Where("released_at IS NULL")
+		} else {
+			tx = tx.Where("released_at IS NOT NULL")
+		}
+	}
+	return listquery.Find[models.LegalHold](tx, &filter.Query)
+}
+
+func (r *legalHoldRepository) CountActive(patientID string) (int64, error) {
+	return countActiveHolds(r.db, patientID)
+}
+
+func (r *legalHoldRepository) Release(id string, releasedBy string, reason string, at time.Time) error {
+	result := r.db.Model(&models.LegalHold{}).
+		Where("id = ? AND released_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"released_at":    at,
+			"released_by_id": releasedBy,
+			"release_reason": reason,
+			"updated_at":     at,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrLegalHoldChanged
+	}
+	return nil
+}
+
+func countActiveHolds(db *gorm.DB, patientID string) (int64, error) {
+	var count int64
+	err := db.Model(&models.LegalHold{}).
+		Where("patient_id = ? AND released_at IS NULL", patientID).
+		Count(&count).Error
+	return count, err
+}
+
+// lockPatient mengunci baris pasien (termasuk yang sudah di-soft delete)
+// sampai transaksi selesai
+func lockPatient(tx *gorm.DB, patientID string) error {
+	var patient models.Patient
+	err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").First(&patient, "id = ?", patientID).Error
+	return translateError(err)
+}
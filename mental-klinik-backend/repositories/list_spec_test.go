@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// jsonField mencari field struct dengan nama JSON name, termasuk field dari
+// struct yang di-embed
+func jsonField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if found, ok := jsonField(field.Type, name); ok {
+				return found, true
+			}
+			continue
+		}
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// Cursor dibentuk dari nilai sort key di JSON baris, sehingga setiap kolom
+// sortable spec cursor harus ada di model dan tidak boleh NULL kecuali
+// ditandai Nullable
+func TestCursorSpecSortFields(t *testing.T) {
+	tests := []struct {
+		name string
+		spec listquery.Spec
+		row  any
+	}{
+		{"patients", PatientListSpec, models.Patient{}},
+		{"legacy birth dates", PatientLegacyBirthDateSpec, models.Patient{}},
+		{"appointments", AppointmentListSpec, models.Appointment{}},
+		{"appointment history", AppointmentHistorySpec, models.Appointment{}},
+		{"assessments", AssessmentListSpec, models.Assessment{}},
+		{"predictions", PredictionListSpec, models.Prediction{}},
+		{"medical records", MedicalRecordListSpec, models.MedicalRecord{}},
+		{"timeline", PatientTimelineSpec, models.TimelineEvent{}},
+		{"duplicate candidates", DuplicateCandidateListSpec, models.DuplicateCandidate{}},
+		{"patient merges", PatientMergeListSpec, models.PatientMerge{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.spec.Cursor {
+				t.Fatal("spec does not support cursor paging")
+			}
+			for _, field := range tt.spec.Fields {
+				if !field.Sortable || field.Nullable {
+					continue
+				}
+				structField, ok := jsonField(reflect.TypeOf(tt.row), field.Name)
+				if !ok {
+					t.Errorf("sort field %s is not in the row JSON", field.Name)
+					continue
+				}
+				if structField.Type.Kind() == reflect.Pointer || structField.Type.Name() == "DeletedAt" {
+					t.Errorf("sort field %s can be NULL but is not marked Nullable", field.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestTrashSpec(t *testing.T) {
+	spec := TrashSpec(AppointmentListSpec)
+	if len(spec.Fields) != len(AppointmentListSpec.Fields)+1 {
+		t.Fatalf("TrashSpec has %d fields, want %d", len(spec.Fields), len(AppointmentListSpec.Fields)+1)
+	}
+	deletedAt := spec.Fields[len(spec.Fields)-1]
+	if deletedAt.Name != "deletedAt" || deletedAt.Column != "appointments.deleted_at" || !deletedAt.Sortable {
+		t.Errorf("deletedAt = %+v", deletedAt)
+	}
+	if spec.DefaultSort != "-deletedAt" || spec.Cursor || len(spec.Params) != 0 {
+		t.Errorf("TrashSpec = %+v", spec)
+	}
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// MedicalRecordListSpec adalah kolom rekam medis yang boleh difilter dan di-sort
+var MedicalRecordListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "patientId", Column: "patient_id", Ops: listquery.EqualityOps},
+		{Name: "userId", Column: "user_id", Ops: listquery.EqualityOps},
+		{Name: "createdAt", Column: "created_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "updatedAt", Column: "updated_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "-createdAt",
+	Cursor:      true,
+}
+
+// MedicalRecordTrashSpec adalah kolom trash rekam medis yang boleh difilter dan di-sort
+var MedicalRecordTrashSpec = TrashSpec(MedicalRecordListSpec)
+
+// MedicalRecordFilter menampung parameter list rekam medis (filter, sort, paging)
+type MedicalRecordFilter struct {
+	CareTeamOf         string // jika terisi, hanya pasien di tim perawatan user ini
+	EmergencyPatientID string // pasien akses darurat (break-the-glass) yang ikut terlihat
+	listquery.Query
+}
+
+type MedicalRecordRepository interface {
+	Create(record *models.MedicalRecord) error
+	FindByID(id string) (*models.MedicalRecord, error)
+	FindAll(filter MedicalRecordFilter) ([]models.MedicalRecord, listquery.Page, error)
+	Update(record *models.MedicalRecord) error
+	Delete(record *models.MedicalRecord) error
+	FindDeleted(query listquery.Query) ([]models.MedicalRecord, listquery.Page, error)
+	Restore(id string) (*models.MedicalRecord, RestoreResult, error)
+}
+
+type medicalRecordRepository struct {
+	db *gorm.DB
+}
+
+func NewMedicalRecordRepository(db *gorm.DB) MedicalRecordRepository {
+	return &medicalRecordRepository{db: db}
+}
+
+func (r *medicalRecordRepository) Create(record *models.MedicalRecord) error {
+	return r.db.Create(record).Error
+}
+
+func (r *medicalRecordRepository) FindByID(id string) (*models.MedicalRecord, error) {
+	var record models.MedicalRecord
+	if err := r.db.Preload("Patient").Preload("User", unscoped).First(&record, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &record, nil
+}
+
+func (r *medicalRecordRepository) FindAll(filter MedicalRecordFilter) ([]models.MedicalRecord, listquery.Page, error) {
+	query := r.db.Model(&models.MedicalRecord{}).Preload("Patient").Preload("User", unscoped)
+	if filter.CareTeamOf != "" {
+		query = query.Where("patient_id IN (?)", scopedPatientIDs(r.db, filter.CareTeamOf, filter.EmergencyPatientID))
+	}
+	return listquery.Find[models.MedicalRecord](query, &filter.Query)
+}
+
+func (r *medicalRecordRepository) Update(record *models.MedicalRecord) error {
+	return r.db.Omit(clause.Associations).Save(record).Error
+}
+
+func (r *medicalRecordRepository) Delete(record *models.MedicalRecord) error {
+	return r.db.Delete(record).Error
+}
+
+func (r *medicalRecordRepository) FindDeleted(query listquery.Query) ([]models.MedicalRecord, listquery.Page, error) {
+	tx := r.db.Model(&models.MedicalRecord{}).Preload("Patient").Preload("User")
+	return findDeleted[models.MedicalRecord](tx, "medical_records", &query)
+}
+
+// Restore memulihkan rekam medis dari trash jika pasiennya masih aktif.
+// Penulisnya boleh sudah dihapus (rekam medis tetap milik pasien).
+func (r *medicalRecordRepository) Restore(id string) (*models.MedicalRecord, RestoreResult, error) {
+	var record models.MedicalRecord
+	result := RestoreResult{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockDeleted(tx, &record, id); err != nil {
+			return err
+		}
+		if err := requireActivePatient(tx, record.PatientID); err != nil {
+			return err
+		}
+		return restoreRows(tx.Where("id = ?", id), &models.MedicalRecord{}, "medical_records", result)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	record.DeletedAt = gorm.DeletedAt{}
+	return &record, result, nil
+}
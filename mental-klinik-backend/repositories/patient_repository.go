@@ -0,0 +1,242 @@
+package repositories
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// PatientListSpec adalah kolom pasien yang boleh difilter dan di-sort
+var PatientListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "fullName", Column: "full_name", Sortable: true, Ops: listquery.TextOps},
+		{Name: "gender", Column: "gender", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "birthDate", Column: "birth_date", Type: listquery.Time, Ops: listquery.RangeOps},
+		{Name: "createdAt", Column: "created_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "updatedAt", Column: "updated_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "-createdAt",
+	Params:      []string{"search", "minAge", "maxAge"},
+	Cursor:      true,
+}
+
+// PatientTrashSpec adalah kolom trash pasien yang boleh difilter dan di-sort
+var PatientTrashSpec = TrashSpec(PatientListSpec)
+
+// PatientLegacyBirthDateSpec adalah kolom laporan tanggal lahir lama yang
+// gagal dikonversi
+var PatientLegacyBirthDateSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "fullName", Column: "full_name", Sortable: true, Ops: listquery.TextOps},
+		{Name: "createdAt", Column: "created_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "createdAt",
+	Cursor:      true,
+}
+
+// PatientFilter menampung parameter list pasien (search, filter, sort, paging)
+type PatientFilter struct {
+	Search             string
+	CareTeamOf         string // jika terisi, hanya pasien di tim perawatan user ini
+	EmergencyPatientID string // pasien akses darurat (break-the-glass) yang ikut terlihat
+	MinAge             *int   // usia minimal (tahun penuh), pasien tanpa tanggal lahir tidak ikut
+	MaxAge             *int   // usia maksimal (tahun penuh), pasien tanpa tanggal lahir tidak ikut
+	listquery.Query
+}
+
+type PatientRepository interface {
+	Create(patient *models.Patient) error
+	FindByID(id string) (*models.Patient, error)
+	FindByIDWithDeleted(id string) (*models.Patient, error)
+	FindByNIK(nik string) (*models.Patient, error)
+	FindAll(filter PatientFilter) ([]models.Patient, listquery.Page, error)
+	Update(patient *models.Patient) error
+	Delete(id string) (*DeleteResult, error)
+	FindDeleted(query listquery.Query) ([]models.Patient, listquery.Page, error)
+	Restore(id string, cascade bool) (*models.Patient, RestoreResult, error)
+	FindLegacyBirthDates(query listquery.Query) ([]models.Patient, listquery.Page, error)
+}
+
+// BlindIndexer menghitung blind index (hash berkunci) untuk pencarian exact
+// pada kolom terenkripsi, diimplementasikan oleh encryption.Keyring
+type BlindIndexer interface {
+	BlindIndex(value string) string
+}
+
+type patientRepository struct {
+	db    *gorm.DB
+	index BlindIndexer
+}
+
+func NewPatientRepository(db *gorm.DB, index BlindIndexer) PatientRepository {
+	return &patientRepository{db: db, index: index}
+}
+
+func (r *patientRepository) Create(patient *models.Patient) error {
+	patient.NIKIndex = r.nikIndex(patient.NIK)
+	return r.db.Create(patient).Error
+}
+
+func (r *patientRepository) FindByID(id string) (*models.Patient, error) {
+	var patient models.Patient
+	if err := r.db.Scopes(preloadContacts).First(&patient, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &patient, nil
+}
+
+// FindByIDWithDeleted juga mengembalikan pasien yang sudah di-soft delete
+// (datanya masih tersimpan, misalnya untuk export dan penghapusan data)
+func (r *patientRepository) FindByIDWithDeleted(id string) (*models.Patient, error) {
+	var patient models.Patient
+	if err := r.db.Unscoped().Scopes(preloadContacts).First(&patient, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &patient, nil
+}
+
+func (r *patientRepository) FindByNIK(nik string) (*models.Patient, error) {
+	var patient models.Patient
+	if err := r.db.Where(r.nikCondition(nik)).First(&patient).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &patient, nil
+}
+
+func (r *patientRepository) FindAll(filter PatientFilter) ([]models.Patient, listquery.Page, error) {
+	query := r.db.Model(&models.Patient{}).Scopes(preloadContacts)
+
+	// Search
+	if filter.Search != "" {
+		query = query.Where(r.db.Where("full_name ILIKE ?", "%"+filter.Search+"%").Or(r.nikCondition(filter.Search)))
+	}
+	if filter.CareTeamOf != "" {
+		query = query.Where("id IN (?)", scopedPatientIDs(r.db, filter.CareTeamOf, filter.EmergencyPatientID))
+	}
+
+	// Usia N tahun: lahir setelah hari ini N+1 tahun lalu dan paling lambat
+	// hari ini N tahun lalu
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if filter.MinAge != nil {
+		query = query.Where("birth_date <= ?", today.AddDate(-*filter.MinAge, 0, 0))
+	}
+	if filter.MaxAge != nil {
+		query = query.Where("birth_date > ?", today.AddDate(-*filter.MaxAge-1, 0, 0))
+	}
+
+	return listquery.Find[models.Patient](query, &filter.Query)
+}
+
+func (r *patientRepository) Update(patient *models.Patient) error {
+	patient.NIKIndex = r.nikIndex(patient.NIK)
+	return r.db.Omit(clause.Associations).Save(patient).Error
+}
+
+// Delete men-soft delete pasien beserta data klinisnya sesuai
+// PatientRelations dalam satu transaksi
+func (r *patientRepository) Delete(id string) (*DeleteResult, error) {
+	var result *DeleteResult
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		result, err = deleteWithRelations(tx, &models.Patient{}, id, PatientRelations, "")
+		return err
+	})
+	return result, err
+}
+
+// FindLegacyBirthDates mengembalikan pasien yang tanggal lahir lamanya (teks)
+// tidak bisa dikonversi ke kolom date dan masih menunggu dikoreksi
+func (r *patientRepository) FindLegacyBirthDates(query listquery.Query) ([]models.Patient, listquery.Page, error) {
+	return listquery.Find[models.Patient](r.db.Model(&models.Patient{}).Where("birth_date_legacy IS NOT NULL"), &query)
+}
+
+func (r *patientRepository) FindDeleted(query listquery.Query) ([]models.Patient, listquery.Page, error) {
+	return findDeleted[models.Patient](r.db.Model(&models.Patient{}).Scopes(preloadContacts), "patients", &query)
+}
+
+// Restore memulihkan pasien dari trash. NIK-nya tidak boleh sudah dipakai
+// pasien aktif lain, dan pasien yang sudah dianonimkan atau digabung ke
+// pasien lain tidak bisa dipulihkan.
+// Dengan cascade, appointment, assessment (beserta prediksinya) dan rekam
+// medis yang terhapus bersama pasien ikut dipulihkan.
+func (r *patientRepository) Restore(id string, cascade bool) (*models.Patient, RestoreResult, error) {
+	var patient models.Patient
+	result := RestoreResult{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockDeleted(tx, &patient, id); err != nil {
+			return err
+		}
+		if patient.AnonymizedAt != nil {
+			return restoreConflict("patient data has been anonymized")
+		}
+		if patient.MergedIntoID != nil {
+			return restoreConflict("patient was merged into %s, undo the merge instead", *patient.MergedIntoID)
+		}
+		var other models.Patient
+		err := tx.Where(r.nikCondition(patient.NIK)).Where("id <> ?", id).Select("id").First(&other).Error
+		if err == nil {
+			return restoreConflict("NIK is registered to active patient %s", other.ID)
+		}
+		if err = translateError(err); !errors.Is(err, ErrNotFound) {
+			return err
+		}
+
+		if err := restoreRows(tx.Where("id = ?", id), &models.Patient{}, "patients", result); err != nil {
+			return err
+		}
+		if !cascade {
+			return nil
+		}
+
+		children := tx.Where("patient_id = ?", id).Scopes(deletedWith(patient.DeletedAt.Time)).Session(&gorm.Session{})
+		if err := restoreRows(children, &models.Appointment{}, "appointments", result); err != nil {
+			return err
+		}
+		if err := restoreRows(children, &models.Assessment{}, "assessments", result); err != nil {
+			return err
+		}
+		if err := restoreRows(children, &models.MedicalRecord{}, "medical_records", result); err != nil {
+			return err
+		}
+		// Prediksi dari assessment pasien yang sekarang aktif
+		predictions := tx.Where("assessment_id IN (?)", tx.Model(&models.Assessment{}).Select("id").Where("patient_id = ?", id)).
+			Scopes(deletedWith(patient.DeletedAt.Time))
+		return restoreRows(predictions, &models.Prediction{}, "predictions", result)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	patient.DeletedAt = gorm.DeletedAt{}
+	if err := r.db.Scopes(orderContacts).Where("patient_id = ?", id).Find(&patient.Contacts).Error; err != nil {
+		return nil, nil, err
+	}
+	return &patient, result, nil
+}
+
+func (r *patientRepository) nikIndex(nik string) *string {
+	if index := r.index.BlindIndex(nik); index != "" {
+		return &index
+	}
+	return nil
+}
+
+// nikCondition mencari NIK lewat blind index (NIK disimpan terenkripsi, jadi
+// hanya exact match). Baris lama yang belum dienkripsi ulang (nik_index masih
+// NULL) dicocokkan dengan nilai plaintext-nya.
+func (r *patientRepository) nikCondition(nik string) *gorm.DB {
+	return matchNIK(r.db, r.index, nik)
+}
+
+func matchNIK(db *gorm.DB, index BlindIndexer, nik string) *gorm.DB {
+	return db.Where("nik_index = ?", index.BlindIndex(nik)).
+		Or("nik_index IS NULL AND nik = ?", strings.TrimSpace(nik))
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	_ "mental-klinik-backend/encryption"
+	"mental-klinik-backend/models"
+)
+
+// prefixIndex adalah BlindIndexer palsu yang hasilnya mudah dibaca
+type prefixIndex string
+
+func (p prefixIndex) BlindIndex(value string) string {
+	if value = strings.TrimSpace(value); value != "" {
+		return string(p) + value
+	}
+	return ""
+}
+
+// dryRunDB membentuk SQL tanpa koneksi database
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestPatientCreateSetsNIKIndex(t *testing.T) {
+	repo := NewPatientRepository(dryRunDB(t), prefixIndex("k1:"))
+
+	patient := &models.Patient{ID: "patient-001-aaaaaaaa", NIK: " 3201011508900001 "}
+	if err := repo.Create(patient); err != nil {
+		t.Fatal(err)
+	}
+	if patient.NIKIndex == nil || *patient.NIKIndex != "k1:3201011508900001" {
+		t.Errorf("NIKIndex = %v", patient.NIKIndex)
+	}
+
+	// Blind index sama untuk NIK yang sama, berbeda untuk key lain
+	again := &models.Patient{ID: "patient-002-bbbbbbbb", NIK: "3201011508900001"}
+	_ = repo.Create(again)
+	other := &models.Patient{ID: "patient-003-cccccccc", NIK: "3201011508900001"}
+	_ = NewPatientRepository(dryRunDB(t), prefixIndex("k2:")).Create(other)
+	if *again.NIKIndex != *patient.NIKIndex || *other.NIKIndex == *patient.NIKIndex {
+		t.Errorf("NIKIndex = %s, %s, %s", *patient.NIKIndex, *again.NIKIndex, *other.NIKIndex)
+	}
+
+	noNIK := &models.Patient{ID: "patient-004-dddddddd"}
+	_ = repo.Create(noNIK)
+	if noNIK.NIKIndex != nil {
+		t.Errorf("NIKIndex without NIK = %s, want NULL", *noNIK.NIKIndex)
+	}
+}
+
+func TestMatchNIK(t *testing.T) {
+	db := dryRunDB(t)
+	var patient models.Patient
+	stmt := db.Where(matchNIK(db, prefixIndex("k1:"), " 3201011508900001 ")).Where("id <> ?", "patient-001-aaaaaaaa").First(&patient).Statement
+
+	// Baris lama tanpa blind index dicocokkan dengan plaintext, dan kondisi
+	// lain tetap berlaku untuk kedua cabang
+	want := `WHERE (nik_index = $1 OR (nik_index IS NULL AND nik = $2)) AND id <> $3`
+	if sql := stmt.SQL.String(); !strings.Contains(sql, want) {
+		t.Errorf("SQL = %s, want %s", sql, want)
+	}
+	if vars := stmt.Vars[:3]; !reflect.DeepEqual(vars, []any{"k1:3201011508900001", "3201011508900001", "patient-001-aaaaaaaa"}) {
+		t.Errorf("vars = %v", vars)
+	}
+}
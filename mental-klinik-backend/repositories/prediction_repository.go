@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// PredictionListSpec adalah kolom prediksi yang boleh difilter dan di-sort
+var PredictionListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "assessmentId", Column: "assessment_id", Ops: listquery.EqualityOps},
+		{Name: "resultLabel", Column: "result_label", Sortable: true, Ops: listquery.TextOps},
+		{Name: "probabilityScore", Column: "probability_score", Type: listquery.Number, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "createdAt", Column: "created_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "updatedAt", Column: "updated_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "-createdAt",
+	Cursor:      true,
+}
+
+// PredictionTrashSpec adalah kolom trash prediksi yang boleh difilter dan di-sort
+var PredictionTrashSpec = TrashSpec(PredictionListSpec)
+
+// PredictionFilter menampung parameter list prediksi (filter, sort, paging)
+type PredictionFilter struct {
+	CareTeamOf         string // jika terisi, hanya pasien di tim perawatan user ini
+	EmergencyPatientID string // pasien akses darurat (break-the-glass) yang ikut terlihat
+	listquery.Query
+}
+
+type PredictionRepository interface {
+	Create(prediction *models.Prediction) error
+	FindByID(id string) (*models.Prediction, error)
+	FindByAssessmentID(assessmentID string) (*models.Prediction, error)
+	FindAll(filter PredictionFilter) ([]models.Prediction, listquery.Page, error)
+	Update(prediction *models.Prediction) error
+	Delete(prediction *models.Prediction) error
+	FindDeleted(query listquery.Query) ([]models.Prediction, listquery.Page, error)
+	Restore(id string) (*models.Prediction, RestoreResult, error)
+}
+
+type predictionRepository struct {
+	db *gorm.DB
+}
+
+func NewPredictionRepository(db *gorm.DB) PredictionRepository {
+	return &predictionRepository{db: db}
+}
+
+func (r *predictionRepository) Create(prediction *models.Prediction) error {
+	return r.db.Create(prediction).Error
+}
+
+func (r *predictionRepository) FindByID(id string) (*models.Prediction, error) {
+	var prediction models.Prediction
+	if err := r.db.Preload("Assessment").First(&prediction, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &prediction, nil
+}
+
+func (r *predictionRepository) FindByAssessmentID(assessmentID string) (*models.Prediction, error) {
+	var prediction models.Prediction
+	if err := r.db.Preload("Assessment").Where("assessment_id = ?", assessmentID).First(&prediction).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &prediction, nil
+}
+
+func (r *predictionRepository) FindAll(filter PredictionFilter) ([]models.Prediction, listquery.Page, error) {
+	tx := r.db.Model(&models.Prediction{}).Preload("Assessment")
+	if filter.CareTeamOf != "" {
+		tx = tx.Where("assessment_id IN (?)", r.db.Model(&models.Assessment{}).Select("id").
+			Where("patient_id IN (?)", scopedPatientIDs(r.db, filter.CareTeamOf, filter.EmergencyPatientID)))
+	}
+	return listquery.Find[models.Prediction](tx, &filter.Query)
+}
+
+func (r *predictionRepository) Update(prediction *models.Prediction) error {
+	return r.db.Omit(clause.Associations).Save(prediction).Error
+}
+
+func (r *predictionRepository) Delete(prediction *models.Prediction) error {
+	return r.db.Delete(prediction).Error
+}
+
+func (r *predictionRepository) FindDeleted(query listquery.Query) ([]models.Prediction, listquery.Page, error) {
+	tx := r.db.Model(&models.Prediction{}).Preload("Assessment")
+	return findDeleted[models.Prediction](tx, "predictions", &query)
+}
+
+// Restore memulihkan prediksi dari trash. Assessment-nya harus masih aktif dan
+// belum punya prediksi lain (satu assessment satu prediksi).
+func (r *predictionRepository) Restore(id string) (*models.Prediction, RestoreResult, error) {
+	var prediction models.Prediction
+	result := RestoreResult{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockDeleted(tx, &prediction, id); err != nil {
+			return err
+		}
+		var assessment models.Assessment
+		err := tx.First(&assessment, "id = ?", prediction.AssessmentID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return restoreConflict("assessment %s is deleted, restore the assessment first", prediction.AssessmentID)
+		}
+		if err != nil {
+			return err
+		}
+		prediction.Assessment = &assessment
+
+		taken, err := isActive(tx, &models.Prediction{}, "assessment_id = ?", prediction.AssessmentID)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return restoreConflict("assessment %s already has another prediction", prediction.AssessmentID)
+		}
+		return restoreRows(tx.Where("id = ?", id), &models.Prediction{}, "predictions", result)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	prediction.DeletedAt = gorm.DeletedAt{}
+	return &prediction, result, nil
+}
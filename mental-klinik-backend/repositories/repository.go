@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound dikembalikan oleh semua repository ketika data tidak ditemukan,
+// sehingga service tidak perlu bergantung pada error milik GORM.
+var ErrNotFound = errors.New("record not found")
+
+// translateError mengubah gorm.ErrRecordNotFound menjadi ErrNotFound
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,330 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// ErrRetentionRunning dikembalikan saat run retensi lain masih berjalan
+var ErrRetentionRunning = errors.New("another retention run is still running")
+
+// RetentionRunListSpec adalah kolom riwayat run retensi yang boleh difilter
+// dan di-sort
+var RetentionRunListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "trigger", Column: "trigger", Ops: listquery.EqualityOps},
+		{Name: "status", Column: "status", Ops: listquery.EqualityOps},
+		{Name: "reportRunId", Column: "report_run_id", Ops: listquery.EqualityOps},
+		{Name: "startedAt", Column: "started_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "-startedAt",
+	Params:      []string{"dryRun"},
+}
+
+// RetentionRunFilter menampung parameter riwayat run retensi
+type RetentionRunFilter struct {
+	DryRun *bool
+	listquery.Query
+}
+
+// RetentionRule adalah satu kebijakan retensi dengan batas waktu yang sudah
+// dihitung: baris yang dasar waktunya sebelum Cutoff memenuhi syarat
+type RetentionRule struct {
+	Entity string
+	Action string
+	Basis  string
+	Cutoff time.Time
+}
+
+// RetentionPreview adalah hasil dry-run satu kebijakan
+type RetentionPreview struct {
+	Eligible  int64
+	OnHold    int64
+	SampleIDs []string
+}
+
+type RetentionRepository interface {
+	CreateRun(run *models.RetentionRun) error
+	FinishRun(run *models.RetentionRun) error
+	FailInterrupted(at time.Time) (int64, error)
+	FindRunByID(id string) (*models.RetentionRun, error)
+	FindRuns(filter RetentionRunFilter) ([]models.RetentionRun, listquery.Page, error)
+	Preview(rule RetentionRule, samples int) (*RetentionPreview, error)
+	Apply(rule RetentionRule, batchSize int, at time.Time) (int64, error)
+}
+
+type retentionRepository struct {
+	db *gorm.DB
+}
+
+func NewRetentionRepository(db *gorm.DB) RetentionRepository {
+	return &retentionRepository{db: db}
+}
+
+// CreateRun menyimpan run baru dengan status running jika tidak ada run lain
+// yang sedang berjalan. Index unik uni_retention_runs_running menjaga hal
+// yang sama antar instance.
+func (r *retentionRepository) CreateRun(run *models.RetentionRun) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var running int64
+		if err := tx.Model(&models.RetentionRun{}).Where("status = ?", models.RetentionRunning).Count(&running).Error; err != nil {
+			return err
+		}
+		if running > 0 {
+			return ErrRetentionRunning
+		}
+		return tx.Create(run).Error
+	})
+}
+
+func (r *retentionRepository) FinishRun(run *models.RetentionRun) error {
+	return r.db.Model(&models.RetentionRun{}).Where("id = ?", run.ID).
+		Updates(map[string]interface{}{
+			"status":      run.Status,
+			"error":       run.Error,
+			"finished_at": run.FinishedAt,
+			"results":     run.Results,
+			"updated_at":  time.Now(),
+		}).Error
+}
+
+// FailInterrupted menandai run yang masih running sebagai gagal. Dipanggil
+// saat startup: run tersebut terputus karena server berhenti.
+func (r *retentionRepository) FailInterrupted(at time.Time) (int64, error) {
+	result := r.db.Model(&models.RetentionRun{}).
+		Where("status = ?", models.RetentionRunning).
+		Updates(map[string]interface{}{
+			"status":      models.RetentionFailed,
+			"error":       "interrupted by server shutdown",
+			"finished_at": at,
+			"updated_at":  at,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *retentionRepository) FindRunByID(id string) (*models.RetentionRun, error) {
+	var run models.RetentionRun
+	if err := r.db.First(&run, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &run, nil
+}
+
+func (r *retentionRepository) FindRuns(filter RetentionRunFilter) ([]models.RetentionRun, listquery.Page, error) {
+	tx := r.db.Model(&models.RetentionRun{})
+	if filter.DryRun != nil {
+		tx = tx.Where("dry_run = ?", *filter.DryRun)
+	}
+	return listquery.Find[models.RetentionRun](tx, &filter.Query)
+}
+
+// Preview menghitung baris yang memenuhi syarat tanpa mengubah data
+func (r *retentionRepository) Preview(rule RetentionRule, samples int) (*RetentionPreview, error) {
+	target, err := retentionTargetOf(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &RetentionPreview{SampleIDs: []string{}}
+	var matching int64
+	if err := target.scope(r.db, rule).Count(&matching).Error; err != nil {
+		return nil, err
+	}
+	if err := target.eligible(r.db, rule).Count(&preview.Eligible).Error; err != nil {
+		return nil, err
+	}
+	preview.OnHold = matching - preview.Eligible
+	err = target.eligible(r.db, rule).Order(target.table+".id").Limit(samples).
+		Pluck(target.table+".id", &preview.SampleIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+// Apply menghapus atau menganonimkan baris yang memenuhi syarat per batch.
+// Setiap batch dipilih ulang dan dijalankan di transaksinya sendiri supaya
+// lock tidak ditahan lama; baris yang sudah diproses tidak lagi memenuhi
+// syarat sehingga loop berhenti saat tidak ada sisa.
+func (r *retentionRepository) Apply(rule RetentionRule, batchSize int, at time.Time) (int64, error) {
+	target, err := retentionTargetOf(rule)
+	if err != nil {
+		return 0, err
+	}
+	action := target.actions[rule.Action]
+
+	var total int64
+	for {
+		var processed int64
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			var ids []string
+			err := target.eligible(tx, rule).Order(target.table+".id").Limit(batchSize).
+				Pluck(target.table+".id", &ids).Error
+			if err != nil || len(ids) == 0 {
+				return err
+			}
+			processed, err = action(tx, ids, at)
+			return err
+		})
+		if err != nil {
+			return total, err
+		}
+		total += processed
+		if processed == 0 {
+			return total, nil
+		}
+	}
+}
+
+// retentionTarget menjelaskan cara memilih dan memproses baris satu entity
+type retentionTarget struct {
+	table string
+	model interface{}
+	// bases memberi kondisi dasar waktu, cutoff sebagai parameter
+	bases map[string]string
+	// pending menyaring baris yang sudah diproses (untuk anonymize)
+	pending map[string]string
+	// notOnHold mengecualikan baris milik pasien dengan legal hold aktif
+	notOnHold string
+	actions   map[string]func(tx *gorm.DB, ids []string, at time.Time) (int64, error)
+}
+
+const activeHoldPatients = "SELECT patient_id FROM legal_holds WHERE released_at IS NULL"
+
+// lastVisitBefore bernilai true jika pasien (kolom patientColumn) tidak punya
+// appointment selesai atau rekam medis baru sejak cutoff
+func lastVisitBefore(patientColumn string) string {
+	return fmt.Sprintf(`NOT EXISTS (SELECT 1 FROM appointments a WHERE a.patient_id = %[1]s AND a.status = 'done' AND a.schedule_at >= @cutoff)
+		AND NOT EXISTS (SELECT 1 FROM medical_records m WHERE m.patient_id = %[1]s AND m.created_at >= @cutoff)`, patientColumn)
+}
+
+var retentionTargets = map[string]retentionTarget{
+	"appointment": {
+		table:     "appointments",
+		model:     &models.Appointment{},
+		bases:     map[string]string{models.RetentionBasisDeleted: "appointments.deleted_at < @cutoff"},
+		pending:   map[string]string{models.RetentionAnonymize: "appointments.notes <> ''"},
+		notOnHold: "appointments.patient_id NOT IN (" + activeHoldPatients + ")",
+		actions: map[string]func(tx *gorm.DB, ids []string, at time.Time) (int64, error){
+			models.RetentionPurge: purgeRows(&models.Appointment{}),
+			models.RetentionAnonymize: func(tx *gorm.DB, ids []string, at time.Time) (int64, error) {
+				result := tx.Unscoped().Model(&models.Appointment{}).Where("id IN ?", ids).
+					Updates(map[string]interface{}{"notes": "", "updated_at": at})
+				return result.RowsAffected, result.Error
+			},
+		},
+	},
+	"assessment": {
+		table:     "assessments",
+		model:     &models.Assessment{},
+		bases:     map[string]string{models.RetentionBasisDeleted: "assessments.deleted_at < @cutoff"},
+		notOnHold: "assessments.patient_id NOT IN (" + activeHoldPatients + ")",
+		actions: map[string]func(tx *gorm.DB, ids []string, at time.Time) (int64, error){
+			models.RetentionPurge: func(tx *gorm.DB, ids []string, at time.Time) (int64, error) {
+				// Prediksi asesmen ikut dihapus karena foreign key
+				if err := tx.Unscoped().Where("assessment_id IN ?", ids).Delete(&models.Prediction{}).Error; err != nil {
+					return 0, err
+				}
+				return purgeRows(&models.Assessment{})(tx, ids, at)
+			},
+		},
+	},
+	"prediction": {
+		table: "predictions",
+		model: &models.Prediction{},
+		bases: map[string]string{models.RetentionBasisDeleted: "predictions.deleted_at < @cutoff"},
+		notOnHold: "predictions.assessment_id NOT IN (SELECT id FROM assessments WHERE patient_id IN (" +
+			activeHoldPatients + "))",
+		actions: map[string]func(tx *gorm.DB, ids []string, at time.Time) (int64, error){
+			models.RetentionPurge: purgeRows(&models.Prediction{}),
+		},
+	},
+	"medical_record": {
+		table: "medical_records",
+		model: &models.MedicalRecord{},
+		bases: map[string]string{
+			models.RetentionBasisDeleted:   "medical_records.deleted_at < @cutoff",
+			models.RetentionBasisLastVisit: lastVisitBefore("medical_records.patient_id"),
+		},
+		notOnHold: "medical_records.patient_id NOT IN (" + activeHoldPatients + ")",
+		actions: map[string]func(tx *gorm.DB, ids []string, at time.Time) (int64, error){
+			models.RetentionPurge: purgeRows(&models.MedicalRecord{}),
+		},
+	},
+	"patient": {
+		table: "patients",
+		model: &models.Patient{},
+		bases: map[string]string{
+			models.RetentionBasisDeleted:   "patients.deleted_at < @cutoff",
+			models.RetentionBasisLastVisit: "patients.created_at < @cutoff AND " + lastVisitBefore("patients.id"),
+		},
+		pending:   map[string]string{models.RetentionAnonymize: "patients.anonymized_at IS NULL"},
+		notOnHold: "patients.id NOT IN (" + activeHoldPatients + ")",
+		actions: map[string]func(tx *gorm.DB, ids []string, at time.Time) (int64, error){
+			models.RetentionAnonymize: func(tx *gorm.DB, ids []string, at time.Time) (int64, error) {
+				for _, id := range ids {
+					if err := anonymizePatient(tx, id, at); err != nil {
+						return 0, err
+					}
+				}
+				return int64(len(ids)), nil
+			},
+		},
+	},
+	"data_export": {
+		table: "data_exports",
+		model: &models.DataExport{},
+		bases: map[string]string{
+			// Export gagal tidak punya expires_at, dihitung dari waktu permintaan
+			models.RetentionBasisExpired: "(data_exports.expires_at < @cutoff OR (data_exports.status = 'failed' AND data_exports.requested_at < @cutoff))",
+		},
+		notOnHold: "data_exports.patient_id NOT IN (" + activeHoldPatients + ")",
+		actions: map[string]func(tx *gorm.DB, ids []string, at time.Time) (int64, error){
+			models.RetentionPurge: purgeRows(&models.DataExport{}),
+		},
+	},
+}
+
+func retentionTargetOf(rule RetentionRule) (*retentionTarget, error) {
+	target, ok := retentionTargets[rule.Entity]
+	if !ok {
+		return nil, fmt.Errorf("retention: unknown entity %q", rule.Entity)
+	}
+	if _, ok := target.bases[rule.Basis]; !ok {
+		return nil, fmt.Errorf("retention: %s does not support basis %q", rule.Entity, rule.Basis)
+	}
+	if _, ok := target.actions[rule.Action]; !ok {
+		return nil, fmt.Errorf("retention: %s does not support action %q", rule.Entity, rule.Action)
+	}
+	return &target, nil
+}
+
+// scope memilih baris yang memenuhi dasar waktu, termasuk yang ditahan
+// legal hold
+func (t *retentionTarget) scope(db *gorm.DB, rule RetentionRule) *gorm.DB {
+	tx := db.Unscoped().Model(t.model).
+		Where(t.bases[rule.Basis], map[string]interface{}{"cutoff": rule.Cutoff})
+	if pending, ok := t.pending[rule.Action]; ok {
+		tx = tx.Where(pending)
+	}
+	return tx
+}
+
+// eligible adalah scope tanpa baris yang ditahan legal hold
+func (t *retentionTarget) eligible(db *gorm.DB, rule RetentionRule) *gorm.DB {
+	return t.scope(db, rule).Where(t.notOnHold)
+}
+
+func purgeRows(model interface{}) func(tx *gorm.DB, ids []string, at time.Time) (int64, error) {
+	return func(tx *gorm.DB, ids []string, _ time.Time) (int64, error) {
+		result := tx.Unscoped().Where("id IN ?", ids).Delete(model)
+		return result.RowsAffected, result.Error
+	}
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"fmt"
+	"regexp"
+
+	"gorm.io/gorm"
+)
+
+var sequenceNamePattern = regexp.MustCompile(`^[a-z][a-z_]*$`)
+
+// SequenceRepository mengambil nomor urut dari sequence Postgres id_seq_<entity>.
+// nextval aman dipanggil bersamaan sehingga dua request tidak mendapat nomor
+// yang sama.
+type SequenceRepository interface {
+	Next(entity string) (int64, error)
+}
+
+type sequenceRepository struct {
+	db *gorm.DB
+}
+
+func NewSequenceRepository(db *gorm.DB) SequenceRepository {
+	return &sequenceRepository{db: db}
+}
+
+func (r *sequenceRepository) Next(entity string) (int64, error) {
+	if !sequenceNamePattern.MatchString(entity) {
+		return 0, fmt.Errorf("invalid sequence name %q", entity)
+	}
+
+	var next int64
+	err := r.db.Raw("SELECT nextval(?::regclass)", "id_seq_"+entity).Scan(&next).Error
+	return next, err
+}
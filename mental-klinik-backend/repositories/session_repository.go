@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mental-klinik-backend/models"
+)
+
+// ErrTokenAlreadyUsed dikembalikan RotateRefreshToken jika token sudah pernah
+// dirotasi (termasuk oleh request lain yang berjalan bersamaan)
+var ErrTokenAlreadyUsed = errors.New("refresh token already used")
+
+type SessionRepository interface {
+	CreateSession(session *models.AuthSession, token *models.RefreshToken) error
+	FindSessionByID(id string) (*models.AuthSession, error)
+	FindRefreshTokenByHash(hash string) (*models.RefreshToken, error)
+	RotateRefreshToken(used *models.RefreshToken, next *models.RefreshToken) error
+	RevokeSession(id string, reason string) error
+	RevokeUserSessions(userID string, reason string) (int64, error)
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+// CreateSession menyimpan sesi baru beserta refresh token pertamanya
+func (r *sessionRepository) CreateSession(session *models.AuthSession, token *models.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User").Create(session).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Session").Create(token).Error
+	})
+}
+
+func (r *sessionRepository) FindSessionByID(id string) (*models.AuthSession, error) {
+	var session models.AuthSession
+	if err := r.db.First(&session, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &session, nil
+}
+
+func (r *sessionRepository) FindRefreshTokenByHash(hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := r.db.Preload("Session").First(&token, "token_hash = ?", hash).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken menandai token lama sudah dipakai dan menyimpan token
+// penggantinya dalam satu transaksi. Update bersyarat used_at IS NULL
+// memastikan satu token hanya bisa dirotasi sekali.
+func (r *sessionRepository) RotateRefreshToken(used *models.RefreshToken, next *models.RefreshToken) error {
+	now := time.Now()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL", used.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTokenAlreadyUsed
+		}
+
+		if err := tx.Model(&models.AuthSession{}).Where("id = ?", used.SessionID).
+			Update("last_used_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Session").Create(next).Error
+	})
+}
+
+func (r *sessionRepository) RevokeSession(id string, reason string) error {
+	return r.db.Model(&models.AuthSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason}).Error
+}
+
+// RevokeUserSessions mencabut semua sesi aktif milik user dan mengembalikan
+// jumlah sesi yang dicabut
+func (r *sessionRepository) RevokeUserSessions(userID string, reason string) (int64, error) {
+	result := r.db.Model(&models.AuthSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason})
+	return result.RowsAffected, result.Error
+}
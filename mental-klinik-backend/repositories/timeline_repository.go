@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// PatientTimelineSpec adalah kolom timeline pasien yang boleh difilter dan
+// di-sort. id ikut di sort default supaya event dengan waktu sama tetap urut.
+var PatientTimelineSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "timeline.id", Sortable: true},
+		{Name: "type", Column: "timeline.type", Ops: listquery.EqualityOps},
+		{Name: "occurredAt", Column: "timeline.occurred_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "-occurredAt,-id",
+	Cursor:      true,
+}
+
+// PatientTimelineFilter menampung parameter timeline satu pasien. Types
+// adalah jenis event yang boleh dilihat user; jenis lain tidak pernah
+// di-query.
+type PatientTimelineFilter struct {
+	PatientID string
+	Types     []string
+	listquery.Query
+}
+
+// timelineSources adalah query sumber tiap jenis event. Semua menghasilkan
+// kolom yang sama dan hanya mengambil data yang belum dihapus.
+var timelineSources = map[string]string{
+	models.TimelineAppointmentScheduled: `SELECT 'appointment_scheduled:' || a.id AS id, 'appointment_scheduled' AS type,
+		a.created_at AS occurred_at, a.id AS appointment_id, NULL AS status_change_id,
+		NULL AS assessment_id, NULL AS prediction_id, NULL AS medical_record_id
+		FROM appointments a WHERE a.patient_id = @patient AND a.deleted_at IS NULL`,
+	models.TimelineAppointmentStatusChanged: `SELECT 'appointment_status_changed:' || c.id, 'appointment_status_changed',
+		c.changed_at, c.appointment_id, c.id, NULL, NULL, NULL
+		FROM appointment_status_changes c JOIN appointments a ON a.id = c.appointment_id
+		WHERE a.patient_id = @patient AND a.deleted_at IS NULL`,
+	models.TimelineAssessmentTaken: `SELECT 'assessment_taken:' || s.id, 'assessment_taken',
+		s.date, NULL, NULL, s.id, NULL, NULL
+		FROM assessments s WHERE s.patient_id = @patient AND s.deleted_at IS NULL`,
+	models.TimelinePredictionGenerated: `SELECT 'prediction_generated:' || p.id, 'prediction_generated',
+		p.created_at, NULL, NULL, NULL, p.id, NULL
+		FROM predictions p JOIN assessments s ON s.id = p.assessment_id
+		WHERE s.patient_id = @patient AND s.deleted_at IS NULL AND p.deleted_at IS NULL`,
+	models.TimelineRecordWritten: `SELECT 'medical_record_written:' || m.id, 'medical_record_written',
+		m.created_at, NULL, NULL, NULL, NULL, m.id
+		FROM medical_records m WHERE m.patient_id = @patient AND m.deleted_at IS NULL`,
+}
+
+type TimelineRepository interface {
+	FindByPatientID(filter PatientTimelineFilter) ([]models.TimelineEvent, listquery.Page, error)
+}
+
+type timelineRepository struct {
+	db *gorm.DB
+}
+
+func NewTimelineRepository(db *gorm.DB) TimelineRepository {
+	return &timelineRepository{db: db}
+}
+
+// FindByPatientID menyusun timeline pasien dari seluruh sumber event yang
+// diizinkan (UNION ALL), lalu mengisi detail event di halaman ini lewat
+// relasi pasien (Appointments, Assessments, MedicalRecords). Prediksi diambil
+// langsung karena satu assessment bisa punya beberapa prediksi.
+func (r *timelineRepository) FindByPatientID(filter PatientTimelineFilter) ([]models.TimelineEvent, listquery.Page, error) {
+	var sources []string
+	for _, eventType := range models.TimelineEventTypes {
+		for _, allowed := range filter.Types {
+			if eventType == allowed {
+				sources = append(sources, timelineSources[eventType])
+			}
+		}
+	}
+	if len(sources) == 0 {
+		var page listquery.Page
+		if !filter.Cursor || filter.WithTotal {
+			var total int64
+			page.Total = &total
+		}
+		return nil, page, nil
+	}
+
+	union := r.db.Raw(strings.Join(sources, "\nUNION ALL\n"), map[string]interface{}{"patient": filter.PatientID})
+	events, page, err := listquery.Find[models.TimelineEvent](r.db.Table("(?) AS timeline", union), &filter.Query)
+	if err != nil || len(events) == 0 {
+		return events, page, err
+	}
+	return events, page, r.loadDetails(filter.PatientID, events)
+}
+
+func (r *timelineRepository) loadDetails(patientID string, events []models.TimelineEvent) error {
+	var appointmentIDs, changeIDs, assessmentIDs, predictionIDs, recordIDs []string
+	for _, event := range events {
+		if event.AppointmentID != nil {
+			appointmentIDs = append(appointmentIDs, *event.AppointmentID)
+		}
+		if event.StatusChangeID != nil {
+			changeIDs = append(changeIDs, *event.StatusChangeID)
+		}
+		if event.AssessmentID != nil {
+			assessmentIDs = append(assessmentIDs, *event.AssessmentID)
+		}
+		if event.PredictionID != nil {
+			predictionIDs = append(predictionIDs, *event.PredictionID)
+		}
+		if event.MedicalRecordID != nil {
+			recordIDs = append(recordIDs, *event.MedicalRecordID)
+		}
+	}
+
+	var patient models.Patient
+	err := r.db.
+		Preload("Appointments", "id IN ?", appointmentIDs).
+		Preload("Appointments.User", unscoped).
+		Preload("Appointments.StatusChanges", "id IN ?", changeIDs).
+		Preload("Appointments.StatusChanges.ChangedBy", unscoped).
+		Preload("Assessments", "id IN ?", assessmentIDs).
+		Preload("MedicalRecords", "id IN ?", recordIDs).
+		Preload("MedicalRecords.User", unscoped).
+		First(&patient, "id = ?", patientID).Error
+	if err != nil {
+		return translateError(err)
+	}
+
+	var predictions []models.Prediction
+	if len(predictionIDs) > 0 {
+		if err := r.db.Where("id IN ?", predictionIDs).Find(&predictions).Error; err != nil {
+			return err
+		}
+	}
+
+	appointments := map[string]*models.Appointment{}
+	changes := map[string]*models.AppointmentStatusChange{}
+	for i := range patient.Appointments {
+		appointment := &patient.Appointments[i]
+		appointments[appointment.ID] = appointment
+		for j := range appointment.StatusChanges {
+			changes[appointment.StatusChanges[j].ID] = &appointment.StatusChanges[j]
+		}
+	}
+	assessments := map[string]*models.Assessment{}
+	for i := range patient.Assessments {
+		assessments[patient.Assessments[i].ID] = &patient.Assessments[i]
+	}
+	predictionsByID := map[string]*models.Prediction{}
+	for i := range predictions {
+		predictionsByID[predictions[i].ID] = &predictions[i]
+	}
+	records := map[string]*models.MedicalRecord{}
+	for i := range patient.MedicalRecords {
+		records[patient.MedicalRecords[i].ID] = &patient.MedicalRecords[i]
+	}
+
+	// Data yang terhapus di antara dua query membuat detail event kosong
+	for i := range events {
+		event := &events[i]
+		if event.AppointmentID != nil {
+			event.Appointment = appointments[*event.AppointmentID]
+		}
+		if event.StatusChangeID != nil {
+			event.StatusChange = changes[*event.StatusChangeID]
+		}
+		if event.AssessmentID != nil {
+			event.Assessment = assessments[*event.AssessmentID]
+		}
+		if event.PredictionID != nil {
+			event.Prediction = predictionsByID[*event.PredictionID]
+		}
+		if event.MedicalRecordID != nil {
+			event.MedicalRecord = records[*event.MedicalRecordID]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// RestoreCascadeWindow adalah selisih waktu maksimal antara deleted_at anak
+// dan induknya supaya anak dianggap terhapus bersama induk dan ikut
+// dipulihkan saat restore dengan cascade
+const RestoreCascadeWindow = time.Minute
+
+// RestoreResult adalah jumlah baris per tabel yang dipulihkan satu restore
+type RestoreResult map[string]int64
+
+// RestoreConflictError dikembalikan saat data di trash tidak bisa dipulihkan
+// karena bentrok dengan data yang sekarang aktif
+type RestoreConflictError struct {
+	Reason string
+}
+
+func (e *RestoreConflictError) Error() string {
+	return "cannot restore: " + e.Reason
+}
+
+func restoreConflict(format string, args ...interface{}) error {
+	return &RestoreConflictError{Reason: fmt.Sprintf(format, args...)}
+}
+
+// TrashSpec membentuk spec list trash dari spec list biasa: ditambah filter
+// dan sort deletedAt, urutan default dari yang terakhir dihapus. Search dan
+// paging cursor tidak didukung di trash; deletedAt tidak ada di JSON model
+// sehingga cursor tidak bisa dibentuk.
+func TrashSpec(spec listquery.Spec) listquery.Spec {
+	column := "deleted_at"
+	if id := spec.Fields[0].Column; strings.Contains(id, ".") {
+		column = id[:strings.Index(id, ".")+1] + column
+	}
+	fields := append([]listquery.Field{}, spec.Fields...)
+	fields = append(fields, listquery.Field{
+		Name: "deletedAt", Column: column, Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps,
+	})
+	return listquery.Spec{
+		Fields:       fields,
+		DefaultSort:  "-deletedAt",
+		DefaultLimit: spec.DefaultLimit,
+		MaxLimit:     spec.MaxLimit,
+	}
+}
+
+// findDeleted mengembalikan isi trash: baris yang sudah di-soft delete.
+// Relasi yang di-preload ikut terbaca walaupun sudah dihapus.
+func findDeleted[T any](tx *gorm.DB, table string, query *listquery.Query) ([]T, listquery.Page, error) {
+	return listquery.Find[T](tx.Unscoped().Where(table+".deleted_at IS NOT NULL"), query)
+}
+
+// lockDeleted mengambil dan mengunci baris di trash. ErrNotFound jika baris
+// tidak ada atau tidak sedang dihapus.
+func lockDeleted(tx *gorm.DB, dest interface{}, id string) error {
+	err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("deleted_at IS NOT NULL").First(dest, "id = ?", id).Error
+	return translateError(err)
+}
+
+// isActive bernilai true jika ada baris aktif (belum dihapus) yang cocok
+func isActive(tx *gorm.DB, model interface{}, query interface{}, args ...interface{}) (bool, error) {
+	var count int64
+	err := tx.Model(model).Where(query, args...).Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+// requireActivePatient menolak restore data milik pasien yang masih di trash
+func requireActivePatient(tx *gorm.DB, patientID string) error {
+	active, err := isActive(tx, &models.Patient{}, "id = ?", patientID)
+	if err != nil {
+		return err
+	}
+	if !active {
+		return restoreConflict("patient %s is deleted, restore the patient first", patientID)
+	}
+	return nil
+}
+
+// restoreRows mengosongkan deleted_at baris di trash yang cocok dengan kondisi
+// pada tx dan mencatat jumlahnya di result dengan nama tabel sebagai key
+func restoreRows(tx *gorm.DB, model interface{}, table string, result RestoreResult) error {
+	restored := tx.Unscoped().Model(model).Where("deleted_at IS NOT NULL").Update("deleted_at", nil)
+	if restored.Error != nil {
+		return restored.Error
+	}
+	if restored.RowsAffected > 0 {
+		result[table] += restored.RowsAffected
+	}
+	return nil
+}
+
+// deletedWith membatasi query ke baris yang terhapus bersama induk yang
+// dihapus pada waktu at
+func deletedWith(at time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("deleted_at BETWEEN ? AND ?", at.Add(-RestoreCascadeWindow), at.Add(RestoreCascadeWindow))
+	}
+}
@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// UserListSpec adalah kolom user yang boleh difilter dan di-sort
+var UserListSpec = listquery.Spec{
+	Fields: []listquery.Field{
+		{Name: "id", Column: "id", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "fullName", Column: "full_name", Sortable: true, Ops: listquery.TextOps},
+		{Name: "email", Column: "email", Sortable: true, Ops: listquery.TextOps},
+		{Name: "role", Column: "role", Sortable: true, Ops: listquery.EqualityOps},
+		{Name: "createdAt", Column: "created_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+		{Name: "updatedAt", Column: "updated_at", Type: listquery.Time, Sortable: true, Ops: listquery.RangeOps},
+	},
+	DefaultSort: "-createdAt",
+	Params:      []string{"search"},
+}
+
+// UserTrashSpec adalah kolom trash user yang boleh difilter dan di-sort
+var UserTrashSpec = TrashSpec(UserListSpec)
+
+// UserFilter menampung parameter list user (search, filter, sort, paging)
+type UserFilter struct {
+	Search string
+	listquery.Query
+}
+
+type UserRepository interface {
+	Create(user *models.User) error
+	FindByID(id string) (*models.User, error)
+	FindByEmail(email string) (*models.User, error)
+	ExistsByRole(role string) (bool, error)
+	FindAll(filter UserFilter) ([]models.User, listquery.Page, error)
+	Update(user *models.User) error
+	Delete(id string, reassignTo string) (*DeleteResult, error)
+	FindDeleted(query listquery.Query) ([]models.User, listquery.Page, error)
+	Restore(id string) (*models.User, RestoreResult, error)
+}
+
+type userRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+func (r *userRepository) Create(user *models.User) error {
+	return r.db.Create(user).Error
+}
+
+func (r *userRepository) FindByID(id string) (*models.User, error) {
+	var user models.User
+	if err := r.db.First(&user, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByEmail(email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) ExistsByRole(role string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.User{}).Where("role = ?", role).Limit(1).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *userRepository) FindAll(filter UserFilter) ([]models.User, listquery.Page, error) {
+	query := r.db.Model(&models.User{})
+
+	// Search
+	if filter.Search != "" {
+		query = query.Where("full_name ILIKE ? OR email ILIKE ?", "%"+filter.Search+"%", "%"+filter.Search+"%")
+	}
+
+	return listquery.Find[models.User](query, &filter.Query)
+}
+
+func (r *userRepository) Update(user *models.User) error {
+	return r.db.Omit(clause.Associations).Save(user).Error
+}
+
+// Delete men-soft delete user sesuai UserRelations dalam satu transaksi.
+// reassignTo (opsional) adalah user aktif dengan role yang sama yang menerima
+// appointment yang belum berjalan.
+func (r *userRepository) Delete(id string, reassignTo string) (*DeleteResult, error) {
+	var result *DeleteResult
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, "id = ?", id).Error; err != nil {
+			return translateError(err)
+		}
+		if reassignTo != "" {
+			var target models.User
+			err := tx.Clauses(clause.Locking{Strength: "SHARE"}).First(&target, "id = ?", reassignTo).Error
+			if errors.Is(translateError(err), ErrNotFound) || (err == nil && (target.ID == id || target.Role != user.Role)) {
+				return ErrInvalidReassignTarget
+			}
+			if err != nil {
+				return err
+			}
+		}
+		var err error
+		result, err = deleteWithRelations(tx, &user, id, UserRelations, reassignTo)
+		return err
+	})
+	return result, err
+}
+
+func (r *userRepository) FindDeleted(query listquery.Query) ([]models.User, listquery.Page, error) {
+	return findDeleted[models.User](r.db.Model(&models.User{}), "users", &query)
+}
+
+// Restore memulihkan user dari trash jika email-nya belum dipakai user aktif
+// lain. Sesi login yang dicabut saat penghapusan tidak ikut dipulihkan.
+func (r *userRepository) Restore(id string) (*models.User, RestoreResult, error) {
+	var user models.User
+	result := RestoreResult{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockDeleted(tx, &user, id); err != nil {
+			return err
+		}
+		taken, err := isActive(tx, &models.User{}, "email = ? AND id <> ?", user.Email, id)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return restoreConflict("email %s is used by another active user", user.Email)
+		}
+		return restoreRows(tx.Where("id = ?", id), &models.User{}, "users", result)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	user.DeletedAt = gorm.DeletedAt{}
+	return &user, result, nil
+}
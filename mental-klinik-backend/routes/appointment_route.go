@@ -7,34 +7,39 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func AppointmentRoutes(r *gin.Engine) {
+func AppointmentRoutes(r *gin.Engine, ac *controllers.AppointmentController, auth gin.HandlerFunc) {
 	appointment := r.Group("/api/appointments")
 
-	// Semua endpoint di bawah wajib JWT
+	// Semua endpoint di bawah wajib JWT. Hak akses per role dan kepemilikan
+	// dicek di handler lewat policy (lihat policy/rules.go).
 	protected := appointment.Group("/")
-	protected.Use(middlewares.AuthMiddleware())
+	protected.Use(auth)
 
-	// Create Appointment (Admin & Staff & Dokter bisa buat)
-	protected.POST("/", middlewares.AuthorizeRole("admin", "staff", "doctor"), controllers.CreateAppointment)
+	// Create Appointment (admin & staff, dokter hanya untuk dirinya sendiri)
+	protected.POST("/", ac.CreateAppointment)
 
-	// Get All Appointments (Admin & Staff)
-	protected.GET("/", middlewares.AuthorizeRole("admin", "staff"), controllers.GetAllAppointments)
+	// Get All Appointments (dokter hanya pasien di tim perawatannya)
+	protected.GET("/", ac.GetAllAppointments)
+
+	// Trash & restore appointment yang sudah dihapus (Admin only)
+	protected.GET("/trash", middlewares.AuthorizeRole("admin"), ac.GetAppointmentTrash)
+	protected.POST("/:id/restore", middlewares.AuthorizeRole("admin"), ac.RestoreAppointment)
 
 	// Get Appointment by ID
-	protected.GET("/:id", middlewares.AuthorizeRole("admin", "staff", "doctor"), controllers.GetAppointmentByID)
+	protected.GET("/:id", ac.GetAppointmentByID)
 
-	// Update Appointment (Admin & Dokter saja yang boleh ubah jadwal/notes)
-	protected.PUT("/:id", middlewares.AuthorizeRole("admin", "doctor"), controllers.UpdateAppointment)
+	// Update Appointment (admin, atau dokter pemilik appointment)
+	protected.PUT("/:id", ac.UpdateAppointment)
 
 	// Delete Appointment (Admin only)
-	protected.DELETE("/:id", middlewares.AuthorizeRole("admin"), controllers.DeleteAppointment)
+	protected.DELETE("/:id", ac.DeleteAppointment)
 
 	// Get Appointments by Patient ID (untuk melihat riwayat appointment pasien tertentu)
-	protected.GET("/appoinmentPatient/:patientId", middlewares.AuthorizeRole("admin", "staff", "doctor"), controllers.GetAppointmentsByPatientID)
+	protected.GET("/appoinmentPatient/:patientId", ac.GetAppointmentsByPatientID)
 
 	// Get Appointments by User ID (dokter bisa lihat miliknya sendiri)
-	protected.GET("/appoinmentUser/:userId", middlewares.AuthorizeRole("admin", "doctor"), controllers.GetAppointmentsByUserID)
+	protected.GET("/appoinmentUser/:userId", ac.GetAppointmentsByUserID)
 
-	// Change status (misal: pending → done, cancel, dsb) — hanya dokter dan admin
-	protected.PATCH("/:id/statusAppoinment", middlewares.AuthorizeRole("admin", "doctor"), controllers.ChangeAppointmentStatus)
+	// Change status (misal: pending → done, cancel, dsb) — admin atau dokter pemilik appointment
+	protected.PATCH("/:id/statusAppoinment", ac.ChangeAppointmentStatus)
 }
@@ -7,28 +7,32 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func AssessmentRoutes(r *gin.Engine) {
+func AssessmentRoutes(r *gin.Engine, ac *controllers.AssessmentController, auth gin.HandlerFunc) {
 	assessment := r.Group("/api/assessments")
 
-	// Semua endpoint di bawah wajib JWT
+	// Semua endpoint di bawah wajib JWT. Hak akses dicek di handler lewat policy.
 	protected := assessment.Group("/")
-	protected.Use(middlewares.AuthMiddleware())
+	protected.Use(auth)
 
-	// Create Assessment (admin, doctor, staff bisa membuat)
-	protected.POST("/", middlewares.AuthorizeRole("admin", "doctor", "staff"), controllers.CreateAssessment)
+	// Create Assessment (admin, staff, dokter untuk pasien di tim perawatannya)
+	protected.POST("/", ac.CreateAssessment)
 
-	// Get All Assessments (admin dan staff bisa lihat semua)
-	protected.GET("/", middlewares.AuthorizeRole("admin", "staff"), controllers.GetAllAssessments)
+	// Get All Assessments (dokter hanya pasien di tim perawatannya)
+	protected.GET("/", ac.GetAllAssessments)
 
-	// Get Assessment by ID (admin, doctor, staff)
-	protected.GET("/:id", middlewares.AuthorizeRole("admin", "doctor", "staff"), controllers.GetAssessmentByID)
+	// Trash & restore assessment yang sudah dihapus (hanya admin)
+	protected.GET("/trash", middlewares.AuthorizeRole("admin"), ac.GetAssessmentTrash)
+	protected.POST("/:id/restore", middlewares.AuthorizeRole("admin"), ac.RestoreAssessment)
 
-	// Update Assessment (admin dan doctor bisa ubah, misal untuk koreksi jawaban atau tanggal)
-	protected.PUT("/:id", middlewares.AuthorizeRole("admin", "doctor"), controllers.UpdateAssessment)
+	// Get Assessment by ID
+	protected.GET("/:id", ac.GetAssessmentByID)
+
+	// Update Assessment (admin, dokter untuk pasien di tim perawatannya)
+	protected.PUT("/:id", ac.UpdateAssessment)
 
 	// Delete Assessment (hanya admin yang boleh)
-	protected.DELETE("/:id", middlewares.AuthorizeRole("admin"), controllers.DeleteAssessment)
+	protected.DELETE("/:id", ac.DeleteAssessment)
 
 	// Get Assessments by Patient ID (riwayat assessment pasien tertentu)
-	protected.GET("/byPatient/:patientId", middlewares.AuthorizeRole("admin", "doctor", "staff"), controllers.GetAssessmentsByPatientID)
+	protected.GET("/byPatient/:patientId", ac.GetAssessmentsByPatientID)
 }
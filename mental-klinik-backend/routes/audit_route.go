@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"mental-klinik-backend/controllers"
+	"mental-klinik-backend/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AuditRoutes(r *gin.Engine, ac *controllers.AuditController, auth gin.HandlerFunc) {
+	audit := r.Group("/api/audit-logs")
+
+	// Audit log akses PHI hanya untuk admin
+	protected := audit.Group("/")
+	protected.Use(auth, middlewares.AuthorizeRole("admin"))
+
+	protected.GET("/", ac.GetAuditLogs)
+	protected.GET("/export", ac.ExportAuditLogs)
+	protected.GET("/verify", ac.VerifyAuditChain)
+}
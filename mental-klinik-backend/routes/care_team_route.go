@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"mental-klinik-backend/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CareTeamRoutes(r *gin.Engine, cc *controllers.CareTeamController, auth gin.HandlerFunc) {
+	careTeam := r.Group("/api/care-team")
+
+	// Protected Routes - Requires JWT. Hak akses dicek di handler lewat policy.
+	protected := careTeam.Group("/")
+	protected.Use(auth)
+
+	// Assign, transfer, akhiri & handover (admin & staff)
+	protected.POST("/", cc.AssignCareTeam)
+	protected.POST("/handover", cc.HandoverCaseload)
+	protected.POST("/:id/transfer", cc.TransferCareAssignment)
+	protected.POST("/:id/end", cc.EndCareAssignment)
+
+	// List & detail (dokter hanya tim perawatannya), caseload user yang login
+	protected.GET("/", cc.GetAllCareAssignments)
+	protected.GET("/caseload", cc.GetMyCaseload)
+	protected.GET("/:id", cc.GetCareAssignmentByID)
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"mental-klinik-backend/controllers"
+	"mental-klinik-backend/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ConsentRoutes(r *gin.Engine, cc *controllers.ConsentController, auth gin.HandlerFunc) {
+	// Dokumen persetujuan: semua user yang login boleh membaca, hanya admin
+	// yang menerbitkan versi baru
+	documents := r.Group("/api/consent-documents")
+	documents.Use(auth)
+	documents.GET("/", cc.GetConsentDocuments)
+	documents.GET("/:id", cc.GetConsentDocumentByID)
+	documents.POST("/", middlewares.AuthorizeRole("admin"), cc.PublishConsentDocument)
+
+	// Persetujuan per pasien. Hak akses dicek di handler lewat policy.
+	consents := r.Group("/api/patients/:id/consents")
+	consents.Use(auth)
+	consents.POST("", cc.GrantConsent)
+	consents.GET("", cc.GetPatientConsents)
+	consents.GET("/summary", cc.GetConsentSummary)
+	consents.POST("/:consentId/withdraw", cc.WithdrawConsent)
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"mental-klinik-backend/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ContactRoutes(r *gin.Engine, cc *controllers.ContactController, auth gin.HandlerFunc) {
+	// Kontak darurat dan wali per pasien. Hak akses dicek di handler lewat
+	// policy.
+	contacts := r.Group("/api/patients/:id/contacts")
+	contacts.Use(auth)
+	contacts.POST("", cc.CreateContact)
+	contacts.GET("", cc.GetContacts)
+	contacts.GET("/:contactId", cc.GetContactByID)
+	contacts.PUT("/:contactId", cc.UpdateContact)
+	contacts.DELETE("/:contactId", cc.DeleteContact)
+}
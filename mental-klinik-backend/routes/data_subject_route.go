@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"mental-klinik-backend/controllers"
+	"mental-klinik-backend/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func DataSubjectRoutes(r *gin.Engine, dc *controllers.DataSubjectController, auth gin.HandlerFunc) {
+	admin := middlewares.AuthorizeRole("admin")
+
+	// Permintaan hapus bisa dicatat staff pendaftaran, sisanya khusus admin
+	r.POST("/api/patients/:id/data-exports", auth, admin, dc.RequestDataExport)
+	r.POST("/api/patients/:id/erasure-requests", auth, middlewares.AuthorizeRole("admin", "staff"), dc.RequestErasure)
+	r.POST("/api/patients/:id/legal-holds", auth, admin, dc.PlaceLegalHold)
+
+	exports := r.Group("/api/data-exports")
+	exports.Use(auth, admin)
+	exports.GET("/", dc.GetDataExports)
+	exports.GET("/:id", dc.GetDataExportByID)
+	exports.GET("/:id/download", dc.DownloadDataExport)
+
+	erasures := r.Group("/api/erasure-requests")
+	erasures.Use(auth, admin)
+	erasures.GET("/", dc.GetErasureRequests)
+	erasures.GET("/:id", dc.GetErasureRequestByID)
+	erasures.POST("/:id/approve", dc.ApproveErasure)
+	erasures.POST("/:id/reject", dc.RejectErasure)
+	erasures.GET("/:id/certificate", dc.GetErasureCertificate)
+
+	holds := r.Group("/api/legal-holds")
+	holds.Use(auth, admin)
+	holds.GET("/", dc.GetLegalHolds)
+	holds.POST("/:id/release", dc.ReleaseLegalHold)
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"mental-klinik-backend/controllers"
+	"mental-klinik-backend/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func DuplicateRoutes(r *gin.Engine, dc *controllers.DuplicateController, auth gin.HandlerFunc) {
+	// Deteksi dan penggabungan pasien duplikat hanya untuk admin
+	patient := r.Group("/api/patients")
+	protected := patient.Group("/")
+	protected.Use(auth, middlewares.AuthorizeRole("admin"))
+
+	// Scan dan antrean review kandidat duplikat
+	protected.GET("/duplicates/scans", dc.GetDuplicateScans)
+	protected.POST("/duplicates/scans", dc.StartDuplicateScan)
+	protected.GET("/duplicates", dc.GetDuplicateCandidates)
+	protected.GET("/duplicates/:id", dc.GetDuplicateCandidateByID)
+	protected.POST("/duplicates/:id/dismiss", dc.DismissDuplicateCandidate)
+
+	// Penggabungan pasien dan pembatalannya
+	protected.GET("/merges", dc.GetPatientMerges)
+	protected.POST("/merges", dc.MergePatients)
+	protected.GET("/merges/:id", dc.GetPatientMergeByID)
+	protected.POST("/merges/:id/undo", dc.UndoPatientMerge)
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"mental-klinik-backend/controllers"
+	"mental-klinik-backend/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func EmergencyAccessRoutes(r *gin.Engine, ec *controllers.EmergencyAccessController, auth gin.HandlerFunc) {
+	// Break-the-glass: dokter di luar tim perawatan membuka akses darurat
+	r.POST("/api/patients/:id/break-glass", auth, middlewares.AuthorizeRole("doctor"), ec.BreakGlass)
+
+	// Laporan review, review & pencabutan akses darurat hanya untuk admin
+	emergency := r.Group("/api/emergency-access")
+	protected := emergency.Group("/")
+	protected.Use(auth, middlewares.AuthorizeRole("admin"))
+
+	protected.GET("/", ec.GetEmergencyAccesses)
+	protected.GET("/:id", ec.GetEmergencyAccessByID)
+	protected.POST("/:id/review", ec.ReviewEmergencyAccess)
+	protected.POST("/:id/revoke", ec.RevokeEmergencyAccess)
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"mental-klinik-backend/controllers"
+	"mental-klinik-backend/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InvitationRoutes(r *gin.Engine, ic *controllers.InvitationController, auth gin.HandlerFunc) {
+	invitation := r.Group("/api/invitations")
+
+	// Semua endpoint undangan hanya untuk admin
+	protected := invitation.Group("/")
+	protected.Use(auth, middlewares.AuthorizeRole("admin"))
+
+	protected.POST("/", ic.CreateInvitation)
+	protected.GET("/", ic.GetAllInvitations)
+	protected.DELETE("/:id", ic.RevokeInvitation)
+}
@@ -7,25 +7,29 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func MedicalRecordRoutes(r *gin.Engine) {
+func MedicalRecordRoutes(r *gin.Engine, mc *controllers.MedicalRecordController, auth gin.HandlerFunc) {
 	medical := r.Group("/api/medical-records")
 
-	// Semua endpoint wajib login
+	// Semua endpoint wajib login. Hak akses dicek di handler lewat policy.
 	protected := medical.Group("/")
-	protected.Use(middlewares.AuthMiddleware())
+	protected.Use(auth)
 
-	// Create Medical Record (Admin, Dokter, Staff)
-	protected.POST("/", middlewares.AuthorizeRole("admin", "doctor", "staff"), controllers.CreateMedicalRecord)
+	// Create Medical Record (admin, staff, dokter sebagai penulis untuk pasien di tim perawatannya)
+	protected.POST("/", mc.CreateMedicalRecord)
 
-	// Get All Medical Records (Admin, Dokter, Staff)
-	protected.GET("/", middlewares.AuthorizeRole("admin", "doctor", "staff"), controllers.GetAllMedicalRecords)
+	// Get All Medical Records (dokter hanya pasien di tim perawatannya)
+	protected.GET("/", mc.GetAllMedicalRecords)
 
-	// Get Medical Record by ID (Admin, Dokter, Staff)
-	protected.GET("/:id", middlewares.AuthorizeRole("admin", "doctor", "staff"), controllers.GetMedicalRecordByID)
+	// Trash & restore rekam medis yang sudah dihapus (Admin only)
+	protected.GET("/trash", middlewares.AuthorizeRole("admin"), mc.GetMedicalRecordTrash)
+	protected.POST("/:id/restore", middlewares.AuthorizeRole("admin"), mc.RestoreMedicalRecord)
 
-	// Update Medical Record (Admin, Dokter)
-	protected.PUT("/:id", middlewares.AuthorizeRole("admin", "doctor"), controllers.UpdateMedicalRecord)
+	// Get Medical Record by ID
+	protected.GET("/:id", mc.GetMedicalRecordByID)
+
+	// Update Medical Record (admin, atau dokter penulis rekam medis)
+	protected.PUT("/:id", mc.UpdateMedicalRecord)
 
 	// Delete Medical Record (Admin only)
-	protected.DELETE("/:id", middlewares.AuthorizeRole("admin"), controllers.DeleteMedicalRecord)
+	protected.DELETE("/:id", mc.DeleteMedicalRecord)
 }
@@ -7,19 +7,29 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func PatientRoutes(r *gin.Engine) {
+func PatientRoutes(r *gin.Engine, pc *controllers.PatientController, auth gin.HandlerFunc) {
 	patient := r.Group("/api/patients")
 
-	// Protected Routes - Requires JWT
+	// Protected Routes - Requires JWT. Hak akses dicek di handler lewat policy.
 	protected := patient.Group("/")
-	protected.Use(middlewares.AuthMiddleware())
+	protected.Use(auth)
 
-	// Only admin can get full list of patients
-	protected.POST("/", controllers.CreatePatient)
-	protected.GET("/", middlewares.AuthorizeRole("admin", "staff"), controllers.GetAllPatients)
+	// Create & list (admin & staff, dokter hanya melihat pasien di tim perawatannya)
+	protected.POST("/", pc.CreatePatient)
+	protected.GET("/", pc.GetAllPatients)
 
-	// Get by ID, Update, Delete (dapat dibuka untuk admin & staff)
-	protected.GET("/:id", middlewares.AuthorizeRole("admin", "staff"), controllers.GetPatientByID)
-	protected.PUT("/:id", middlewares.AuthorizeRole("admin", "staff"), controllers.UpdatePatient)
-	protected.DELETE("/:id", middlewares.AuthorizeRole("admin"), controllers.DeletePatient)
-}
\ No newline at end of file
+	// Baca NIK untuk mengisi otomatis form pendaftaran
+	protected.POST("/nik/decode", pc.DecodeNIK)
+
+	// Trash & restore pasien yang sudah dihapus (admin)
+	protected.GET("/trash", middlewares.AuthorizeRole("admin"), pc.GetPatientTrash)
+	protected.POST("/:id/restore", middlewares.AuthorizeRole("admin"), pc.RestorePatient)
+
+	// Tanggal lahir lama yang gagal dikonversi, dikoreksi lewat update (admin)
+	protected.GET("/legacy-birth-dates", middlewares.AuthorizeRole("admin"), pc.GetLegacyBirthDates)
+
+	// Get by ID, Update (admin & staff), Delete (admin)
+	protected.GET("/:id", pc.GetPatientByID)
+	protected.PUT("/:id", pc.UpdatePatient)
+	protected.DELETE("/:id", pc.DeletePatient)
+}
@@ -7,28 +7,32 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func PredictionRoutes(r *gin.Engine) {
+func PredictionRoutes(r *gin.Engine, pc *controllers.PredictionController, auth gin.HandlerFunc) {
 	predictions := r.Group("/api/predictions")
 
-	// Semua endpoint di bawah wajib login
+	// Semua endpoint di bawah wajib login. Hak akses dicek di handler lewat policy.
 	protected := predictions.Group("/")
-	protected.Use(middlewares.AuthMiddleware())
+	protected.Use(auth)
 
-	// GET semua prediksi (admin, doctor, staff)
-	protected.GET("/", middlewares.AuthorizeRole("admin", "doctor", "staff"), controllers.GetAllPredictions)
+	// GET semua prediksi (dokter hanya pasien di tim perawatannya)
+	protected.GET("/", pc.GetAllPredictions)
 
-	// GET prediksi berdasarkan ID (admin, doctor, staff)
-	protected.GET("/:id", middlewares.AuthorizeRole("admin", "doctor", "staff"), controllers.GetPredictionByID)
+	// Trash & restore prediksi yang sudah dihapus (admin)
+	protected.GET("/trash", middlewares.AuthorizeRole("admin"), pc.GetPredictionTrash)
+	protected.POST("/:id/restore", middlewares.AuthorizeRole("admin"), pc.RestorePrediction)
 
-	// GET prediksi berdasarkan assessment ID (admin, doctor, staff)
-	protected.GET("/assessment/:assessment_id", middlewares.AuthorizeRole("admin", "doctor", "staff"), controllers.GetPredictionByAssessmentID)
+	// GET prediksi berdasarkan ID
+	protected.GET("/:id", pc.GetPredictionByID)
 
-	// POST prediksi baru berdasarkan assessment ID (admin, doctor)
-	protected.POST("/:id", middlewares.AuthorizeRole("admin", "doctor"), controllers.PredictMentalHealth)
+	// GET prediksi berdasarkan assessment ID
+	protected.GET("/assessment/:assessment_id", pc.GetPredictionByAssessmentID)
 
-	// PUT update prediksi (admin saja atau sesuai kebutuhan)
-	protected.PUT("/:id", middlewares.AuthorizeRole("admin"), controllers.UpdatePredictionByID)
+	// POST prediksi baru berdasarkan assessment ID (admin, dokter untuk pasien di tim perawatannya)
+	protected.POST("/:id", pc.PredictMentalHealth)
+
+	// PUT update prediksi (admin)
+	protected.PUT("/:id", pc.UpdatePredictionByID)
 
 	// DELETE prediksi (admin)
-	protected.DELETE("/:id", middlewares.AuthorizeRole("admin"), controllers.DeletePredictionByID)
+	protected.DELETE("/:id", pc.DeletePredictionByID)
 }
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"mental-klinik-backend/controllers"
+	"mental-klinik-backend/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RetentionRoutes(r *gin.Engine, rc *controllers.RetentionController, auth gin.HandlerFunc) {
+	// Kebijakan retensi dan riwayat run hanya untuk admin
+	retention := r.Group("/api/retention")
+	protected := retention.Group("/")
+	protected.Use(auth, middlewares.AuthorizeRole("admin"))
+
+	protected.GET("/policies", rc.GetRetentionPolicies)
+	protected.GET("/runs", rc.GetRetentionRuns)
+	protected.POST("/runs", rc.StartRetentionRun)
+	protected.GET("/runs/:id", rc.GetRetentionRunByID)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"mental-klinik-backend/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TimelineRoutes(r *gin.Engine, tc *controllers.TimelineController, auth gin.HandlerFunc) {
+	patient := r.Group("/api/patients")
+
+	// Protected Routes - Requires JWT. Hak akses dicek di handler lewat policy.
+	protected := patient.Group("/")
+	protected.Use(auth)
+
+	// Timeline klinis gabungan satu pasien
+	protected.GET("/:id/timeline", tc.GetPatientTimeline)
+}
@@ -7,19 +7,27 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func UserRoutes(r *gin.Engine) {
+func UserRoutes(r *gin.Engine, uc *controllers.UserController, auth gin.HandlerFunc) {
 	user := r.Group("/api/users")
 
 	// Public Routes
-	user.POST("/register", controllers.Register)
-	user.POST("/login", controllers.Login)
+	user.POST("/register", uc.Register)
+	user.POST("/login", uc.Login)
+	user.POST("/refresh", uc.Refresh)
 
 	// Protected Routes (Require JWT)
 	protected := user.Group("/")
-	protected.Use(middlewares.AuthMiddleware())
+	protected.Use(auth)
 
-	protected.GET("/", middlewares.AuthorizeRole("admin"), controllers.GetAllUsers)
-	protected.GET("/:id", middlewares.AuthorizeRole("admin"), controllers.GetUserByID)
-	protected.PUT("/:id", controllers.UpdateUser)	
-	protected.DELETE("/:id", middlewares.AuthorizeRole("admin"), controllers.DeleteUser)
+	protected.POST("/logout", uc.Logout)
+	// Hak akses dicek di handler lewat policy (admin, atau user itu sendiri)
+	protected.GET("/", uc.GetAllUsers)
+	protected.GET("/:id", uc.GetUserByID)
+	protected.PUT("/:id", uc.UpdateUser)
+	protected.DELETE("/:id", uc.DeleteUser)
+	protected.POST("/:id/revoke-sessions", uc.RevokeUserSessions)
+
+	// Trash & restore user yang sudah dihapus (admin)
+	protected.GET("/trash", middlewares.AuthorizeRole("admin"), uc.GetUserTrash)
+	protected.POST("/:id/restore", middlewares.AuthorizeRole("admin"), uc.RestoreUser)
 }
\ No newline at end of file
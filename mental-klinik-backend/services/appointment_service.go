@@ -0,0 +1,177 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/utils"
+)
+
+type AppointmentService interface {
+	Create(input dto.CreateAppointmentRequest) (*models.Appointment, error)
+	GetAll(filter repositories.AppointmentFilter) ([]models.Appointment, listquery.Page, error)
+	GetByID(id string) (*models.Appointment, error)
+	Update(id string, input dto.UpdateAppointmentRequest) ([]dto.UpdatedField, error)
+	Delete(id string) error
+	GetByPatientID(patientID string, query listquery.Query) ([]models.Appointment, listquery.Page, error)
+	GetByUserID(userID string, query listquery.Query) ([]models.Appointment, listquery.Page, error)
+	ChangeStatus(id string, status string, changedBy string) error
+	GetTrash(query listquery.Query) ([]models.Appointment, listquery.Page, error)
+	Restore(id string) (*models.Appointment, repositories.RestoreResult, error)
+}
+
+type appointmentService struct {
+	appointments repositories.AppointmentRepository
+	patients     repositories.PatientRepository
+	users        repositories.UserRepository
+	ids          utils.IDGenerator
+}
+
+func NewAppointmentService(
+	appointments repositories.AppointmentRepository,
+	patients repositories.PatientRepository,
+	users repositories.UserRepository,
+	ids utils.IDGenerator,
+) AppointmentService {
+	return &appointmentService{
+		appointments: appointments,
+		patients:     patients,
+		users:        users,
+		ids:          ids,
+	}
+}
+
+func (s *appointmentService) Create(input dto.CreateAppointmentRequest) (*models.Appointment, error) {
+	// Validasi keberadaan Patient dan User
+	if _, err := s.patients.FindByID(input.PatientID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+	if _, err := s.users.FindByID(input.UserID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	// Generate ID
+	id, err := s.ids.Generate(utils.EntityAppointment)
+	if err != nil {
+		return nil, err
+	}
+
+	appointment := &models.Appointment{
+		ID:         id,
+		PatientID:  input.PatientID,
+		UserID:     input.UserID,
+		ScheduleAt: input.ScheduleAt,
+		Status:     "pending",
+		Notes:      input.Notes,
+	}
+
+	if err := s.appointments.Create(appointment); err != nil {
+		return nil, err
+	}
+	return appointment, nil
+}
+
+func (s *appointmentService) GetAll(filter repositories.AppointmentFilter) ([]models.Appointment, listquery.Page, error) {
+	return s.appointments.FindAll(filter)
+}
+
+func (s *appointmentService) GetByID(id string) (*models.Appointment, error) {
+	appointment, err := s.appointments.FindByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrAppointmentNotFound
+	}
+	return appointment, err
+}
+
+func (s *appointmentService) Update(id string, input dto.UpdateAppointmentRequest) ([]dto.UpdatedField, error) {
+	appointment, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedFields []dto.UpdatedField
+
+	if input.ScheduleAt != nil {
+		appointment.ScheduleAt = *input.ScheduleAt
+		updatedFields = append(updatedFields, dto.UpdatedField{
+			Field: "scheduleAt",
+			Value: input.ScheduleAt,
+		})
+	}
+	if input.Notes != nil {
+		appointment.Notes = *input.Notes
+		updatedFields = append(updatedFields, dto.UpdatedField{
+			Field: "notes",
+			Value: input.Notes,
+		})
+	}
+
+	if len(updatedFields) == 0 {
+		return nil, ErrNoFieldsToUpdate
+	}
+
+	if err := s.appointments.Update(appointment); err != nil {
+		return nil, err
+	}
+	return updatedFields, nil
+}
+
+func (s *appointmentService) Delete(id string) error {
+	return s.appointments.Delete(id)
+}
+
+func (s *appointmentService) GetByPatientID(patientID string, query listquery.Query) ([]models.Appointment, listquery.Page, error) {
+	return s.appointments.FindByPatientID(patientID, query)
+}
+
+func (s *appointmentService) GetByUserID(userID string, query listquery.Query) ([]models.Appointment, listquery.Page, error) {
+	return s.appointments.FindByUserID(userID, query)
+}
+
+// ChangeStatus mengubah status appointment dan mencatatnya di riwayat status
+// (lihat timeline pasien)
+func (s *appointmentService) ChangeStatus(id string, status string, changedBy string) error {
+	changeID, err := s.ids.Generate(utils.EntityStatusChange)
+	if err != nil {
+		return err
+	}
+	change := &models.AppointmentStatusChange{
+		ID:            changeID,
+		AppointmentID: id,
+		ToStatus:      status,
+		ChangedAt:     time.Now(),
+	}
+	if changedBy != "" {
+		change.ChangedByID = &changedBy
+	}
+	if err := s.appointments.ChangeStatus(change); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrAppointmentNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *appointmentService) GetTrash(query listquery.Query) ([]models.Appointment, listquery.Page, error) {
+	return s.appointments.FindDeleted(query)
+}
+
+// Restore memulihkan appointment dari trash
+func (s *appointmentService) Restore(id string) (*models.Appointment, repositories.RestoreResult, error) {
+	restored, result, err := s.appointments.Restore(id)
+	if err != nil {
+		return nil, nil, translateRestoreError(err, ErrAppointmentNotFound)
+	}
+	return restored, result, nil
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/utils"
+)
+
+type AssessmentService interface {
+	Create(input dto.CreateAssessmentRequest) (*models.Assessment, error)
+	GetAll(filter repositories.AssessmentFilter) ([]models.Assessment, listquery.Page, error)
+	GetByID(id string) (*models.Assessment, error)
+	Update(id string, input dto.UpdateAssessmentRequest) (*models.Assessment, []dto.UpdatedField, error)
+	Delete(id string) error
+	GetByPatientID(patientID string, query listquery.Query) ([]models.Assessment, listquery.Page, error)
+	GetTrash(query listquery.Query) ([]models.Assessment, listquery.Page, error)
+	Restore(id string, cascade bool) (*models.Assessment, repositories.RestoreResult, error)
+}
+
+type assessmentService struct {
+	assessments repositories.AssessmentRepository
+	ids         utils.IDGenerator
+}
+
+func NewAssessmentService(assessments repositories.AssessmentRepository, ids utils.IDGenerator) AssessmentService {
+	return &assessmentService{assessments: assessments, ids: ids}
+}
+
+func (s *assessmentService) Create(input dto.CreateAssessmentRequest) (*models.Assessment, error) {
+	id, err := s.ids.Generate(utils.EntityAssessment)
+	if err != nil {
+		return nil, err
+	}
+
+	assessment := &models.Assessment{
+		ID:        id,
+		PatientID: input.PatientID,
+		Date:      input.Date,
+		Answers:   utils.MarshalToJSON(input.Answers),
+	}
+
+	if err := s.assessments.Create(assessment); err != nil {
+		return nil, err
+	}
+	return assessment, nil
+}
+
+func (s *assessmentService) GetAll(filter repositories.AssessmentFilter) ([]models.Assessment, listquery.Page, error) {
+	return s.assessments.FindAll(filter)
+}
+
+func (s *assessmentService) GetByID(id string) (*models.Assessment, error) {
+	assessment, err := s.assessments.FindByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrAssessmentNotFound
+	}
+	return assessment, err
+}
+
+func (s *assessmentService) Update(id string, input dto.UpdateAssessmentRequest) (*models.Assessment, []dto.UpdatedField, error) {
+	assessment, err := s.GetByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Update field
+	var updatedFields []dto.UpdatedField
+	if !assessment.Date.Equal(input.Date) {
+		assessment.Date = input.Date
+		updatedFields = append(updatedFields, dto.UpdatedField{Field: "date", Value: input.Date})
+	}
+	answers := utils.MarshalToJSON(input.Answers)
+	if !jsonEqual(assessment.Answers, answers) {
+		assessment.Answers = answers
+		updatedFields = append(updatedFields, dto.UpdatedField{Field: "answers", Value: input.Answers})
+	}
+	assessment.UpdatedAt = time.Now()
+
+	if err := s.assessments.Update(assessment); err != nil {
+		return nil, nil, err
+	}
+	return assessment, updatedFields, nil
+}
+
+func (s *assessmentService) Delete(id string) error {
+	return s.assessments.Delete(id)
+}
+
+func (s *assessmentService) GetByPatientID(patientID string, query listquery.Query) ([]models.Assessment, listquery.Page, error) {
+	return s.assessments.FindByPatientID(patientID, query)
+}
+
+// jsonEqual membandingkan isi dua dokumen JSON, bukan byte-nya (jsonb dari
+// Postgres diformat ulang)
+func jsonEqual(a, b []byte) bool {
+	var x, y interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func (s *assessmentService) GetTrash(query listquery.Query) ([]models.Assessment, listquery.Page, error) {
+	return s.assessments.FindDeleted(query)
+}
+
+// Restore memulihkan assessment dari trash, dengan cascade beserta
+// prediksinya
+func (s *assessmentService) Restore(id string, cascade bool) (*models.Assessment, repositories.RestoreResult, error) {
+	restored, result, err := s.assessments.Restore(id, cascade)
+	if err != nil {
+		return nil, nil, translateRestoreError(err, ErrAssessmentNotFound)
+	}
+	return restored, result, nil
+}
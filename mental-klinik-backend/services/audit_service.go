@@ -0,0 +1,154 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+)
+
+// AuditEvent adalah satu akses ke data pasien yang akan dicatat. Changes
+// berisi field yang berubah (dari service Update) dan Fields berisi field yang
+// dibuka (reveal); keduanya disimpan hanya nama field-nya supaya audit log
+// tidak menjadi salinan kedua data pasien.
+type AuditEvent struct {
+	ActorID      string
+	ActorRole    string
+	SessionID    string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	PatientID    string
+	Changes      []dto.UpdatedField
+	Fields       []string
+	Reason       string
+	IPAddress    string
+	UserAgent    string
+}
+
+// AuditVerification adalah hasil pemeriksaan rantai hash audit log
+type AuditVerification struct {
+	Valid    bool
+	Checked  int64
+	BrokenAt *int64
+	Reason   string
+	LastHash string
+}
+
+type AuditService interface {
+	Record(events ...AuditEvent) error
+	GetAll(query listquery.Query) ([]models.AuditLog, listquery.Page, error)
+	Export(query listquery.Query, fn func(entry *models.AuditLog) error) error
+	Verify() (*AuditVerification, error)
+}
+
+type auditService struct {
+	logs repositories.AuditRepository
+}
+
+func NewAuditService(logs repositories.AuditRepository) AuditService {
+	return &auditService{logs: logs}
+}
+
+// Record menambahkan event ke audit log dalam satu transaksi
+func (s *auditService) Record(events ...AuditEvent) error {
+	// Presisi mikrodetik sama dengan timestamptz, supaya hash bisa dihitung
+	// ulang dari data yang tersimpan
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	entries := make([]models.AuditLog, 0, len(events))
+	for _, e := range events {
+		entry := models.AuditLog{
+			OccurredAt:   now,
+			ActorID:      e.ActorID,
+			ActorRole:    e.ActorRole,
+			SessionID:    e.SessionID,
+			Action:       e.Action,
+			ResourceType: e.ResourceType,
+			ResourceID:   e.ResourceID,
+			PatientID:    e.PatientID,
+			IPAddress:    e.IPAddress,
+			UserAgent:    e.UserAgent,
+			Reason:       e.Reason,
+		}
+		if len(e.Changes) > 0 || len(e.Fields) > 0 {
+			fields := append([]string{}, e.Fields...)
+			for _, change := range e.Changes {
+				fields = append(fields, change.Field)
+			}
+			raw, err := json.Marshal(fields)
+			if err != nil {
+				return err
+			}
+			entry.ChangedFields = raw
+		}
+		entries = append(entries, entry)
+	}
+	return s.logs.Append(entries)
+}
+
+func (s *auditService) GetAll(query listquery.Query) ([]models.AuditLog, listquery.Page, error) {
+	return s.logs.FindAll(query)
+}
+
+func (s *auditService) Export(query listquery.Query, fn func(entry *models.AuditLog) error) error {
+	return s.logs.Each(query, fn)
+}
+
+// errChainBroken menghentikan iterasi saat rantai hash pertama kali putus
+var errChainBroken = errors.New("audit chain broken")
+
+// Verify menghitung ulang hash seluruh entry dari awal dan memastikan setiap
+// entry menunjuk ke hash entry sebelumnya, tanpa nomor yang hilang, sampai
+// ke posisi terakhir yang tercatat di audit_chain_head
+func (s *auditService) Verify() (*AuditVerification, error) {
+	result := &AuditVerification{Valid: true}
+	var prevID int64
+	prevHash := ""
+
+	broken := func(id int64, reason string) error {
+		result.Valid = false
+		result.BrokenAt = &id
+		result.Reason = reason
+		return errChainBroken
+	}
+
+	err := s.logs.Each(listquery.Query{}, func(entry *models.AuditLog) error {
+		switch {
+		case entry.ID != prevID+1:
+			return broken(entry.ID, "missing entries before this id")
+		case entry.PrevHash != prevHash:
+			return broken(entry.ID, "previous hash does not match")
+		case entry.ComputeHash(prevHash) != entry.Hash:
+			return broken(entry.ID, "entry content does not match its hash")
+		}
+		prevID, prevHash = entry.ID, entry.Hash
+		result.Checked++
+		return nil
+	})
+	if err != nil && !errors.Is(err, errChainBroken) {
+		return nil, err
+	}
+	result.LastHash = prevHash
+	if !result.Valid {
+		return result, nil
+	}
+
+	// Entry terakhir yang dihapus tidak terlihat dari rantai, jadi bandingkan
+	// dengan posisi head
+	head, err := s.logs.Head()
+	if err != nil {
+		return nil, err
+	}
+	if head.LastID != prevID || head.LastHash != prevHash {
+		id := prevID + 1
+		result.Valid = false
+		result.BrokenAt = &id
+		result.Reason = "chain head does not match the last entry"
+	}
+	return result, nil
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+)
+
+// fakeAuditRepository menyimpan rantai audit di memori dengan aturan yang
+// sama seperti repository database
+type fakeAuditRepository struct {
+	entries []models.AuditLog
+	head    models.AuditChainHead
+}
+
+func (r *fakeAuditRepository) Append(entries []models.AuditLog) error {
+	for i := range entries {
+		entries[i].ID = r.head.LastID + 1
+		entries[i].PrevHash = r.head.LastHash
+		entries[i].Hash = entries[i].ComputeHash(r.head.LastHash)
+		r.head.LastID, r.head.LastHash = entries[i].ID, entries[i].Hash
+	}
+	r.entries = append(r.entries, entries...)
+	return nil
+}
+
+func (r *fakeAuditRepository) FindAll(query listquery.Query) ([]models.AuditLog, listquery.Page, error) {
+	return r.entries, listquery.Page{}, nil
+}
+
+func (r *fakeAuditRepository) Each(query listquery.Query, fn func(entry *models.AuditLog) error) error {
+	for i := range r.entries {
+		entry := r.entries[i]
+		if err := fn(&entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *fakeAuditRepository) Head() (*models.AuditChainHead, error) {
+	head := r.head
+	return &head, nil
+}
+
+func newAuditChain(t *testing.T, n int) (*fakeAuditRepository, AuditService) {
+	t.Helper()
+	repo := &fakeAuditRepository{}
+	service := NewAuditService(repo)
+	for i := 0; i < n; i++ {
+		err := service.Record(AuditEvent{
+			ActorID: "doctor-001-aaaaaaaa", ActorRole: "doctor", Action: models.AuditActionRead,
+			ResourceType: "patient", ResourceID: "patient-001-bbbbbbbb", PatientID: "patient-001-bbbbbbbb",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return repo, service
+}
+
+func TestAuditRecord(t *testing.T) {
+	repo, service := newAuditChain(t, 1)
+	err := service.Record(
+		AuditEvent{ActorID: "doctor-001-aaaaaaaa", Action: models.AuditActionUpdate, Changes: []dto.UpdatedField{{Field: "phone", Value: "08123456789"}}},
+		AuditEvent{ActorID: "doctor-001-aaaaaaaa", Action: models.AuditActionReveal, Fields: []string{"nik"}, Reason: "verifikasi BPJS"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(repo.entries) != 3 {
+		t.Fatalf("%d entries, want 3", len(repo.entries))
+	}
+	update, reveal := repo.entries[1], repo.entries[2]
+	if update.PrevHash != repo.entries[0].Hash || reveal.PrevHash != update.Hash {
+		t.Error("entries recorded together are not chained in order")
+	}
+	// Hanya nama field yang disimpan, bukan nilainya
+	if fields := update.Fields(); !slices.Equal(fields, []string{"phone"}) || string(update.ChangedFields) != `["phone"]` {
+		t.Errorf("update fields = %s", update.ChangedFields)
+	}
+	if fields := reveal.Fields(); !slices.Equal(fields, []string{"nik"}) || reveal.Reason != "verifikasi BPJS" {
+		t.Errorf("reveal = %s %q", reveal.ChangedFields, reveal.Reason)
+	}
+	if repo.entries[0].OccurredAt.Location().String() != "UTC" || repo.entries[0].OccurredAt.Nanosecond()%1000 != 0 {
+		t.Errorf("occurredAt %v is not UTC with microsecond precision", repo.entries[0].OccurredAt)
+	}
+}
+
+func TestAuditVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		tamper   func(repo *fakeAuditRepository)
+		checked  int64
+		brokenAt int64
+		reason   string
+	}{
+		{"intact chain", func(repo *fakeAuditRepository) {}, 5, 0, ""},
+		{"modified row", func(repo *fakeAuditRepository) {
+			repo.entries[2].ActorID = "admin-001-cccccccc"
+		}, 2, 3, "entry content does not match its hash"},
+		{"modified row with recomputed hash", func(repo *fakeAuditRepository) {
+			repo.entries[2].Action = models.AuditActionDelete
+			repo.entries[2].Hash = repo.entries[2].ComputeHash(repo.entries[2].PrevHash)
+		}, 3, 4, "previous hash does not match"},
+		{"deleted middle row", func(repo *fakeAuditRepository) {
+			repo.entries = slices.Delete(repo.entries, 1, 2)
+		}, 1, 3, "missing entries before this id"},
+		{"truncated tail", func(repo *fakeAuditRepository) {
+			repo.entries = repo.entries[:3]
+		}, 3, 4, "chain head does not match the last entry"},
+		{"broken genesis", func(repo *fakeAuditRepository) {
+			repo.entries[0].PrevHash = "forged"
+			repo.entries[0].Hash = repo.entries[0].ComputeHash("forged")
+		}, 0, 1, "previous hash does not match"},
+		{"broken previous hash link", func(repo *fakeAuditRepository) {
+			repo.entries[3].PrevHash = repo.entries[1].Hash
+		}, 3, 4, "previous hash does not match"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, service := newAuditChain(t, 5)
+			tt.tamper(repo)
+
+			result, err := service.Verify()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Checked != tt.checked {
+				t.Errorf("checked %d entries, want %d", result.Checked, tt.checked)
+			}
+			if tt.brokenAt == 0 {
+				if !result.Valid || result.BrokenAt != nil || result.LastHash != repo.head.LastHash {
+					t.Errorf("Verify = %+v, want a valid chain", result)
+				}
+				return
+			}
+			if result.Valid || result.BrokenAt == nil || *result.BrokenAt != tt.brokenAt || result.Reason != tt.reason {
+				t.Errorf("Verify = %+v, want broken at %d: %s", result, tt.brokenAt, tt.reason)
+			}
+		})
+	}
+}
+
+func TestAuditVerifyEmpty(t *testing.T) {
+	_, service := newAuditChain(t, 0)
+	result, err := service.Verify()
+	if err != nil || !result.Valid || result.Checked != 0 {
+		t.Errorf("Verify = %+v, %v, want a valid empty chain", result, err)
+	}
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/utils"
+)
+
+type CareTeamService interface {
+	Assign(input dto.AssignCareTeamRequest, assignedBy string) (*models.CareAssignment, error)
+	GetAll(filter repositories.CareAssignmentFilter) ([]models.CareAssignment, listquery.Page, error)
+	GetByID(id string) (*models.CareAssignment, error)
+	Caseload(userID string, query listquery.Query) ([]models.CareAssignment, listquery.Page, error)
+	Transfer(id string, input dto.TransferCareRequest, actor string) (*models.CareAssignment, error)
+	End(id string, input dto.EndCareRequest, actor string) (*models.CareAssignment, error)
+	Handover(input dto.CareHandoverRequest, actor string) (int, []models.CareAssignment, error)
+}
+
+type careTeamService struct {
+	assignments repositories.CareTeamRepository
+	patients    repositories.PatientRepository
+	users       repositories.UserRepository
+	ids         utils.IDGenerator
+}
+
+func NewCareTeamService(
+	assignments repositories.CareTeamRepository,
+	patients repositories.PatientRepository,
+	users repositories.UserRepository,
+	ids utils.IDGenerator,
+) CareTeamService {
+	return &careTeamService{
+		assignments: assignments,
+		patients:    patients,
+		users:       users,
+		ids:         ids,
+	}
+}
+
+func (s *careTeamService) Assign(input dto.AssignCareTeamRequest, assignedBy string) (*models.CareAssignment, error) {
+	start, err := utils.ParseDate(input.StartDate, utils.Today())
+	if err != nil {
+		return nil, ErrInvalidCareDates
+	}
+	var end *time.Time
+	if input.EndDate != "" {
+		e, err := utils.ParseDate(input.EndDate, start)
+		if err != nil || !e.After(start) {
+			return nil, ErrInvalidCareDates
+		}
+		end = &e
+	}
+
+	if _, err := s.patients.FindByID(input.PatientID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+	if _, err := s.findCareUser(input.UserID, input.Role); err != nil {
+		return nil, err
+	}
+
+	// Cek tumpang tindih dengan penugasan lain di periode yang sama
+	existing, err := s.assignments.FindOpenByPatient(input.PatientID, start)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range existing {
+		if end != nil && !a.StartDate.Before(*end) {
+			continue
+		}
+		if a.UserID == input.UserID {
+			return nil, ErrAlreadyOnCareTeam
+		}
+		if input.Role == models.CareRolePrimary && a.Role == models.CareRolePrimary {
+			return nil, ErrPrimaryClinicianExists
+		}
+	}
+
+	id, err := s.ids.Generate(utils.EntityCareTeam)
+	if err != nil {
+		return nil, err
+	}
+	assignment := &models.CareAssignment{
+		ID:           id,
+		PatientID:    input.PatientID,
+		UserID:       input.UserID,
+		Role:         input.Role,
+		StartDate:    start,
+		EndDate:      end,
+		AssignedByID: assignedBy,
+	}
+	if err := s.assignments.Create(assignment); err != nil {
+		return nil, err
+	}
+	return s.GetByID(id)
+}
+
+func (s *careTeamService) GetAll(filter repositories.CareAssignmentFilter) ([]models.CareAssignment, listquery.Page, error) {
+	return s.assignments.FindAll(filter)
+}
+
+func (s *careTeamService) GetByID(id string) (*models.CareAssignment, error) {
+	assignment, err := s.assignments.FindByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrCareAssignmentNotFound
+	}
+	return assignment, err
+}
+
+// Caseload mengambil pasien yang sedang ditangani user (penugasan aktif hari ini)
+func (s *careTeamService) Caseload(userID string, query listquery.Query) ([]models.CareAssignment, listquery.Page, error) {
+	active := true
+	return s.assignments.FindAll(repositories.CareAssignmentFilter{
+		UserID: userID,
+		Active: &active,
+		Query:  query,
+	})
+}
+
+// Transfer mengakhiri penugasan pada tanggal efektif dan memberikan peran yang
+// sama ke user lain mulai tanggal tersebut
+func (s *careTeamService) Transfer(id string, input dto.TransferCareRequest, actor string) (*models.CareAssignment, error) {
+	from, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	effective, err := utils.ParseDate(input.EffectiveDate, utils.Today())
+	if err != nil {
+		return nil, ErrInvalidCareDates
+	}
+	if input.ToUserID == from.UserID {
+		return nil, ErrSameCareUser
+	}
+	if _, err := s.findCareUser(input.ToUserID, from.Role); err != nil {
+		return nil, err
+	}
+
+	transfer, err := s.planTransfer(from, input.ToUserID, effective, actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.assignments.Transfer([]repositories.CareTransfer{transfer}, input.Reason, actor); err != nil {
+		if errors.Is(err, repositories.ErrCareAssignmentChanged) {
+			return nil, ErrCareAssignmentEnded
+		}
+		return nil, err
+	}
+
+	if transfer.Next != nil {
+		return s.GetByID(transfer.Next.ID)
+	}
+	return s.GetByID(from.ID)
+}
+
+func (s *careTeamService) End(id string, input dto.EndCareRequest, actor string) (*models.CareAssignment, error) {
+	assignment, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := utils.ParseDate(input.EndDate, utils.Today())
+	if err != nil || endDate.Before(assignment.StartDate) {
+		return nil, ErrInvalidCareDates
+	}
+	if !assignment.OpenOn(endDate) {
+		return nil, ErrCareAssignmentEnded
+	}
+
+	if err := s.assignments.End(id, endDate, input.Reason, actor); err != nil {
+		if errors.Is(err, repositories.ErrCareAssignmentChanged) {
+			return nil, ErrCareAssignmentEnded
+		}
+		return nil, err
+	}
+	return s.GetByID(id)
+}
+
+// Handover memindahkan semua penugasan yang belum berakhir milik satu user ke
+// user lain dalam satu transaksi. Mengembalikan jumlah penugasan yang
+// diakhiri dan penugasan baru milik user tujuan.
+func (s *careTeamService) Handover(input dto.CareHandoverRequest, actor string) (int, []models.CareAssignment, error) {
+	if input.FromUserID == input.ToUserID {
+		return 0, nil, ErrSameCareUser
+	}
+	effective, err := utils.ParseDate(input.EffectiveDate, utils.Today())
+	if err != nil {
+		return 0, nil, ErrInvalidCareDates
+	}
+	to, err := s.findUser(input.ToUserID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	assignments, err := s.assignments.FindOpenByUser(input.FromUserID, effective)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	onlyPatients := make(map[string]bool, len(input.PatientIDs))
+	for _, id := range input.PatientIDs {
+		onlyPatients[id] = true
+	}
+
+	var transfers []repositories.CareTransfer
+	for i := range assignments {
+		from := &assignments[i]
+		if len(onlyPatients) > 0 && !onlyPatients[from.PatientID] {
+			continue
+		}
+		if !canHoldCareRole(to, from.Role) {
+			return 0, nil, ErrCareRoleMismatch
+		}
+		transfer, err := s.planTransfer(from, to.ID, effective, actor)
+		if err != nil {
+			return 0, nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+	if len(transfers) == 0 {
+		return 0, []models.CareAssignment{}, nil
+	}
+
+	if err := s.assignments.Transfer(transfers, input.Reason, actor); err != nil {
+		if errors.Is(err, repositories.ErrCareAssignmentChanged) {
+			return 0, nil, ErrCareAssignmentEnded
+		}
+		return 0, nil, err
+	}
+
+	created := make([]models.CareAssignment, 0, len(transfers))
+	for _, t := range transfers {
+		if t.Next == nil {
+			continue
+		}
+		assignment, err := s.GetByID(t.Next.ID)
+		if err != nil {
+			return 0, nil, err
+		}
+		created = append(created, *assignment)
+	}
+	return len(transfers), created, nil
+}
+
+// planTransfer menyusun langkah pemindahan satu penugasan ke user tujuan.
+// Jika user tujuan sudah ada di tim perawatan pasien, penugasan lamanya
+// dipertahankan, kecuali yang dipindahkan adalah klinisi utama: penugasan
+// lama user tujuan diakhiri dan ia naik menjadi klinisi utama.
+func (s *careTeamService) planTransfer(from *models.CareAssignment, toUserID string, effective time.Time, actor string) (repositories.CareTransfer, error) {
+	// Penugasan yang belum dimulai dipindahkan mulai tanggal mulainya
+	day := effective
+	if from.StartDate.After(day) {
+		day = from.StartDate
+	}
+	if !from.OpenOn(day) {
+		return repositories.CareTransfer{}, ErrCareAssignmentEnded
+	}
+
+	existing, err := s.assignments.FindOpenByPatient(from.PatientID, day)
+	if err != nil {
+		return repositories.CareTransfer{}, err
+	}
+
+	transfer := repositories.CareTransfer{From: from, EffectiveDate: day}
+	for i := range existing {
+		a := &existing[i]
+		if a.ID == from.ID || a.UserID != toUserID {
+			continue
+		}
+		if a.StartDate.After(day) {
+			return repositories.CareTransfer{}, ErrAlreadyOnCareTeam
+		}
+		if from.Role != models.CareRolePrimary || a.Role == models.CareRolePrimary {
+			return transfer, nil
+		}
+		transfer.Replaced = a
+	}
+
+	id, err := s.ids.Generate(utils.EntityCareTeam)
+	if err != nil {
+		return repositories.CareTransfer{}, err
+	}
+	transfer.Next = &models.CareAssignment{
+		ID:           id,
+		PatientID:    from.PatientID,
+		UserID:       toUserID,
+		Role:         from.Role,
+		StartDate:    day,
+		EndDate:      from.EndDate,
+		AssignedByID: actor,
+	}
+	return transfer, nil
+}
+
+func (s *careTeamService) findUser(id string) (*models.User, error) {
+	user, err := s.users.FindByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+// findCareUser memastikan user ada dan role-nya cocok dengan peran di tim
+// perawatan
+func (s *careTeamService) findCareUser(id string, careRole string) (*models.User, error) {
+	user, err := s.findUser(id)
+	if err != nil {
+		return nil, err
+	}
+	if !canHoldCareRole(user, careRole) {
+		return nil, ErrCareRoleMismatch
+	}
+	return user, nil
+}
+
+// canHoldCareRole: klinisi (primary/secondary) harus dokter, peran staff
+// harus user staff
+func canHoldCareRole(user *models.User, careRole string) bool {
+	if careRole == models.CareRoleStaff {
+		return user.Role == "staff"
+	}
+	return user.Role == "doctor"
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/utils"
+)
+
+// ConsentChecker dipakai fitur yang membutuhkan persetujuan pasien. Require
+// mengembalikan error yang membungkus ErrConsentRequired jika pasien belum
+// memberi atau sudah menarik persetujuan jenis tersebut.
+type ConsentChecker interface {
+	Require(patientID string, consentType string) error
+}
+
+// ConsentStatus adalah status satu jenis persetujuan pasien. Consent nil
+// berarti belum ada persetujuan aktif; CurrentVersion 0 berarti dokumennya
+// belum diterbitkan.
+type ConsentStatus struct {
+	Type           string
+	Consent        *models.PatientConsent
+	CurrentVersion int
+}
+
+type ConsentService interface {
+	ConsentChecker
+	PublishDocument(input dto.CreateConsentDocumentRequest, createdBy string) (*models.ConsentDocument, error)
+	GetDocuments(filter repositories.ConsentDocumentFilter) ([]models.ConsentDocument, listquery.Page, error)
+	GetDocumentByID(id string) (*models.ConsentDocument, error)
+	Grant(patientID string, input dto.GrantConsentRequest, capturedBy string) (*models.PatientConsent, error)
+	GetAll(filter repositories.PatientConsentFilter) ([]models.PatientConsent, listquery.Page, error)
+	GetByID(id string) (*models.PatientConsent, error)
+	Withdraw(id string, input dto.WithdrawConsentRequest, withdrawnBy string) (*models.PatientConsent, error)
+	Summary(patientID string) ([]ConsentStatus, error)
+}
+
+type consentService struct {
+	consents repositories.ConsentRepository
+	patients repositories.PatientRepository
+	contacts repositories.ContactRepository
+	ids      utils.IDGenerator
+}
+
+func NewConsentService(
+	consents repositories.ConsentRepository,
+	patients repositories.PatientRepository,
+	contacts repositories.ContactRepository,
+	ids utils.IDGenerator,
+) ConsentService {
+	return &consentService{
+		consents: consents,
+		patients: patients,
+		contacts: contacts,
+		ids:      ids,
+	}
+}
+
+func (s *consentService) PublishDocument(input dto.CreateConsentDocumentRequest, createdBy string) (*models.ConsentDocument, error) {
+	id, err := s.ids.Generate(utils.EntityConsentDoc)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	document := &models.ConsentDocument{
+		ID:          id,
+		Type:        input.Type,
+		Title:       strings.TrimSpace(input.Title),
+		Body:        input.Body,
+		CreatedByID: createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.consents.CreateDocument(document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+func (s *consentService) GetDocuments(filter repositories.ConsentDocumentFilter) ([]models.ConsentDocument, listquery.Page, error) {
+	return s.consents.FindDocuments(filter)
+}
+
+func (s *consentService) GetDocumentByID(id string) (*models.ConsentDocument, error) {
+	document, err := s.consents.FindDocumentByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrConsentDocumentNotFound
+	}
+	return document, err
+}
+
+// Grant mencatat persetujuan pasien atas versi terbaru dokumen. Persetujuan
+// aktif jenis yang sama atas versi lama ditarik otomatis (digantikan).
+func (s *consentService) Grant(patientID string, input dto.GrantConsentRequest, capturedBy string) (*models.PatientConsent, error) {
+	now := time.Now()
+	grantedAt := now
+	if input.GrantedAt != "" {
+		t, err := time.Parse(time.RFC3339, input.GrantedAt)
+		if err != nil || t.After(now) {
+			return nil, ErrInvalidConsentDate
+		}
+		grantedAt = t
+	}
+
+	patient, err := s.patients.FindByID(patientID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+	guardian := strings.TrimSpace(input.GuardianName)
+	var guardianContactID *string
+	if input.GuardianContactID != "" {
+		contact, err := s.contacts.FindByID(input.GuardianContactID)
+		if errors.Is(err, repositories.ErrNotFound) || (err == nil && (contact.PatientID != patientID || !contact.LegalGuardian)) {
+			return nil, ErrInvalidGuardianContact
+		}
+		if err != nil {
+			return nil, err
+		}
+		guardianContactID = &contact.ID
+		if guardian == "" {
+			guardian = contact.FullName
+		}
+	}
+	if patient.IsMinor(grantedAt) && guardian == "" {
+		return nil, ErrGuardianRequired
+	}
+	document, err := s.GetDocumentByID(input.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.consents.FindCurrentDocument(document.Type)
+	if err != nil {
+		return nil, err
+	}
+	if current.ID != document.ID {
+		return nil, ErrConsentDocumentOutdated
+	}
+
+	existing, err := s.consents.FindActive(patientID, document.Type)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return nil, err
+	}
+	if existing != nil && existing.DocumentID == document.ID {
+		return nil, ErrConsentAlreadyGranted
+	}
+
+	id, err := s.ids.Generate(utils.EntityConsent)
+	if err != nil {
+		return nil, err
+	}
+	consent := &models.PatientConsent{
+		ID:                id,
+		PatientID:         patientID,
+		DocumentID:        document.ID,
+		Type:              document.Type,
+		Method:            input.Method,
+		Notes:             strings.TrimSpace(input.Notes),
+		GuardianName:      guardian,
+		GuardianContactID: guardianContactID,
+		GrantedAt:         grantedAt,
+		CapturedByID:      capturedBy,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	reason := fmt.Sprintf("superseded by version %d", document.Version)
+	if err := s.consents.Grant(consent, existing, reason); err != nil {
+		if errors.Is(err, repositories.ErrConsentChanged) {
+			// Persetujuan lama ditarik oleh request lain di tengah proses
+			return nil, ErrConsentWithdrawn
+		}
+		return nil, err
+	}
+	return s.GetByID(consent.ID)
+}
+
+func (s *consentService) GetAll(filter repositories.PatientConsentFilter) ([]models.PatientConsent, listquery.Page, error) {
+	return s.consents.FindAll(filter)
+}
+
+func (s *consentService) GetByID(id string) (*models.PatientConsent, error) {
+	consent, err := s.consents.FindByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrConsentNotFound
+	}
+	return consent, err
+}
+
+func (s *consentService) Withdraw(id string, input dto.WithdrawConsentRequest, withdrawnBy string) (*models.PatientConsent, error) {
+	if _, err := s.GetByID(id); err != nil {
+		return nil, err
+	}
+	err := s.consents.Withdraw(id, withdrawnBy, strings.TrimSpace(input.Reason), time.Now())
+	if err != nil {
+		if errors.Is(err, repositories.ErrConsentChanged) {
+			return nil, ErrConsentWithdrawn
+		}
+		return nil, err
+	}
+	return s.GetByID(id)
+}
+
+// Summary mengembalikan status setiap jenis persetujuan pasien
+func (s *consentService) Summary(patientID string) ([]ConsentStatus, error) {
+	if _, err := s.patients.FindByID(patientID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+	active, err := s.consents.FindAllActive(patientID)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := s.consents.LatestVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	byType := make(map[string]*models.PatientConsent, len(active))
+	for i := range active {
+		byType[active[i].Type] = &active[i]
+	}
+	statuses := make([]ConsentStatus, 0, len(models.ConsentTypes))
+	for _, consentType := range models.ConsentTypes {
+		statuses = append(statuses, ConsentStatus{
+			Type:           consentType,
+			Consent:        byType[consentType],
+			CurrentVersion: versions[consentType],
+		})
+	}
+	return statuses, nil
+}
+
+// Require mengecek persetujuan aktif. Persetujuan atas versi dokumen lama
+// tetap berlaku sampai ditarik.
+func (s *consentService) Require(patientID string, consentType string) error {
+	_, err := s.consents.FindActive(patientID, consentType)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return fmt.Errorf("%w: %s", ErrConsentRequired, consentType)
+	}
+	return err
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/utils"
+)
+
+// ContactDirectory dipakai fitur yang menghubungi keluarga pasien, misalnya
+// peringatan krisis dan notifikasi. NotifiableContacts mengembalikan kontak
+// sesuai urutan dihubungi; jika clinical bernilai true (pesan berisi
+// informasi klinis) hanya kontak yang boleh menerima informasi klinis dan
+// wali sah pasien di bawah umur yang dikembalikan.
+type ContactDirectory interface {
+	NotifiableContacts(patientID string, clinical bool) ([]models.PatientContact, error)
+}
+
+type ContactService interface {
+	ContactDirectory
+	Create(patientID string, input dto.CreateContactRequest) (*models.PatientContact, error)
+	GetAll(filter repositories.PatientContactFilter) ([]models.PatientContact, listquery.Page, error)
+	GetByID(patientID string, id string) (*models.PatientContact, error)
+	Update(patientID string, id string, input dto.UpdateContactRequest) (*models.PatientContact, []dto.UpdatedField, error)
+	Delete(patientID string, id string) error
+}
+
+type contactService struct {
+	contacts repositories.ContactRepository
+	patients repositories.PatientRepository
+	ids      utils.IDGenerator
+}
+
+func NewContactService(contacts repositories.ContactRepository, patients repositories.PatientRepository, ids utils.IDGenerator) ContactService {
+	return &contactService{contacts: contacts, patients: patients, ids: ids}
+}
+
+// Create menambahkan kontak pasien. Priority kosong berarti setelah kontak
+// terakhir pasien.
+func (s *contactService) Create(patientID string, input dto.CreateContactRequest) (*models.PatientContact, error) {
+	patient, err := s.patients.FindByID(patientID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	contact, err := newContact(s.ids, patientID, input, nextContactPriority(patient.Contacts))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.contacts.Create(contact); err != nil {
+		return nil, err
+	}
+	return contact, nil
+}
+
+func (s *contactService) GetAll(filter repositories.PatientContactFilter) ([]models.PatientContact, listquery.Page, error) {
+	return s.contacts.FindAll(filter)
+}
+
+// GetByID mengambil kontak milik pasien patientID; kontak pasien lain
+// dianggap tidak ada
+func (s *contactService) GetByID(patientID string, id string) (*models.PatientContact, error) {
+	contact, err := s.contacts.FindByID(id)
+	if errors.Is(err, repositories.ErrNotFound) || (err == nil && contact.PatientID != patientID) {
+		return nil, ErrContactNotFound
+	}
+	return contact, err
+}
+
+// Update mengubah field yang diisi dan mengembalikan field yang benar-benar
+// berubah (dipakai audit log)
+func (s *contactService) Update(patientID string, id string, input dto.UpdateContactRequest) (*models.PatientContact, []dto.UpdatedField, error) {
+	contact, err := s.GetByID(patientID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var updatedFields []dto.UpdatedField
+	updatedFields = applyStringUpdate(updatedFields, "fullName", &contact.FullName, strings.TrimSpace(input.FullName))
+	updatedFields = applyStringUpdate(updatedFields, "relationship", &contact.Relationship, input.Relationship)
+	updatedFields = applyStringUpdate(updatedFields, "phone", &contact.Phone, strings.TrimSpace(input.Phone))
+	if input.Priority != nil && *input.Priority != contact.Priority {
+		contact.Priority = *input.Priority
+		updatedFields = append(updatedFields, dto.UpdatedField{Field: "priority", Value: contact.Priority})
+	}
+	if input.MayReceiveClinicalInfo != nil && *input.MayReceiveClinicalInfo != contact.MayReceiveClinicalInfo {
+		contact.MayReceiveClinicalInfo = *input.MayReceiveClinicalInfo
+		updatedFields = append(updatedFields, dto.UpdatedField{Field: "mayReceiveClinicalInfo", Value: contact.MayReceiveClinicalInfo})
+	}
+	if input.LegalGuardian != nil && *input.LegalGuardian != contact.LegalGuardian {
+		contact.LegalGuardian = *input.LegalGuardian
+		updatedFields = append(updatedFields, dto.UpdatedField{Field: "legalGuardian", Value: contact.LegalGuardian})
+	}
+
+	if len(updatedFields) == 0 {
+		return nil, nil, ErrNoFieldsToUpdate
+	}
+
+	contact.UpdatedAt = time.Now()
+	if err := s.contacts.Update(contact); err != nil {
+		return nil, nil, err
+	}
+	return contact, updatedFields, nil
+}
+
+// Delete menghapus kontak permanen. Persetujuan yang pernah diberikan kontak
+// wali ini tetap menyimpan nama walinya.
+func (s *contactService) Delete(patientID string, id string) error {
+	if _, err := s.GetByID(patientID, id); err != nil {
+		return err
+	}
+	err := s.contacts.Delete(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrContactNotFound
+	}
+	return err
+}
+
+// NotifiableContacts memilih kontak yang boleh dihubungi. Wali sah pasien di
+// bawah umur selalu boleh menerima informasi klinis.
+func (s *contactService) NotifiableContacts(patientID string, clinical bool) ([]models.PatientContact, error) {
+	patient, err := s.patients.FindByID(patientID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+	if !clinical {
+		return patient.Contacts, nil
+	}
+
+	minor := patient.IsMinor(utils.Today())
+	var contacts []models.PatientContact
+	for _, contact := range patient.Contacts {
+		if contact.MayReceiveClinicalInfo || (minor && contact.LegalGuardian) {
+			contacts = append(contacts, contact)
+		}
+	}
+	return contacts, nil
+}
+
+// newContact membuat kontak baru dari input. fallbackPriority dipakai jika
+// input tidak menentukan priority.
+func newContact(ids utils.IDGenerator, patientID string, input dto.CreateContactRequest, fallbackPriority int) (*models.PatientContact, error) {
+	id, err := ids.Generate(utils.EntityContact)
+	if err != nil {
+		return nil, err
+	}
+	priority := input.Priority
+	if priority == 0 {
+		priority = fallbackPriority
+	}
+	return &models.PatientContact{
+		ID:                     id,
+		PatientID:              patientID,
+		FullName:               strings.TrimSpace(input.FullName),
+		Relationship:           input.Relationship,
+		Phone:                  strings.TrimSpace(input.Phone),
+		Priority:               priority,
+		MayReceiveClinicalInfo: input.MayReceiveClinicalInfo,
+		LegalGuardian:          input.LegalGuardian,
+	}, nil
+}
+
+// nextContactPriority adalah priority setelah kontak terakhir
+func nextContactPriority(contacts []models.PatientContact) int {
+	next := 1
+	for _, contact := range contacts {
+		if contact.Priority >= next {
+			next = contact.Priority + 1
+		}
+	}
+	return next
+}
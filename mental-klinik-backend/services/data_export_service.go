@@ -0,0 +1,510 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/pdf"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/utils"
+)
+
+// DataExportFormat adalah versi format data.json di dalam bundle export
+const DataExportFormat = "mental-klinik-export/v2"
+
+// dataExportTTL adalah lama bundle export bisa di-download setelah selesai
+const dataExportTTL = 7 * 24 * time.Hour
+
+type DataExportService interface {
+	Request(patientID string, requestedBy string) (*models.DataExport, error)
+	Resume() error
+	GetAll(query listquery.Query) ([]models.DataExport, listquery.Page, error)
+	GetByID(id string) (*models.DataExport, error)
+	Download(id string) (*models.DataExport, error)
+}
+
+type dataExportService struct {
+	exports  repositories.DataExportRepository
+	patients repositories.PatientRepository
+}
+
+func NewDataExportService(exports repositories.DataExportRepository, patients repositories.PatientRepository) DataExportService {
+	return &dataExportService{exports: exports, patients: patients}
+}
+
+// Request membuat job export lalu menjalankannya di background. Status job
+// bisa dipantau lewat GetByID.
+func (s *dataExportService) Request(patientID string, requestedBy string) (*models.DataExport, error) {
+	if _, err := s.patients.FindByIDWithDeleted(patientID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	export := &models.DataExport{
+		ID:            uuid.NewString(),
+		PatientID:     patientID,
+		RequestedByID: requestedBy,
+		Status:        models.DataExportPending,
+		RequestedAt:   now,
+	}
+	if err := s.exports.Create(export); err != nil {
+		return nil, err
+	}
+	go s.run(export.ID)
+	return export, nil
+}
+
+// Resume melanjutkan job yang terputus (misalnya server restart di tengah
+// export). Dipanggil sekali saat server start.
+func (s *dataExportService) Resume() error {
+	exports, err := s.exports.FindUnfinished()
+	if err != nil {
+		return err
+	}
+	for _, export := range exports {
+		go s.run(export.ID)
+	}
+	return nil
+}
+
+func (s *dataExportService) GetAll(query listquery.Query) ([]models.DataExport, listquery.Page, error) {
+	return s.exports.FindAll(query)
+}
+
+func (s *dataExportService) GetByID(id string) (*models.DataExport, error) {
+	export, err := s.exports.FindByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrDataExportNotFound
+	}
+	return export, err
+}
+
+// Download mengembalikan export beserta bundle zip-nya
+func (s *dataExportService) Download(id string) (*models.DataExport, error) {
+	export, err := s.exports.FindBundle(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrDataExportNotFound
+		}
+		return nil, err
+	}
+	if export.Status != models.DataExportCompleted {
+		return nil, ErrDataExportNotReady
+	}
+	if export.ExpiresAt != nil && !time.Now().Before(*export.ExpiresAt) || len(export.Bundle) == 0 {
+		return nil, ErrDataExportExpired
+	}
+	return export, nil
+}
+
+func (s *dataExportService) run(id string) {
+	if err := s.exports.Start(id, time.Now()); err != nil {
+		if !errors.Is(err, repositories.ErrDataExportChanged) {
+			log.Printf("data export: start %s: %v", id, err)
+		}
+		return
+	}
+
+	bundle, err := s.build(id)
+	now := time.Now()
+	if err != nil {
+		log.Printf("data export: build %s: %v", id, err)
+		if err := s.exports.Fail(id, "Failed to build export bundle", now); err != nil {
+			log.Printf("data export: mark %s failed: %v", id, err)
+		}
+		return
+	}
+
+	sum := sha256.Sum256(bundle)
+	if err := s.exports.Complete(id, bundle, hex.EncodeToString(sum[:]), now, now.Add(dataExportTTL)); err != nil {
+		log.Printf("data export: complete %s: %v", id, err)
+	}
+}
+
+// build menyusun bundle zip berisi data.json (seluruh data pasien) dan
+// summary.pdf (ringkasan yang mudah dibaca pasien)
+func (s *dataExportService) build(id string) ([]byte, error) {
+	export, err := s.exports.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.exports.CollectPatientData(export.PatientID)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := newExportBundle(export, data, time.Now())
+	content, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{"data.json", content},
+		{"summary.pdf", exportSummaryPDF(bundle)},
+	}
+	for _, file := range files {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(file.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Isi data.json. Struct terpisah dari model supaya format export stabil
+// walaupun model database berubah.
+type exportBundle struct {
+	Format            string                  `json:"format"`
+	ExportID          string                  `json:"exportId"`
+	GeneratedAt       time.Time               `json:"generatedAt"`
+	Patient           exportPatient           `json:"patient"`
+	Contacts          []exportContact         `json:"contacts"`
+	Appointments      []exportAppointment     `json:"appointments"`
+	Assessments       []exportAssessment      `json:"assessments"`
+	MedicalRecords    []exportMedicalRecord   `json:"medicalRecords"`
+	CareTeam          []exportCareAssignment  `json:"careTeam"`
+	Consents          []exportConsent         `json:"consents"`
+	EmergencyAccesses []exportEmergencyAccess `json:"emergencyAccesses"`
+	AccessLog         []exportAccessLogEntry  `json:"accessLog"`
+}
+
+type exportPatient struct {
+	ID        string     `json:"id"`
+	FullName  string     `json:"fullName"`
+	NIK       string     `json:"nik"`
+	BirthDate string     `json:"birthDate"`
+	Gender    string     `json:"gender"`
+	Phone     string     `json:"phone"`
+	Address   string     `json:"address"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+type exportContact struct {
+	ID                     string `json:"id"`
+	FullName               string `json:"fullName"`
+	Relationship           string `json:"relationship"`
+	Phone                  string `json:"phone"`
+	Priority               int    `json:"priority"`
+	MayReceiveClinicalInfo bool   `json:"mayReceiveClinicalInfo"`
+	LegalGuardian          bool   `json:"legalGuardian"`
+}
+
+type exportClinician struct {
+	ID       string `json:"id"`
+	FullName string `json:"fullName"`
+	Role     string `json:"role"`
+}
+
+type exportAppointment struct {
+	ID         string          `json:"id"`
+	ScheduleAt time.Time       `json:"scheduleAt"`
+	Status     string          `json:"status"`
+	Notes      string          `json:"notes"`
+	Clinician  exportClinician `json:"clinician"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	DeletedAt  *time.Time      `json:"deletedAt,omitempty"`
+}
+
+type exportPrediction struct {
+	ID               string     `json:"id"`
+	ResultLabel      string     `json:"resultLabel"`
+	ProbabilityScore float64    `json:"probabilityScore"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	DeletedAt        *time.Time `json:"deletedAt,omitempty"`
+}
+
+type exportAssessment struct {
+	ID         string            `json:"id"`
+	Date       time.Time         `json:"date"`
+	Answers    json.RawMessage   `json:"answers"`
+	Prediction *exportPrediction `json:"prediction,omitempty"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	DeletedAt  *time.Time        `json:"deletedAt,omitempty"`
+}
+
+type exportMedicalRecord struct {
+	ID        string          `json:"id"`
+	Diagnosis string          `json:"diagnosis"`
+	Treatment string          `json:"treatment"`
+	Clinician exportClinician `json:"clinician"`
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *time.Time      `json:"deletedAt,omitempty"`
+}
+
+type exportCareAssignment struct {
+	ID        string          `json:"id"`
+	Role      string          `json:"role"`
+	StartDate string          `json:"startDate"`
+	EndDate   string          `json:"endDate,omitempty"`
+	Clinician exportClinician `json:"clinician"`
+}
+
+type exportConsent struct {
+	ID               string     `json:"id"`
+	Type             string     `json:"type"`
+	DocumentTitle    string     `json:"documentTitle"`
+	DocumentVersion  int        `json:"documentVersion"`
+	Method           string     `json:"method"`
+	GrantedAt        time.Time  `json:"grantedAt"`
+	WithdrawnAt      *time.Time `json:"withdrawnAt,omitempty"`
+	WithdrawalReason string     `json:"withdrawalReason,omitempty"`
+}
+
+type exportEmergencyAccess struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"userId"`
+	Reason    string    `json:"reason"`
+	GrantedAt time.Time `json:"grantedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// exportAccessLogEntry adalah catatan siapa mengakses data pasien. IP dan
+// user agent petugas tidak ikut karena merupakan data pribadi petugas.
+type exportAccessLogEntry struct {
+	OccurredAt   time.Time `json:"occurredAt"`
+	ActorID      string    `json:"actorId"`
+	ActorRole    string    `json:"actorRole"`
+	Action       string    `json:"action"`
+	ResourceType string    `json:"resourceType"`
+	ResourceID   string    `json:"resourceId"`
+	Reason       string    `json:"reason,omitempty"`
+}
+
+func newExportBundle(export *models.DataExport, data *repositories.PatientData, now time.Time) *exportBundle {
+	p := data.Patient
+	bundle := &exportBundle{
+		Format:      DataExportFormat,
+		ExportID:    export.ID,
+		GeneratedAt: now,
+		Patient: exportPatient{
+			ID:        p.ID,
+			FullName:  p.FullName,
+			NIK:       p.NIK,
+			BirthDate: utils.FormatDate(p.BirthDate),
+			Gender:    p.Gender,
+			Phone:     p.Phone,
+			Address:   p.Address,
+			CreatedAt: p.CreatedAt,
+			UpdatedAt: p.UpdatedAt,
+			DeletedAt: deletedAt(p.DeletedAt.Time, p.DeletedAt.Valid),
+		},
+		Contacts:          make([]exportContact, 0, len(p.Contacts)),
+		Appointments:      make([]exportAppointment, 0, len(data.Appointments)),
+		Assessments:       make([]exportAssessment, 0, len(data.Assessments)),
+		MedicalRecords:    make([]exportMedicalRecord, 0, len(data.MedicalRecords)),
+		CareTeam:          make([]exportCareAssignment, 0, len(data.CareAssignments)),
+		Consents:          make([]exportConsent, 0, len(data.Consents)),
+		EmergencyAccesses: make([]exportEmergencyAccess, 0, len(data.EmergencyAccesses)),
+		AccessLog:         make([]exportAccessLogEntry, 0, len(data.AccessLog)),
+	}
+
+	for _, a := range data.Appointments {
+		bundle.Appointments = append(bundle.Appointments, exportAppointment{
+			ID:         a.ID,
+			ScheduleAt: a.ScheduleAt,
+			Status:     a.Status,
+			Notes:      a.Notes,
+			Clinician:  exportClinician{ID: a.User.ID, FullName: a.User.FullName, Role: a.User.Role},
+			CreatedAt:  a.CreatedAt,
+			DeletedAt:  deletedAt(a.DeletedAt.Time, a.DeletedAt.Valid),
+		})
+	}
+	for _, a := range data.Assessments {
+		item := exportAssessment{
+			ID:        a.ID,
+			Date:      a.Date,
+			Answers:   json.RawMessage(a.Answers),
+			CreatedAt: a.CreatedAt,
+			DeletedAt: deletedAt(a.DeletedAt.Time, a.DeletedAt.Valid),
+		}
+		if len(item.Answers) == 0 {
+			item.Answers = json.RawMessage("null")
+		}
+		if pr := a.Prediction; pr != nil {
+			item.Prediction = &exportPrediction{
+				ID:               pr.ID,
+				ResultLabel:      pr.ResultLabel,
+				ProbabilityScore: pr.ProbabilityScore,
+				CreatedAt:        pr.CreatedAt,
+				DeletedAt:        deletedAt(pr.DeletedAt.Time, pr.DeletedAt.Valid),
+			}
+		}
+		bundle.Assessments = append(bundle.Assessments, item)
+	}
+	for _, r := range data.MedicalRecords {
+		bundle.MedicalRecords = append(bundle.MedicalRecords, exportMedicalRecord{
+			ID:        r.ID,
+			Diagnosis: r.Diagnosis,
+			Treatment: r.Treatment,
+			Clinician: exportClinician{ID: r.User.ID, FullName: r.User.FullName, Role: r.User.Role},
+			CreatedAt: r.CreatedAt,
+			UpdatedAt: r.UpdatedAt,
+			DeletedAt: deletedAt(r.DeletedAt.Time, r.DeletedAt.Valid),
+		})
+	}
+	for _, c := range data.CareAssignments {
+		item := exportCareAssignment{
+			ID:        c.ID,
+			Role:      c.Role,
+			StartDate: c.StartDate.Format(utils.DateLayout),
+			Clinician: exportClinician{ID: c.User.ID, FullName: c.User.FullName, Role: c.User.Role},
+		}
+		if c.EndDate != nil {
+			item.EndDate = c.EndDate.Format(utils.DateLayout)
+		}
+		bundle.CareTeam = append(bundle.CareTeam, item)
+	}
+	for _, c := range p.Contacts {
+		bundle.Contacts = append(bundle.Contacts, exportContact{
+			ID:                     c.ID,
+			FullName:               c.FullName,
+			Relationship:           c.Relationship,
+			Phone:                  c.Phone,
+			Priority:               c.Priority,
+			MayReceiveClinicalInfo: c.MayReceiveClinicalInfo,
+			LegalGuardian:          c.LegalGuardian,
+		})
+	}
+	for _, c := range data.Consents {
+		bundle.Consents = append(bundle.Consents, exportConsent{
+			ID:               c.ID,
+			Type:             c.Type,
+			DocumentTitle:    c.Document.Title,
+			DocumentVersion:  c.Document.Version,
+			Method:           c.Method,
+			GrantedAt:        c.GrantedAt,
+			WithdrawnAt:      c.WithdrawnAt,
+			WithdrawalReason: c.WithdrawalReason,
+		})
+	}
+	for _, e := range data.EmergencyAccesses {
+		bundle.EmergencyAccesses = append(bundle.EmergencyAccesses, exportEmergencyAccess{
+			ID:        e.ID,
+			UserID:    e.UserID,
+			Reason:    e.Reason,
+			GrantedAt: e.GrantedAt,
+			ExpiresAt: e.ExpiresAt,
+		})
+	}
+	for _, l := range data.AccessLog {
+		bundle.AccessLog = append(bundle.AccessLog, exportAccessLogEntry{
+			OccurredAt:   l.OccurredAt,
+			ActorID:      l.ActorID,
+			ActorRole:    l.ActorRole,
+			Action:       l.Action,
+			ResourceType: l.ResourceType,
+			ResourceID:   l.ResourceID,
+			Reason:       l.Reason,
+		})
+	}
+	return bundle
+}
+
+func deletedAt(t time.Time, valid bool) *time.Time {
+	if !valid {
+		return nil
+	}
+	return &t
+}
+
+// exportSummaryPDF merangkum bundle untuk dibaca pasien. Jawaban asesmen dan
+// log akses lengkap hanya ada di data.json.
+func exportSummaryPDF(b *exportBundle) []byte {
+	doc := pdf.New("Ringkasan Data Pribadi Pasien")
+	doc.Text("Dokumen ini adalah ringkasan data pribadi Anda yang disimpan klinik, " +
+		"diberikan sesuai hak akses subjek data (UU No. 27 Tahun 2022 tentang Pelindungan Data Pribadi). " +
+		"Data lengkap dalam format yang dapat dibaca mesin ada di file data.json.")
+	doc.Field("ID export", b.ExportID)
+	doc.Field("Dibuat", b.GeneratedAt.Format(time.RFC3339))
+
+	doc.Heading("Identitas")
+	doc.Field("ID pasien", b.Patient.ID)
+	doc.Field("Nama", b.Patient.FullName)
+	doc.Field("NIK", b.Patient.NIK)
+	doc.Field("Tanggal lahir", b.Patient.BirthDate)
+	doc.Field("Jenis kelamin", b.Patient.Gender)
+	doc.Field("Telepon", b.Patient.Phone)
+	doc.Field("Alamat", b.Patient.Address)
+
+	doc.Heading(fmt.Sprintf("Kontak darurat (%d)", len(b.Contacts)))
+	for _, c := range b.Contacts {
+		text := fmt.Sprintf("%d. %s (%s) - %s", c.Priority, c.FullName, c.Relationship, c.Phone)
+		if c.LegalGuardian {
+			text += ", wali sah"
+		}
+		doc.Text(text)
+	}
+
+	doc.Heading(fmt.Sprintf("Janji temu (%d)", len(b.Appointments)))
+	for _, a := range b.Appointments {
+		doc.Text(fmt.Sprintf("%s - %s dengan %s", a.ScheduleAt.Format("2006-01-02 15:04"), a.Status, a.Clinician.FullName))
+	}
+
+	doc.Heading(fmt.Sprintf("Asesmen (%d)", len(b.Assessments)))
+	for _, a := range b.Assessments {
+		text := a.Date.Format(utils.DateLayout)
+		if a.Prediction != nil {
+			text += fmt.Sprintf(" - hasil prediksi: %s (%.2f)", a.Prediction.ResultLabel, a.Prediction.ProbabilityScore)
+		}
+		doc.Text(text)
+	}
+
+	doc.Heading(fmt.Sprintf("Rekam medis (%d)", len(b.MedicalRecords)))
+	for _, r := range b.MedicalRecords {
+		doc.Text(r.CreatedAt.Format(utils.DateLayout) + " oleh " + r.Clinician.FullName)
+		doc.Field("Diagnosis", r.Diagnosis)
+		doc.Field("Terapi", r.Treatment)
+	}
+
+	doc.Heading(fmt.Sprintf("Tim perawatan (%d)", len(b.CareTeam)))
+	for _, c := range b.CareTeam {
+		period := c.StartDate + " s/d " + c.EndDate
+		if c.EndDate == "" {
+			period = "sejak " + c.StartDate
+		}
+		doc.Text(fmt.Sprintf("%s (%s), %s", c.Clinician.FullName, c.Role, period))
+	}
+
+	doc.Heading(fmt.Sprintf("Persetujuan (%d)", len(b.Consents)))
+	for _, c := range b.Consents {
+		text := fmt.Sprintf("%s v%d - diberikan %s", c.DocumentTitle, c.DocumentVersion, c.GrantedAt.Format(utils.DateLayout))
+		if c.WithdrawnAt != nil {
+			text += ", ditarik " + c.WithdrawnAt.Format(utils.DateLayout)
+		}
+		doc.Text(text)
+	}
+
+	doc.Heading("Akses ke data Anda")
+	doc.Text(fmt.Sprintf("%d akses tercatat di audit log, %d di antaranya akses darurat. Rincian ada di data.json (accessLog).",
+		len(b.AccessLog), len(b.EmergencyAccesses)))
+	return doc.Bytes()
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"mental-klinik-backend/repositories"
+)
+
+// translateDeleteError memetakan error penghapusan dari repository ke error
+// domain: relasi penghalang dibungkus ErrDeleteRestricted (blocker-nya tetap
+// bisa diambil dengan errors.As), data yang tidak ada menjadi notFound
+func translateDeleteError(err error, notFound error) error {
+	var blocked *repositories.DeleteBlockedError
+	switch {
+	case errors.As(err, &blocked):
+		return fmt.Errorf("%w: %w", ErrDeleteRestricted, blocked)
+	case errors.Is(err, repositories.ErrInvalidReassignTarget):
+		return ErrInvalidReassignTarget
+	case errors.Is(err, repositories.ErrNotFound):
+		return notFound
+	}
+	return err
+}
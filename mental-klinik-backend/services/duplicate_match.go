@@ -0,0 +1,296 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"mental-klinik-backend/models"
+)
+
+// Bobot tiap bukti pada skor duplikat. Bukti yang tidak diisi di salah satu
+// pasien (tanggal lahir, telepon, NIK) tidak ikut dihitung.
+const (
+	duplicateNameWeight      = 0.45
+	duplicateBirthDateWeight = 0.25
+	duplicatePhoneWeight     = 0.15
+	duplicateNIKWeight       = 0.15
+
+	// duplicateMinNameScore adalah kemiripan nama minimal supaya pasangan
+	// dipertimbangkan, keluarga yang berbagi telepon tidak ikut terdeteksi
+	duplicateMinNameScore = 0.75
+	// duplicateSameName adalah kemiripan nama yang dianggap nama yang sama
+	duplicateSameName = 0.95
+)
+
+// nameTitles adalah sapaan / gelar yang diabaikan saat membandingkan nama
+var nameTitles = map[string]bool{
+	"tn": true, "ny": true, "nn": true, "sdr": true, "sdri": true, "an": true,
+	"bpk": true, "bapak": true, "ibu": true, "h": true, "hj": true, "dr": true,
+}
+
+// duplicateProfile adalah data pasien yang sudah dinormalisasi untuk
+// dibandingkan
+type duplicateProfile struct {
+	id        string
+	nik       string
+	name      string   // token nama terurut, dipisah spasi
+	tokens    []string // token nama terurut
+	birthDate *time.Time
+	phone     string
+}
+
+func newDuplicateProfile(patient *models.Patient) duplicateProfile {
+	tokens := nameTokens(patient.FullName)
+	return duplicateProfile{
+		id:        patient.ID,
+		nik:       strings.TrimSpace(patient.NIK),
+		name:      strings.Join(tokens, " "),
+		tokens:    tokens,
+		birthDate: patient.BirthDate,
+		phone:     normalizePhone(patient.Phone),
+	}
+}
+
+// blockingKeys adalah kunci pengelompokan: hanya pasien yang berbagi minimal
+// satu kunci yang dibandingkan, supaya scan tidak membandingkan semua pasangan
+func (p duplicateProfile) blockingKeys() []string {
+	keys := make([]string, 0, len(p.tokens)+2)
+	for _, token := range p.tokens {
+		if len(token) >= 3 {
+			keys = append(keys, "name:"+token[:3])
+		}
+	}
+	if p.birthDate != nil {
+		keys = append(keys, "birth:"+p.birthDate.Format("2006-01-02"))
+	}
+	if p.phone != "" {
+		keys = append(keys, "phone:"+p.phone)
+	}
+	return keys
+}
+
+// duplicateMatch adalah skor satu pasangan beserta alasannya
+type duplicateMatch struct {
+	score   float64
+	reasons []string
+}
+
+// matchDuplicate menilai kemungkinan a dan b orang yang sama. ok bernilai
+// false jika pasangan tidak perlu direview: nama terlalu berbeda atau tidak
+// ada bukti selain nama.
+func matchDuplicate(a, b duplicateProfile) (duplicateMatch, bool) {
+	nameScore := nameSimilarity(a, b)
+	if nameScore < duplicateMinNameScore {
+		return duplicateMatch{}, false
+	}
+
+	match := duplicateMatch{}
+	total := duplicateNameWeight * nameScore
+	weights := duplicateNameWeight
+	if nameScore >= duplicateSameName {
+		match.reasons = append(match.reasons, models.DuplicateReasonName)
+	} else {
+		match.reasons = append(match.reasons, models.DuplicateReasonSimilarName)
+	}
+
+	corroborated := false
+	if a.birthDate != nil && b.birthDate != nil {
+		corroborated = true
+		weights += duplicateBirthDateWeight
+		switch score := birthDateSimilarity(*a.birthDate, *b.birthDate); {
+		case score == 1:
+			total += duplicateBirthDateWeight
+			match.reasons = append(match.reasons, models.DuplicateReasonBirthDate)
+		case score > 0:
+			total += duplicateBirthDateWeight * score
+			match.reasons = append(match.reasons, models.DuplicateReasonSimilarBirthDate)
+		}
+	}
+	if a.phone != "" && b.phone != "" {
+		corroborated = true
+		weights += duplicatePhoneWeight
+		if a.phone == b.phone {
+			total += duplicatePhoneWeight
+			match.reasons = append(match.reasons, models.DuplicateReasonPhone)
+		}
+	}
+	if a.nik != "" && b.nik != "" {
+		weights += duplicateNIKWeight
+		// NIK yang sama tidak mungkin terdaftar dua kali, yang dicari salah ketik
+		if typo(a.nik, b.nik) {
+			total += duplicateNIKWeight * 0.5
+			match.reasons = append(match.reasons, models.DuplicateReasonSimilarNIK)
+		}
+	}
+	if !corroborated {
+		return duplicateMatch{}, false
+	}
+	match.score = total / weights
+	return match, true
+}
+
+// nameSimilarity adalah kemiripan Jaro-Winkler nama lengkap (token terurut),
+// atau kecocokan token jika salah satu nama lebih pendek ("Budi" dan "Budi
+// Santoso")
+func nameSimilarity(a, b duplicateProfile) float64 {
+	if a.name == "" || b.name == "" {
+		return 0
+	}
+	score := jaroWinkler(a.name, b.name)
+	shorter, longer := a.tokens, b.tokens
+	if len(shorter) > len(longer) {
+		shorter, longer = longer, shorter
+	}
+	if len(shorter) < len(longer) {
+		matched := 0
+		for _, token := range shorter {
+			for _, other := range longer {
+				if jaroWinkler(token, other) >= duplicateSameName {
+					matched++
+					break
+				}
+			}
+		}
+		// Nama yang hanya sebagian tidak pernah dianggap nama yang sama
+		if partial := 0.9 * float64(matched) / float64(len(shorter)); partial > score {
+			score = partial
+		}
+	}
+	return score
+}
+
+// birthDateSimilarity bernilai 1 jika sama, 0.5 jika hanya satu bagian
+// (tanggal, bulan atau tahun) yang berbeda atau tanggal dan bulan tertukar,
+// selain itu 0
+func birthDateSimilarity(a, b time.Time) float64 {
+	if a.Equal(b) {
+		return 1
+	}
+	differs := 0
+	if a.Year() != b.Year() {
+		differs++
+	}
+	if a.Month() != b.Month() {
+		differs++
+	}
+	if a.Day() != b.Day() {
+		differs++
+	}
+	swapped := a.Year() == b.Year() && a.Day() == int(b.Month()) && int(a.Month()) == b.Day()
+	if differs == 1 || swapped {
+		return 0.5
+	}
+	return 0
+}
+
+// typo bernilai true jika a dan b sama panjang dan hanya berbeda satu
+// karakter atau dua karakter bersebelahan yang tertukar
+func typo(a, b string) bool {
+	if len(a) != len(b) || a == b {
+		return false
+	}
+	var diffs []int
+	for i := 0; i < len(a); i++ {
+		if a[i] != b[i] {
+			diffs = append(diffs, i)
+		}
+	}
+	switch len(diffs) {
+	case 1:
+		return true
+	case 2:
+		i, j := diffs[0], diffs[1]
+		return j == i+1 && a[i] == b[j] && a[j] == b[i]
+	}
+	return false
+}
+
+// nameTokens menormalkan nama: huruf kecil, tanpa tanda baca dan gelar,
+// token diurutkan supaya urutan nama tidak berpengaruh
+func nameTokens(name string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	tokens := fields[:0]
+	for _, field := range fields {
+		if !nameTitles[field] {
+			tokens = append(tokens, field)
+		}
+	}
+	sort.Strings(tokens)
+	return tokens
+}
+
+// normalizePhone menyisakan digit dan menyeragamkan awalan 62 / +62 menjadi
+// 0. Nomor yang terlalu pendek dianggap kosong.
+func normalizePhone(phone string) string {
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	normalized := digits.String()
+	if strings.HasPrefix(normalized, "62") {
+		normalized = "0" + normalized[2:]
+	}
+	if len(normalized) < 8 {
+		return ""
+	}
+	return normalized
+}
+
+// jaroWinkler menghitung kemiripan Jaro-Winkler dua string (0 sampai 1)
+func jaroWinkler(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+	window := max(len(ra), len(rb))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		for j := max(0, i-window); j < min(len(rb), i+window+1); j++ {
+			if !matchedB[j] && ra[i] == rb[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, j := 0, 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(ra), len(rb)) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
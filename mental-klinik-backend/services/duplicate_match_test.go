@@ -0,0 +1,232 @@
+package services
+
+import (
+	"cmp"
+	"encoding/json"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"mental-klinik-backend/models"
+)
+
+func date(s string) *time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
+
+func TestJaroWinkler(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"martha", "martha", 1},
+		{"martha", "marhta", 0.961},
+		{"dwayne", "duane", 0.84},
+		{"dixon", "dicksonx", 0.813},
+		{"budi", "santoso", 0},
+		{"", "budi", 0},
+		{"siti", "sity", 0.883},
+	}
+	for _, tt := range tests {
+		got := jaroWinkler(tt.a, tt.b)
+		if math.Abs(got-tt.want) > 0.001 {
+			t.Errorf("jaroWinkler(%q, %q) = %.3f, want %.3f", tt.a, tt.b, got, tt.want)
+		}
+		if back := jaroWinkler(tt.b, tt.a); math.Abs(back-got) > 1e-9 {
+			t.Errorf("jaroWinkler(%q, %q) = %.3f, not symmetric with %.3f", tt.b, tt.a, back, got)
+		}
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		atLeast float64
+		below   float64
+	}{
+		{"same name", "Budi Santoso", "Budi Santoso", duplicateSameName, 1.01},
+		{"order and title ignored", "Tn. Budi Santoso", "santoso, budi", duplicateSameName, 1.01},
+		{"typo", "Budi Santoso", "Budi Santosa", duplicateMinNameScore, 1.01},
+		{"partial name is never the same name", "Budi", "Budi Santoso", duplicateMinNameScore, duplicateSameName},
+		{"different people", "Budi Santoso", "Rina Wulandari", 0, duplicateMinNameScore},
+		{"empty name", "", "Budi", 0, 0.01},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newDuplicateProfile(&models.Patient{FullName: tt.a})
+			b := newDuplicateProfile(&models.Patient{FullName: tt.b})
+			got := nameSimilarity(a, b)
+			if got < tt.atLeast || got >= tt.below {
+				t.Errorf("nameSimilarity(%q, %q) = %.3f, want in [%.2f, %.2f)", tt.a, tt.b, got, tt.atLeast, tt.below)
+			}
+		})
+	}
+}
+
+func TestBirthDateSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"1990-08-15", "1990-08-15", 1},
+		{"1990-08-15", "1991-08-15", 0.5},
+		{"1990-08-15", "1990-08-16", 0.5},
+		{"1990-03-04", "1990-04-03", 0.5},
+		{"1990-08-15", "1991-09-15", 0},
+		{"1990-08-15", "1985-01-01", 0},
+	}
+	for _, tt := range tests {
+		if got := birthDateSimilarity(*date(tt.a), *date(tt.b)); got != tt.want {
+			t.Errorf("birthDateSimilarity(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestTypo(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"3201011508900001", "3201011508900001", false},
+		{"3201011508900001", "3201011508900002", true},
+		{"3201011508900001", "3201011580900001", true},
+		{"3201011508900001", "3201011508900012", false},
+		{"3201011508900001", "3201011508900210", false},
+		{"3201011508900001", "320101150890001", false},
+	}
+	for _, tt := range tests {
+		if got := typo(tt.a, tt.b); got != tt.want {
+			t.Errorf("typo(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := map[string]string{
+		"0812-3456-7890":   "081234567890",
+		"+62 812 3456 789": "08123456789",
+		"6281234567890":    "081234567890",
+		"12345":            "",
+		"":                 "",
+	}
+	for in, want := range tests {
+		if got := normalizePhone(in); got != want {
+			t.Errorf("normalizePhone(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestMatchDuplicate(t *testing.T) {
+	base := models.Patient{ID: "a", FullName: "Budi Santoso", NIK: "3201011508900001", BirthDate: date("1990-08-15"), Phone: "081234567890"}
+	tests := []struct {
+		name    string
+		other   models.Patient
+		ok      bool
+		score   float64 // skor minimal jika ok
+		reasons []string
+	}{
+		{
+			name:    "same person with NIK typo",
+			other:   models.Patient{ID: "b", FullName: "budi santoso", NIK: "3201011508900002", BirthDate: date("1990-08-15"), Phone: "+62 812-3456-7890"},
+			ok:      true,
+			score:   0.9,
+			reasons: []string{models.DuplicateReasonName, models.DuplicateReasonBirthDate, models.DuplicateReasonPhone, models.DuplicateReasonSimilarNIK},
+		},
+		{
+			name:    "similar name and same birth date",
+			other:   models.Patient{ID: "b", FullName: "Budy Santosa", BirthDate: date("1990-08-15")},
+			ok:      true,
+			score:   0.9,
+			reasons: []string{models.DuplicateReasonSimilarName, models.DuplicateReasonBirthDate},
+		},
+		{
+			name:    "same name with different birth date and phone",
+			other:   models.Patient{ID: "b", FullName: "Budi Santoso", BirthDate: date("1975-01-02"), Phone: "081111111111"},
+			ok:      true,
+			score:   0,
+			reasons: []string{models.DuplicateReasonName},
+		},
+		{
+			name:  "name only is not enough",
+			other: models.Patient{ID: "b", FullName: "Budi Santoso"},
+		},
+		{
+			name:  "family sharing a phone",
+			other: models.Patient{ID: "b", FullName: "Rina Wulandari", Phone: "081234567890"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, ok := matchDuplicate(newDuplicateProfile(&base), newDuplicateProfile(&tt.other))
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v (match %+v)", ok, tt.ok, match)
+			}
+			if !ok {
+				return
+			}
+			if match.score < tt.score || match.score > 1 {
+				t.Errorf("score = %.3f, want at least %.2f", match.score, tt.score)
+			}
+			if !slices.Equal(match.reasons, tt.reasons) {
+				t.Errorf("reasons = %v, want %v", match.reasons, tt.reasons)
+			}
+		})
+	}
+}
+
+func TestDetectDuplicates(t *testing.T) {
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	patients := []models.Patient{
+		{ID: "patient-001", FullName: "Budi Santoso", BirthDate: date("1990-08-15"), Phone: "081234567890"},
+		{ID: "patient-002", FullName: "Santoso Budi", BirthDate: date("1990-08-15"), Phone: "0812 3456 7890"},
+		{ID: "patient-003", FullName: "Budi Santosa", BirthDate: date("1990-08-16")},
+		// Tidak berbagi kunci pengelompokan dengan pasien lain
+		{ID: "patient-004", FullName: "Xu Li", BirthDate: date("1990-08-14")},
+		{ID: "patient-005", FullName: "Xu Li", BirthDate: date("2001-02-03")},
+		{ID: "patient-006", FullName: "Rina Wulandari", Phone: "081234567890"},
+	}
+
+	tests := []struct {
+		name      string
+		threshold float64
+		want      [][2]string
+	}{
+		{"low threshold", 0.5, [][2]string{{"patient-001", "patient-002"}, {"patient-001", "patient-003"}, {"patient-002", "patient-003"}}},
+		{"high threshold", 0.95, [][2]string{{"patient-001", "patient-002"}}},
+		{"nothing above 1", 1.01, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := detectDuplicates(patients, tt.threshold, at)
+			var got [][2]string
+			for _, candidate := range candidates {
+				if candidate.PatientAID >= candidate.PatientBID {
+					t.Errorf("pair %s/%s is not ordered", candidate.PatientAID, candidate.PatientBID)
+				}
+				if candidate.Score < tt.threshold {
+					t.Errorf("pair %s/%s score %.3f below threshold", candidate.PatientAID, candidate.PatientBID, candidate.Score)
+				}
+				if candidate.Status != models.DuplicatePending || !candidate.DetectedAt.Equal(at) {
+					t.Errorf("pair %s/%s status %s detected %s", candidate.PatientAID, candidate.PatientBID, candidate.Status, candidate.DetectedAt)
+				}
+				var reasons []string
+				if err := json.Unmarshal(candidate.Reasons, &reasons); err != nil || len(reasons) == 0 {
+					t.Errorf("pair %s/%s reasons %s: %v", candidate.PatientAID, candidate.PatientBID, candidate.Reasons, err)
+				}
+				got = append(got, [2]string{candidate.PatientAID, candidate.PatientBID})
+			}
+			slices.SortFunc(got, func(a, b [2]string) int {
+				return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+			})
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("pairs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
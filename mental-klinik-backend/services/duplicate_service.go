@@ -0,0 +1,265 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+)
+
+// duplicateMaxBlock adalah ukuran kelompok maksimal yang dibandingkan
+// berpasangan. Kelompok yang lebih besar (misalnya awalan nama yang sangat
+// umum) dilewati; pasangannya tetap bisa ditemukan lewat kunci lain.
+const duplicateMaxBlock = 2000
+
+// DuplicateSettings adalah pengaturan job deteksi pasien duplikat
+type DuplicateSettings struct {
+	Enabled   bool
+	Interval  time.Duration
+	Threshold float64 // skor minimal (0 sampai 1) supaya pasangan masuk antrean review
+}
+
+type DuplicateService interface {
+	Settings() DuplicateSettings
+	Scan(trigger string, triggeredBy *string) (*models.DuplicateScan, error)
+	Schedule()
+	RecoverInterrupted() error
+	GetScans(query listquery.Query) ([]models.DuplicateScan, listquery.Page, error)
+	GetCandidates(filter repositories.DuplicateCandidateFilter) ([]models.DuplicateCandidate, listquery.Page, error)
+	GetCandidate(id string) (*models.DuplicateCandidate, error)
+	Dismiss(id string, reviewedBy string) (*models.DuplicateCandidate, error)
+	Merge(input dto.MergePatientsRequest, mergedBy string) (*models.PatientMerge, error)
+	UndoMerge(id string, undoneBy string) (*models.PatientMerge, repositories.RestoreResult, error)
+	GetMerges(query listquery.Query) ([]models.PatientMerge, listquery.Page, error)
+	GetMerge(id string) (*models.PatientMerge, error)
+}
+
+type duplicateService struct {
+	duplicates repositories.DuplicateRepository
+	settings   DuplicateSettings
+}
+
+func NewDuplicateService(duplicates repositories.DuplicateRepository, settings DuplicateSettings) DuplicateService {
+	return &duplicateService{duplicates: duplicates, settings: settings}
+}
+
+func (s *duplicateService) Settings() DuplicateSettings {
+	return s.settings
+}
+
+// Scan memulai pencarian duplikat di background dan mengembalikan scan yang
+// sedang berjalan
+func (s *duplicateService) Scan(trigger string, triggeredBy *string) (*models.DuplicateScan, error) {
+	scan := &models.DuplicateScan{
+		ID:            uuid.NewString(),
+		Trigger:       trigger,
+		TriggeredByID: triggeredBy,
+		Status:        models.DuplicateScanRunning,
+		Threshold:     s.settings.Threshold,
+		StartedAt:     time.Now(),
+	}
+	if err := s.duplicates.CreateScan(scan); err != nil {
+		if errors.Is(err, repositories.ErrDuplicateScanRunning) {
+			return nil, ErrDuplicateScanRunning
+		}
+		return nil, err
+	}
+	go s.execute(scan)
+	return scan, nil
+}
+
+// Schedule menjalankan scan setiap Interval sampai proses berhenti
+func (s *duplicateService) Schedule() {
+	go func() {
+		ticker := time.NewTicker(s.settings.Interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := s.Scan(models.DuplicateScanScheduled, nil); err != nil {
+				log.Printf("duplicates: scheduled scan: %v", err)
+			}
+		}
+	}()
+}
+
+// RecoverInterrupted menandai scan yang terputus saat server berhenti supaya
+// scan berikutnya bisa dimulai
+func (s *duplicateService) RecoverInterrupted() error {
+	count, err := s.duplicates.FailInterrupted(time.Now())
+	if count > 0 {
+		log.Printf("duplicates: marked %d interrupted scan(s) as failed", count)
+	}
+	return err
+}
+
+func (s *duplicateService) execute(scan *models.DuplicateScan) {
+	err := func() error {
+		patients, err := s.duplicates.FindMatchablePatients()
+		if err != nil {
+			return err
+		}
+		scan.Patients = int64(len(patients))
+		candidates := detectDuplicates(patients, s.settings.Threshold, time.Now())
+		scan.Candidates = int64(len(candidates))
+		scan.Created, err = s.duplicates.SaveCandidates(candidates)
+		return err
+	}()
+
+	finished := time.Now()
+	scan.FinishedAt = &finished
+	scan.Status = models.DuplicateScanCompleted
+	if err != nil {
+		log.Printf("duplicates: scan %s failed: %v", scan.ID, err)
+		scan.Status = models.DuplicateScanFailed
+		scan.Error = err.Error()
+	}
+	if err := s.duplicates.FinishScan(scan); err != nil {
+		log.Printf("duplicates: save scan %s: %v", scan.ID, err)
+	}
+}
+
+// detectDuplicates membandingkan pasien yang berbagi kunci pengelompokan dan
+// mengembalikan pasangan dengan skor minimal threshold
+func detectDuplicates(patients []models.Patient, threshold float64, at time.Time) []models.DuplicateCandidate {
+	profiles := make([]duplicateProfile, len(patients))
+	blocks := map[string][]int{}
+	for i := range patients {
+		profiles[i] = newDuplicateProfile(&patients[i])
+		for _, key := range profiles[i].blockingKeys() {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	seen := map[[2]int]bool{}
+	var candidates []models.DuplicateCandidate
+	for key, members := range blocks {
+		if len(members) > duplicateMaxBlock {
+			log.Printf("duplicates: skipped %d patients sharing %s", len(members), strings.SplitN(key, ":", 2)[0])
+			continue
+		}
+		for x := 0; x < len(members); x++ {
+			for y := x + 1; y < len(members); y++ {
+				pair := [2]int{members[x], members[y]}
+				if seen[pair] {
+					continue
+				}
+				seen[pair] = true
+
+				match, ok := matchDuplicate(profiles[pair[0]], profiles[pair[1]])
+				if !ok || match.score < threshold {
+					continue
+				}
+				reasons, _ := json.Marshal(match.reasons)
+				a, b := repositories.OrderedPair(profiles[pair[0]].id, profiles[pair[1]].id)
+				candidates = append(candidates, models.DuplicateCandidate{
+					ID:         uuid.NewString(),
+					PatientAID: a,
+					PatientBID: b,
+					Score:      math.Round(match.score*1000) / 1000,
+					Reasons:    reasons,
+					Status:     models.DuplicatePending,
+					DetectedAt: at,
+					CreatedAt:  at,
+					UpdatedAt:  at,
+				})
+			}
+		}
+	}
+	return candidates
+}
+
+func (s *duplicateService) GetScans(query listquery.Query) ([]models.DuplicateScan, listquery.Page, error) {
+	return s.duplicates.FindScans(query)
+}
+
+func (s *duplicateService) GetCandidates(filter repositories.DuplicateCandidateFilter) ([]models.DuplicateCandidate, listquery.Page, error) {
+	return s.duplicates.FindCandidates(filter)
+}
+
+func (s *duplicateService) GetCandidate(id string) (*models.DuplicateCandidate, error) {
+	candidate, err := s.duplicates.FindCandidateByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrDuplicateCandidateNotFound
+	}
+	return candidate, err
+}
+
+// Dismiss menandai kandidat sebagai bukan orang yang sama sehingga tidak
+// muncul lagi di scan berikutnya
+func (s *duplicateService) Dismiss(id string, reviewedBy string) (*models.DuplicateCandidate, error) {
+	if _, err := s.GetCandidate(id); err != nil {
+		return nil, err
+	}
+	if err := s.duplicates.DismissCandidate(id, reviewedBy, time.Now()); err != nil {
+		if errors.Is(err, repositories.ErrDuplicateCandidateChanged) {
+			return nil, ErrDuplicateCandidateReviewed
+		}
+		return nil, err
+	}
+	return s.GetCandidate(id)
+}
+
+// Merge menggabungkan pasien MergedID ke SurvivorID. Data demografis pasien
+// yang dipertahankan tidak diubah.
+func (s *duplicateService) Merge(input dto.MergePatientsRequest, mergedBy string) (*models.PatientMerge, error) {
+	if input.SurvivorID == input.MergedID {
+		return nil, ErrSamePatientMerge
+	}
+	merge := &models.PatientMerge{
+		ID:         uuid.NewString(),
+		SurvivorID: input.SurvivorID,
+		MergedID:   input.MergedID,
+		MergedByID: mergedBy,
+		MergedAt:   time.Now(),
+	}
+	if err := s.duplicates.Merge(merge); err != nil {
+		return nil, translateMergeError(err, ErrPatientNotFound)
+	}
+	return merge, nil
+}
+
+// UndoMerge membatalkan penggabungan dan memulihkan pasien yang digabung
+func (s *duplicateService) UndoMerge(id string, undoneBy string) (*models.PatientMerge, repositories.RestoreResult, error) {
+	merge, result, err := s.duplicates.UndoMerge(id, undoneBy, time.Now())
+	if err != nil {
+		if errors.Is(err, repositories.ErrPatientMergeChanged) {
+			return nil, nil, ErrPatientMergeUndone
+		}
+		return nil, nil, translateMergeError(err, ErrPatientMergeNotFound)
+	}
+	return merge, result, nil
+}
+
+func (s *duplicateService) GetMerges(query listquery.Query) ([]models.PatientMerge, listquery.Page, error) {
+	return s.duplicates.FindMerges(query)
+}
+
+func (s *duplicateService) GetMerge(id string) (*models.PatientMerge, error) {
+	merge, err := s.duplicates.FindMergeByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrPatientMergeNotFound
+	}
+	return merge, err
+}
+
+func translateMergeError(err error, notFound error) error {
+	var conflict *repositories.RestoreConflictError
+	switch {
+	case errors.Is(err, repositories.ErrNotFound):
+		return notFound
+	case errors.Is(err, repositories.ErrPatientOnLegalHold):
+		return ErrLegalHoldActive
+	case errors.As(err, &conflict):
+		return fmt.Errorf("%w: %s", ErrPatientMergeConflict, conflict.Reason)
+	}
+	return err
+}
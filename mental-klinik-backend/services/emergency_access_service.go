@@ -0,0 +1,161 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+)
+
+// EmergencyTokenGenerator menerbitkan access token akses darurat: token
+// biasa untuk sesi yang sama ditambah scope satu pasien, berlaku sampai
+// expiresAt
+type EmergencyTokenGenerator interface {
+	GenerateEmergencyToken(userID string, role string, sessionID string, accessID string, patientID string, expiresAt time.Time) (string, error)
+}
+
+// EmergencyGrant adalah permintaan akses darurat dari user yang login
+type EmergencyGrant struct {
+	UserID    string
+	Role      string
+	SessionID string
+	PatientID string
+	Reason    string
+	Client    ClientInfo
+}
+
+type EmergencyAccessService interface {
+	Grant(grant EmergencyGrant) (*models.EmergencyAccess, string, error)
+	IsActive(id string) (bool, error)
+	GetAll(filter repositories.EmergencyAccessFilter) ([]models.EmergencyAccess, listquery.Page, int64, error)
+	GetByID(id string) (*models.EmergencyAccess, error)
+	Revoke(id string, revokedBy string) (*models.EmergencyAccess, error)
+	Review(id string, reviewedBy string, input dto.ReviewEmergencyAccessRequest) (*models.EmergencyAccess, error)
+}
+
+type emergencyAccessService struct {
+	accesses repositories.EmergencyAccessRepository
+	patients repositories.PatientRepository
+	careTeam repositories.CareTeamRepository
+	tokens   EmergencyTokenGenerator
+	ttl      time.Duration
+}
+
+func NewEmergencyAccessService(
+	accesses repositories.EmergencyAccessRepository,
+	patients repositories.PatientRepository,
+	careTeam repositories.CareTeamRepository,
+	tokens EmergencyTokenGenerator,
+	ttl time.Duration,
+) EmergencyAccessService {
+	return &emergencyAccessService{
+		accesses: accesses,
+		patients: patients,
+		careTeam: careTeam,
+		tokens:   tokens,
+		ttl:      ttl,
+	}
+}
+
+// Grant mencatat akses darurat lalu menerbitkan token yang membawa scope
+// pasien tersebut. User yang sudah ada di tim perawatan tidak membutuhkannya.
+func (s *emergencyAccessService) Grant(grant EmergencyGrant) (*models.EmergencyAccess, string, error) {
+	if _, err := s.patients.FindByID(grant.PatientID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, "", ErrPatientNotFound
+		}
+		return nil, "", err
+	}
+	onTeam, err := s.careTeam.IsOnCareTeam(grant.UserID, grant.PatientID)
+	if err != nil {
+		return nil, "", err
+	}
+	if onTeam {
+		return nil, "", ErrAlreadyOnCareTeam
+	}
+
+	now := time.Now()
+	access := &models.EmergencyAccess{
+		ID:        uuid.NewString(),
+		UserID:    grant.UserID,
+		PatientID: grant.PatientID,
+		SessionID: grant.SessionID,
+		Reason:    strings.TrimSpace(grant.Reason),
+		IPAddress: grant.Client.IPAddress,
+		UserAgent: grant.Client.UserAgent,
+		GrantedAt: now,
+		ExpiresAt: now.Add(s.ttl),
+	}
+
+	token, err := s.tokens.GenerateEmergencyToken(grant.UserID, grant.Role, grant.SessionID,
+		access.ID, access.PatientID, access.ExpiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.accesses.Create(access); err != nil {
+		return nil, "", err
+	}
+	return access, token, nil
+}
+
+func (s *emergencyAccessService) IsActive(id string) (bool, error) {
+	return s.accesses.IsActive(id, time.Now())
+}
+
+// GetAll mengembalikan laporan akses darurat beserta jumlah yang belum di-review
+func (s *emergencyAccessService) GetAll(filter repositories.EmergencyAccessFilter) ([]models.EmergencyAccess, listquery.Page, int64, error) {
+	accesses, page, err := s.accesses.FindAll(filter)
+	if err != nil {
+		return nil, page, 0, err
+	}
+	pending, err := s.accesses.CountPendingReview()
+	if err != nil {
+		return nil, page, 0, err
+	}
+	return accesses, page, pending, nil
+}
+
+func (s *emergencyAccessService) GetByID(id string) (*models.EmergencyAccess, error) {
+	access, err := s.accesses.FindByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrEmergencyAccessNotFound
+	}
+	return access, err
+}
+
+// Revoke mengakhiri akses darurat sebelum waktunya; token yang sudah
+// diterbitkan langsung ditolak AuthMiddleware
+func (s *emergencyAccessService) Revoke(id string, revokedBy string) (*models.EmergencyAccess, error) {
+	if _, err := s.GetByID(id); err != nil {
+		return nil, err
+	}
+	if err := s.accesses.Revoke(id, revokedBy, time.Now()); err != nil {
+		if errors.Is(err, repositories.ErrEmergencyAccessChanged) {
+			return nil, ErrEmergencyAccessInactive
+		}
+		return nil, err
+	}
+	return s.GetByID(id)
+}
+
+// Review mencatat hasil review admin. Akses yang masih aktif boleh di-review;
+// hasil unjustified tidak mencabut akses secara otomatis.
+func (s *emergencyAccessService) Review(id string, reviewedBy string, input dto.ReviewEmergencyAccessRequest) (*models.EmergencyAccess, error) {
+	if _, err := s.GetByID(id); err != nil {
+		return nil, err
+	}
+	err := s.accesses.Review(id, reviewedBy, input.Outcome, strings.TrimSpace(input.Notes), time.Now())
+	if err != nil {
+		if errors.Is(err, repositories.ErrEmergencyAccessChanged) {
+			return nil, ErrEmergencyAccessReviewed
+		}
+		return nil, err
+	}
+	return s.GetByID(id)
+}
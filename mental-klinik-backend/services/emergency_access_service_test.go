@@ -0,0 +1,248 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+)
+
+// fakeEmergencyAccessRepository menyimpan akses darurat di memori dengan
+// update bersyarat seperti repository database
+type fakeEmergencyAccessRepository struct {
+	accesses []*models.EmergencyAccess
+}
+
+func (r *fakeEmergencyAccessRepository) Create(access *models.EmergencyAccess) error {
+	stored := *access
+	r.accesses = append(r.accesses, &stored)
+	return nil
+}
+
+func (r *fakeEmergencyAccessRepository) find(id string) *models.EmergencyAccess {
+	for _, access := range r.accesses {
+		if access.ID == id {
+			return access
+		}
+	}
+	return nil
+}
+
+func (r *fakeEmergencyAccessRepository) FindByID(id string) (*models.EmergencyAccess, error) {
+	access := r.find(id)
+	if access == nil {
+		return nil, repositories.ErrNotFound
+	}
+	found := *access
+	return &found, nil
+}
+
+func (r *fakeEmergencyAccessRepository) FindAll(filter repositories.EmergencyAccessFilter) ([]models.EmergencyAccess, listquery.Page, error) {
+	var accesses []models.EmergencyAccess
+	for _, access := range r.accesses {
+		if filter.Reviewed != nil && *filter.Reviewed != (access.ReviewedAt != nil) {
+			continue
+		}
+		accesses = append(accesses, *access)
+	}
+	return accesses, listquery.Page{}, nil
+}
+
+func (r *fakeEmergencyAccessRepository) CountPendingReview() (int64, error) {
+	var count int64
+	for _, access := range r.accesses {
+		if access.ReviewedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeEmergencyAccessRepository) IsActive(id string, now time.Time) (bool, error) {
+	access := r.find(id)
+	return access != nil && access.ActiveAt(now), nil
+}
+
+func (r *fakeEmergencyAccessRepository) Revoke(id string, revokedBy string, at time.Time) error {
+	if active, _ := r.IsActive(id, at); !active {
+		return repositories.ErrEmergencyAccessChanged
+	}
+	access := r.find(id)
+	access.RevokedAt, access.RevokedByID = &at, &revokedBy
+	return nil
+}
+
+func (r *fakeEmergencyAccessRepository) Review(id string, reviewedBy string, outcome string, notes string, at time.Time) error {
+	access := r.find(id)
+	if access == nil || access.ReviewedAt != nil {
+		return repositories.ErrEmergencyAccessChanged
+	}
+	access.ReviewedAt, access.ReviewedByID = &at, &reviewedBy
+	access.ReviewOutcome, access.ReviewNotes = outcome, notes
+	return nil
+}
+
+// fakePatientLookup hanya mengimplementasikan FindByID
+type fakePatientLookup struct {
+	repositories.PatientRepository
+	ids map[string]bool
+}
+
+func (r fakePatientLookup) FindByID(id string) (*models.Patient, error) {
+	if !r.ids[id] {
+		return nil, repositories.ErrNotFound
+	}
+	return &models.Patient{ID: id}, nil
+}
+
+// fakeCareTeamRepository hanya mengimplementasikan IsOnCareTeam;
+// members[patientID] berisi user di tim perawatan
+type fakeCareTeamRepository struct {
+	repositories.CareTeamRepository
+	members map[string][]string
+}
+
+func (r fakeCareTeamRepository) IsOnCareTeam(userID string, patientID string) (bool, error) {
+	for _, member := range r.members[patientID] {
+		if member == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// fakeEmergencyTokens menerbitkan token berisi scope pasien
+type fakeEmergencyTokens struct{}
+
+func (fakeEmergencyTokens) GenerateEmergencyToken(userID string, role string, sessionID string, accessID string, patientID string, expiresAt time.Time) (string, error) {
+	return userID + "|" + sessionID + "|" + accessID + "|" + patientID, nil
+}
+
+func newTestEmergencyAccessService(ttl time.Duration) (EmergencyAccessService, *fakeEmergencyAccessRepository) {
+	accesses := &fakeEmergencyAccessRepository{}
+	patients := fakePatientLookup{ids: map[string]bool{"patient-001-aaaaaaaa": true, "patient-002-bbbbbbbb": true}}
+	careTeam := fakeCareTeamRepository{members: map[string][]string{"patient-002-bbbbbbbb": {"doctor-001-cccccccc"}}}
+	return NewEmergencyAccessService(accesses, patients, careTeam, fakeEmergencyTokens{}, ttl), accesses
+}
+
+func emergencyGrant(patientID string) EmergencyGrant {
+	return EmergencyGrant{
+		UserID: "doctor-001-cccccccc", Role: "doctor", SessionID: "session-1", PatientID: patientID,
+		Reason: "  pasien tidak sadar di IGD  ", Client: ClientInfo{IPAddress: "10.0.0.1"},
+	}
+}
+
+func TestEmergencyAccessGrant(t *testing.T) {
+	service, accesses := newTestEmergencyAccessService(time.Hour)
+	access, token, err := service.Grant(emergencyGrant("patient-001-aaaaaaaa"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Token hanya membawa scope pasien yang diminta
+	if token != "doctor-001-cccccccc|session-1|"+access.ID+"|patient-001-aaaaaaaa" {
+		t.Errorf("token = %q", token)
+	}
+	if access.Reason != "pasien tidak sadar di IGD" || access.ExpiresAt.Sub(access.GrantedAt) != time.Hour {
+		t.Errorf("access = %+v", access)
+	}
+	if len(accesses.accesses) != 1 {
+		t.Fatalf("%d accesses stored, want 1", len(accesses.accesses))
+	}
+	if active, _ := service.IsActive(access.ID); !active {
+		t.Error("new emergency access is not active")
+	}
+
+	if _, _, err := service.Grant(emergencyGrant("patient-002-bbbbbbbb")); !errors.Is(err, ErrAlreadyOnCareTeam) {
+		t.Errorf("Grant on own care team = %v, want ErrAlreadyOnCareTeam", err)
+	}
+	if _, _, err := service.Grant(emergencyGrant("patient-404-dddddddd")); !errors.Is(err, ErrPatientNotFound) {
+		t.Errorf("Grant unknown patient = %v, want ErrPatientNotFound", err)
+	}
+	if len(accesses.accesses) != 1 {
+		t.Errorf("rejected grants were stored")
+	}
+}
+
+func TestEmergencyAccessExpiry(t *testing.T) {
+	service, _ := newTestEmergencyAccessService(-time.Minute)
+	access, _, err := service.Grant(emergencyGrant("patient-001-aaaaaaaa"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if active, _ := service.IsActive(access.ID); active {
+		t.Error("expired emergency access is still active")
+	}
+	if _, err := service.Revoke(access.ID, "admin-001-eeeeeeee"); !errors.Is(err, ErrEmergencyAccessInactive) {
+		t.Errorf("Revoke expired access = %v, want ErrEmergencyAccessInactive", err)
+	}
+}
+
+func TestEmergencyAccessRevoke(t *testing.T) {
+	service, _ := newTestEmergencyAccessService(time.Hour)
+	access, _, err := service.Grant(emergencyGrant("patient-001-aaaaaaaa"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revoked, err := service.Revoke(access.ID, "admin-001-eeeeeeee")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revoked.RevokedAt == nil || revoked.RevokedByID == nil || *revoked.RevokedByID != "admin-001-eeeeeeee" {
+		t.Errorf("revoked access = %+v", revoked)
+	}
+	if active, _ := service.IsActive(access.ID); active {
+		t.Error("revoked emergency access is still active")
+	}
+	if _, err := service.Revoke(access.ID, "admin-001-eeeeeeee"); !errors.Is(err, ErrEmergencyAccessInactive) {
+		t.Errorf("second Revoke = %v, want ErrEmergencyAccessInactive", err)
+	}
+	if _, err := service.Revoke("unknown", "admin-001-eeeeeeee"); !errors.Is(err, ErrEmergencyAccessNotFound) {
+		t.Errorf("Revoke unknown = %v, want ErrEmergencyAccessNotFound", err)
+	}
+}
+
+func TestEmergencyAccessReviewReport(t *testing.T) {
+	service, _ := newTestEmergencyAccessService(time.Hour)
+	access, _, err := service.Grant(emergencyGrant("patient-001-aaaaaaaa"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pendingOnly := false
+	report, _, pending, err := service.GetAll(repositories.EmergencyAccessFilter{Reviewed: &pendingOnly})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report) != 1 || report[0].ID != access.ID || report[0].Reason != access.Reason || pending != 1 {
+		t.Fatalf("review queue = %+v, pending %d, want the grant", report, pending)
+	}
+
+	// Akses yang masih aktif boleh di-review dan tidak ikut dicabut
+	reviewed, err := service.Review(access.ID, "admin-001-eeeeeeee", dto.ReviewEmergencyAccessRequest{Outcome: models.EmergencyReviewJustified, Notes: " sesuai catatan IGD "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reviewed.ReviewedAt == nil || reviewed.ReviewOutcome != models.EmergencyReviewJustified || reviewed.ReviewNotes != "sesuai catatan IGD" {
+		t.Errorf("reviewed access = %+v", reviewed)
+	}
+	if active, _ := service.IsActive(access.ID); !active {
+		t.Error("review revoked the emergency access")
+	}
+
+	report, _, pending, _ = service.GetAll(repositories.EmergencyAccessFilter{Reviewed: &pendingOnly})
+	if len(report) != 0 || pending != 0 {
+		t.Errorf("review queue = %+v, pending %d, want empty", report, pending)
+	}
+	report, _, _, _ = service.GetAll(repositories.EmergencyAccessFilter{})
+	if len(report) != 1 {
+		t.Errorf("full report = %+v, want the reviewed grant", report)
+	}
+	if _, err := service.Review(access.ID, "admin-001-eeeeeeee", dto.ReviewEmergencyAccessRequest{Outcome: models.EmergencyReviewUnjustified}); !errors.Is(err, ErrEmergencyAccessReviewed) {
+		t.Errorf("second Review = %v, want ErrEmergencyAccessReviewed", err)
+	}
+}
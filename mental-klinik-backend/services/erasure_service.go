@@ -0,0 +1,265 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/pdf"
+	"mental-klinik-backend/repositories"
+)
+
+// retentionBasis adalah dasar penyimpanan data yang tidak ikut dihapus
+var retentionBasis = map[string]string{
+	"medical_records":    "Permenkes No. 24 Tahun 2022: rekam medis wajib disimpan paling singkat 25 tahun",
+	"audit_logs":         "UU No. 27 Tahun 2022 Pasal 31: rekaman kegiatan pemrosesan data pribadi",
+	"emergency_accesses": "Bukti akuntabilitas akses darurat (break-the-glass)",
+	"patient_consents":   "Bukti dasar pemrosesan (persetujuan) yang pernah diberikan",
+	"legal_holds":        "Bukti legal hold yang pernah berlaku",
+}
+
+// ErasureCertificate adalah isi sertifikat penyelesaian penghapusan data
+type ErasureCertificate struct {
+	CertificateID  string            `json:"certificateId"`
+	PatientID      string            `json:"patientId"`
+	Mode           string            `json:"mode"`
+	Reason         string            `json:"reason"`
+	RequestedByID  string            `json:"requestedById"`
+	RequestedAt    time.Time         `json:"requestedAt"`
+	ApprovedByID   string            `json:"approvedById"`
+	CompletedAt    time.Time         `json:"completedAt"`
+	Erased         map[string]int64  `json:"erased"`
+	Anonymized     map[string]int64  `json:"anonymized"`
+	Retained       map[string]int64  `json:"retained"`
+	RetentionBasis map[string]string `json:"retentionBasis"`
+	Statement      string            `json:"statement"`
+}
+
+type ErasureService interface {
+	Request(patientID string, requestedBy string, input dto.CreateErasureRequest) (*models.ErasureRequest, error)
+	GetAll(query listquery.Query) ([]models.ErasureRequest, listquery.Page, error)
+	GetByID(id string) (*models.ErasureRequest, error)
+	Approve(id string, approvedBy string, input dto.ApproveErasureRequest) (*models.ErasureRequest, error)
+	Reject(id string, reviewedBy string, input dto.RejectErasureRequest) (*models.ErasureRequest, error)
+	Certificate(id string) (*models.ErasureRequest, error)
+	CertificatePDF(request *models.ErasureRequest) ([]byte, error)
+}
+
+type erasureService struct {
+	requests repositories.ErasureRepository
+	patients repositories.PatientRepository
+}
+
+func NewErasureService(requests repositories.ErasureRepository, patients repositories.PatientRepository) ErasureService {
+	return &erasureService{requests: requests, patients: patients}
+}
+
+// Request mencatat permintaan penghapusan. Hanya satu permintaan pending per
+// pasien; pasien yang sudah dihapus tidak bisa diminta lagi.
+func (s *erasureService) Request(patientID string, requestedBy string, input dto.CreateErasureRequest) (*models.ErasureRequest, error) {
+	if _, err := s.patients.FindByIDWithDeleted(patientID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+	conflicts := []struct {
+		status string
+		err    error
+	}{
+		{models.ErasureCompleted, ErrPatientErased},
+		{models.ErasurePending, ErrErasurePending},
+	}
+	for _, conflict := range conflicts {
+		_, err := s.requests.FindByPatient(patientID, conflict.status)
+		if err == nil {
+			return nil, conflict.err
+		}
+		if !errors.Is(err, repositories.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	request := &models.ErasureRequest{
+		ID:            uuid.NewString(),
+		PatientID:     patientID,
+		Mode:          input.Mode,
+		Reason:        strings.TrimSpace(input.Reason),
+		Status:        models.ErasurePending,
+		RequestedByID: requestedBy,
+		RequestedAt:   now,
+	}
+	if err := s.requests.Create(request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *erasureService) GetAll(query listquery.Query) ([]models.ErasureRequest, listquery.Page, error) {
+	return s.requests.FindAll(query)
+}
+
+func (s *erasureService) GetByID(id string) (*models.ErasureRequest, error) {
+	request, err := s.requests.FindByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrErasureRequestNotFound
+	}
+	return request, err
+}
+
+// Approve menyetujui lalu langsung menjalankan penghapusan dalam satu
+// transaksi. Penyetuju harus admin lain selain peminta (prinsip empat mata),
+// dan penghapusan ditolak selama pasien dalam legal hold.
+func (s *erasureService) Approve(id string, approvedBy string, input dto.ApproveErasureRequest) (*models.ErasureRequest, error) {
+	request, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != models.ErasurePending {
+		return nil, ErrErasureNotPending
+	}
+	if request.RequestedByID == approvedBy {
+		return nil, ErrErasureSelfApproval
+	}
+
+	now := time.Now()
+	err = s.requests.Execute(request, approvedBy, strings.TrimSpace(input.Notes), now,
+		func(result repositories.ErasureResult) ([]byte, string, error) {
+			return newErasureCertificate(request, approvedBy, now, result)
+		})
+	if err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrPatientOnLegalHold):
+			return nil, ErrLegalHoldActive
+		case errors.Is(err, repositories.ErrErasureRequestChanged):
+			return nil, ErrErasureNotPending
+		case errors.Is(err, repositories.ErrNotFound):
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+	return s.GetByID(id)
+}
+
+func (s *erasureService) Reject(id string, reviewedBy string, input dto.RejectErasureRequest) (*models.ErasureRequest, error) {
+	if _, err := s.GetByID(id); err != nil {
+		return nil, err
+	}
+	err := s.requests.Reject(id, reviewedBy, strings.TrimSpace(input.Notes), time.Now())
+	if err != nil {
+		if errors.Is(err, repositories.ErrErasureRequestChanged) {
+			return nil, ErrErasureNotPending
+		}
+		return nil, err
+	}
+	return s.GetByID(id)
+}
+
+// Certificate mengembalikan permintaan yang sudah selesai beserta sertifikatnya
+func (s *erasureService) Certificate(id string) (*models.ErasureRequest, error) {
+	request, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != models.ErasureCompleted || len(request.Certificate) == 0 {
+		return nil, ErrErasureNotCompleted
+	}
+	return request, nil
+}
+
+// CertificatePDF menampilkan sertifikat yang tersimpan sebagai PDF, ditutup
+// dengan hash SHA-256 isi JSON-nya
+func (s *erasureService) CertificatePDF(request *models.ErasureRequest) ([]byte, error) {
+	var cert ErasureCertificate
+	if err := json.Unmarshal(request.Certificate, &cert); err != nil {
+		return nil, err
+	}
+
+	doc := pdf.New("Sertifikat Penghapusan Data Pribadi")
+	doc.Text(cert.Statement)
+	doc.Field("Nomor sertifikat", cert.CertificateID)
+	doc.Field("ID pasien", cert.PatientID)
+	doc.Field("Mode", cert.Mode)
+	doc.Field("Alasan", cert.Reason)
+	doc.Field("Diminta oleh", cert.RequestedByID+" pada "+cert.RequestedAt.Format(time.RFC3339))
+	doc.Field("Disetujui oleh", cert.ApprovedByID)
+	doc.Field("Selesai", cert.CompletedAt.Format(time.RFC3339))
+
+	sections := []struct {
+		title     string
+		counts    map[string]int64
+		withBasis bool
+	}{
+		{"Data dihapus permanen", cert.Erased, false},
+		{"Data dianonimkan", cert.Anonymized, false},
+		{"Data disimpan (kewajiban hukum)", cert.Retained, true},
+	}
+	for _, section := range sections {
+		doc.Heading(section.title)
+		for _, table := range sortedKeys(section.counts) {
+			value := fmt.Sprintf("%d baris", section.counts[table])
+			if section.withBasis && cert.RetentionBasis[table] != "" {
+				value += " - " + cert.RetentionBasis[table]
+			}
+			doc.Field(table, value)
+		}
+	}
+
+	doc.Heading("Verifikasi")
+	doc.Field("SHA-256", request.CertificateHash)
+	return doc.Bytes(), nil
+}
+
+func newErasureCertificate(request *models.ErasureRequest, approvedBy string, at time.Time, result repositories.ErasureResult) ([]byte, string, error) {
+	statement := "Data pribadi pasien telah dihapus sesuai UU No. 27 Tahun 2022 tentang Pelindungan Data Pribadi. " +
+		"Data yang wajib disimpan berdasarkan peraturan perundang-undangan tetap disimpan tanpa identitas langsung pasien."
+	if request.Mode == models.ErasureModeAnonymize {
+		statement = "Identitas pasien telah dihapus (anonimisasi) sesuai UU No. 27 Tahun 2022 tentang Pelindungan Data Pribadi. " +
+			"Data klinis tanpa identitas tetap disimpan untuk kewajiban rekam medis dan statistik."
+	}
+	basis := make(map[string]string, len(result.Retained))
+	for table := range result.Retained {
+		basis[table] = retentionBasis[table]
+	}
+
+	content, err := json.Marshal(ErasureCertificate{
+		CertificateID:  request.ID,
+		PatientID:      request.PatientID,
+		Mode:           request.Mode,
+		Reason:         request.Reason,
+		RequestedByID:  request.RequestedByID,
+		RequestedAt:    request.RequestedAt.UTC(),
+		ApprovedByID:   approvedBy,
+		CompletedAt:    at.UTC(),
+		Erased:         result.Erased,
+		Anonymized:     result.Anonymized,
+		Retained:       result.Retained,
+		RetentionBasis: basis,
+		Statement:      statement,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	sum := sha256.Sum256(content)
+	return content, hex.EncodeToString(sum[:]), nil
+}
+
+func sortedKeys(m map[string]int64) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
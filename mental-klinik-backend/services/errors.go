@@ -0,0 +1,99 @@
+package services
+
+import "errors"
+
+// Error domain yang dikembalikan service. Controller memetakan error ini ke
+// status HTTP dan pesan yang sesuai.
+var (
+	ErrPatientNotFound       = errors.New("patient not found")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrAppointmentNotFound   = errors.New("appointment not found")
+	ErrAssessmentNotFound    = errors.New("assessment not found")
+	ErrPredictionNotFound    = errors.New("prediction not found")
+	ErrMedicalRecordNotFound = errors.New("medical record not found")
+
+	ErrNIKAlreadyRegistered = errors.New("NIK already registered")
+	ErrEmailAlreadyUsed     = errors.New("email already used")
+	ErrInvalidCredentials   = errors.New("invalid credentials")
+	ErrInvalidPassword      = errors.New("invalid password")
+	ErrNoFieldsToUpdate     = errors.New("no fields to update")
+
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
+	ErrSessionRevoked      = errors.New("session has been revoked")
+
+	ErrRegistrationClosed      = errors.New("registration requires an invitation")
+	ErrInvitationNotFound      = errors.New("invitation not found")
+	ErrInvalidInvitation       = errors.New("invalid, used or expired invitation")
+	ErrInvitationEmailMismatch = errors.New("email does not match the invitation")
+	ErrInvitationNotPending    = errors.New("invitation is no longer pending")
+	ErrAdminAlreadyExists      = errors.New("an admin user already exists")
+
+	ErrCareAssignmentNotFound = errors.New("care assignment not found")
+	ErrCareAssignmentEnded    = errors.New("care assignment has already ended")
+	ErrAlreadyOnCareTeam      = errors.New("user is already on the patient's care team")
+	ErrPrimaryClinicianExists = errors.New("patient already has a primary clinician")
+	ErrCareRoleMismatch       = errors.New("user role cannot hold this care team role")
+	ErrInvalidCareDates       = errors.New("invalid care assignment dates")
+	ErrSameCareUser           = errors.New("cannot transfer care to the same user")
+
+	ErrContactNotFound = errors.New("contact not found")
+
+	ErrEmergencyAccessNotFound = errors.New("emergency access not found")
+	ErrEmergencyAccessInactive = errors.New("emergency access has already ended")
+	ErrEmergencyAccessReviewed = errors.New("emergency access has already been reviewed")
+
+	ErrConsentDocumentNotFound = errors.New("consent document not found")
+	ErrConsentDocumentOutdated = errors.New("a newer version of this consent document has been published")
+	ErrConsentNotFound         = errors.New("consent not found")
+	ErrConsentAlreadyGranted   = errors.New("patient has already consented to this document")
+	ErrConsentWithdrawn        = errors.New("consent has already been withdrawn")
+	ErrInvalidConsentDate      = errors.New("consent grant time cannot be in the future")
+	ErrGuardianRequired        = errors.New("guardianName or guardianContactId is required for patients under 18")
+	ErrInvalidGuardianContact  = errors.New("guardianContactId must be a legal guardian contact of the patient")
+	// ErrConsentRequired dibungkus dengan jenis persetujuannya, cek dengan
+	// errors.Is
+	ErrConsentRequired = errors.New("patient consent is missing or withdrawn")
+
+	ErrDataExportNotFound = errors.New("data export not found")
+	ErrDataExportNotReady = errors.New("data export is not ready yet")
+	ErrDataExportExpired  = errors.New("data export has expired")
+
+	ErrErasureRequestNotFound = errors.New("erasure request not found")
+	ErrErasurePending         = errors.New("patient already has a pending erasure request")
+	ErrErasureNotPending      = errors.New("erasure request has already been reviewed")
+	ErrErasureSelfApproval    = errors.New("erasure request must be approved by another admin")
+	ErrErasureNotCompleted    = errors.New("erasure request has not been completed")
+	ErrPatientErased          = errors.New("patient data has already been erased")
+	ErrLegalHoldNotFound      = errors.New("legal hold not found")
+	ErrLegalHoldActive        = errors.New("patient is under an active legal hold")
+	ErrLegalHoldReleased      = errors.New("legal hold has already been released")
+
+	ErrRetentionRunNotFound = errors.New("retention run not found")
+	ErrRetentionRunning     = errors.New("another retention run is still running")
+
+	// ErrRestoreConflict dibungkus dengan alasan data di trash tidak bisa
+	// dipulihkan, cek dengan errors.Is
+	ErrRestoreConflict = errors.New("cannot restore")
+
+	// ErrDeleteRestricted membungkus *repositories.DeleteBlockedError yang
+	// berisi daftar relasi penghalang, cek dengan errors.Is / errors.As
+	ErrDeleteRestricted      = errors.New("cannot delete")
+	ErrInvalidReassignTarget = errors.New("reassignTo must be another active user with the same role")
+
+	// ErrInvalidPatientData membungkus *nik.ValidationError yang berisi
+	// kesalahan per field, cek dengan errors.Is / errors.As
+	ErrInvalidPatientData = errors.New("invalid patient data")
+)
+
+var (
+	ErrDuplicateScanRunning       = errors.New("another duplicate scan is still running")
+	ErrDuplicateCandidateNotFound = errors.New("duplicate candidate not found")
+	ErrDuplicateCandidateReviewed = errors.New("duplicate candidate has already been reviewed")
+	ErrPatientMergeNotFound       = errors.New("patient merge not found")
+	ErrPatientMergeUndone         = errors.New("patient merge has already been undone")
+	ErrSamePatientMerge           = errors.New("cannot merge a patient into itself")
+	// ErrPatientMergeConflict dibungkus dengan alasan penggabungan atau
+	// pembatalannya ditolak, cek dengan errors.Is
+	ErrPatientMergeConflict = errors.New("cannot merge patients")
+)
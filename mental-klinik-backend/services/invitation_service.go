@@ -0,0 +1,70 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+)
+
+type InvitationService interface {
+	Create(input dto.CreateInvitationRequest, createdBy string) (*models.Invitation, string, error)
+	GetAll(query listquery.Query) ([]models.Invitation, listquery.Page, error)
+	Revoke(id string) error
+}
+
+type invitationService struct {
+	invitations repositories.InvitationRepository
+	ttl         time.Duration
+}
+
+func NewInvitationService(invitations repositories.InvitationRepository, ttl time.Duration) InvitationService {
+	return &invitationService{invitations: invitations, ttl: ttl}
+}
+
+// Create membuat undangan baru dan mengembalikan token aslinya (hanya sekali)
+func (s *invitationService) Create(input dto.CreateInvitationRequest, createdBy string) (*models.Invitation, string, error) {
+	raw, err := newOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	invitation := &models.Invitation{
+		ID:          uuid.NewString(),
+		Email:       strings.ToLower(strings.TrimSpace(input.Email)),
+		Role:        input.Role,
+		TokenHash:   hashToken(raw),
+		ExpiresAt:   now.Add(s.ttl),
+		CreatedByID: createdBy,
+		CreatedAt:   now,
+	}
+	if err := s.invitations.Create(invitation); err != nil {
+		return nil, "", err
+	}
+	return invitation, raw, nil
+}
+
+func (s *invitationService) GetAll(query listquery.Query) ([]models.Invitation, listquery.Page, error) {
+	return s.invitations.FindAll(query)
+}
+
+func (s *invitationService) Revoke(id string) error {
+	invitation, err := s.invitations.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrInvitationNotFound
+		}
+		return err
+	}
+	if invitation.UsedAt != nil || invitation.RevokedAt != nil {
+		return ErrInvitationNotPending
+	}
+	return s.invitations.Revoke(id)
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+)
+
+type LegalHoldService interface {
+	Place(patientID string, placedBy string, input dto.PlaceLegalHoldRequest) (*models.LegalHold, error)
+	GetAll(filter repositories.LegalHoldFilter) ([]models.LegalHold, listquery.Page, error)
+	GetByID(id string) (*models.LegalHold, error)
+	Release(id string, releasedBy string, input dto.ReleaseLegalHoldRequest) (*models.LegalHold, error)
+}
+
+type legalHoldService struct {
+	holds    repositories.LegalHoldRepository
+	patients repositories.PatientRepository
+}
+
+func NewLegalHoldService(holds repositories.LegalHoldRepository, patients repositories.PatientRepository) LegalHoldService {
+	return &legalHoldService{holds: holds, patients: patients}
+}
+
+// Place memasang legal hold. Pasien yang sudah di-soft delete tetap bisa
+// ditahan karena datanya masih ada.
+func (s *legalHoldService) Place(patientID string, placedBy string, input dto.PlaceLegalHoldRequest) (*models.LegalHold, error) {
+	now := time.Now()
+	hold := &models.LegalHold{
+		ID:         uuid.NewString(),
+		PatientID:  patientID,
+		Reason:     strings.TrimSpace(input.Reason),
+		Reference:  strings.TrimSpace(input.Reference),
+		PlacedByID: placedBy,
+		PlacedAt:   now,
+	}
+	if err := s.holds.Create(hold); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+	return hold, nil
+}
+
+func (s *legalHoldService) GetAll(filter repositories.LegalHoldFilter) ([]models.LegalHold, listquery.Page, error) {
+	return s.holds.FindAll(filter)
+}
+
+func (s *legalHoldService) GetByID(id string) (*models.LegalHold, error) {
+	hold, err := s.holds.FindByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrLegalHoldNotFound
+	}
+	return hold, err
+}
+
+func (s *legalHoldService) Release(id string, releasedBy string, input dto.ReleaseLegalHoldRequest) (*models.LegalHold, error) {
+	if _, err := s.GetByID(id); err != nil {
+		return nil, err
+	}
+	err := s.holds.Release(id, releasedBy, strings.TrimSpace(input.Reason), time.Now())
+	if err != nil {
+		if errors.Is(err, repositories.ErrLegalHoldChanged) {
+			return nil, ErrLegalHoldReleased
+		}
+		return nil, err
+	}
+	return s.GetByID(id)
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/utils"
+)
+
+type MedicalRecordService interface {
+	Create(input dto.CreateMedicalRecordRequest) (*models.MedicalRecord, error)
+	GetAll(filter repositories.MedicalRecordFilter) ([]models.MedicalRecord, listquery.Page, error)
+	GetByID(id string) (*models.MedicalRecord, error)
+	Update(id string, input dto.UpdateMedicalRecordRequest) ([]dto.UpdatedField, error)
+	Delete(id string) error
+	GetTrash(query listquery.Query) ([]models.MedicalRecord, listquery.Page, error)
+	Restore(id string) (*models.MedicalRecord, repositories.RestoreResult, error)
+}
+
+type medicalRecordService struct {
+	records  repositories.MedicalRecordRepository
+	patients repositories.PatientRepository
+	users    repositories.UserRepository
+	ids      utils.IDGenerator
+}
+
+func NewMedicalRecordService(
+	records repositories.MedicalRecordRepository,
+	patients repositories.PatientRepository,
+	users repositories.UserRepository,
+	ids utils.IDGenerator,
+) MedicalRecordService {
+	return &medicalRecordService{
+		records:  records,
+		patients: patients,
+		users:    users,
+		ids:      ids,
+	}
+}
+
+func (s *medicalRecordService) Create(input dto.CreateMedicalRecordRequest) (*models.MedicalRecord, error) {
+	// Validasi Patient
+	if _, err := s.patients.FindByID(input.PatientID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrPatientNotFound
+		}
+		return nil, err
+	}
+
+	// Validasi User
+	if _, err := s.users.FindByID(input.UserID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	// Generate custom ID
+	id, err := s.ids.Generate(utils.EntityMedicalRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &models.MedicalRecord{
+		ID:        id,
+		PatientID: input.PatientID,
+		UserID:    input.UserID,
+		Diagnosis: input.Diagnosis,
+		Treatment: input.Treatment,
+	}
+
+	if err := s.records.Create(record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (s *medicalRecordService) GetAll(filter repositories.MedicalRecordFilter) ([]models.MedicalRecord, listquery.Page, error) {
+	return s.records.FindAll(filter)
+}
+
+func (s *medicalRecordService) GetByID(id string) (*models.MedicalRecord, error) {
+	record, err := s.records.FindByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrMedicalRecordNotFound
+	}
+	return record, err
+}
+
+func (s *medicalRecordService) Update(id string, input dto.UpdateMedicalRecordRequest) ([]dto.UpdatedField, error) {
+	record, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedFields []dto.UpdatedField
+
+	if record.PatientID != input.PatientID {
+		record.PatientID = input.PatientID
+		updatedFields = append(updatedFields, dto.UpdatedField{
+			Field: "patientId",
+			Value: input.PatientID,
+		})
+	}
+	if record.UserID != input.UserID {
+		record.UserID = input.UserID
+		updatedFields = append(updatedFields, dto.UpdatedField{
+			Field: "userId",
+			Value: input.UserID,
+		})
+	}
+	if record.Diagnosis != input.Diagnosis {
+		record.Diagnosis = input.Diagnosis
+		updatedFields = append(updatedFields, dto.UpdatedField{
+			Field: "diagnosis",
+			Value: input.Diagnosis,
+		})
+	}
+	if record.Treatment != input.Treatment {
+		record.Treatment = input.Treatment
+		updatedFields = append(updatedFields, dto.UpdatedField{
+			Field: "treatment",
+			Value: input.Treatment,
+		})
+	}
+
+	if len(updatedFields) == 0 {
+		return nil, ErrNoFieldsToUpdate
+	}
+
+	record.UpdatedAt = time.Now()
+	if err := s.records.Update(record); err != nil {
+		return nil, err
+	}
+	return updatedFields, nil
+}
+
+func (s *medicalRecordService) Delete(id string) error {
+	record, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	return s.records.Delete(record)
+}
+
+func (s *medicalRecordService) GetTrash(query listquery.Query) ([]models.MedicalRecord, listquery.Page, error) {
+	return s.records.FindDeleted(query)
+}
+
+// Restore memulihkan rekam medis dari trash
+func (s *medicalRecordService) Restore(id string) (*models.MedicalRecord, repositories.RestoreResult, error) {
+	restored, result, err := s.records.Restore(id)
+	if err != nil {
+		return nil, nil, translateRestoreError(err, ErrMedicalRecordNotFound)
+	}
+	return restored, result, nil
+}
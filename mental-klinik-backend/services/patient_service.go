@@ -0,0 +1,282 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/nik"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/utils"
+)
+
+type PatientService interface {
+	Create(input dto.CreatePatientRequest) (*models.Patient, error)
+	DecodeNIK(raw string) (*nik.Info, error)
+	GetAll(filter repositories.PatientFilter) ([]models.Patient, listquery.Page, error)
+	GetByID(id string) (*models.Patient, error)
+	Update(id string, input dto.UpdatePatientInput) (*models.Patient, []dto.UpdatedField, error)
+	Delete(id string) (*repositories.DeleteResult, error)
+	GetTrash(query listquery.Query) ([]models.Patient, listquery.Page, error)
+	Restore(id string, cascade bool) (*models.Patient, repositories.RestoreResult, error)
+	GetLegacyBirthDates(query listquery.Query) ([]LegacyBirthDate, listquery.Page, error)
+}
+
+// LegacyBirthDate adalah pasien dengan tanggal lahir lama yang gagal
+// dikonversi. SuggestedBirthDate diambil dari NIK jika NIK-nya valid.
+type LegacyBirthDate struct {
+	Patient            models.Patient
+	SuggestedBirthDate *time.Time
+}
+
+type patientService struct {
+	patients repositories.PatientRepository
+	contacts repositories.ContactRepository
+	ids      utils.IDGenerator
+	nik      *nik.Validator
+}
+
+func NewPatientService(
+	patients repositories.PatientRepository,
+	contacts repositories.ContactRepository,
+	ids utils.IDGenerator,
+	nikValidator *nik.Validator,
+) PatientService {
+	return &patientService{patients: patients, contacts: contacts, ids: ids, nik: nikValidator}
+}
+
+func (s *patientService) Create(input dto.CreatePatientRequest) (*models.Patient, error) {
+	// NIK harus valid dan cocok dengan tanggal lahir serta jenis kelamin
+	if err := s.nik.Validate(input.NIK, input.BirthDate, input.Gender); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatientData, err)
+	}
+
+	// Cek apakah NIK sudah terdaftar
+	if _, err := s.patients.FindByNIK(input.NIK); !errors.Is(err, repositories.ErrNotFound) {
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrNIKAlreadyRegistered
+	}
+
+	// Generate ID custom
+	id, err := s.ids.Generate(utils.EntityPatient)
+	if err != nil {
+		return nil, err
+	}
+
+	// Format sudah divalidasi bersama NIK
+	birthDate, err := time.Parse(utils.DateLayout, input.BirthDate)
+	if err != nil {
+		return nil, err
+	}
+
+	patient := &models.Patient{
+		ID:        id,
+		FullName:  input.FullName,
+		NIK:       input.NIK,
+		BirthDate: &birthDate,
+		Gender:    input.Gender,
+		Phone:     input.Phone,
+		Address:   input.Address,
+	}
+
+	// Kontak ikut dibuat bersama pasien. emergencyContact lama menjadi kontak
+	// tanpa nama jika tidak ada kontak lain dengan nomor yang sama.
+	contacts := input.Contacts
+	if phone := strings.TrimSpace(input.EmergencyContact); phone != "" && !hasContactPhone(contacts, phone) {
+		contacts = append(contacts, dto.CreateContactRequest{Relationship: models.ContactRelationshipOther, Phone: phone})
+	}
+	for _, contactInput := range contacts {
+		contact, err := newContact(s.ids, id, contactInput, nextContactPriority(patient.Contacts))
+		if err != nil {
+			return nil, err
+		}
+		patient.Contacts = append(patient.Contacts, *contact)
+	}
+
+	if err := s.patients.Create(patient); err != nil {
+		return nil, err
+	}
+	return patient, nil
+}
+
+func (s *patientService) GetAll(filter repositories.PatientFilter) ([]models.Patient, listquery.Page, error) {
+	return s.patients.FindAll(filter)
+}
+
+func (s *patientService) GetByID(id string) (*models.Patient, error) {
+	patient, err := s.patients.FindByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrPatientNotFound
+	}
+	return patient, err
+}
+
+// Update mengubah field yang diisi dan mengembalikan field yang benar-benar
+// berubah (dipakai audit log)
+func (s *patientService) Update(id string, input dto.UpdatePatientInput) (*models.Patient, []dto.UpdatedField, error) {
+	patient, err := s.GetByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// NIK dicocokkan ulang dengan tanggal lahir dan jenis kelamin jika salah
+	// satunya berubah
+	if input.NIK != "" || input.BirthDate != "" || input.Gender != "" {
+		if err := s.nik.Validate(valueOr(input.NIK, patient.NIK), valueOr(input.BirthDate, utils.FormatDate(patient.BirthDate)), valueOr(input.Gender, patient.Gender)); err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidPatientData, err)
+		}
+	}
+
+	// Check if NIK is changing and already exists
+	if input.NIK != "" && input.NIK != patient.NIK {
+		if _, err := s.patients.FindByNIK(input.NIK); !errors.Is(err, repositories.ErrNotFound) {
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, nil, ErrNIKAlreadyRegistered
+		}
+	}
+
+	// Update fields if provided
+	var updatedFields []dto.UpdatedField
+	updatedFields = applyStringUpdate(updatedFields, "nik", &patient.NIK, input.NIK)
+	updatedFields = applyStringUpdate(updatedFields, "fullName", &patient.FullName, input.FullName)
+	if input.BirthDate != "" && input.BirthDate != utils.FormatDate(patient.BirthDate) {
+		birthDate, err := time.Parse(utils.DateLayout, input.BirthDate)
+		if err != nil {
+			return nil, nil, err
+		}
+		patient.BirthDate = &birthDate
+		patient.BirthDateLegacy = nil
+		updatedFields = append(updatedFields, dto.UpdatedField{Field: "birthDate", Value: input.BirthDate})
+	}
+	updatedFields = applyStringUpdate(updatedFields, "gender", &patient.Gender, input.Gender)
+	updatedFields = applyStringUpdate(updatedFields, "phone", &patient.Phone, input.Phone)
+	updatedFields = applyStringUpdate(updatedFields, "address", &patient.Address, input.Address)
+
+	if err := s.patients.Update(patient); err != nil {
+		return nil, nil, err
+	}
+
+	// emergencyContact lama mengganti telepon kontak prioritas tertinggi
+	if phone := strings.TrimSpace(input.EmergencyContact); phone != "" {
+		changed, err := s.updatePrimaryContactPhone(patient, phone)
+		if err != nil {
+			return nil, nil, err
+		}
+		if changed {
+			updatedFields = append(updatedFields, dto.UpdatedField{Field: "emergencyContact", Value: phone})
+		}
+	}
+	return patient, updatedFields, nil
+}
+
+// updatePrimaryContactPhone mengganti telepon kontak prioritas tertinggi
+// pasien, atau membuat kontak baru jika pasien belum punya kontak
+func (s *patientService) updatePrimaryContactPhone(patient *models.Patient, phone string) (bool, error) {
+	primary := patient.PrimaryContact()
+	if primary == nil {
+		input := dto.CreateContactRequest{Relationship: models.ContactRelationshipOther, Phone: phone}
+		contact, err := newContact(s.ids, patient.ID, input, 1)
+		if err != nil {
+			return false, err
+		}
+		if err := s.contacts.Create(contact); err != nil {
+			return false, err
+		}
+		patient.Contacts = append(patient.Contacts, *contact)
+		return true, nil
+	}
+	if primary.Phone == phone {
+		return false, nil
+	}
+	primary.Phone = phone
+	primary.UpdatedAt = time.Now()
+	return true, s.contacts.Update(primary)
+}
+
+// hasContactPhone bernilai true jika salah satu kontak memakai nomor phone
+func hasContactPhone(contacts []dto.CreateContactRequest, phone string) bool {
+	for _, contact := range contacts {
+		if strings.TrimSpace(contact.Phone) == phone {
+			return true
+		}
+	}
+	return false
+}
+
+// valueOr mengembalikan value jika terisi, selain itu fallback
+func valueOr(value string, fallback string) string {
+	if value != "" {
+		return value
+	}
+	return fallback
+}
+
+// DecodeNIK membaca wilayah, tanggal lahir dan jenis kelamin dari NIK untuk
+// membantu pengisian form pendaftaran
+func (s *patientService) DecodeNIK(raw string) (*nik.Info, error) {
+	info, err := s.nik.Decode(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatientData, err)
+	}
+	return info, nil
+}
+
+// GetLegacyBirthDates mengembalikan pasien yang tanggal lahirnya perlu
+// dikoreksi manual setelah migrasi ke kolom date
+func (s *patientService) GetLegacyBirthDates(query listquery.Query) ([]LegacyBirthDate, listquery.Page, error) {
+	patients, page, err := s.patients.FindLegacyBirthDates(query)
+	if err != nil {
+		return nil, page, err
+	}
+	results := make([]LegacyBirthDate, 0, len(patients))
+	for _, patient := range patients {
+		result := LegacyBirthDate{Patient: patient}
+		if info, err := s.nik.Decode(patient.NIK); err == nil {
+			result.SuggestedBirthDate = &info.BirthDate
+		}
+		results = append(results, result)
+	}
+	return results, page, nil
+}
+
+// applyStringUpdate mengganti nilai field jika input terisi dan berbeda dari
+// nilai sekarang, lalu mencatatnya di daftar field yang berubah
+func applyStringUpdate(fields []dto.UpdatedField, name string, target *string, value string) []dto.UpdatedField {
+	if value == "" || value == *target {
+		return fields
+	}
+	*target = value
+	return append(fields, dto.UpdatedField{Field: name, Value: value})
+}
+
+// Delete men-soft delete pasien beserta appointment, assessment (dan
+// prediksinya) serta rekam medisnya
+func (s *patientService) Delete(id string) (*repositories.DeleteResult, error) {
+	result, err := s.patients.Delete(id)
+	if err != nil {
+		return nil, translateDeleteError(err, ErrPatientNotFound)
+	}
+	return result, nil
+}
+
+func (s *patientService) GetTrash(query listquery.Query) ([]models.Patient, listquery.Page, error) {
+	return s.patients.FindDeleted(query)
+}
+
+// Restore memulihkan pasien dari trash, dengan cascade beserta data yang
+// terhapus bersamanya
+func (s *patientService) Restore(id string, cascade bool) (*models.Patient, repositories.RestoreResult, error) {
+	restored, result, err := s.patients.Restore(id, cascade)
+	if err != nil {
+		return nil, nil, translateRestoreError(err, ErrPatientNotFound)
+	}
+	return restored, result, nil
+}
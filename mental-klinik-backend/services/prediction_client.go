@@ -0,0 +1,87 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"mental-klinik-backend/dto"
+)
+
+var (
+	ErrPredictionServiceUnavailable = errors.New("prediction service unavailable")
+	ErrPredictionServiceStatus      = errors.New("prediction service returned error status")
+	ErrPredictionResultInvalid      = errors.New("invalid prediction result")
+)
+
+// PredictionResult adalah hasil yang dikembalikan model ML
+type PredictionResult struct {
+	ResultLabel      string  `json:"resultLabel"`
+	ProbabilityScore float64 `json:"probabilityScore"`
+}
+
+// PredictionClient mengabstraksi service ML eksternal supaya bisa diganti
+// dengan fake saat testing.
+type PredictionClient interface {
+	Predict(answers dto.AssessmentAnswers) (*PredictionResult, error)
+}
+
+type httpPredictionClient struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPPredictionClient(url string, timeout time.Duration) PredictionClient {
+	return &httpPredictionClient{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *httpPredictionClient) Predict(answers dto.AssessmentAnswers) (*PredictionResult, error) {
+	// Kirim data ke model Python
+	payload := map[string]interface{}{
+		"schizophrenia_share":   answers.SchizophreniaShare,
+		"anxiety_share":         answers.AnxietyShare,
+		"bipolar_share":         answers.BipolarShare,
+		"eating_disorder_share": answers.EatingDisorderShare,
+		"DALYs":                 answers.DALYs,
+		"suicide_rate":          answers.SuicideRate,
+		"depression_dalys":      answers.DepressionDALYs,
+		"schizophrenia_dalys":   answers.SchizophreniaDALYs,
+		"bipolar_dalys":         answers.BipolarDALYs,
+		"eating_dalys":          answers.EatingDALYs,
+		"anxiety_dalys":         answers.AnxietyDALYs,
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Post(p.url, "application/json", bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		log.Printf("prediction: request to model service failed: %v", err)
+		return nil, ErrPredictionServiceUnavailable
+	}
+	defer resp.Body.Close()
+
+	// Baca body sekali saja
+	bodyBytes, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("prediction: model service returned status %d", resp.StatusCode)
+		return nil, ErrPredictionServiceStatus
+	}
+
+	var result PredictionResult
+	if err := json.Unmarshal(bodyBytes, &result); err != nil {
+		log.Printf("prediction: decode model response: %v", err)
+		return nil, ErrPredictionResultInvalid
+	}
+	return &result, nil
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/utils"
+)
+
+var ErrInvalidAssessmentAnswers = errors.New("invalid assessment answers")
+
+type PredictionService interface {
+	Predict(assessmentID string) (*models.Prediction, error)
+	GetAll(filter repositories.PredictionFilter) ([]models.Prediction, listquery.Page, error)
+	GetByID(id string) (*models.Prediction, error)
+	GetByAssessmentID(assessmentID string) (*models.Prediction, error)
+	Update(id string, input dto.UpdatePredictionRequest) (*models.Prediction, []dto.UpdatedField, error)
+	Delete(id string) error
+	GetTrash(query listquery.Query) ([]models.Prediction, listquery.Page, error)
+	Restore(id string) (*models.Prediction, repositories.RestoreResult, error)
+}
+
+type predictionService struct {
+	predictions repositories.PredictionRepository
+	assessments repositories.AssessmentRepository
+	client      PredictionClient
+	consents    ConsentChecker
+	ids         utils.IDGenerator
+}
+
+func NewPredictionService(
+	predictions repositories.PredictionRepository,
+	assessments repositories.AssessmentRepository,
+	client PredictionClient,
+	consents ConsentChecker,
+	ids utils.IDGenerator,
+) PredictionService {
+	return &predictionService{
+		predictions: predictions,
+		assessments: assessments,
+		client:      client,
+		consents:    consents,
+		ids:         ids,
+	}
+}
+
+func (s *predictionService) Predict(assessmentID string) (*models.Prediction, error) {
+	// Cek apakah assessment ada
+	assessment, err := s.assessments.FindByID(assessmentID)
+	if err != nil {
+		log.Printf("prediction: load assessment %s: %v", assessmentID, err)
+		return nil, ErrAssessmentNotFound
+	}
+
+	// Jawaban asesmen hanya dikirim ke service AI jika pasien menyetujuinya
+	if err := s.consents.Require(assessment.PatientID, models.ConsentTypeAIPrediction); err != nil {
+		return nil, err
+	}
+
+	// Decode jawaban ke dalam struct
+	var answers dto.AssessmentAnswers
+	if err := json.Unmarshal(assessment.Answers, &answers); err != nil {
+		log.Printf("prediction: decode answers of assessment %s: %v", assessmentID, err)
+		return nil, ErrInvalidAssessmentAnswers
+	}
+
+	result, err := s.client.Predict(answers)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := s.ids.Generate(utils.EntityPrediction)
+	if err != nil {
+		return nil, err
+	}
+
+	// Simpan hasil prediksi
+	prediction := &models.Prediction{
+		ID:               id,
+		AssessmentID:     assessmentID,
+		ResultLabel:      result.ResultLabel,
+		ProbabilityScore: result.ProbabilityScore,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	if err := s.predictions.Create(prediction); err != nil {
+		return nil, err
+	}
+	return prediction, nil
+}
+
+func (s *predictionService) GetAll(filter repositories.PredictionFilter) ([]models.Prediction, listquery.Page, error) {
+	return s.predictions.FindAll(filter)
+}
+
+func (s *predictionService) GetByID(id string) (*models.Prediction, error) {
+	prediction, err := s.predictions.FindByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrPredictionNotFound
+	}
+	return prediction, err
+}
+
+func (s *predictionService) GetByAssessmentID(assessmentID string) (*models.Prediction, error) {
+	prediction, err := s.predictions.FindByAssessmentID(assessmentID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrPredictionNotFound
+	}
+	return prediction, err
+}
+
+func (s *predictionService) Update(id string, input dto.UpdatePredictionRequest) (*models.Prediction, []dto.UpdatedField, error) {
+	prediction, err := s.GetByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Update field
+	var updatedFields []dto.UpdatedField
+	if prediction.ResultLabel != input.ResultLabel {
+		prediction.ResultLabel = input.ResultLabel
+		updatedFields = append(updatedFields, dto.UpdatedField{Field: "resultLabel", Value: input.ResultLabel})
+	}
+	if prediction.ProbabilityScore != input.ProbabilityScore {
+		prediction.ProbabilityScore = input.ProbabilityScore
+		updatedFields = append(updatedFields, dto.UpdatedField{Field: "probabilityScore", Value: input.ProbabilityScore})
+	}
+
+	if err := s.predictions.Update(prediction); err != nil {
+		return nil, nil, err
+	}
+	return prediction, updatedFields, nil
+}
+
+func (s *predictionService) Delete(id string) error {
+	prediction, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	return s.predictions.Delete(prediction)
+}
+
+func (s *predictionService) GetTrash(query listquery.Query) ([]models.Prediction, listquery.Page, error) {
+	return s.predictions.FindDeleted(query)
+}
+
+// Restore memulihkan prediksi dari trash
+func (s *predictionService) Restore(id string) (*models.Prediction, repositories.RestoreResult, error) {
+	restored, result, err := s.predictions.Restore(id)
+	if err != nil {
+		return nil, nil, translateRestoreError(err, ErrPredictionNotFound)
+	}
+	return restored, result, nil
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/utils"
+)
+
+// retentionSamples adalah jumlah contoh ID per kebijakan di laporan dry-run
+const retentionSamples = 20
+
+// RetentionPolicy adalah satu kebijakan retensi dari konfigurasi
+type RetentionPolicy struct {
+	Entity    string
+	Action    string
+	Basis     string
+	RetainFor utils.Period
+}
+
+// RetentionSettings adalah pengaturan scheduler retensi
+type RetentionSettings struct {
+	Enabled   bool
+	Interval  time.Duration
+	DryRun    bool
+	BatchSize int
+	Policies  []RetentionPolicy
+}
+
+type RetentionService interface {
+	Settings() RetentionSettings
+	Run(trigger string, triggeredBy *string, dryRun bool) (*models.RetentionRun, error)
+	Schedule()
+	RecoverInterrupted() error
+	GetRuns(filter repositories.RetentionRunFilter) ([]models.RetentionRun, listquery.Page, error)
+	GetRun(id string) (*models.RetentionRun, error)
+}
+
+type retentionService struct {
+	runs     repositories.RetentionRepository
+	settings RetentionSettings
+}
+
+func NewRetentionService(runs repositories.RetentionRepository, settings RetentionSettings) RetentionService {
+	return &retentionService{runs: runs, settings: settings}
+}
+
+func (s *retentionService) Settings() RetentionSettings {
+	return s.settings
+}
+
+// Run memulai run retensi di background dan mengembalikan laporan dry-run
+// yang sedang disusun. Jika dryRun false, setelah laporan selesai kebijakan
+// dijalankan sebagai run terpisah yang menunjuk laporan tersebut.
+func (s *retentionService) Run(trigger string, triggeredBy *string, dryRun bool) (*models.RetentionRun, error) {
+	report, err := s.startRun(trigger, triggeredBy, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	go s.execute(report, dryRun)
+	return report, nil
+}
+
+// Schedule menjalankan kebijakan setiap Interval sampai proses berhenti
+func (s *retentionService) Schedule() {
+	go func() {
+		ticker := time.NewTicker(s.settings.Interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := s.Run(models.RetentionTriggerScheduled, nil, s.settings.DryRun); err != nil {
+				log.Printf("retention: scheduled run: %v", err)
+			}
+		}
+	}()
+}
+
+// RecoverInterrupted menandai run yang terputus saat server berhenti supaya
+// run berikutnya bisa dimulai
+func (s *retentionService) RecoverInterrupted() error {
+	count, err := s.runs.FailInterrupted(time.Now())
+	if count > 0 {
+		log.Printf("retention: marked %d interrupted run(s) as failed", count)
+	}
+	return err
+}
+
+func (s *retentionService) GetRuns(filter repositories.RetentionRunFilter) ([]models.RetentionRun, listquery.Page, error) {
+	return s.runs.FindRuns(filter)
+}
+
+func (s *retentionService) GetRun(id string) (*models.RetentionRun, error) {
+	run, err := s.runs.FindRunByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrRetentionRunNotFound
+	}
+	return run, err
+}
+
+func (s *retentionService) startRun(trigger string, triggeredBy *string, dryRun bool, reportID *string) (*models.RetentionRun, error) {
+	run := &models.RetentionRun{
+		ID:            uuid.NewString(),
+		Trigger:       trigger,
+		TriggeredByID: triggeredBy,
+		DryRun:        dryRun,
+		ReportRunID:   reportID,
+		Status:        models.RetentionRunning,
+		StartedAt:     time.Now(),
+	}
+	if err := s.runs.CreateRun(run); err != nil {
+		if errors.Is(err, repositories.ErrRetentionRunning) {
+			return nil, ErrRetentionRunning
+		}
+		return nil, err
+	}
+	return run, nil
+}
+
+// execute menyusun laporan dry-run lalu, jika diminta, menjalankan kebijakan
+// dengan batas waktu yang sama seperti di laporan
+func (s *retentionService) execute(report *models.RetentionRun, dryRun bool) {
+	now := time.Now()
+	results := make([]models.RetentionResult, 0, len(s.settings.Policies))
+	var failure error
+	for _, policy := range s.settings.Policies {
+		rule := repositories.RetentionRule{
+			Entity: policy.Entity,
+			Action: policy.Action,
+			Basis:  policy.Basis,
+			Cutoff: policy.RetainFor.Before(now),
+		}
+		result := models.RetentionResult{
+			Entity:    rule.Entity,
+			Action:    rule.Action,
+			Basis:     rule.Basis,
+			RetainFor: policy.RetainFor.String(),
+			Cutoff:    rule.Cutoff,
+		}
+		preview, err := s.runs.Preview(rule, retentionSamples)
+		if err != nil {
+			failure = err
+			break
+		}
+		result.Eligible = preview.Eligible
+		result.OnHold = preview.OnHold
+		result.SampleIDs = preview.SampleIDs
+		results = append(results, result)
+	}
+	s.finish(report, results, failure)
+	if failure != nil || dryRun {
+		return
+	}
+
+	run, err := s.startRun(report.Trigger, report.TriggeredByID, false, &report.ID)
+	if err != nil {
+		log.Printf("retention: start run after report %s: %v", report.ID, err)
+		return
+	}
+	for i := range results {
+		result := &results[i]
+		rule := repositories.RetentionRule{
+			Entity: result.Entity,
+			Action: result.Action,
+			Basis:  result.Basis,
+			Cutoff: result.Cutoff,
+		}
+		result.Processed, err = s.runs.Apply(rule, s.settings.BatchSize, time.Now())
+		if err != nil {
+			failure = err
+			results = results[:i+1]
+			break
+		}
+	}
+	s.finish(run, results, failure)
+}
+
+func (s *retentionService) finish(run *models.RetentionRun, results []models.RetentionResult, failure error) {
+	finished := time.Now()
+	run.FinishedAt = &finished
+	run.Status = models.RetentionCompleted
+	if failure != nil {
+		log.Printf("retention: run %s failed: %v", run.ID, failure)
+		run.Status = models.RetentionFailed
+		run.Error = failure.Error()
+	}
+	run.Results, _ = json.Marshal(results)
+	if err := s.runs.FinishRun(run); err != nil {
+		log.Printf("retention: save run %s: %v", run.ID, err)
+	}
+}
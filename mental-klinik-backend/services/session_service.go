@@ -0,0 +1,198 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+)
+
+// Alasan pencabutan sesi yang disimpan di auth_sessions.revoked_reason
+const (
+	RevokeReasonLogout       = "logout"
+	RevokeReasonAdmin        = "revoked_by_admin"
+	RevokeReasonTokenReuse   = "refresh_token_reuse"
+	RevokeReasonUserDeleted  = "user_deleted"
+	RevokeReasonUserNotFound = "user_not_found"
+	RevokeReasonRoleChanged  = "role_changed"
+)
+
+// TokenGenerator menerbitkan access token (JWT) untuk satu sesi
+type TokenGenerator interface {
+	GenerateToken(userID string, role string, sessionID string) (string, time.Time, error)
+}
+
+// TokenPair adalah access token + refresh token yang dikirim ke client
+type TokenPair struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+// ClientInfo dicatat di sesi supaya admin bisa mengenali perangkatnya
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+type SessionService interface {
+	Start(user *models.User, client ClientInfo) (*TokenPair, error)
+	Refresh(refreshToken string) (*TokenPair, *models.User, error)
+	Logout(sessionID string) error
+	RevokeAllForUser(userID string, reason string) (int64, error)
+	IsActive(sessionID string) (bool, error)
+}
+
+type sessionService struct {
+	sessions   repositories.SessionRepository
+	users      repositories.UserRepository
+	tokens     TokenGenerator
+	refreshTTL time.Duration
+}
+
+func NewSessionService(
+	sessions repositories.SessionRepository,
+	users repositories.UserRepository,
+	tokens TokenGenerator,
+	refreshTTL time.Duration,
+) SessionService {
+	return &sessionService{
+		sessions:   sessions,
+		users:      users,
+		tokens:     tokens,
+		refreshTTL: refreshTTL,
+	}
+}
+
+// Start membuat sesi baru setelah login berhasil
+func (s *sessionService) Start(user *models.User, client ClientInfo) (*TokenPair, error) {
+	session := &models.AuthSession{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		UserAgent: client.UserAgent,
+		IPAddress: client.IPAddress,
+		CreatedAt: time.Now(),
+	}
+
+	raw, refresh, err := s.newRefreshToken(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sessions.CreateSession(session, refresh); err != nil {
+		return nil, err
+	}
+	return s.pair(user, session.ID, raw, refresh)
+}
+
+// Refresh merotasi refresh token: token lama tidak bisa dipakai lagi dan
+// client menerima pasangan token baru. Token lama yang dipakai ulang berarti
+// token tersebut bocor, sehingga seluruh sesi (family) dicabut.
+func (s *sessionService) Refresh(refreshToken string) (*TokenPair, *models.User, error) {
+	current, err := s.sessions.FindRefreshTokenByHash(hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, nil, ErrInvalidRefreshToken
+		}
+		return nil, nil, err
+	}
+
+	if current.Session.RevokedAt != nil {
+		return nil, nil, ErrSessionRevoked
+	}
+	if current.UsedAt != nil {
+		return nil, nil, s.revokeForReuse(current.SessionID)
+	}
+	if time.Now().After(current.ExpiresAt) {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	// Role dibaca ulang supaya perubahan role langsung berlaku di token baru
+	user, err := s.users.FindByID(current.Session.UserID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			if err := s.sessions.RevokeSession(current.SessionID, RevokeReasonUserNotFound); err != nil {
+				return nil, nil, err
+			}
+			return nil, nil, ErrInvalidRefreshToken
+		}
+		return nil, nil, err
+	}
+
+	raw, next, err := s.newRefreshToken(current.SessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.sessions.RotateRefreshToken(current, next); err != nil {
+		if errors.Is(err, repositories.ErrTokenAlreadyUsed) {
+			return nil, nil, s.revokeForReuse(current.SessionID)
+		}
+		return nil, nil, err
+	}
+
+	pair, err := s.pair(user, current.SessionID, raw, next)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pair, user, nil
+}
+
+func (s *sessionService) revokeForReuse(sessionID string) error {
+	if err := s.sessions.RevokeSession(sessionID, RevokeReasonTokenReuse); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+func (s *sessionService) Logout(sessionID string) error {
+	return s.sessions.RevokeSession(sessionID, RevokeReasonLogout)
+}
+
+func (s *sessionService) RevokeAllForUser(userID string, reason string) (int64, error) {
+	return s.sessions.RevokeUserSessions(userID, reason)
+}
+
+// IsActive dipakai AuthMiddleware untuk menolak access token dari sesi yang
+// sudah dicabut
+func (s *sessionService) IsActive(sessionID string) (bool, error) {
+	session, err := s.sessions.FindSessionByID(sessionID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return session.RevokedAt == nil, nil
+}
+
+func (s *sessionService) newRefreshToken(sessionID string) (string, *models.RefreshToken, error) {
+	raw, err := newOpaqueToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now()
+	return raw, &models.RefreshToken{
+		ID:        uuid.NewString(),
+		SessionID: sessionID,
+		TokenHash: hashToken(raw),
+		ExpiresAt: now.Add(s.refreshTTL),
+		CreatedAt: now,
+	}, nil
+}
+
+func (s *sessionService) pair(user *models.User, sessionID string, raw string, refresh *models.RefreshToken) (*TokenPair, error) {
+	access, expiresAt, err := s.tokens.GenerateToken(user.ID, user.Role, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenPair{
+		AccessToken:           access,
+		AccessTokenExpiresAt:  expiresAt,
+		RefreshToken:          raw,
+		RefreshTokenExpiresAt: refresh.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+)
+
+// fakeSessionRepository menyimpan sesi dan refresh token di memori, dengan
+// rotasi bersyarat used_at seperti repository database
+type fakeSessionRepository struct {
+	sessions map[string]*models.AuthSession
+	tokens   map[string]*models.RefreshToken // per token hash
+}
+
+func newFakeSessionRepository() *fakeSessionRepository {
+	return &fakeSessionRepository{sessions: map[string]*models.AuthSession{}, tokens: map[string]*models.RefreshToken{}}
+}
+
+func (r *fakeSessionRepository) CreateSession(session *models.AuthSession, token *models.RefreshToken) error {
+	storedSession, storedToken := *session, *token
+	r.sessions[session.ID] = &storedSession
+	r.tokens[token.TokenHash] = &storedToken
+	return nil
+}
+
+func (r *fakeSessionRepository) FindSessionByID(id string) (*models.AuthSession, error) {
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+	found := *session
+	return &found, nil
+}
+
+func (r *fakeSessionRepository) FindRefreshTokenByHash(hash string) (*models.RefreshToken, error) {
+	token, ok := r.tokens[hash]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+	found := *token
+	found.Session = *r.sessions[token.SessionID]
+	return &found, nil
+}
+
+func (r *fakeSessionRepository) RotateRefreshToken(used *models.RefreshToken, next *models.RefreshToken) error {
+	stored := r.tokens[used.TokenHash]
+	if stored.UsedAt != nil {
+		return repositories.ErrTokenAlreadyUsed
+	}
+	now := time.Now()
+	stored.UsedAt = &now
+	r.sessions[used.SessionID].LastUsedAt = &now
+	created := *next
+	r.tokens[next.TokenHash] = &created
+	return nil
+}
+
+func (r *fakeSessionRepository) RevokeSession(id string, reason string) error {
+	if session, ok := r.sessions[id]; ok && session.RevokedAt == nil {
+		now := time.Now()
+		session.RevokedAt, session.RevokedReason = &now, reason
+	}
+	return nil
+}
+
+func (r *fakeSessionRepository) RevokeUserSessions(userID string, reason string) (int64, error) {
+	var n int64
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.RevokedAt == nil {
+			now := time.Now()
+			session.RevokedAt, session.RevokedReason = &now, reason
+			n++
+		}
+	}
+	return n, nil
+}
+
+// fakeUserRepository hanya mengimplementasikan FindByID; method lain tidak
+// dipakai session service
+type fakeUserRepository struct {
+	repositories.UserRepository
+	users map[string]*models.User
+}
+
+func (r *fakeUserRepository) FindByID(id string) (*models.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+	found := *user
+	return &found, nil
+}
+
+// fakeTokens menerbitkan access token yang isinya mudah dibaca
+type fakeTokens struct{}
+
+func (fakeTokens) GenerateToken(userID string, role string, sessionID string) (string, time.Time, error) {
+	return userID + "|" + role + "|" + sessionID, time.Now().Add(15 * time.Minute), nil
+}
+
+func newTestSessionService(refreshTTL time.Duration) (SessionService, *fakeSessionRepository, *fakeUserRepository) {
+	sessions := newFakeSessionRepository()
+	users := &fakeUserRepository{users: map[string]*models.User{
+		"doctor-001-aaaaaaaa": {ID: "doctor-001-aaaaaaaa", Role: "doctor"},
+	}}
+	return NewSessionService(sessions, users, fakeTokens{}, refreshTTL), sessions, users
+}
+
+func startSession(t *testing.T, service SessionService, users *fakeUserRepository) (*TokenPair, string) {
+	t.Helper()
+	pair, err := service.Start(users.users["doctor-001-aaaaaaaa"], ClientInfo{UserAgent: "test", IPAddress: "10.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pair, pair.AccessToken[len("doctor-001-aaaaaaaa|doctor|"):]
+}
+
+func TestSessionRefreshRotates(t *testing.T) {
+	service, sessions, users := newTestSessionService(time.Hour)
+	first, sessionID := startSession(t, service, users)
+	if first.RefreshToken == "" || sessions.tokens[hashToken(first.RefreshToken)] == nil {
+		t.Fatal("refresh token is not stored as a hash")
+	}
+
+	// Role dibaca ulang saat refresh
+	users.users["doctor-001-aaaaaaaa"].Role = "admin"
+	second, user, err := service.Refresh(first.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if user.ID != "doctor-001-aaaaaaaa" || second.AccessToken != "doctor-001-aaaaaaaa|admin|"+sessionID {
+		t.Errorf("access token = %s, user = %+v", second.AccessToken, user)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Error("refresh token was not rotated")
+	}
+	if sessions.tokens[hashToken(first.RefreshToken)].UsedAt == nil || sessions.sessions[sessionID].LastUsedAt == nil {
+		t.Error("old refresh token is not marked used")
+	}
+
+	third, _, err := service.Refresh(second.RefreshToken)
+	if err != nil || third.RefreshToken == second.RefreshToken {
+		t.Errorf("second Refresh = %v", err)
+	}
+	if active, _ := service.IsActive(sessionID); !active {
+		t.Error("session is not active after rotation")
+	}
+}
+
+func TestSessionRefreshReuseRevokesFamily(t *testing.T) {
+	service, sessions, users := newTestSessionService(time.Hour)
+	first, sessionID := startSession(t, service, users)
+	other, otherID := startSession(t, service, users)
+	second, _, err := service.Refresh(first.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Token yang sudah dirotasi dipakai lagi: seluruh sesi dicabut
+	if _, _, err := service.Refresh(first.RefreshToken); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("Refresh reused token = %v, want ErrRefreshTokenReused", err)
+	}
+	session := sessions.sessions[sessionID]
+	if session.RevokedAt == nil || session.RevokedReason != RevokeReasonTokenReuse {
+		t.Errorf("session = %+v, want revoked for token reuse", session)
+	}
+	if _, _, err := service.Refresh(second.RefreshToken); !errors.Is(err, ErrSessionRevoked) {
+		t.Errorf("Refresh newest token = %v, want ErrSessionRevoked", err)
+	}
+	if active, _ := service.IsActive(sessionID); active {
+		t.Error("revoked session is still active")
+	}
+
+	// Sesi lain milik user yang sama tidak ikut dicabut
+	if active, _ := service.IsActive(otherID); !active {
+		t.Error("another session of the same user was revoked")
+	}
+	if _, _, err := service.Refresh(other.RefreshToken); err != nil {
+		t.Errorf("Refresh another session: %v", err)
+	}
+}
+
+func TestSessionRefreshExpired(t *testing.T) {
+	service, sessions, users := newTestSessionService(-time.Minute)
+	pair, sessionID := startSession(t, service, users)
+
+	if _, _, err := service.Refresh(pair.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("Refresh expired token = %v, want ErrInvalidRefreshToken", err)
+	}
+	if sessions.tokens[hashToken(pair.RefreshToken)].UsedAt != nil || sessions.sessions[sessionID].RevokedAt != nil {
+		t.Error("expired token was rotated or revoked its session")
+	}
+}
+
+func TestSessionRefreshInvalid(t *testing.T) {
+	service, sessions, users := newTestSessionService(time.Hour)
+	if _, _, err := service.Refresh("not-a-token"); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("Refresh unknown token = %v, want ErrInvalidRefreshToken", err)
+	}
+
+	// User yang sudah dihapus tidak bisa refresh, sesinya dicabut
+	pair, sessionID := startSession(t, service, users)
+	delete(users.users, "doctor-001-aaaaaaaa")
+	if _, _, err := service.Refresh(pair.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("Refresh deleted user = %v, want ErrInvalidRefreshToken", err)
+	}
+	if reason := sessions.sessions[sessionID].RevokedReason; reason != RevokeReasonUserNotFound {
+		t.Errorf("revoked reason = %q, want %q", reason, RevokeReasonUserNotFound)
+	}
+}
+
+func TestSessionLogoutAndRevokeAll(t *testing.T) {
+	service, sessions, users := newTestSessionService(time.Hour)
+	first, firstID := startSession(t, service, users)
+	_, secondID := startSession(t, service, users)
+	_, thirdID := startSession(t, service, users)
+
+	if err := service.Logout(firstID); err != nil {
+		t.Fatal(err)
+	}
+	if sessions.sessions[firstID].RevokedReason != RevokeReasonLogout {
+		t.Errorf("logout reason = %q", sessions.sessions[firstID].RevokedReason)
+	}
+	if _, _, err := service.Refresh(first.RefreshToken); !errors.Is(err, ErrSessionRevoked) {
+		t.Errorf("Refresh after logout = %v, want ErrSessionRevoked", err)
+	}
+
+	n, err := service.RevokeAllForUser("doctor-001-aaaaaaaa", RevokeReasonAdmin)
+	if err != nil || n != 2 {
+		t.Fatalf("RevokeAllForUser = %d, %v, want 2 sessions", n, err)
+	}
+	for _, id := range []string{secondID, thirdID} {
+		if active, _ := service.IsActive(id); active || sessions.sessions[id].RevokedReason != RevokeReasonAdmin {
+			t.Errorf("session %s is still active", id)
+		}
+	}
+	// Alasan logout tidak ditimpa
+	if sessions.sessions[firstID].RevokedReason != RevokeReasonLogout {
+		t.Errorf("logout reason overwritten with %q", sessions.sessions[firstID].RevokedReason)
+	}
+	if active, err := service.IsActive("unknown"); active || err != nil {
+		t.Errorf("IsActive(unknown) = %v, %v", active, err)
+	}
+}
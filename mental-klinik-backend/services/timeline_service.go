@@ -0,0 +1,37 @@
+package services
+
+import (
+	"errors"
+
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+)
+
+type TimelineService interface {
+	GetByPatientID(filter repositories.PatientTimelineFilter) ([]models.TimelineEvent, listquery.Page, error)
+}
+
+type timelineService struct {
+	timeline repositories.TimelineRepository
+	patients repositories.PatientRepository
+}
+
+func NewTimelineService(timeline repositories.TimelineRepository, patients repositories.PatientRepository) TimelineService {
+	return &timelineService{timeline: timeline, patients: patients}
+}
+
+// GetByPatientID mengembalikan timeline klinis pasien yang masih aktif
+func (s *timelineService) GetByPatientID(filter repositories.PatientTimelineFilter) ([]models.TimelineEvent, listquery.Page, error) {
+	if _, err := s.patients.FindByID(filter.PatientID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, listquery.Page{}, ErrPatientNotFound
+		}
+		return nil, listquery.Page{}, err
+	}
+	events, page, err := s.timeline.FindByPatientID(filter)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, listquery.Page{}, ErrPatientNotFound
+	}
+	return events, page, err
+}
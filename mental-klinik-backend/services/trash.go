@@ -0,0 +1,22 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"mental-klinik-backend/repositories"
+)
+
+// translateRestoreError memetakan error restore dari repository ke error
+// domain: konflik dibungkus ErrRestoreConflict beserta alasannya, data yang
+// tidak ada di trash menjadi notFound
+func translateRestoreError(err error, notFound error) error {
+	var conflict *repositories.RestoreConflictError
+	switch {
+	case errors.As(err, &conflict):
+		return fmt.Errorf("%w: %s", ErrRestoreConflict, conflict.Reason)
+	case errors.Is(err, repositories.ErrNotFound):
+		return notFound
+	}
+	return err
+}
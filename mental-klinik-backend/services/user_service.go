@@ -0,0 +1,250 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"mental-klinik-backend/dto"
+	"mental-klinik-backend/listquery"
+	"mental-klinik-backend/models"
+	"mental-klinik-backend/repositories"
+	"mental-klinik-backend/utils"
+)
+
+type UserService interface {
+	Register(input dto.RegisterUserRequest) (*models.User, error)
+	BootstrapAdmin(fullName string, email string, password string) (*models.User, error)
+	Login(input dto.LoginUserRequest, client ClientInfo) (*TokenPair, *models.User, error)
+	GetAll(filter repositories.UserFilter) ([]models.User, listquery.Page, error)
+	GetByID(id string) (*models.User, error)
+	Update(id string, input dto.UpdateUserInput) (*models.User, error)
+	Delete(id string, reassignTo string) (*repositories.DeleteResult, error)
+	GetTrash(query listquery.Query) ([]models.User, listquery.Page, error)
+	Restore(id string) (*models.User, repositories.RestoreResult, error)
+}
+
+type userService struct {
+	users             repositories.UserRepository
+	invitations       repositories.InvitationRepository
+	sessions          SessionService
+	ids               utils.IDGenerator
+	allowRegistration bool
+}
+
+func NewUserService(
+	users repositories.UserRepository,
+	invitations repositories.InvitationRepository,
+	sessions SessionService,
+	ids utils.IDGenerator,
+	allowRegistration bool,
+) UserService {
+	return &userService{
+		users:             users,
+		invitations:       invitations,
+		sessions:          sessions,
+		ids:               ids,
+		allowRegistration: allowRegistration,
+	}
+}
+
+// Register mendaftarkan user lewat token undangan. Role selalu diambil dari
+// undangan; tanpa undangan hanya diizinkan jika pendaftaran mandiri dibuka
+// dan user selalu menjadi staff.
+func (s *userService) Register(input dto.RegisterUserRequest) (*models.User, error) {
+	var invitation *models.Invitation
+	role := "staff"
+
+	if input.InviteToken != "" {
+		found, err := s.invitations.FindByTokenHash(hashToken(input.InviteToken))
+		if err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return nil, ErrInvalidInvitation
+			}
+			return nil, err
+		}
+		if found.Status(time.Now()) != "pending" {
+			return nil, ErrInvalidInvitation
+		}
+		if !strings.EqualFold(found.Email, strings.TrimSpace(input.Email)) {
+			return nil, ErrInvitationEmailMismatch
+		}
+		invitation = found
+		role = found.Role
+	} else if !s.allowRegistration {
+		return nil, ErrRegistrationClosed
+	}
+
+	user, err := s.newUser(input.FullName, input.Email, input.Password, role)
+	if err != nil {
+		return nil, err
+	}
+
+	if invitation == nil {
+		err = s.users.Create(user)
+	} else {
+		err = s.invitations.AcceptInvitation(invitation, user)
+		if errors.Is(err, repositories.ErrInvitationUnavailable) {
+			return nil, ErrInvalidInvitation
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// BootstrapAdmin membuat admin pertama. Hanya berhasil jika belum ada admin,
+// admin berikutnya dibuat lewat undangan.
+func (s *userService) BootstrapAdmin(fullName string, email string, password string) (*models.User, error) {
+	exists, err := s.users.ExistsByRole("admin")
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrAdminAlreadyExists
+	}
+
+	user, err := s.newUser(fullName, email, password, "admin")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.users.Create(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// newUser memvalidasi email belum dipakai lalu menyiapkan user dengan password
+// yang sudah di-hash dan ID dari generator
+func (s *userService) newUser(fullName string, email string, password string, role string) (*models.User, error) {
+	// Cek apakah email sudah digunakan
+	if _, err := s.users.FindByEmail(email); !errors.Is(err, repositories.ErrNotFound) {
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrEmailAlreadyUsed
+	}
+
+	// Hash password
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	// ID user memakai role sebagai prefix, nomor urut per role
+	id, err := s.ids.Generate(role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.User{
+		ID:       id,
+		FullName: fullName,
+		Email:    email,
+		Password: hashedPassword,
+		Role:     role,
+	}, nil
+}
+
+func (s *userService) Login(input dto.LoginUserRequest, client ClientInfo) (*TokenPair, *models.User, error) {
+	user, err := s.users.FindByEmail(input.Email)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, nil, ErrInvalidCredentials
+		}
+		return nil, nil, err
+	}
+
+	if !utils.CheckPasswordHash(input.Password, user.Password) {
+		return nil, nil, ErrInvalidPassword
+	}
+
+	tokens, err := s.sessions.Start(user, client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, user, nil
+}
+
+func (s *userService) GetAll(filter repositories.UserFilter) ([]models.User, listquery.Page, error) {
+	return s.users.FindAll(filter)
+}
+
+func (s *userService) GetByID(id string) (*models.User, error) {
+	user, err := s.users.FindByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+// Update mengubah data user. Hak akses (termasuk perubahan role) dicek
+// controller lewat policy sebelum memanggil Update.
+func (s *userService) Update(id string, input dto.UpdateUserInput) (*models.User, error) {
+	user, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	roleChanged := input.Role != "" && input.Role != user.Role
+
+	// Optional: Hash New Password if Provided
+	if input.Password != "" {
+		hashed, err := utils.HashPassword(input.Password)
+		if err != nil {
+			return nil, err
+		}
+		user.Password = hashed
+	}
+
+	if input.FullName != "" {
+		user.FullName = input.FullName
+	}
+	if input.Email != "" {
+		user.Email = input.Email
+	}
+	if roleChanged {
+		user.Role = input.Role
+	}
+
+	if err := s.users.Update(user); err != nil {
+		return nil, err
+	}
+
+	// Sesi lama membawa role lama di access token-nya
+	if roleChanged {
+		if _, err := s.sessions.RevokeAllForUser(user.ID, RevokeReasonRoleChanged); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// Delete men-soft delete user. Appointment yang belum berjalan dipindahkan
+// ke reassignTo; tanpa pengganti, atau jika user masih di tim perawatan
+// pasien, penghapusan ditolak dengan ErrDeleteRestricted.
+func (s *userService) Delete(id string, reassignTo string) (*repositories.DeleteResult, error) {
+	result, err := s.users.Delete(id, reassignTo)
+	if err != nil {
+		return nil, translateDeleteError(err, ErrUserNotFound)
+	}
+	// User yang dihapus tidak boleh tetap memegang sesi aktif
+	if _, err := s.sessions.RevokeAllForUser(id, RevokeReasonUserDeleted); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *userService) GetTrash(query listquery.Query) ([]models.User, listquery.Page, error) {
+	return s.users.FindDeleted(query)
+}
+
+// Restore memulihkan user dari trash
+func (s *userService) Restore(id string) (*models.User, repositories.RestoreResult, error) {
+	restored, result, err := s.users.Restore(id)
+	if err != nil {
+		return nil, nil, translateRestoreError(err, ErrUserNotFound)
+	}
+	return restored, result, nil
+}
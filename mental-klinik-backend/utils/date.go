@@ -0,0 +1,38 @@
+package utils
+
+import "time"
+
+// DateLayout adalah format tanggal tanpa jam yang dipakai di request/response
+const DateLayout = "2006-01-02"
+
+// DateOf mengambil tanggal kalender dari t (zona waktu t) sebagai tengah malam
+// UTC, supaya aman disimpan ke kolom date dan dibandingkan antar tanggal.
+func DateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Today adalah tanggal hari ini menurut zona waktu server
+func Today() time.Time {
+	return DateOf(time.Now())
+}
+
+// ParseDate mem-parse tanggal YYYY-MM-DD, string kosong menghasilkan fallback
+func ParseDate(raw string, fallback time.Time) (time.Time, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	t, err := time.Parse(DateLayout, raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return DateOf(t), nil
+}
+
+// FormatDate memformat tanggal opsional sebagai YYYY-MM-DD, nil menjadi ""
+func FormatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(DateLayout)
+}
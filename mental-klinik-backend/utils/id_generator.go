@@ -1,38 +1,114 @@
 package utils
 
 import (
+	"crypto/rand"
 	"fmt"
-	"math/rand"
+	"regexp"
+	"strings"
 	"time"
+
+	"github.com/oklog/ulid/v2"
 )
 
-var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+// Prefix ID per jenis entity. ID user memakai role sebagai prefix
+// (admin-001-xxxx, doctor-001-xxxx, ...).
+const (
+	EntityPatient       = "patient"
+	EntityAppointment   = "appointment"
+	EntityAssessment    = "assessment"
+	EntityMedicalRecord = "record"
+	EntityPrediction    = "prediction"
+	EntityCareTeam      = "care"
+	EntityConsent       = "consent"
+	EntityConsentDoc    = "consentdoc"
+	EntityContact       = "contact"
+	EntityStatusChange  = "statuschange"
+)
 
-func generateRandomString(n int) string {
-	rand.Seed(time.Now().UnixNano())
-	b := make([]rune, n)
-	for i := range b {
-		b[i] = letters[rand.Intn(len(letters))]
-	}
-	return string(b)
+// Format ID yang didukung
+const (
+	IDFormatReadable = "readable" // patient-001-AbC123xY
+	IDFormatULID     = "ulid"     // patient-01J9ZQ3V7N8K2M4P6R8T0W2Y4A
+)
+
+var letters = []byte("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+
+var entityPattern = regexp.MustCompile(`^[a-z][a-z_]*$`)
+
+// Sequencer memberikan nomor urut berikutnya untuk satu jenis entity. Nomor
+// tidak pernah dipakai ulang, termasuk setelah data di-soft delete.
+type Sequencer interface {
+	Next(entity string) (int64, error)
 }
 
-func GenerateCustomUserID(role string, index int) string {
-	return fmt.Sprintf("%s-%03d-%s", role, index, generateRandomString(8))
+// IDGenerator membuat ID baru untuk entity dengan prefix tertentu
+type IDGenerator interface {
+	Generate(entity string) (string, error)
 }
 
-func GenerateCustomPatientID(index int) string {
-	return fmt.Sprintf("patient-%03d-%s", index, generateRandomString(8))
+type idGenerator struct {
+	format    string
+	sequencer Sequencer
 }
 
-func GenerateCustomAppointmentID(index int) string {
-	return fmt.Sprintf("appointment-%03d-%s", index, generateRandomString(8))
+// NewIDGenerator membuat generator dengan format readable (butuh sequencer)
+// atau ulid (sequencer boleh nil).
+func NewIDGenerator(format string, sequencer Sequencer) (IDGenerator, error) {
+	switch format {
+	case IDFormatReadable:
+		if sequencer == nil {
+			return nil, fmt.Errorf("id format %q requires a sequencer", format)
+		}
+	case IDFormatULID:
+	default:
+		return nil, fmt.Errorf("unknown id format %q", format)
+	}
+	return &idGenerator{format: format, sequencer: sequencer}, nil
 }
 
-func GenerateCustomAssessmentID(index int) string {
-	return fmt.Sprintf("assessment-%03d-%s", index, generateRandomString(8))
+func (g *idGenerator) Generate(entity string) (string, error) {
+	if !entityPattern.MatchString(entity) {
+		return "", fmt.Errorf("invalid entity name %q", entity)
+	}
+
+	if g.format == IDFormatULID {
+		id, err := ulid.New(ulid.Timestamp(time.Now()), rand.Reader)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s-%s", entity, strings.ToLower(id.String())), nil
+	}
+
+	seq, err := g.sequencer.Next(entity)
+	if err != nil {
+		return "", fmt.Errorf("next sequence for %s: %w", entity, err)
+	}
+
+	suffix, err := randomString(8)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%03d-%s", entity, seq, suffix), nil
 }
 
-func GenerateCustomMedicalRecordID(index int) string {
-	return fmt.Sprintf("record-%03d-%s", index, generateRandomString(8))
+// randomString membuat string acak dari crypto/rand. Byte >= 248 dibuang
+// supaya setiap karakter punya peluang yang sama (248 = 4 * 62).
+func randomString(n int) (string, error) {
+	out := make([]byte, 0, n)
+	buf := make([]byte, n*2)
+	for len(out) < n {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		for _, b := range buf {
+			if b >= 248 {
+				continue
+			}
+			out = append(out, letters[int(b)%len(letters)])
+			if len(out) == n {
+				break
+			}
+		}
+	}
+	return string(out), nil
 }
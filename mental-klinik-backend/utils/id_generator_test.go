@@ -0,0 +1,172 @@
+package utils
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// fakeSequencer adalah Sequencer di memori yang aman dipakai banyak goroutine
+type fakeSequencer struct {
+	mu   sync.Mutex
+	next map[string]int64
+	err  error
+}
+
+func (s *fakeSequencer) Next(entity string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return 0, s.err
+	}
+	if s.next == nil {
+		s.next = map[string]int64{}
+	}
+	s.next[entity]++
+	return s.next[entity], nil
+}
+
+func TestGenerateConcurrent(t *testing.T) {
+	const goroutines, perGoroutine = 50, 200
+
+	tests := []struct {
+		format  string
+		pattern *regexp.Regexp
+	}{
+		{IDFormatReadable, regexp.MustCompile(`^patient-(\d{3,})-[a-zA-Z0-9]{8}$`)},
+		{IDFormatULID, regexp.MustCompile(`^patient-[0-9a-hjkmnp-tv-z]{26}$`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			sequencer := &fakeSequencer{}
+			generator, err := NewIDGenerator(tt.format, sequencer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make(chan string, goroutines*perGoroutine)
+			errs := make(chan error, goroutines*perGoroutine)
+			var wg sync.WaitGroup
+			for range goroutines {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range perGoroutine {
+						id, err := generator.Generate(EntityPatient)
+						if err != nil {
+							errs <- err
+							continue
+						}
+						ids <- id
+					}
+				}()
+			}
+			wg.Wait()
+			close(ids)
+			close(errs)
+
+			for err := range errs {
+				t.Fatalf("Generate: %v", err)
+			}
+			seen := map[string]bool{}
+			sequences := map[int64]bool{}
+			for id := range ids {
+				match := tt.pattern.FindStringSubmatch(id)
+				if match == nil {
+					t.Fatalf("id %q does not match %s", id, tt.pattern)
+				}
+				if seen[id] {
+					t.Fatalf("duplicate id %q", id)
+				}
+				seen[id] = true
+				if tt.format == IDFormatReadable {
+					seq, _ := strconv.ParseInt(match[1], 10, 64)
+					if sequences[seq] {
+						t.Fatalf("sequence %d used twice", seq)
+					}
+					sequences[seq] = true
+				}
+			}
+			if len(seen) != goroutines*perGoroutine {
+				t.Fatalf("got %d ids, want %d", len(seen), goroutines*perGoroutine)
+			}
+			if tt.format == IDFormatReadable && len(sequences) != goroutines*perGoroutine {
+				t.Fatalf("got %d sequences, want %d", len(sequences), goroutines*perGoroutine)
+			}
+		})
+	}
+}
+
+func TestGenerateReadablePadding(t *testing.T) {
+	generator, err := NewIDGenerator(IDFormatReadable, &fakeSequencer{next: map[string]int64{EntityMedicalRecord: 6, "doctor": 1233}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		entity string
+		prefix string
+	}{
+		{EntityMedicalRecord, "record-007-"},
+		{"doctor", "doctor-1234-"},
+		{EntityPatient, "patient-001-"},
+	}
+	for _, tt := range tests {
+		id, err := generator.Generate(tt.entity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(id) != len(tt.prefix)+8 || id[:len(tt.prefix)] != tt.prefix {
+			t.Errorf("Generate(%q) = %q, want %s + 8 random characters", tt.entity, id, tt.prefix)
+		}
+	}
+}
+
+func TestGenerateSequencerError(t *testing.T) {
+	errSequence := errors.New("sequence id_seq_patient does not exist")
+	generator, err := NewIDGenerator(IDFormatReadable, &fakeSequencer{err: errSequence})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := generator.Generate(EntityPatient)
+	if !errors.Is(err, errSequence) {
+		t.Fatalf("Generate error = %v, want %v", err, errSequence)
+	}
+	if id != "" {
+		t.Fatalf("Generate returned id %q together with an error", id)
+	}
+}
+
+func TestGenerateInvalidEntity(t *testing.T) {
+	for _, format := range []string{IDFormatReadable, IDFormatULID} {
+		generator, err := NewIDGenerator(format, &fakeSequencer{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entity := range []string{"", "Patient", "patient-1", "1patient", "pa tient"} {
+			if id, err := generator.Generate(entity); err == nil {
+				t.Errorf("%s: Generate(%q) = %q, want error", format, entity, id)
+			}
+		}
+	}
+}
+
+func TestNewIDGenerator(t *testing.T) {
+	tests := []struct {
+		format    string
+		sequencer Sequencer
+		wantErr   bool
+	}{
+		{IDFormatReadable, &fakeSequencer{}, false},
+		{IDFormatReadable, nil, true},
+		{IDFormatULID, nil, false},
+		{"uuid", &fakeSequencer{}, true},
+	}
+	for _, tt := range tests {
+		_, err := NewIDGenerator(tt.format, tt.sequencer)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NewIDGenerator(%q, %v) error = %v, wantErr %v", tt.format, tt.sequencer, err, tt.wantErr)
+		}
+	}
+}
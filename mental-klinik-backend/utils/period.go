@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var periodPattern = regexp.MustCompile(`^(?:(\d+)y)?(?:(\d+)m)?(?:(\d+)d)?$`)
+
+// Period adalah rentang kalender (tahun, bulan, hari) seperti "2y", "18m",
+// "90d" atau "1y6m". Dipakai untuk masa retensi yang terlalu panjang untuk
+// time.Duration dan harus mengikuti kalender (tahun kabisat, panjang bulan).
+type Period struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+// ParsePeriod mem-parse period dengan satuan y (tahun), m (bulan), d (hari)
+func ParsePeriod(raw string) (Period, error) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	match := periodPattern.FindStringSubmatch(raw)
+	if raw == "" || match == nil {
+		return Period{}, fmt.Errorf("invalid period %q, use e.g. 2y, 18m, 90d or 1y6m", raw)
+	}
+	var p Period
+	for i, target := range []*int{&p.Years, &p.Months, &p.Days} {
+		if match[i+1] != "" {
+			*target, _ = strconv.Atoi(match[i+1])
+		}
+	}
+	return p, nil
+}
+
+// IsZero bernilai true jika period tidak punya panjang
+func (p Period) IsZero() bool {
+	return p.Years == 0 && p.Months == 0 && p.Days == 0
+}
+
+// Before mengembalikan waktu t dikurangi period
+func (p Period) Before(t time.Time) time.Time {
+	return t.AddDate(-p.Years, -p.Months, -p.Days)
+}
+
+func (p Period) String() string {
+	var b strings.Builder
+	for _, part := range []struct {
+		value int
+		unit  string
+	}{{p.Years, "y"}, {p.Months, "m"}, {p.Days, "d"}} {
+		if part.value != 0 {
+			fmt.Fprintf(&b, "%d%s", part.value, part.unit)
+		}
+	}
+	if b.Len() == 0 {
+		return "0d"
+	}
+	return b.String()
+}
+
+func (p *Period) UnmarshalText(text []byte) error {
+	parsed, err := ParsePeriod(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+func (p Period) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}